@@ -0,0 +1,13 @@
+package accountranking_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAccountRanking(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Account Ranking Suite")
+}
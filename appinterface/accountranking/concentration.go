@@ -0,0 +1,67 @@
+package accountranking
+
+import (
+	"math/big"
+
+	"github.com/crypto-com/chain-indexing/appinterface/accountranking/view"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Decimal places of the Gini coefficient and the shares
+const CONCENTRATION_PRECISION = 6
+
+// NewConcentration measures how concentrated the amounts are. The amounts must be in ascending order.
+// Negative amounts are counted as zero.
+func NewConcentration(
+	metric view.RANKING_METRIC,
+	sortedAmounts []*big.Int,
+	computedAt utctime.UTCTime,
+) view.ConcentrationRow {
+	n := int64(len(sortedAmounts))
+
+	// Gini = 2 * sum(i * x_i) / (n * sum(x_i)) - (n + 1) / n, with x_i in ascending order and i from 1
+	total := new(big.Int)
+	weightedTotal := new(big.Int)
+	for i, amount := range sortedAmounts {
+		if amount.Sign() <= 0 {
+			continue
+		}
+		total.Add(total, amount)
+		weightedTotal.Add(weightedTotal, new(big.Int).Mul(big.NewInt(int64(i+1)), amount))
+	}
+
+	concentration := view.ConcentrationRow{
+		Metric:      metric,
+		Accounts:    n,
+		Total:       total.String(),
+		Gini:        new(big.Rat).FloatString(CONCENTRATION_PRECISION),
+		Top10Share:  new(big.Rat).FloatString(CONCENTRATION_PRECISION),
+		Top100Share: new(big.Rat).FloatString(CONCENTRATION_PRECISION),
+		ComputedAt:  computedAt,
+	}
+	if total.Sign() == 0 {
+		return concentration
+	}
+
+	gini := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(2), weightedTotal),
+		new(big.Int).Mul(big.NewInt(n), total),
+	)
+	gini.Sub(gini, big.NewRat(n+1, n))
+	concentration.Gini = gini.FloatString(CONCENTRATION_PRECISION)
+	concentration.Top10Share = topShare(sortedAmounts, 10, total).FloatString(CONCENTRATION_PRECISION)
+	concentration.Top100Share = topShare(sortedAmounts, 100, total).FloatString(CONCENTRATION_PRECISION)
+
+	return concentration
+}
+
+// topShare returns the share of the total held by the largest top amounts
+func topShare(sortedAmounts []*big.Int, top int, total *big.Int) *big.Rat {
+	topTotal := new(big.Int)
+	for i := len(sortedAmounts) - 1; i >= 0 && i >= len(sortedAmounts)-top; i-- {
+		if sortedAmounts[i].Sign() > 0 {
+			topTotal.Add(topTotal, sortedAmounts[i])
+		}
+	}
+	return new(big.Rat).SetFrac(topTotal, total)
+}
@@ -0,0 +1,65 @@
+package accountranking_test
+
+import (
+	"math/big"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/accountranking"
+	"github.com/crypto-com/chain-indexing/appinterface/accountranking/view"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+var _ = Describe("NewConcentration", func() {
+	amounts := func(values ...int64) []*big.Int {
+		result := make([]*big.Int, 0, len(values))
+		for _, value := range values {
+			result = append(result, big.NewInt(value))
+		}
+		return result
+	}
+
+	It("should be zero when all accounts hold the same amount", func() {
+		concentration := accountranking.NewConcentration(view.RANKING_BY_BALANCE, amounts(5, 5, 5, 5), utctime.Now())
+
+		Expect(concentration.Accounts).To(Equal(int64(4)))
+		Expect(concentration.Total).To(Equal("20"))
+		Expect(concentration.Gini).To(Equal("0.000000"))
+		Expect(concentration.Top10Share).To(Equal("1.000000"))
+	})
+
+	It("should approach one when a single account holds everything", func() {
+		concentration := accountranking.NewConcentration(view.RANKING_BY_BALANCE, amounts(0, 0, 0, 100), utctime.Now())
+
+		Expect(concentration.Gini).To(Equal("0.750000"))
+		Expect(concentration.Top100Share).To(Equal("1.000000"))
+	})
+
+	It("should count negative amounts as zero", func() {
+		concentration := accountranking.NewConcentration(view.RANKING_BY_BALANCE, amounts(-10, 1, 3), utctime.Now())
+
+		Expect(concentration.Total).To(Equal("4"))
+		// 2 * (2 * 1 + 3 * 3) / (3 * 4) - 4 / 3
+		Expect(concentration.Gini).To(Equal("0.500000"))
+	})
+
+	It("should only count the largest amounts in the top share", func() {
+		values := make([]int64, 0, 20)
+		for i := int64(1); i <= 20; i++ {
+			values = append(values, i)
+		}
+		concentration := accountranking.NewConcentration(view.RANKING_BY_STAKED, amounts(values...), utctime.Now())
+
+		// (11 + ... + 20) / (1 + ... + 20)
+		Expect(concentration.Top10Share).To(Equal("0.738095"))
+	})
+
+	It("should be zero when there is no amount", func() {
+		concentration := accountranking.NewConcentration(view.RANKING_BY_STAKED, amounts(), utctime.Now())
+
+		Expect(concentration.Accounts).To(Equal(int64(0)))
+		Expect(concentration.Gini).To(Equal("0.000000"))
+		Expect(concentration.Top10Share).To(Equal("0.000000"))
+	})
+})
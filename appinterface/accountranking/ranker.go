@@ -0,0 +1,158 @@
+package accountranking
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/accountranking/view"
+	account_message_view "github.com/crypto-com/chain-indexing/appinterface/projection/account_message/view"
+	balance_view "github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	delegation_view "github.com/crypto-com/chain-indexing/appinterface/projection/delegation/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+const DEFAULT_RANK_INTERVAL = 1 * time.Minute
+const DEFAULT_RANK_BATCH_SIZE = uint64(1000)
+
+// Ranker periodically copies the balance, the staked amount and the number of transactions of each
+// account from the Balance, Delegation and AccountMessage projections into the account rankings. It
+// goes through the accounts in batches, one batch every interval, and measures the concentrations of
+// the balances and the staked amounts, excluding the module accounts, at the end of every round.
+type Ranker struct {
+	logger applogger.Logger
+
+	balancesView                 *balance_view.Balances
+	delegationsView              *delegation_view.Delegations
+	accountTransactionsTotalView *account_message_view.AccountTransactionsTotal
+	rankingsView                 *view.AccountRankings
+	concentrationsView           *view.AccountRankingConcentrations
+
+	moduleAccounts tmcosmosutils.ModuleAccounts
+	interval       time.Duration
+	batchSize      uint64
+
+	// Last ranked address of the current round
+	cursor string
+
+	now func() utctime.UTCTime
+}
+
+type RankerConfig struct {
+	AccountAddressPrefix string
+	Interval             time.Duration
+	BatchSize            uint64
+}
+
+func NewRanker(logger applogger.Logger, rdbHandle *rdb.Handle, config RankerConfig) *Ranker {
+	return &Ranker{
+		logger: logger.WithFields(applogger.LogFields{
+			"module": "AccountRanker",
+		}),
+
+		balancesView:                 balance_view.NewBalances(rdbHandle),
+		delegationsView:              delegation_view.NewDelegations(rdbHandle),
+		accountTransactionsTotalView: account_message_view.NewAccountTransactionsTotal(rdbHandle),
+		rankingsView:                 view.NewAccountRankings(rdbHandle),
+		concentrationsView:           view.NewAccountRankingConcentrations(rdbHandle),
+
+		moduleAccounts: tmcosmosutils.NewModuleAccounts(config.AccountAddressPrefix),
+		interval:       config.Interval,
+		batchSize:      config.BatchSize,
+
+		now: utctime.Now,
+	}
+}
+
+// Run ranks a batch every interval until the context is cancelled
+func (ranker *Ranker) Run(ctx context.Context) error {
+	for {
+		if _, err := ranker.RankBatch(); err != nil {
+			ranker.logger.Errorf("error ranking accounts: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(ranker.interval):
+		}
+	}
+}
+
+// RankBatch ranks the next batch of accounts and returns the number of accounts ranked. Once all
+// accounts are ranked, the concentrations are measured and a new round starts from the first account.
+func (ranker *Ranker) RankBatch() (int, error) {
+	balances, err := ranker.balancesView.ListAfter(ranker.cursor, ranker.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error listing balances: %v", err)
+	}
+
+	rankedAt := ranker.now()
+	for i, balance := range balances {
+		delegations, err := ranker.delegationsView.ListAllByDelegator(balance.Address)
+		if err != nil {
+			return i, fmt.Errorf("error listing delegations of %s: %v", balance.Address, err)
+		}
+		staked := new(big.Int)
+		for _, delegation := range delegations {
+			amount, ok := new(big.Int).SetString(delegation.Amount, 10)
+			if !ok {
+				return i, fmt.Errorf(
+					"error parsing delegation amount of %s on %s: %s",
+					balance.Address, delegation.ValidatorAddress, delegation.Amount,
+				)
+			}
+			staked.Add(staked, amount)
+		}
+
+		transactions, err := ranker.accountTransactionsTotalView.FindBy(balance.Address)
+		if err != nil {
+			return i, fmt.Errorf("error getting total transactions of %s: %v", balance.Address, err)
+		}
+
+		if err := ranker.rankingsView.Upsert(&view.AccountRankingRow{
+			Address:      balance.Address,
+			Balance:      balance.Balance,
+			Staked:       staked.String(),
+			Transactions: transactions,
+			RankedAt:     rankedAt,
+		}); err != nil {
+			return i, fmt.Errorf("error upserting account ranking: %v", err)
+		}
+	}
+
+	if uint64(len(balances)) < ranker.batchSize {
+		ranker.cursor = ""
+		if err := ranker.measureConcentrations(); err != nil {
+			return len(balances), err
+		}
+	} else {
+		ranker.cursor = balances[len(balances)-1].Address
+	}
+
+	return len(balances), nil
+}
+
+func (ranker *Ranker) measureConcentrations() error {
+	filter := view.AccountRankingsListFilter{
+		ExcludedAddresses: ranker.moduleAccounts.Addresses(),
+	}
+	computedAt := ranker.now()
+	for _, metric := range []view.RANKING_METRIC{view.RANKING_BY_BALANCE, view.RANKING_BY_STAKED} {
+		amounts, err := ranker.rankingsView.ListAllAmounts(filter, metric)
+		if err != nil {
+			return fmt.Errorf("error listing account %s amounts: %v", metric, err)
+		}
+
+		concentration := NewConcentration(metric, amounts, computedAt)
+		if err := ranker.concentrationsView.Upsert(&concentration); err != nil {
+			return fmt.Errorf("error upserting %s concentration: %v", metric, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package accountranking_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/accountranking"
+	"github.com/crypto-com/chain-indexing/appinterface/accountranking/view"
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	account_message_view "github.com/crypto-com/chain-indexing/appinterface/projection/account_message/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance"
+	delegation_view "github.com/crypto-com/chain-indexing/appinterface/projection/delegation/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+)
+
+var _ = Describe("Ranker", func() {
+	var conn rdb.Conn
+	var moduleAccounts tmcosmosutils.ModuleAccounts
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		moduleAccounts = tmcosmosutils.NewModuleAccounts("tcro")
+
+		MustReplayEvents(
+			balance.NewBalance(NewFakeLogger(), conn, "basetcro"),
+			[]event_entity.Event{NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+				anyGenesis.AppState.Bank.Balances = []genesis.Balance{
+					{Address: "tcro1alice", Coins: []genesis.MinDeposit{{Denom: "basetcro", Amount: "1000"}}},
+					{Address: "tcro1bob", Coins: []genesis.MinDeposit{{Denom: "basetcro", Amount: "200"}}},
+					{Address: "tcro1carol", Coins: []genesis.MinDeposit{{Denom: "basetcro", Amount: "300"}}},
+					{
+						Address: moduleAccounts.BondedTokensPool,
+						Coins:   []genesis.MinDeposit{{Denom: "basetcro", Amount: "5000"}},
+					},
+				}
+			})},
+		)
+
+		delegationsView := delegation_view.NewDelegations(conn.ToHandle())
+		for _, delegation := range []delegation_view.DelegationRow{
+			{DelegatorAddress: "tcro1bob", ValidatorAddress: "tcrocncl1a", Shares: "3000", Amount: "3000"},
+			{DelegatorAddress: "tcro1bob", ValidatorAddress: "tcrocncl1b", Shares: "2000", Amount: "2000"},
+		} {
+			Expect(delegationsView.Upsert(&delegation)).To(Succeed())
+		}
+		Expect(account_message_view.NewAccountTransactionsTotal(conn.ToHandle()).Increment("tcro1carol", 7)).To(Succeed())
+	})
+
+	list := func(by view.RANKING_METRIC, excludedAddresses []string) []view.AccountRankingRow {
+		rankings, _, err := view.NewAccountRankings(conn.ToHandle()).List(
+			view.AccountRankingsListFilter{ExcludedAddresses: excludedAddresses},
+			by,
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		return rankings
+	}
+
+	It("should rank the accounts batch by batch and measure the concentrations at the end of a round", func() {
+		ranker := accountranking.NewRanker(NewFakeLogger(), conn.ToHandle(), accountranking.RankerConfig{
+			AccountAddressPrefix: "tcro",
+			Interval:             time.Minute,
+			BatchSize:            3,
+		})
+		concentrationsView := view.NewAccountRankingConcentrations(conn.ToHandle())
+
+		ranked, err := ranker.RankBatch()
+		Expect(err).To(BeNil())
+		Expect(ranked).To(Equal(3))
+		_, err = concentrationsView.FindBy(view.RANKING_BY_BALANCE)
+		Expect(err).To(Equal(rdb.ErrNoRows))
+
+		ranked, err = ranker.RankBatch()
+		Expect(err).To(BeNil())
+		Expect(ranked).To(Equal(1))
+
+		byBalance := list(view.RANKING_BY_BALANCE, nil)
+		Expect(byBalance).To(HaveLen(4))
+		Expect(byBalance[0].Address).To(Equal(moduleAccounts.BondedTokensPool))
+		Expect(byBalance[1].Address).To(Equal("tcro1alice"))
+
+		byStaked := list(view.RANKING_BY_STAKED, moduleAccounts.Addresses())
+		Expect(byStaked).To(HaveLen(3))
+		Expect(byStaked[0].Address).To(Equal("tcro1bob"))
+		Expect(byStaked[0].Staked).To(Equal("5000"))
+
+		byTransactions := list(view.RANKING_BY_TRANSACTIONS, moduleAccounts.Addresses())
+		Expect(byTransactions[0].Address).To(Equal("tcro1carol"))
+		Expect(byTransactions[0].Transactions).To(Equal(int64(7)))
+
+		balanceConcentration, err := concentrationsView.FindBy(view.RANKING_BY_BALANCE)
+		Expect(err).To(BeNil())
+		Expect(balanceConcentration.Accounts).To(Equal(int64(3)))
+		Expect(balanceConcentration.Total).To(Equal("1500"))
+		stakedConcentration, err := concentrationsView.FindBy(view.RANKING_BY_STAKED)
+		Expect(err).To(BeNil())
+		Expect(stakedConcentration.Top10Share).To(Equal("1.000000"))
+		Expect(stakedConcentration.Gini).To(Equal("0.666667"))
+	})
+})
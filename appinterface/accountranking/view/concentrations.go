@@ -0,0 +1,138 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// AccountRankingConcentrations keeps how concentrated the balances and the staked amounts are among the
+// accounts, as of the end of the last ranking round
+type AccountRankingConcentrations struct {
+	rdb *rdb.Handle
+}
+
+func NewAccountRankingConcentrations(handle *rdb.Handle) *AccountRankingConcentrations {
+	return &AccountRankingConcentrations{
+		handle,
+	}
+}
+
+func (concentrationsView *AccountRankingConcentrations) Upsert(concentration *ConcentrationRow) error {
+	total, ok := new(big.Int).SetString(concentration.Total, 10)
+	if !ok {
+		return fmt.Errorf("error parsing concentration total: %s", concentration.Total)
+	}
+
+	sql, sqlArgs, err := concentrationsView.rdb.StmtBuilder.Insert(
+		"view_account_ranking_concentrations",
+	).Columns(
+		"metric",
+		"accounts",
+		"total",
+		"gini",
+		"top_10_share",
+		"top_100_share",
+		"computed_at",
+	).Values(
+		concentration.Metric,
+		concentration.Accounts,
+		concentrationsView.rdb.Bton(total),
+		concentration.Gini,
+		concentration.Top10Share,
+		concentration.Top100Share,
+		concentrationsView.rdb.Tton(&concentration.ComputedAt),
+	).Suffix(`ON CONFLICT (metric) DO UPDATE SET
+		accounts = EXCLUDED.accounts,
+		total = EXCLUDED.total,
+		gini = EXCLUDED.gini,
+		top_10_share = EXCLUDED.top_10_share,
+		top_100_share = EXCLUDED.top_100_share,
+		computed_at = EXCLUDED.computed_at
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building concentration upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := concentrationsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting concentration into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting concentration into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when no ranking round is completed yet
+func (concentrationsView *AccountRankingConcentrations) FindBy(metric RANKING_METRIC) (*ConcentrationRow, error) {
+	sql, sqlArgs, err := concentrationsView.rdb.StmtBuilder.Select(
+		"metric",
+		"accounts",
+		"total",
+		"gini",
+		"top_10_share",
+		"top_100_share",
+		"computed_at",
+	).From(
+		"view_account_ranking_concentrations",
+	).Where(
+		"metric = ?", metric,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building concentration selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := concentrationsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing concentration selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+
+	var concentration ConcentrationRow
+	totalReader := concentrationsView.rdb.NtobReader()
+	computedAtReader := concentrationsView.rdb.NtotReader()
+	if err = rowsResult.Scan(
+		&concentration.Metric,
+		&concentration.Accounts,
+		totalReader.ScannableArg(),
+		&concentration.Gini,
+		&concentration.Top10Share,
+		&concentration.Top100Share,
+		computedAtReader.ScannableArg(),
+	); err != nil {
+		return nil, fmt.Errorf("error scanning concentration row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	total, err := totalReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing concentration total: %v: %w", err, rdb.ErrQuery)
+	}
+	concentration.Total = total.String()
+	computedAt, err := computedAtReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing concentration time: %v: %w", err, rdb.ErrQuery)
+	}
+	concentration.ComputedAt = *computedAt
+
+	return &concentration, nil
+}
+
+// ConcentrationRow shares are decimals between 0 and 1. Gini coefficient is 0 when all accounts hold
+// the same amount and approaches 1 when a single account holds everything.
+type ConcentrationRow struct {
+	Metric      RANKING_METRIC  `json:"metric"`
+	Accounts    int64           `json:"accounts"`
+	Total       string          `json:"total"`
+	Gini        string          `json:"gini"`
+	Top10Share  string          `json:"top10Share"`
+	Top100Share string          `json:"top100Share"`
+	ComputedAt  utctime.UTCTime `json:"computedAt"`
+}
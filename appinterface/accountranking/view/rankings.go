@@ -0,0 +1,235 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type RANKING_METRIC = string
+
+const (
+	RANKING_BY_BALANCE      RANKING_METRIC = "balance"
+	RANKING_BY_STAKED       RANKING_METRIC = "staked"
+	RANKING_BY_TRANSACTIONS RANKING_METRIC = "transactions"
+)
+
+// AccountRankings keeps the base denom balance, the staked amount and the number of transactions of
+// each account as of the last time the account is ranked
+type AccountRankings struct {
+	rdb *rdb.Handle
+}
+
+func NewAccountRankings(handle *rdb.Handle) *AccountRankings {
+	return &AccountRankings{
+		handle,
+	}
+}
+
+func (rankingsView *AccountRankings) Upsert(ranking *AccountRankingRow) error {
+	balance, ok := new(big.Int).SetString(ranking.Balance, 10)
+	if !ok {
+		return fmt.Errorf("error parsing account ranking balance: %s", ranking.Balance)
+	}
+	staked, ok := new(big.Int).SetString(ranking.Staked, 10)
+	if !ok {
+		return fmt.Errorf("error parsing account ranking staked amount: %s", ranking.Staked)
+	}
+
+	sql, sqlArgs, err := rankingsView.rdb.StmtBuilder.Insert(
+		"view_account_rankings",
+	).Columns(
+		"address",
+		"balance",
+		"staked",
+		"transactions",
+		"ranked_at",
+	).Values(
+		ranking.Address,
+		rankingsView.rdb.Bton(balance),
+		rankingsView.rdb.Bton(staked),
+		ranking.Transactions,
+		rankingsView.rdb.Tton(&ranking.RankedAt),
+	).Suffix(`ON CONFLICT (address) DO UPDATE SET
+		balance = EXCLUDED.balance,
+		staked = EXCLUDED.staked,
+		transactions = EXCLUDED.transactions,
+		ranked_at = EXCLUDED.ranked_at
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building account ranking upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := rankingsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting account ranking into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting account ranking into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type AccountRankingsListFilter struct {
+	ExcludedAddresses []string
+}
+
+// List returns the accounts ordered by the metric from the largest, with ties broken by address
+func (rankingsView *AccountRankings) List(
+	filter AccountRankingsListFilter,
+	by RANKING_METRIC,
+	pagination *pagination_interface.Pagination,
+) ([]AccountRankingRow, *pagination_interface.PaginationResult, error) {
+	if !IsValidRankingMetric(by) {
+		return nil, nil, fmt.Errorf("invalid account ranking metric: %s", by)
+	}
+
+	stmtBuilder := rankingsView.selectStmtBuilder().OrderBy(
+		fmt.Sprintf("%s DESC", by), "address",
+	)
+	if len(filter.ExcludedAddresses) > 0 {
+		stmtBuilder = stmtBuilder.Where(sq.NotEq{"address": filter.ExcludedAddresses})
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		rankingsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building account rankings select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := rankingsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing account rankings select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	rankings := make([]AccountRankingRow, 0)
+	for rowsResult.Next() {
+		ranking, scanErr := rankingsView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		rankings = append(rankings, *ranking)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return rankings, paginationResult, nil
+}
+
+// ListAllAmounts returns the balances or staked amounts of all accounts in ascending order without
+// pagination. Negative amounts are returned as is.
+func (rankingsView *AccountRankings) ListAllAmounts(
+	filter AccountRankingsListFilter,
+	by RANKING_METRIC,
+) ([]*big.Int, error) {
+	if by != RANKING_BY_BALANCE && by != RANKING_BY_STAKED {
+		return nil, fmt.Errorf("invalid account ranking amount metric: %s", by)
+	}
+
+	stmtBuilder := rankingsView.rdb.StmtBuilder.Select(
+		by,
+	).From(
+		"view_account_rankings",
+	).OrderBy(by)
+	if len(filter.ExcludedAddresses) > 0 {
+		stmtBuilder = stmtBuilder.Where(sq.NotEq{"address": filter.ExcludedAddresses})
+	}
+	sql, sqlArgs, err := stmtBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building account ranking amounts select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := rankingsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing account ranking amounts select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	amounts := make([]*big.Int, 0)
+	for rowsResult.Next() {
+		amountReader := rankingsView.rdb.NtobReader()
+		if scanErr := rowsResult.Scan(amountReader.ScannableArg()); scanErr != nil {
+			return nil, fmt.Errorf("error scanning account ranking amount row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		amount, parseErr := amountReader.Parse()
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing account ranking amount: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		amounts = append(amounts, amount)
+	}
+
+	return amounts, nil
+}
+
+func (rankingsView *AccountRankings) selectStmtBuilder() sq.SelectBuilder {
+	return rankingsView.rdb.StmtBuilder.Select(
+		"address",
+		"balance",
+		"staked",
+		"transactions",
+		"ranked_at",
+	).From(
+		"view_account_rankings",
+	)
+}
+
+func (rankingsView *AccountRankings) scan(rowsResult rdb.RowsResult) (*AccountRankingRow, error) {
+	var ranking AccountRankingRow
+	balanceReader := rankingsView.rdb.NtobReader()
+	stakedReader := rankingsView.rdb.NtobReader()
+	rankedAtReader := rankingsView.rdb.NtotReader()
+	if err := rowsResult.Scan(
+		&ranking.Address,
+		balanceReader.ScannableArg(),
+		stakedReader.ScannableArg(),
+		&ranking.Transactions,
+		rankedAtReader.ScannableArg(),
+	); err != nil {
+		return nil, fmt.Errorf("error scanning account ranking row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	balance, err := balanceReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account ranking balance: %v: %w", err, rdb.ErrQuery)
+	}
+	ranking.Balance = balance.String()
+	staked, err := stakedReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account ranking staked amount: %v: %w", err, rdb.ErrQuery)
+	}
+	ranking.Staked = staked.String()
+	rankedAt, err := rankedAtReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account ranking time: %v: %w", err, rdb.ErrQuery)
+	}
+	ranking.RankedAt = *rankedAt
+
+	return &ranking, nil
+}
+
+func IsValidRankingMetric(by string) bool {
+	return by == RANKING_BY_BALANCE || by == RANKING_BY_STAKED || by == RANKING_BY_TRANSACTIONS
+}
+
+// AccountRankingRow amounts are in base denom
+type AccountRankingRow struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+	// Tokens of all delegations of the account
+	Staked       string          `json:"staked"`
+	Transactions int64           `json:"transactions"`
+	RankedAt     utctime.UTCTime `json:"rankedAt"`
+}
@@ -1,6 +1,8 @@
 package cosmosapp
 
 type Client interface {
+	Account(accountAddress string) (*Account, error)
+	Balance(targetAddress string, targetDenom string) (*AccountBalance, error)
 	Validator(validatorAddress string) (*Validator, error)
 	Delegation(delegator string, validator string) (*DelegationResponse, error)
 }
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/crypto-com/chain-indexing/appinterface/cosmosapp"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func NewMockClient() *MockClient {
+	return &MockClient{}
+}
+
+func (client *MockClient) Account(accountAddress string) (*cosmosapp.Account, error) {
+	args := client.Called(accountAddress)
+	result, _ := args.Get(0).(*cosmosapp.Account)
+	return result, args.Error(1)
+}
+
+func (client *MockClient) Balance(targetAddress string, targetDenom string) (*cosmosapp.AccountBalance, error) {
+	args := client.Called(targetAddress, targetDenom)
+	result, _ := args.Get(0).(*cosmosapp.AccountBalance)
+	return result, args.Error(1)
+}
+
+func (client *MockClient) Validator(validatorAddress string) (*cosmosapp.Validator, error) {
+	args := client.Called(validatorAddress)
+	result, _ := args.Get(0).(*cosmosapp.Validator)
+	return result, args.Error(1)
+}
+
+func (client *MockClient) Delegation(delegator string, validator string) (*cosmosapp.DelegationResponse, error) {
+	args := client.Called(delegator, validator)
+	result, _ := args.Get(0).(*cosmosapp.DelegationResponse)
+	return result, args.Error(1)
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	sq "github.com/Masterminds/squirrel"
+
 	"github.com/crypto-com/chain-indexing/appinterface/rdb"
 	entity_event "github.com/crypto-com/chain-indexing/entity/event"
 )
@@ -19,7 +21,7 @@ const DEFAULT_TABLE = "events"
 // | version | INT64     | NOT NULL    |
 // | payload | JSONB     | NOT NULL    |
 
-var _ entity_event.Store = &RDbStore{}
+var _ entity_event.FilterableStore = &RDbStore{}
 
 // EventStore implemented using relational database
 type RDbStore struct {
@@ -62,13 +64,46 @@ func (store *RDbStore) GetLatestHeight() (*int64, error) {
 }
 
 func (store *RDbStore) GetAllByHeight(height int64) ([]entity_event.Event, error) {
-	sql, args, err := store.rdbHandle.StmtBuilder.Select(
+	return store.getAllByHeightWhere(height, nil)
+}
+
+// GetAllByHeightWithFilters returns all events at the height matching any of the filters. Filtering
+// is done in the database such that unmatched events are never decoded.
+func (store *RDbStore) GetAllByHeightWithFilters(
+	height int64,
+	filters []entity_event.Filter,
+) ([]entity_event.Event, error) {
+	if len(filters) == 0 {
+		return []entity_event.Event{}, nil
+	}
+
+	filterConditions := make(sq.Or, 0, len(filters))
+	for _, filter := range filters {
+		condition := sq.And{
+			sq.Eq{"name": filter.Name},
+			sq.GtOrEq{"version": filter.MinVersion},
+		}
+		if filter.MaybeMaxVersion != nil {
+			condition = append(condition, sq.LtOrEq{"version": *filter.MaybeMaxVersion})
+		}
+		filterConditions = append(filterConditions, condition)
+	}
+
+	return store.getAllByHeightWhere(height, filterConditions)
+}
+
+func (store *RDbStore) getAllByHeightWhere(height int64, maybeCondition sq.Sqlizer) ([]entity_event.Event, error) {
+	stmtBuilder := store.rdbHandle.StmtBuilder.Select(
 		"uuid", "height", "name", "version", "payload",
 	).From(
 		store.table,
 	).Where(
 		"height = ?", height,
-	).OrderBy("id").ToSql()
+	)
+	if maybeCondition != nil {
+		stmtBuilder = stmtBuilder.Where(maybeCondition)
+	}
+	sql, args, err := stmtBuilder.OrderBy("id").ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("error building get all events by height selection SQL: %v", err)
 	}
@@ -1,9 +1,15 @@
 package eventhandler
 
-import "github.com/crypto-com/chain-indexing/entity/event"
+import (
+	"context"
+
+	"github.com/crypto-com/chain-indexing/entity/event"
+)
 
 type Handler interface {
 	GetLastHandledEventHeight() (*int64, error)
 
-	HandleEvents(blockHeight int64, events []event.Event) error
+	// HandleEvents handles the events of the block height. It returns the context error when the context
+	// is done before the events are handled.
+	HandleEvents(ctx context.Context, blockHeight int64, events []event.Event) error
 }
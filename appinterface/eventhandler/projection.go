@@ -1,68 +1,119 @@
 package eventhandler
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/crypto-com/chain-indexing/entity/event"
 	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/metrics"
 )
 
 var _ Handler = &ProjectionHandler{}
 
+const DEPENDENCIES_WAIT_INTERVAL = time.Second
+
 type ProjectionHandler struct {
 	logger     applogger.Logger
 	projection projection_entity.Projection
+
+	subscriptions []projection_entity.EventSubscription
+
+	maybeDependencyGraph *projection_entity.DependencyGraph
+
+	metrics metrics.Metrics
 }
 
 func NewProjectionHandler(logger applogger.Logger, projection projection_entity.Projection) *ProjectionHandler {
 	return &ProjectionHandler{
 		logger,
 		projection,
+
+		projection_entity.SubscriptionsOf(projection),
+
+		nil,
+
+		metrics.NewNoopMetrics(),
 	}
 }
 
+// WithDependencyGraph makes the handler wait for the projection dependencies to handle a height
+// before handling the same height.
+func (handler *ProjectionHandler) WithDependencyGraph(graph *projection_entity.DependencyGraph) *ProjectionHandler {
+	handler.maybeDependencyGraph = graph
+
+	return handler
+}
+
+// WithMetrics records the projection progress and event handling latency to the metrics
+func (handler *ProjectionHandler) WithMetrics(metrics metrics.Metrics) *ProjectionHandler {
+	handler.metrics = metrics
+
+	return handler
+}
+
 func (handler *ProjectionHandler) GetLastHandledEventHeight() (*int64, error) {
-	return handler.projection.GetLastHandledEventHeight()
+	maybeLastHandledEventHeight, err := handler.projection.GetLastHandledEventHeight()
+	if err != nil {
+		return nil, err
+	}
+	if maybeLastHandledEventHeight != nil {
+		handler.metrics.SetProjectionLastHandledHeight(handler.projection.Id(), *maybeLastHandledEventHeight)
+	}
+
+	return maybeLastHandledEventHeight, nil
 }
 
-func (handler *ProjectionHandler) HandleEvents(blockHeight int64, events []event.Event) error {
+func (handler *ProjectionHandler) HandleEvents(ctx context.Context, blockHeight int64, events []event.Event) error {
 	logger := handler.logger.WithFields(applogger.LogFields{
 		"height": blockHeight,
 	})
 
-	filteredEvents := make([]event.Event, 0)
-	for _, event := range events {
-		if !isListeningEvent(event, handler.projection.GetEventsToListen()) {
-			//eventLogger.WithFields(applogger.LogFields{
-			//	"eventName": event.Name(),
-			//}).Debugf("skipping because event is not one of the listening events")
-			continue
-		}
-		filteredEvents = append(filteredEvents, event)
+	if err := handler.waitForDependencies(ctx, blockHeight); err != nil {
+		return fmt.Errorf("error waiting for projection dependencies: %v", err)
 	}
 
+	filteredEvents := projection_entity.FilterSubscribedEvents(events, handler.subscriptions)
+
 	logger = logger.WithFields(applogger.LogFields{
 		"eventCount": len(filteredEvents),
 	})
+	startTime := time.Now()
 	if err := handler.projection.HandleEvents(blockHeight, filteredEvents); err != nil {
 		logger.WithFields(applogger.LogFields{
 			"events": filteredEvents,
 		}).Errorf("error handling filtered events: %v", err)
 		return fmt.Errorf("error handling filtered events: %v", err)
 	}
+	handler.metrics.ObserveProjectionHandleEvents(handler.projection.Id(), len(filteredEvents), time.Since(startTime))
+	handler.metrics.SetProjectionLastHandledHeight(handler.projection.Id(), blockHeight)
 
 	logger.Infof("successfully handled events")
 	return nil
 }
 
-func isListeningEvent(event event.Event, eventsToListen []string) bool {
-	targetEventName := event.Name()
-	for _, eventName := range eventsToListen {
-		if targetEventName == eventName {
-			return true
-		}
+// waitForDependencies blocks until the projection dependencies handled the height or the context is done
+func (handler *ProjectionHandler) waitForDependencies(ctx context.Context, blockHeight int64) error {
+	if handler.maybeDependencyGraph == nil {
+		return nil
 	}
 
-	return false
+	for {
+		handled, err := handler.maybeDependencyGraph.AreDependenciesHandled(handler.projection.Id(), blockHeight)
+		if err != nil {
+			return err
+		}
+		if handled {
+			return nil
+		}
+
+		handler.logger.Debugf("waiting for dependencies to handle height %d", blockHeight)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(DEPENDENCIES_WAIT_INTERVAL):
+		}
+	}
 }
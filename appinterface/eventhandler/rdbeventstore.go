@@ -1,6 +1,7 @@
 package eventhandler
 
 import (
+	"context"
 	"fmt"
 
 	event_interface "github.com/crypto-com/chain-indexing/appinterface/event"
@@ -42,7 +43,7 @@ func (handler *RDbEventStoreHandler) GetLastHandledEventHeight() (*int64, error)
 	return handler.statusStore.GetLastIndexedBlockHeight()
 }
 
-func (handler *RDbEventStoreHandler) HandleEvents(blockHeight int64, events []event.Event) error {
+func (handler *RDbEventStoreHandler) HandleEvents(_ context.Context, blockHeight int64, events []event.Event) error {
 	handler.logger.Debug("start persisting blocks events")
 	tx, err := handler.rdbConn.Begin()
 	if err != nil {
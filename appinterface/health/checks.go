@@ -0,0 +1,145 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/polling"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// NewDatabaseCheck checks the database connectivity
+func NewDatabaseCheck(rdbHandle *rdb.Handle) Check {
+	return func() []Component {
+		var one int64
+		if err := rdbHandle.QueryRow("SELECT 1").Scan(&one); err != nil {
+			return []Component{NewDownComponent("database", fmt.Sprintf("error querying database: %v", err), nil)}
+		}
+		return []Component{NewUpComponent("database", nil)}
+	}
+}
+
+// NewLastSyncedBlockCheck checks the time of the last block projected into the blocks view is within
+// the max age. It requires the Block projection.
+func NewLastSyncedBlockCheck(rdbHandle *rdb.Handle, maxAge time.Duration) Check {
+	return func() []Component {
+		const name = "lastSyncedBlock"
+
+		sql, sqlArgs, err := rdbHandle.StmtBuilder.Select(
+			"height", "time",
+		).From(
+			"view_blocks",
+		).OrderBy(
+			"height DESC",
+		).Limit(1).ToSql()
+		if err != nil {
+			return []Component{NewDownComponent(name, fmt.Sprintf("error building last block selection sql: %v", err), nil)}
+		}
+
+		var height int64
+		timeReader := rdbHandle.NtotReader()
+		if err = rdbHandle.QueryRow(sql, sqlArgs...).Scan(&height, timeReader.ScannableArg()); err != nil {
+			if errors.Is(err, rdb.ErrNoRows) {
+				return []Component{NewDownComponent(name, "no block has been synced yet", nil)}
+			}
+			return []Component{NewDownComponent(name, fmt.Sprintf("error scanning last block: %v", err), nil)}
+		}
+		blockTime, err := timeReader.Parse()
+		if err != nil {
+			return []Component{NewDownComponent(name, fmt.Sprintf("error parsing last block time: %v", err), nil)}
+		}
+
+		age := time.Duration(utctime.Now().UnixNano() - blockTime.UnixNano())
+		details := map[string]interface{}{
+			"height": height,
+			"time":   blockTime,
+			"age":    age.Round(time.Second).String(),
+			"maxAge": maxAge.String(),
+		}
+		if age > maxAge {
+			return []Component{NewDownComponent(
+				name, fmt.Sprintf("last synced block is older than %v", maxAge), details,
+			)}
+		}
+		return []Component{NewUpComponent(name, details)}
+	}
+}
+
+// NewProjectionsLagCheck checks the number of blocks each projection is behind the chain latest
+// height, as polled by the InfoManager, is within the max lag
+func NewProjectionsLagCheck(
+	rdbHandle *rdb.Handle, projections []entity_projection.Projection, maxLag int64,
+) Check {
+	statusView := polling.NewStatus(rdbHandle)
+
+	return func() []Component {
+		components := make([]Component, 0, len(projections))
+
+		var latestHeight int64
+		rawLatestHeight, err := statusView.FindBy("LatestHeight")
+		if err == nil {
+			latestHeight, err = strconv.ParseInt(rawLatestHeight, 10, 64)
+		}
+		if err != nil {
+			message := fmt.Sprintf("error getting chain latest height: %v", err)
+			if errors.Is(err, rdb.ErrNoRows) {
+				message = "chain latest height is unknown yet"
+			}
+			for _, projection := range projections {
+				components = append(components, NewDownComponent(projectionComponentName(projection), message, nil))
+			}
+			return components
+		}
+
+		for _, projection := range projections {
+			name := projectionComponentName(projection)
+
+			maybeLastHandledHeight, err := projection.GetLastHandledEventHeight()
+			if err != nil {
+				components = append(components, NewDownComponent(
+					name, fmt.Sprintf("error getting last handled height: %v", err), nil,
+				))
+				continue
+			}
+
+			// Nothing handled means the projection is behind by the whole chain
+			lag := latestHeight + 1
+			if maybeLastHandledHeight != nil {
+				lag = latestHeight - *maybeLastHandledHeight
+			}
+			details := map[string]interface{}{
+				"lastHandledHeight": maybeLastHandledHeight,
+				"latestHeight":      latestHeight,
+				"lag":               lag,
+				"maxLag":            maxLag,
+			}
+			if lag > maxLag {
+				components = append(components, NewDownComponent(
+					name, fmt.Sprintf("projection is more than %d blocks behind", maxLag), details,
+				))
+				continue
+			}
+			components = append(components, NewUpComponent(name, details))
+		}
+
+		return components
+	}
+}
+
+func projectionComponentName(projection entity_projection.Projection) string {
+	return fmt.Sprintf("projection:%s", projection.Id())
+}
+
+// NewRPCCheck checks the reachability of a RPC server with the ping function
+func NewRPCCheck(name string, ping func() error) Check {
+	return func() []Component {
+		if err := ping(); err != nil {
+			return []Component{NewDownComponent(name, fmt.Sprintf("error reaching RPC: %v", err), nil)}
+		}
+		return []Component{NewUpComponent(name, nil)}
+	}
+}
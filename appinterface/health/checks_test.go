@@ -0,0 +1,128 @@
+package health_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/health"
+	"github.com/crypto-com/chain-indexing/appinterface/polling"
+	block_view "github.com/crypto-com/chain-indexing/appinterface/projection/block/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
+	. "github.com/crypto-com/chain-indexing/entity/projection/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+)
+
+var _ = Describe("Checks", func() {
+	var rdbHandle *rdb.Handle
+	BeforeEach(func() {
+		rdbHandle = MustNewTestRDbConn().ToHandle()
+	})
+
+	newProjection := func(id string, maybeLastHandledHeight *int64) *MockProjection {
+		projection := NewMockProjection()
+		projection.On("Id").Return(id)
+		projection.On("GetLastHandledEventHeight").Return(maybeLastHandledHeight, nil)
+		return projection
+	}
+
+	Describe("RunChecks", func() {
+		It("should report up when all components are up", func() {
+			report := health.RunChecks([]health.Check{
+				health.NewDatabaseCheck(rdbHandle),
+				health.NewRPCCheck("tendermint", func() error { return nil }),
+			})
+
+			Expect(report.IsUp()).To(BeTrue())
+			Expect(report.Components).To(HaveLen(2))
+			Expect(report.Components[0].Name).To(Equal("database"))
+			Expect(report.Components[1].Name).To(Equal("tendermint"))
+		})
+
+		It("should report down when any component is down", func() {
+			report := health.RunChecks([]health.Check{
+				health.NewDatabaseCheck(rdbHandle),
+				health.NewRPCCheck("tendermint", func() error { return errors.New("connection refused") }),
+			})
+
+			Expect(report.IsUp()).To(BeFalse())
+			Expect(report.Components[0].Status).To(Equal(health.STATUS_UP))
+			Expect(report.Components[1].Status).To(Equal(health.STATUS_DOWN))
+			Expect(*report.Components[1].MaybeMessage).To(ContainSubstring("connection refused"))
+		})
+	})
+
+	Describe("NewLastSyncedBlockCheck", func() {
+		insertBlock := func(height int64, blockTime utctime.UTCTime) {
+			Expect(block_view.NewBlocks(rdbHandle).Insert(&block_view.Block{
+				Height:                height,
+				Hash:                  fmt.Sprintf("hash%d", height),
+				Time:                  blockTime,
+				AppHash:               "apphash",
+				TransactionCount:      0,
+				CommittedCouncilNodes: []block_view.BlockCommittedCouncilNode{},
+			})).To(Succeed())
+		}
+
+		It("should be down when no block has been synced", func() {
+			components := health.NewLastSyncedBlockCheck(rdbHandle, time.Minute)()
+
+			Expect(components).To(HaveLen(1))
+			Expect(components[0].Status).To(Equal(health.STATUS_DOWN))
+		})
+
+		It("should be up when the last synced block is within the max age", func() {
+			insertBlock(1, utctime.FromUnixNano(utctime.Now().UnixNano()-time.Hour.Nanoseconds()))
+			insertBlock(2, utctime.Now())
+
+			components := health.NewLastSyncedBlockCheck(rdbHandle, time.Minute)()
+
+			Expect(components[0].Status).To(Equal(health.STATUS_UP))
+			Expect(components[0].Details["height"]).To(Equal(int64(2)))
+		})
+
+		It("should be down when the last synced block is older than the max age", func() {
+			insertBlock(1, utctime.FromUnixNano(utctime.Now().UnixNano()-time.Hour.Nanoseconds()))
+
+			components := health.NewLastSyncedBlockCheck(rdbHandle, time.Minute)()
+
+			Expect(components[0].Status).To(Equal(health.STATUS_DOWN))
+		})
+	})
+
+	Describe("NewProjectionsLagCheck", func() {
+		It("should be down for every projection when the latest height is unknown", func() {
+			components := health.NewProjectionsLagCheck(rdbHandle, []entity_projection.Projection{
+				newProjection("Block", primptr.Int64(10)),
+			}, 5)()
+
+			Expect(components).To(HaveLen(1))
+			Expect(components[0].Name).To(Equal("projection:Block"))
+			Expect(components[0].Status).To(Equal(health.STATUS_DOWN))
+		})
+
+		It("should report the lag of each projection against the latest height", func() {
+			Expect(polling.NewStatus(rdbHandle).Insert("LatestHeight", "100")).To(Succeed())
+
+			components := health.NewProjectionsLagCheck(rdbHandle, []entity_projection.Projection{
+				newProjection("Block", primptr.Int64(98)),
+				newProjection("Transaction", primptr.Int64(90)),
+				newProjection("Validator", nil),
+			}, 5)()
+
+			Expect(components).To(HaveLen(3))
+			Expect(components[0].Status).To(Equal(health.STATUS_UP))
+			Expect(components[0].Details["lag"]).To(Equal(int64(2)))
+			Expect(components[1].Name).To(Equal("projection:Transaction"))
+			Expect(components[1].Status).To(Equal(health.STATUS_DOWN))
+			Expect(components[1].Details["lag"]).To(Equal(int64(10)))
+			Expect(components[2].Status).To(Equal(health.STATUS_DOWN))
+		})
+	})
+})
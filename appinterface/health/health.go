@@ -0,0 +1,62 @@
+package health
+
+const STATUS_UP = "UP"
+const STATUS_DOWN = "DOWN"
+
+// Check checks the health of one or more components
+type Check = func() []Component
+
+type Component struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Reason of being down
+	MaybeMessage *string                `json:"message"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+}
+
+func NewUpComponent(name string, details map[string]interface{}) Component {
+	return Component{
+		Name:         name,
+		Status:       STATUS_UP,
+		MaybeMessage: nil,
+		Details:      details,
+	}
+}
+
+func NewDownComponent(name string, message string, details map[string]interface{}) Component {
+	return Component{
+		Name:         name,
+		Status:       STATUS_DOWN,
+		MaybeMessage: &message,
+		Details:      details,
+	}
+}
+
+// Report is the health of all the checked components. It is up only when all the components are
+// up.
+type Report struct {
+	Status     string      `json:"status"`
+	Components []Component `json:"components"`
+}
+
+func (report *Report) IsUp() bool {
+	return report.Status == STATUS_UP
+}
+
+// RunChecks runs the checks in order and reports the components health
+func RunChecks(checks []Check) *Report {
+	report := &Report{
+		Status:     STATUS_UP,
+		Components: make([]Component, 0, len(checks)),
+	}
+	for _, check := range checks {
+		for _, component := range check() {
+			if component.Status != STATUS_UP {
+				report.Status = STATUS_DOWN
+			}
+			report.Components = append(report.Components, component)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,182 @@
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+const DEFAULT_LEASE_NAME = "indexer"
+const DEFAULT_LEASE_DURATION = 15 * time.Second
+const DEFAULT_RENEW_INTERVAL = 5 * time.Second
+
+// Elector elects a single leader among the instances sharing the same database using a lease. The
+// leader renews the lease periodically while the standbys keep trying to acquire it, such that a
+// standby takes over once the leader stops renewing.
+type Elector struct {
+	logger     applogger.Logger
+	leasesView *Leases
+
+	leaseName     string
+	holderId      string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+
+	rwMutex sync.RWMutex
+	// Generation of the lease held, nil when not leading
+	maybeGeneration *int64
+}
+
+type ElectorConfig struct {
+	LeaseName string
+	// Unique identity of the instance
+	HolderId string
+	// Leader not renewing the lease within the duration loses the leadership
+	LeaseDuration time.Duration
+	// Must be shorter than LeaseDuration. The leader steps down when it fails to renew the lease
+	// for LeaseDuration - RenewInterval such that it stops before the others can take over.
+	RenewInterval time.Duration
+}
+
+func NewElector(logger applogger.Logger, rdbHandle *rdb.Handle, config ElectorConfig) (*Elector, error) {
+	if config.HolderId == "" {
+		return nil, fmt.Errorf("error creating leader elector: missing holder id")
+	}
+	if config.RenewInterval <= 0 || config.RenewInterval >= config.LeaseDuration {
+		return nil, fmt.Errorf(
+			"error creating leader elector: renew interval %v must be positive and shorter than lease duration %v",
+			config.RenewInterval, config.LeaseDuration,
+		)
+	}
+
+	return &Elector{
+		logger: logger.WithFields(applogger.LogFields{
+			"module":   "LeaderElector",
+			"holderId": config.HolderId,
+		}),
+		leasesView: NewLeases(rdbHandle),
+
+		leaseName:     config.LeaseName,
+		holderId:      config.HolderId,
+		leaseDuration: config.LeaseDuration,
+		renewInterval: config.RenewInterval,
+	}, nil
+}
+
+func (elector *Elector) HolderId() string {
+	return elector.holderId
+}
+
+// IsLeader returns true when this instance currently holds the leadership
+func (elector *Elector) IsLeader() bool {
+	elector.rwMutex.RLock()
+	defer elector.rwMutex.RUnlock()
+
+	return elector.maybeGeneration != nil
+}
+
+// Run campaigns for the leadership until the context is done. Each time the leadership is acquired,
+// `lead` is run with a context cancelled when the leadership is lost or the context is done. Returns
+// the error of `lead` when it fails while leading.
+func (elector *Elector) Run(ctx context.Context, lead func(ctx context.Context) error) error {
+	elector.logger.Info("campaigning for leadership")
+	for {
+		maybeGeneration, err := elector.leasesView.TryAcquire(elector.leaseName, elector.holderId, elector.leaseDuration)
+		if err != nil {
+			elector.logger.Errorf("error trying to acquire leadership: %v", err)
+		} else if maybeGeneration != nil {
+			if leadErr := elector.lead(ctx, *maybeGeneration, lead); leadErr != nil {
+				return leadErr
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(elector.renewInterval):
+		}
+	}
+}
+
+func (elector *Elector) lead(ctx context.Context, generation int64, lead func(ctx context.Context) error) error {
+	elector.logger.Infof("acquired leadership of generation %d", generation)
+	elector.setGeneration(&generation)
+	defer elector.setGeneration(nil)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	leadErrCh := make(chan error, 1)
+	go func() {
+		leadErrCh <- lead(leaderCtx)
+	}()
+
+	lastRenewedAt := time.Now()
+	for {
+		select {
+		case leadErr := <-leadErrCh:
+			elector.release()
+			if ctx.Err() != nil {
+				return nil
+			}
+			if leadErr != nil {
+				return fmt.Errorf("error leading: %v", leadErr)
+			}
+			return fmt.Errorf("error leading: stopped unexpectedly")
+		case <-time.After(elector.renewInterval):
+		}
+
+		maybeGeneration, err := elector.leasesView.TryAcquire(elector.leaseName, elector.holderId, elector.leaseDuration)
+		if err == nil && maybeGeneration != nil && *maybeGeneration == generation {
+			lastRenewedAt = time.Now()
+			continue
+		}
+
+		if err != nil && time.Since(lastRenewedAt) < elector.leaseDuration-elector.renewInterval {
+			elector.logger.Errorf("error renewing leadership, will retry: %v", err)
+			continue
+		}
+
+		if err != nil {
+			elector.logger.Errorf("lost leadership: error renewing leadership: %v", err)
+		} else if maybeGeneration != nil {
+			elector.logger.Error("lost leadership: lease expired before it was renewed")
+		} else {
+			elector.logger.Error("lost leadership: lease acquired by another instance")
+		}
+		// Writes through the fenced connections fail from now on while leading is drained
+		elector.setGeneration(nil)
+		cancel()
+		if leadErr := <-leadErrCh; leadErr != nil {
+			elector.logger.Errorf("error stopping after losing leadership: %v", leadErr)
+		}
+		return nil
+	}
+}
+
+func (elector *Elector) release() {
+	if err := elector.leasesView.Release(elector.leaseName, elector.holderId); err != nil {
+		elector.logger.Errorf("error releasing leadership: %v", err)
+		return
+	}
+	elector.logger.Info("released leadership")
+}
+
+// generation returns the generation of the lease held, nil when not leading
+func (elector *Elector) generation() *int64 {
+	elector.rwMutex.RLock()
+	defer elector.rwMutex.RUnlock()
+
+	return elector.maybeGeneration
+}
+
+func (elector *Elector) setGeneration(maybeGeneration *int64) {
+	elector.rwMutex.Lock()
+	defer elector.rwMutex.Unlock()
+
+	elector.maybeGeneration = maybeGeneration
+}
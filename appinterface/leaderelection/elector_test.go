@@ -0,0 +1,151 @@
+package leaderelection_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/leaderelection"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	. "github.com/crypto-com/chain-indexing/test"
+)
+
+var _ = Describe("Elector", func() {
+	var conn rdb.Conn
+	var rdbHandle *rdb.Handle
+	BeforeEach(func() {
+		conn = MustNewInMemoryRDbConn()
+		rdbHandle = conn.ToHandle()
+	})
+
+	newElector := func(holderId string) *leaderelection.Elector {
+		elector, err := leaderelection.NewElector(NewFakeLogger(), rdbHandle, leaderelection.ElectorConfig{
+			LeaseName:     "indexer",
+			HolderId:      holderId,
+			LeaseDuration: 300 * time.Millisecond,
+			RenewInterval: 50 * time.Millisecond,
+		})
+		Expect(err).To(BeNil())
+		return elector
+	}
+
+	leadUntilDone := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	It("should return error when renew interval is not shorter than lease duration", func() {
+		_, err := leaderelection.NewElector(NewFakeLogger(), rdbHandle, leaderelection.ElectorConfig{
+			LeaseName:     "indexer",
+			HolderId:      "A",
+			LeaseDuration: time.Second,
+			RenewInterval: time.Second,
+		})
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should elect a single leader and fail over when the leader stops", func() {
+		electorA := newElector("A")
+		electorB := newElector("B")
+
+		ctxA, cancelA := context.WithCancel(context.Background())
+		stoppedA := make(chan error, 1)
+		go func() {
+			stoppedA <- electorA.Run(ctxA, leadUntilDone)
+		}()
+		Eventually(electorA.IsLeader, time.Second).Should(BeTrue())
+
+		ctxB, cancelB := context.WithCancel(context.Background())
+		defer cancelB()
+		go func() {
+			_ = electorB.Run(ctxB, leadUntilDone)
+		}()
+		Consistently(electorB.IsLeader, 500*time.Millisecond).Should(BeFalse())
+
+		cancelA()
+		Eventually(stoppedA, time.Second).Should(Receive(BeNil()))
+		Expect(electorA.IsLeader()).To(BeFalse())
+		Eventually(electorB.IsLeader, time.Second).Should(BeTrue())
+
+		lease, err := leaderelection.NewLeases(rdbHandle).FindBy("indexer")
+		Expect(err).To(BeNil())
+		Expect(lease.HolderId).To(Equal("B"))
+	})
+
+	It("should return error when leading fails", func() {
+		elector := newElector("A")
+
+		err := elector.Run(context.Background(), func(ctx context.Context) error {
+			return errors.New("any error")
+		})
+		Expect(err).To(MatchError("error leading: any error"))
+		Expect(elector.IsLeader()).To(BeFalse())
+	})
+
+	Describe("FenceConn", func() {
+		BeforeEach(func() {
+			_, err := conn.Exec("CREATE TABLE fenced_writes (id BIGINT NOT NULL)")
+			Expect(err).To(BeNil())
+		})
+
+		countWrites := func() int64 {
+			var count int64
+			Expect(conn.QueryRow("SELECT COUNT(*) FROM fenced_writes").Scan(&count)).To(Succeed())
+			return count
+		}
+
+		It("should only write while holding the lease of its generation", func() {
+			elector := newElector("A")
+			fencedConn := elector.FenceConn(conn)
+
+			_, err := fencedConn.Exec("INSERT INTO fenced_writes (id) VALUES (1)")
+			Expect(err).To(MatchError(leaderelection.ErrLeaseLost))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stopped := make(chan error, 1)
+			go func() {
+				stopped <- elector.Run(ctx, func(ctx context.Context) error {
+					if _, err := fencedConn.Exec("INSERT INTO fenced_writes (id) VALUES (2)"); err != nil {
+						return err
+					}
+
+					// Another instance takes over while this leader has not yet noticed
+					leasesView := leaderelection.NewLeases(rdbHandle)
+					if err := leasesView.Release("indexer", "A"); err != nil {
+						return err
+					}
+					if _, err := leasesView.TryAcquire("indexer", "B", time.Minute); err != nil {
+						return err
+					}
+
+					tx, err := fencedConn.Begin()
+					if err != nil {
+						return err
+					}
+					if _, err = tx.Exec("INSERT INTO fenced_writes (id) VALUES (3)"); err != nil {
+						return err
+					}
+					if err = tx.Commit(); !errors.Is(err, leaderelection.ErrLeaseLost) {
+						return fmt.Errorf("expected lease lost error, got: %v", err)
+					}
+
+					<-ctx.Done()
+					return nil
+				})
+			}()
+
+			Eventually(elector.IsLeader, time.Second).Should(BeTrue())
+			Eventually(elector.IsLeader, time.Second).Should(BeFalse())
+			Expect(countWrites()).To(Equal(int64(1)))
+
+			cancel()
+			Eventually(stopped, time.Second).Should(Receive(BeNil()))
+		})
+	})
+})
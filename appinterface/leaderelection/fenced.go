@@ -0,0 +1,98 @@
+package leaderelection
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+var _ rdb.Conn = &FencedConn{}
+var _ rdb.Tx = &fencedTx{}
+
+// FencedConn is a rdb.Conn whose writes only take effect while the elector holds the leadership of the
+// lease generation it acquired. A leader which lost the lease, e.g. while it is paused or still draining,
+// can no longer write once another instance has taken over.
+//
+// Transactions are fenced on commit and writes outside a transaction must go through Exec. Reads are not
+// fenced.
+type FencedConn struct {
+	rdb.Conn
+
+	elector *Elector
+}
+
+// FenceConn returns the connection with its writes fenced by the leadership of the elector
+func (elector *Elector) FenceConn(conn rdb.Conn) *FencedConn {
+	return &FencedConn{
+		conn,
+
+		elector,
+	}
+}
+
+func (conn *FencedConn) Begin() (rdb.Tx, error) {
+	tx, err := conn.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fencedTx{
+		tx,
+
+		conn.elector,
+	}, nil
+}
+
+func (conn *FencedConn) Exec(sql string, args ...interface{}) (rdb.ExecResult, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(sql, args...)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (conn *FencedConn) ToHandle() *rdb.Handle {
+	handle := conn.Conn.ToHandle()
+
+	return &rdb.Handle{
+		Runner:      conn,
+		TypeConv:    handle.TypeConv,
+		StmtBuilder: handle.StmtBuilder,
+	}
+}
+
+type fencedTx struct {
+	rdb.Tx
+
+	elector *Elector
+}
+
+// Commit commits the transaction only when the elector still holds the lease of its generation. The
+// lease is locked until the commit such that it cannot change holder in between.
+func (tx *fencedTx) Commit() error {
+	if err := tx.fence(); err != nil {
+		_ = tx.Tx.Rollback()
+		return fmt.Errorf("error committing fenced transaction: %w", err)
+	}
+
+	return tx.Tx.Commit()
+}
+
+func (tx *fencedTx) fence() error {
+	maybeGeneration := tx.elector.generation()
+	if maybeGeneration == nil {
+		return ErrLeaseLost
+	}
+
+	return NewLeases(tx.Tx.ToHandle()).Fence(tx.elector.leaseName, tx.elector.holderId, *maybeGeneration)
+}
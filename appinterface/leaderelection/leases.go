@@ -0,0 +1,220 @@
+package leaderelection
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+const DEFAULT_LEASE_TABLE = "leader_leases"
+
+var ErrLeaseLost = errors.New("lease lost")
+
+// Leases stores the leadership leases. A lease is held by a single holder until it expires, after
+// which any holder can acquire it.
+type Leases struct {
+	rdb   *rdb.Handle
+	table string
+}
+
+func NewLeases(handle *rdb.Handle) *Leases {
+	return &Leases{
+		handle,
+		DEFAULT_LEASE_TABLE,
+	}
+}
+
+// DB_NOW_SQL is the current time of the database clock in nanoseconds since the Unix epoch. Lease times
+// are taken from the database clock such that the clocks of the instances do not matter.
+const DB_NOW_SQL = "(date_part('epoch', now()) * 1000000)::BIGINT * 1000"
+
+// TryAcquire acquires the lease when it is free or expired, or renews it when the holder already
+// holds it. Returns the generation of the lease when the holder holds it for `duration` from now, nil
+// when it is held by another holder. The generation increases every time a holder starts a new term
+// such that writes of a previous term can be fenced off with Fence.
+func (leasesView *Leases) TryAcquire(name string, holderId string, duration time.Duration) (*int64, error) {
+	// The conflict update is skipped, and no row is returned, when the lease is held by another
+	// holder and has not expired
+	sql, sqlArgs, err := leasesView.rdb.StmtBuilder.Insert(
+		leasesView.table,
+	).Columns(
+		"name",
+		"holder_id",
+		"generation",
+		"acquired_at",
+		"renewed_at",
+		"expires_at",
+	).Values(
+		name,
+		holderId,
+		1,
+		sq.Expr(DB_NOW_SQL),
+		sq.Expr(DB_NOW_SQL),
+		sq.Expr(DB_NOW_SQL+" + ?", duration.Nanoseconds()),
+	).Suffix(fmt.Sprintf(
+		"ON CONFLICT (name) DO UPDATE SET "+
+			"holder_id = EXCLUDED.holder_id, "+
+			"generation = CASE WHEN %[1]s.holder_id = EXCLUDED.holder_id AND %[1]s.expires_at >= EXCLUDED.renewed_at "+
+			"THEN %[1]s.generation ELSE %[1]s.generation + 1 END, "+
+			"acquired_at = CASE WHEN %[1]s.holder_id = EXCLUDED.holder_id AND %[1]s.expires_at >= EXCLUDED.renewed_at "+
+			"THEN %[1]s.acquired_at ELSE EXCLUDED.acquired_at END, "+
+			"renewed_at = EXCLUDED.renewed_at, "+
+			"expires_at = EXCLUDED.expires_at "+
+			"WHERE %[1]s.holder_id = EXCLUDED.holder_id OR %[1]s.expires_at < EXCLUDED.renewed_at "+
+			"RETURNING generation",
+		leasesView.table,
+	)).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building lease acquisition sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := leasesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring lease: %v: %w", err, rdb.ErrWrite)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, nil
+	}
+	var generation int64
+	if err = rowsResult.Scan(&generation); err != nil {
+		return nil, fmt.Errorf("error scanning acquired lease generation: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return &generation, nil
+}
+
+// Fence returns ErrLeaseLost unless the holder still holds the unexpired lease of the generation. Run
+// within a transaction, it locks the lease such that no other holder can acquire it before the
+// transaction ends.
+func (leasesView *Leases) Fence(name string, holderId string, generation int64) error {
+	sql, sqlArgs, err := leasesView.rdb.StmtBuilder.Select(
+		"generation",
+	).From(
+		leasesView.table,
+	).Where(
+		"name = ? AND holder_id = ? AND generation = ?", name, holderId, generation,
+	).Where(
+		"expires_at >= " + DB_NOW_SQL,
+	).Suffix("FOR SHARE").ToSql()
+	if err != nil {
+		return fmt.Errorf("error building lease fencing sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var fencedGeneration int64
+	if err = leasesView.rdb.QueryRow(sql, sqlArgs...).Scan(&fencedGeneration); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return ErrLeaseLost
+		}
+		return fmt.Errorf("error fencing lease: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return nil
+}
+
+// Release expires the lease when it is held by the holder such that others can acquire it without
+// waiting. The lease is kept to preserve its generation.
+func (leasesView *Leases) Release(name string, holderId string) error {
+	sql, sqlArgs, err := leasesView.rdb.StmtBuilder.Update(
+		leasesView.table,
+	).Set(
+		"expires_at", sq.Expr(DB_NOW_SQL+" - 1"),
+	).Where(
+		"name = ? AND holder_id = ?", name, holderId,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building lease release sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	if _, err = leasesView.rdb.Exec(sql, sqlArgs...); err != nil {
+		return fmt.Errorf("error releasing lease: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns the lease by name regardless of whether it has expired. Returns rdb.ErrNoRows when
+// the lease has never been acquired.
+func (leasesView *Leases) FindBy(name string) (*Lease, error) {
+	return leasesView.findBy(name, false)
+}
+
+// FindUnexpiredBy returns the lease by name when it has not expired by the database clock. Returns
+// rdb.ErrNoRows when the lease has never been acquired or has expired.
+func (leasesView *Leases) FindUnexpiredBy(name string) (*Lease, error) {
+	return leasesView.findBy(name, true)
+}
+
+func (leasesView *Leases) findBy(name string, unexpiredOnly bool) (*Lease, error) {
+	stmtBuilder := leasesView.rdb.StmtBuilder.Select(
+		"name",
+		"holder_id",
+		"generation",
+		"acquired_at",
+		"renewed_at",
+		"expires_at",
+	).From(
+		leasesView.table,
+	).Where(
+		"name = ?", name,
+	)
+	if unexpiredOnly {
+		stmtBuilder = stmtBuilder.Where("expires_at >= " + DB_NOW_SQL)
+	}
+
+	sql, sqlArgs, err := stmtBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building lease selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var lease Lease
+	acquiredAtTimeReader := leasesView.rdb.NtotReader()
+	renewedAtTimeReader := leasesView.rdb.NtotReader()
+	expiresAtTimeReader := leasesView.rdb.NtotReader()
+	if err = leasesView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&lease.Name,
+		&lease.HolderId,
+		&lease.Generation,
+		acquiredAtTimeReader.ScannableArg(),
+		renewedAtTimeReader.ScannableArg(),
+		expiresAtTimeReader.ScannableArg(),
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning lease row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	acquiredAt, err := acquiredAtTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing lease acquired time: %v: %w", err, rdb.ErrQuery)
+	}
+	lease.AcquiredAt = *acquiredAt
+	renewedAt, err := renewedAtTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing lease renewed time: %v: %w", err, rdb.ErrQuery)
+	}
+	lease.RenewedAt = *renewedAt
+	expiresAt, err := expiresAtTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing lease expiry time: %v: %w", err, rdb.ErrQuery)
+	}
+	lease.ExpiresAt = *expiresAt
+
+	return &lease, nil
+}
+
+type Lease struct {
+	Name       string          `json:"name"`
+	HolderId   string          `json:"holderId"`
+	Generation int64           `json:"generation"`
+	AcquiredAt utctime.UTCTime `json:"acquiredAt"`
+	RenewedAt  utctime.UTCTime `json:"renewedAt"`
+	ExpiresAt  utctime.UTCTime `json:"expiresAt"`
+}
@@ -0,0 +1,108 @@
+package leaderelection_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/leaderelection"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	. "github.com/crypto-com/chain-indexing/test"
+)
+
+var _ = Describe("Leases", func() {
+	var leasesView *leaderelection.Leases
+	BeforeEach(func() {
+		leasesView = leaderelection.NewLeases(MustNewTestRDbConn().ToHandle())
+	})
+
+	It("should acquire free lease with the database clock", func() {
+		before := time.Now()
+		Expect(leasesView.TryAcquire("indexer", "A", time.Second)).To(Equal(primptr.Int64(1)))
+
+		lease, err := leasesView.FindBy("indexer")
+		Expect(err).To(BeNil())
+		Expect(lease.HolderId).To(Equal("A"))
+		Expect(lease.Generation).To(Equal(int64(1)))
+		Expect(lease.AcquiredAt).To(Equal(lease.RenewedAt))
+		Expect(lease.RenewedAt.UnixNano()).To(BeNumerically("~", before.UnixNano(), time.Second.Nanoseconds()))
+		Expect(lease.ExpiresAt.UnixNano() - lease.RenewedAt.UnixNano()).To(Equal(time.Second.Nanoseconds()))
+	})
+
+	It("should renew lease held by the same holder within the same generation", func() {
+		Expect(leasesView.TryAcquire("indexer", "A", time.Second)).To(Equal(primptr.Int64(1)))
+		acquired, err := leasesView.FindBy("indexer")
+		Expect(err).To(BeNil())
+
+		time.Sleep(time.Millisecond)
+		Expect(leasesView.TryAcquire("indexer", "A", time.Second)).To(Equal(primptr.Int64(1)))
+
+		lease, err := leasesView.FindBy("indexer")
+		Expect(err).To(BeNil())
+		Expect(lease.AcquiredAt).To(Equal(acquired.AcquiredAt))
+		Expect(lease.RenewedAt.UnixNano()).To(BeNumerically(">", acquired.RenewedAt.UnixNano()))
+	})
+
+	It("should not acquire lease held by another holder until it expires", func() {
+		Expect(leasesView.TryAcquire("indexer", "A", 50*time.Millisecond)).To(Equal(primptr.Int64(1)))
+
+		Expect(leasesView.TryAcquire("indexer", "B", time.Second)).To(BeNil())
+		lease, err := leasesView.FindBy("indexer")
+		Expect(err).To(BeNil())
+		Expect(lease.HolderId).To(Equal("A"))
+
+		time.Sleep(60 * time.Millisecond)
+		Expect(leasesView.TryAcquire("indexer", "B", time.Second)).To(Equal(primptr.Int64(2)))
+		lease, err = leasesView.FindBy("indexer")
+		Expect(err).To(BeNil())
+		Expect(lease.HolderId).To(Equal("B"))
+		Expect(lease.Generation).To(Equal(int64(2)))
+	})
+
+	It("should start a new generation when the holder acquires its expired lease again", func() {
+		Expect(leasesView.TryAcquire("indexer", "A", time.Millisecond)).To(Equal(primptr.Int64(1)))
+
+		time.Sleep(5 * time.Millisecond)
+		Expect(leasesView.TryAcquire("indexer", "A", time.Second)).To(Equal(primptr.Int64(2)))
+	})
+
+	It("should only release lease held by the holder and keep its generation", func() {
+		Expect(leasesView.TryAcquire("indexer", "A", time.Second)).To(Equal(primptr.Int64(1)))
+
+		Expect(leasesView.Release("indexer", "B")).To(Succeed())
+		Expect(leasesView.TryAcquire("indexer", "B", time.Second)).To(BeNil())
+
+		Expect(leasesView.Release("indexer", "A")).To(Succeed())
+		Expect(leasesView.TryAcquire("indexer", "B", time.Second)).To(Equal(primptr.Int64(2)))
+	})
+
+	It("should fence the holder of a previous generation", func() {
+		Expect(leasesView.TryAcquire("indexer", "A", time.Second)).To(Equal(primptr.Int64(1)))
+		Expect(leasesView.Fence("indexer", "A", 1)).To(Succeed())
+
+		Expect(leasesView.Release("indexer", "A")).To(Succeed())
+		Expect(leasesView.Fence("indexer", "A", 1)).To(Equal(leaderelection.ErrLeaseLost))
+
+		Expect(leasesView.TryAcquire("indexer", "B", time.Second)).To(Equal(primptr.Int64(2)))
+		Expect(leasesView.Fence("indexer", "A", 1)).To(Equal(leaderelection.ErrLeaseLost))
+		Expect(leasesView.Fence("indexer", "B", 2)).To(Succeed())
+	})
+
+	It("should only find the unexpired lease by the database clock", func() {
+		_, err := leasesView.FindUnexpiredBy("indexer")
+		Expect(err).To(Equal(rdb.ErrNoRows))
+
+		Expect(leasesView.TryAcquire("indexer", "A", time.Second)).To(Equal(primptr.Int64(1)))
+		lease, err := leasesView.FindUnexpiredBy("indexer")
+		Expect(err).To(BeNil())
+		Expect(lease.HolderId).To(Equal("A"))
+
+		Expect(leasesView.Release("indexer", "A")).To(Succeed())
+		_, err = leasesView.FindUnexpiredBy("indexer")
+		Expect(err).To(Equal(rdb.ErrNoRows))
+		_, err = leasesView.FindBy("indexer")
+		Expect(err).To(BeNil())
+	})
+})
@@ -5,7 +5,6 @@ import (
 	"strconv"
 
 	cosmosapp_interface "github.com/crypto-com/chain-indexing/appinterface/cosmosapp"
-	cosmosapp_infrastructure "github.com/crypto-com/chain-indexing/infrastructure/cosmosapp"
 
 	account_view "github.com/crypto-com/chain-indexing/appinterface/projection/account/view"
 
@@ -24,16 +23,18 @@ func ConvertToInt64(s string) int64 {
 	return i
 }
 
+// Account keeps the account balances queried from the node on every transfer. See the Balance
+// projection for balances computed from the events only.
 type Account struct {
 	*rdbprojectionbase.Base
 
 	rdbConn    rdb.Conn
 	logger     applogger.Logger
-	httpclinet *cosmosapp_infrastructure.HTTPClient // cosmos light client deaemon port : 1317 (default)
-	baseDenom  string                               // tbasecro, basecro
+	httpclinet cosmosapp_interface.Client // cosmos light client deaemon port : 1317 (default)
+	baseDenom  string                     // tbasecro, basecro
 }
 
-func NewAccount(logger applogger.Logger, rdbConn rdb.Conn, httpclient *cosmosapp_infrastructure.HTTPClient, baseDenom string) *Account {
+func NewAccount(logger applogger.Logger, rdbConn rdb.Conn, httpclient cosmosapp_interface.Client, baseDenom string) *Account {
 	return &Account{
 		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "Account"),
 		rdbConn,
@@ -64,6 +64,7 @@ func (projection *AccountMessage) HandleEvents(height int64, events []event_enti
 	rdbTxHandle := rdbTx.ToHandle()
 	accountMessagesView := view.NewAccountMessages(rdbTxHandle)
 	accountMessagesTotalView := view.NewAccountMessagesTotal(rdbTxHandle)
+	accountTransactionsTotalView := view.NewAccountTransactionsTotal(rdbTxHandle)
 
 	var blockTime utctime.UTCTime
 	var blockHash string
@@ -358,6 +359,8 @@ func (projection *AccountMessage) HandleEvents(height int64, events []event_enti
 		}
 	}
 
+	// Accounts involved in each transaction of the block
+	transactionAccounts := make(map[string]bool)
 	for i, accountMessage := range accountMessages {
 		// TODO: Change to use InsertAll
 		accountMessages[i].Row.BlockHash = blockHash
@@ -379,6 +382,13 @@ func (projection *AccountMessage) HandleEvents(height int64, events []event_enti
 			); err != nil {
 				return fmt.Errorf("error incremnting total account message of account: %w", err)
 			}
+			transactionAccount := fmt.Sprintf("%s:%s", accountMessage.Row.TransactionHash, involvedAccount)
+			if !transactionAccounts[transactionAccount] {
+				if err := accountTransactionsTotalView.Increment(involvedAccount, 1); err != nil {
+					return fmt.Errorf("error incrementing total account transaction of account: %w", err)
+				}
+				transactionAccounts[transactionAccount] = true
+			}
 			deduplicatedAccounts = append(deduplicatedAccounts, involvedAccount)
 			insertedAccounts[involvedAccount] = true
 		}
@@ -0,0 +1,18 @@
+package view
+
+import (
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// AccountTransactionsTotal keeps the number of transactions each account is involved in. The identity
+// is the account address.
+type AccountTransactionsTotal struct {
+	*view.Total
+}
+
+func NewAccountTransactionsTotal(rdbHandle *rdb.Handle) *AccountTransactionsTotal {
+	return &AccountTransactionsTotal{
+		view.NewTotal(rdbHandle, "view_account_transactions_total"),
+	}
+}
@@ -0,0 +1,270 @@
+package accountactivity
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/accountactivity/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &AccountActivity{}
+
+// AccountActivity profiles the activity of each account from the messages it is involved in and the
+// fees it pays. An account is active in a block when it is involved in a message or pays a fee.
+//
+// The accounts involved in the messages are the same as the AccountMessage projection. Transfers are
+// MsgSend and MsgMultiSend. In a MsgMultiSend, every input is a counterparty of every output. Fees of
+// the transactions signed by a multisig account without an explicit fee payer are not attributed.
+type AccountActivity struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+
+	accountAddressPrefix string
+}
+
+func NewAccountActivity(logger applogger.Logger, rdbConn rdb.Conn, accountAddressPrefix string) *AccountActivity {
+	return &AccountActivity{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "AccountActivity"),
+
+		rdbConn,
+		logger,
+
+		accountAddressPrefix,
+	}
+}
+
+func (_ *AccountActivity) GetEventsToListen() []string {
+	return append([]string{
+		event_usecase.BLOCK_CREATED,
+		event_usecase.TRANSACTION_CREATED,
+		event_usecase.TRANSACTION_FAILED,
+	}, event_usecase.MSG_EVENTS...)
+}
+
+func (projection *AccountActivity) OnInit() error {
+	return nil
+}
+
+// blockActivity is the activity of an account within a block
+type blockActivity struct {
+	messages      int64
+	msgTypes      map[string]int64
+	feesPaid      *big.Int
+	totalSent     *big.Int
+	totalReceived *big.Int
+}
+
+// blockActivities keeps the activities of the accounts in the order they are first active in the block
+type blockActivities struct {
+	addresses  []string
+	activities map[string]*blockActivity
+	// Counterparty transfers in the order they are first made in the block
+	counterparties []counterparty
+	transfers      map[counterparty]int64
+}
+
+type counterparty struct {
+	address      string
+	counterparty string
+}
+
+func newBlockActivities() *blockActivities {
+	return &blockActivities{
+		addresses:  make([]string, 0),
+		activities: make(map[string]*blockActivity),
+
+		counterparties: make([]counterparty, 0),
+		transfers:      make(map[counterparty]int64),
+	}
+}
+
+func (activities *blockActivities) of(address string) *blockActivity {
+	if activity, ok := activities.activities[address]; ok {
+		return activity
+	}
+	activity := &blockActivity{
+		msgTypes:      make(map[string]int64),
+		feesPaid:      new(big.Int),
+		totalSent:     new(big.Int),
+		totalReceived: new(big.Int),
+	}
+	activities.addresses = append(activities.addresses, address)
+	activities.activities[address] = activity
+	return activity
+}
+
+func (activities *blockActivities) send(from string, to string, amount *big.Int) {
+	activities.of(from).totalSent.Add(activities.of(from).totalSent, amount)
+	activities.of(to).totalReceived.Add(activities.of(to).totalReceived, amount)
+	activities.addCounterparty(from, to)
+}
+
+// addCounterparty records a transfer on both accounts. Transfers to self have no counterparty.
+func (activities *blockActivities) addCounterparty(from string, to string) {
+	if from == to {
+		return
+	}
+	for _, pair := range []counterparty{{from, to}, {to, from}} {
+		if _, ok := activities.transfers[pair]; !ok {
+			activities.counterparties = append(activities.counterparties, pair)
+		}
+		activities.transfers[pair] += 1
+	}
+}
+
+func (projection *AccountActivity) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var maybeBlockTime *utctime.UTCTime
+	activities := newBlockActivities()
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if transactionCreatedEvent, ok := event.(*event_usecase.TransactionCreated); ok {
+			if err := projection.payFee(
+				activities, transactionCreatedEvent.FeePayer, transactionCreatedEvent.Senders, transactionCreatedEvent.Fee,
+			); err != nil {
+				return fmt.Errorf("error handling fee of transaction %s: %v", transactionCreatedEvent.TxHash, err)
+			}
+		} else if transactionFailedEvent, ok := event.(*event_usecase.TransactionFailed); ok {
+			// Failed transaction events carry no signers, the fee payer is only known when explicitly set
+			if err := projection.payFee(
+				activities, transactionFailedEvent.FeePayer, nil, transactionFailedEvent.Fee,
+			); err != nil {
+				return fmt.Errorf("error handling fee of transaction %s: %v", transactionFailedEvent.TxHash, err)
+			}
+		} else if msgEvent, ok := event.(event_usecase.MsgEvent); ok {
+			for _, account := range involvedAccounts(msgEvent) {
+				activity := activities.of(account)
+				activity.messages += 1
+				activity.msgTypes[msgEvent.MsgType()] += 1
+			}
+			if !msgEvent.TxSuccess() {
+				continue
+			}
+
+			if msgSendEvent, ok := msgEvent.(*event_usecase.MsgSend); ok {
+				activities.send(msgSendEvent.FromAddress, msgSendEvent.ToAddress, msgSendEvent.Amount.ToBigInt())
+			} else if msgMultiSendEvent, ok := msgEvent.(*event_usecase.MsgMultiSend); ok {
+				for _, input := range msgMultiSendEvent.Inputs {
+					activities.of(input.Address).totalSent.Add(
+						activities.of(input.Address).totalSent, input.Amount.ToBigInt(),
+					)
+				}
+				for _, output := range msgMultiSendEvent.Outputs {
+					activities.of(output.Address).totalReceived.Add(
+						activities.of(output.Address).totalReceived, output.Amount.ToBigInt(),
+					)
+				}
+				for _, input := range msgMultiSendEvent.Inputs {
+					for _, output := range msgMultiSendEvent.Outputs {
+						activities.addCounterparty(input.Address, output.Address)
+					}
+				}
+			}
+		}
+	}
+
+	// Genesis has no block and no activity
+	if maybeBlockTime != nil {
+		if err := projection.handleActivities(rdbTxHandle, height, *maybeBlockTime, activities); err != nil {
+			return fmt.Errorf("error handling account activities: %v", err)
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *AccountActivity) payFee(
+	activities *blockActivities,
+	feePayer string,
+	senders []event_usecase.TransactionSigner,
+	fee coin.Coin,
+) error {
+	payer, err := feePayerOf(projection.accountAddressPrefix, feePayer, senders)
+	if err != nil {
+		return fmt.Errorf("error getting fee payer: %v", err)
+	}
+	if payer == "" {
+		projection.logger.Debug("skipping fee of transaction without single key fee payer")
+		return nil
+	}
+
+	activity := activities.of(payer)
+	activity.feesPaid.Add(activity.feesPaid, fee.ToBigInt())
+	return nil
+}
+
+func (projection *AccountActivity) handleActivities(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	activities *blockActivities,
+) error {
+	activitiesView := view.NewAccountActivities(rdbTxHandle)
+	messageTypesView := view.NewAccountMessageTypes(rdbTxHandle)
+	counterpartiesView := view.NewAccountCounterparties(rdbTxHandle)
+
+	for _, address := range activities.addresses {
+		activity := activities.activities[address]
+		if err := activitiesView.Accumulate(&view.AccountActivityRow{
+			Address:               address,
+			FirstSeenBlockHeight:  blockHeight,
+			FirstSeenBlockTime:    blockTime,
+			LastActiveBlockHeight: blockHeight,
+			LastActiveBlockTime:   blockTime,
+			Messages:              activity.messages,
+			FeesPaid:              activity.feesPaid.String(),
+			TotalSent:             activity.totalSent.String(),
+			TotalReceived:         activity.totalReceived.String(),
+		}); err != nil {
+			return fmt.Errorf("error accumulating activity of %s: %v", address, err)
+		}
+
+		for msgType, total := range activity.msgTypes {
+			if err := messageTypesView.Increment(address, msgType, total); err != nil {
+				return fmt.Errorf("error incrementing %s messages of %s: %v", msgType, address, err)
+			}
+		}
+	}
+
+	for _, pair := range activities.counterparties {
+		if err := counterpartiesView.Increment(
+			pair.address, pair.counterparty, activities.transfers[pair], blockHeight,
+		); err != nil {
+			return fmt.Errorf("error incrementing transfers between %s and %s: %v", pair.address, pair.counterparty, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package accountactivity_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAccountActivity(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Account Activity Suite")
+}
@@ -0,0 +1,162 @@
+package accountactivity_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/accountactivity"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/accountactivity/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	projection_view "github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+)
+
+// Address of the signer public key
+const signerAddress = "tcro1p4fzn6ta24c6ek4v2qls6y5uug44ku9tnypcaf"
+
+var _ = Describe("AccountActivity", func() {
+	var conn rdb.Conn
+	var projection *accountactivity.AccountActivity
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = accountactivity.NewAccountActivity(NewFakeLogger(), conn, "tcro")
+	})
+
+	transactionParams := func(hash string, fee int64) usecase_model.CreateTransactionParams {
+		return usecase_model.CreateTransactionParams{
+			TxHash:   hash,
+			MsgCount: 1,
+			Signers: []usecase_model.TransactionSigner{{
+				Type:    accountactivity.SECP256K1_PUBKEY_TYPE,
+				Pubkeys: []string{"A3ill3YNyWvcMstrbssC9SpzhMm+tCMWPB7bgOqWQZYk"},
+			}},
+			Fee: coin.MustNewCoinFromInt(fee),
+		}
+	}
+	msgSend := func(height int64, txHash string, success bool, to string, amount int64) event_entity.Event {
+		msgCommonParams := NewMsgCommonParams(height, txHash)
+		msgCommonParams.TxSuccess = success
+		return event_usecase.NewMsgSend(msgCommonParams, event_usecase.MsgSendCreatedParams{
+			FromAddress: signerAddress,
+			ToAddress:   to,
+			Amount:      coin.MustNewCoinFromInt(amount),
+		})
+	}
+
+	replayBlocks := func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, GENESIS_TIME),
+			event_usecase.NewTransactionCreated(1, transactionParams("a", 10)),
+			msgSend(1, "a", true, "tcro1bob", 100),
+			event_usecase.NewTransactionCreated(1, transactionParams("b", 20)),
+			msgSend(1, "b", true, "tcro1bob", 200),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, TimeAt(5*time.Minute)),
+			event_usecase.NewTransactionFailed(2, transactionParams("c", 30)),
+			msgSend(2, "c", false, "tcro1carol", 300),
+			event_usecase.NewTransactionCreated(2, transactionParams("d", 40)),
+			event_usecase.NewMsgMultiSend(NewMsgCommonParams(2, "d"), usecase_model.MsgMultiSendParams{
+				Inputs: []usecase_model.MsgMultiSendInput{
+					{Address: signerAddress, Amount: coin.MustNewCoinFromInt(50)},
+				},
+				Outputs: []usecase_model.MsgMultiSendOutput{
+					{Address: "tcro1bob", Amount: coin.MustNewCoinFromInt(20)},
+					{Address: "tcro1dave", Amount: coin.MustNewCoinFromInt(30)},
+				},
+			}),
+		})
+	}
+
+	It("should profile the activity of the accounts", func() {
+		replayBlocks()
+
+		activitiesView := view.NewAccountActivities(conn.ToHandle())
+		signer, err := activitiesView.FindBy(signerAddress)
+		Expect(err).To(BeNil())
+		Expect(*signer).To(Equal(view.AccountActivityRow{
+			Address:               signerAddress,
+			FirstSeenBlockHeight:  1,
+			FirstSeenBlockTime:    GENESIS_TIME,
+			LastActiveBlockHeight: 2,
+			LastActiveBlockTime:   TimeAt(5 * time.Minute),
+			Messages:              4,
+			// Fee of the failed transaction has no fee payer to attribute to
+			FeesPaid:      "70",
+			TotalSent:     "350",
+			TotalReceived: "0",
+		}))
+
+		bob, err := activitiesView.FindBy("tcro1bob")
+		Expect(err).To(BeNil())
+		Expect(bob.Messages).To(Equal(int64(3)))
+		Expect(bob.TotalReceived).To(Equal("320"))
+		Expect(bob.FeesPaid).To(Equal("0"))
+
+		carol, err := activitiesView.FindBy("tcro1carol")
+		Expect(err).To(BeNil())
+		Expect(carol.FirstSeenBlockHeight).To(Equal(int64(2)))
+		Expect(carol.TotalReceived).To(Equal("0"))
+	})
+
+	It("should count the messages of the accounts by type", func() {
+		replayBlocks()
+
+		messageTypes, err := view.NewAccountMessageTypes(conn.ToHandle()).ListAllByAddress(signerAddress)
+		Expect(err).To(BeNil())
+		Expect(messageTypes).To(Equal([]view.AccountMessageTypeRow{
+			{MsgType: event_usecase.MSG_SEND, Total: 3},
+			{MsgType: event_usecase.MSG_MULTI_SEND, Total: 1},
+		}))
+	})
+
+	It("should record the counterparties of the successful transfers", func() {
+		replayBlocks()
+
+		counterpartiesView := view.NewAccountCounterparties(conn.ToHandle())
+		counterparties, _, err := counterpartiesView.ListByAddress(
+			signerAddress, pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(counterparties).To(Equal([]view.AccountCounterpartyRow{
+			{Counterparty: "tcro1bob", Transfers: 3, LastTransferredBlockHeight: 2},
+			{Counterparty: "tcro1dave", Transfers: 1, LastTransferredBlockHeight: 2},
+		}))
+
+		bobCounterparties, _, err := counterpartiesView.ListByAddress(
+			"tcro1bob", pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(bobCounterparties).To(HaveLen(1))
+	})
+
+	It("should filter the accounts by activity", func() {
+		replayBlocks()
+
+		activeFrom := TimeAt(5 * time.Minute)
+		activities, _, err := view.NewAccountActivities(conn.ToHandle()).List(
+			view.AccountActivitiesListFilter{
+				MaybeActiveFromTime: &activeFrom,
+				MaybeMinMessages:    primptr.Int64(2),
+			},
+			view.AccountActivitiesListOrder{
+				MaybeMessages: primptr.String(projection_view.ORDER_DESC),
+			},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(activities).To(HaveLen(2))
+		Expect(activities[0].Address).To(Equal(signerAddress))
+		Expect(activities[1].Address).To(Equal("tcro1bob"))
+	})
+})
@@ -0,0 +1,93 @@
+package accountactivity
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+const SECP256K1_PUBKEY_TYPE = "/cosmos.crypto.secp256k1.PubKey"
+
+// involvedAccounts returns the deduplicated accounts involved in the message. Returns nil when the
+// message involves no account, such as a validator unjailing itself.
+func involvedAccounts(msgEvent event_usecase.MsgEvent) []string {
+	var accounts []string
+	switch typedEvent := msgEvent.(type) {
+	case *event_usecase.MsgSend:
+		accounts = []string{typedEvent.FromAddress, typedEvent.ToAddress}
+	case *event_usecase.MsgMultiSend:
+		for _, input := range typedEvent.Inputs {
+			accounts = append(accounts, input.Address)
+		}
+		for _, output := range typedEvent.Outputs {
+			accounts = append(accounts, output.Address)
+		}
+	case *event_usecase.MsgSetWithdrawAddress:
+		accounts = []string{typedEvent.DelegatorAddress, typedEvent.WithdrawAddress}
+	case *event_usecase.MsgWithdrawDelegatorReward:
+		accounts = []string{typedEvent.DelegatorAddress}
+	case *event_usecase.MsgWithdrawValidatorCommission:
+		accounts = []string{typedEvent.RecipientAddress}
+	case *event_usecase.MsgFundCommunityPool:
+		accounts = []string{typedEvent.Depositor}
+	case *event_usecase.MsgSubmitTextProposal:
+		accounts = []string{typedEvent.ProposerAddress}
+	case *event_usecase.MsgSubmitParamChangeProposal:
+		accounts = []string{typedEvent.ProposerAddress}
+	case *event_usecase.MsgSubmitCommunityPoolSpendProposal:
+		accounts = []string{typedEvent.ProposerAddress}
+	case *event_usecase.MsgSubmitSoftwareUpgradeProposal:
+		accounts = []string{typedEvent.ProposerAddress}
+	case *event_usecase.MsgSubmitCancelSoftwareUpgradeProposal:
+		accounts = []string{typedEvent.ProposerAddress}
+	case *event_usecase.MsgDeposit:
+		accounts = []string{typedEvent.Depositor}
+	case *event_usecase.MsgVote:
+		accounts = []string{typedEvent.Voter}
+	case *event_usecase.MsgCreateValidator:
+		accounts = []string{typedEvent.DelegatorAddress}
+	case *event_usecase.MsgDelegate:
+		accounts = []string{typedEvent.DelegatorAddress}
+	case *event_usecase.MsgUndelegate:
+		accounts = []string{typedEvent.DelegatorAddress}
+	case *event_usecase.MsgBeginRedelegate:
+		accounts = []string{typedEvent.DelegatorAddress}
+	}
+
+	seen := make(map[string]bool)
+	deduplicated := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		if account == "" || seen[account] {
+			continue
+		}
+		seen[account] = true
+		deduplicated = append(deduplicated, account)
+	}
+	if len(deduplicated) == 0 {
+		return nil
+	}
+	return deduplicated
+}
+
+// feePayerOf returns the fee payer of the transaction, which defaults to the first signer. Returns empty
+// string when the first signer is a multisig account, whose address cannot be derived from the keys.
+func feePayerOf(
+	accountAddressPrefix string,
+	feePayer string,
+	senders []event_usecase.TransactionSigner,
+) (string, error) {
+	if feePayer != "" {
+		return feePayer, nil
+	}
+	if len(senders) == 0 || senders[0].Type != SECP256K1_PUBKEY_TYPE || len(senders[0].Pubkeys) != 1 {
+		return "", nil
+	}
+
+	pubKey, err := base64.StdEncoding.DecodeString(senders[0].Pubkeys[0])
+	if err != nil {
+		return "", fmt.Errorf("error decoding signer public key: %v", err)
+	}
+	return tmcosmosutils.AccountAddressFromPubKey(accountAddressPrefix, pubKey)
+}
@@ -0,0 +1,277 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// AccountActivities keeps when each account is first seen and last active, and the totals of its
+// messages, fees paid and base denom transfers
+type AccountActivities struct {
+	rdb *rdb.Handle
+}
+
+func NewAccountActivities(handle *rdb.Handle) *AccountActivities {
+	return &AccountActivities{
+		handle,
+	}
+}
+
+// Accumulate adds the activity of a block to the account. The first seen height and time are only
+// recorded when the account is seen for the first time.
+func (activitiesView *AccountActivities) Accumulate(activity *AccountActivityRow) error {
+	feesPaid, ok := new(big.Int).SetString(activity.FeesPaid, 10)
+	if !ok {
+		return fmt.Errorf("error parsing account activity fees paid: %s", activity.FeesPaid)
+	}
+	totalSent, ok := new(big.Int).SetString(activity.TotalSent, 10)
+	if !ok {
+		return fmt.Errorf("error parsing account activity total sent: %s", activity.TotalSent)
+	}
+	totalReceived, ok := new(big.Int).SetString(activity.TotalReceived, 10)
+	if !ok {
+		return fmt.Errorf("error parsing account activity total received: %s", activity.TotalReceived)
+	}
+
+	sql, sqlArgs, err := activitiesView.rdb.StmtBuilder.Insert(
+		"view_account_activities AS activities",
+	).Columns(
+		"address",
+		"first_seen_block_height",
+		"first_seen_block_time",
+		"last_active_block_height",
+		"last_active_block_time",
+		"messages",
+		"fees_paid",
+		"total_sent",
+		"total_received",
+	).Values(
+		activity.Address,
+		activity.FirstSeenBlockHeight,
+		activitiesView.rdb.Tton(&activity.FirstSeenBlockTime),
+		activity.LastActiveBlockHeight,
+		activitiesView.rdb.Tton(&activity.LastActiveBlockTime),
+		activity.Messages,
+		activitiesView.rdb.Bton(feesPaid),
+		activitiesView.rdb.Bton(totalSent),
+		activitiesView.rdb.Bton(totalReceived),
+	).Suffix(`ON CONFLICT (address) DO UPDATE SET
+		last_active_block_height = EXCLUDED.last_active_block_height,
+		last_active_block_time = EXCLUDED.last_active_block_time,
+		messages = activities.messages + EXCLUDED.messages,
+		fees_paid = activities.fees_paid + EXCLUDED.fees_paid,
+		total_sent = activities.total_sent + EXCLUDED.total_sent,
+		total_received = activities.total_received + EXCLUDED.total_received
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building account activity upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := activitiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting account activity into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting account activity into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when the account is never active
+func (activitiesView *AccountActivities) FindBy(address string) (*AccountActivityRow, error) {
+	sql, sqlArgs, err := activitiesView.selectStmtBuilder().Where("address = ?", address).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building account activity selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := activitiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing account activity selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return activitiesView.scan(rowsResult)
+}
+
+type AccountActivitiesListFilter struct {
+	// Inclusive
+	MaybeActiveFromTime *utctime.UTCTime
+	// Exclusive
+	MaybeActiveToTime *utctime.UTCTime
+	// Inclusive
+	MaybeFirstSeenFromTime *utctime.UTCTime
+	MaybeMinMessages       *int64
+}
+
+// Only the first non-nil order is applied. Defaults to the latest active first.
+type AccountActivitiesListOrder struct {
+	MaybeLastActiveBlockHeight *view.ORDER
+	MaybeFirstSeenBlockHeight  *view.ORDER
+	MaybeMessages              *view.ORDER
+}
+
+func (activitiesView *AccountActivities) List(
+	filter AccountActivitiesListFilter,
+	order AccountActivitiesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]AccountActivityRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := activitiesView.selectStmtBuilder()
+	if filter.MaybeActiveFromTime != nil {
+		stmtBuilder = stmtBuilder.Where(
+			"last_active_block_time >= ?", activitiesView.rdb.Tton(filter.MaybeActiveFromTime),
+		)
+	}
+	if filter.MaybeActiveToTime != nil {
+		stmtBuilder = stmtBuilder.Where(
+			"last_active_block_time < ?", activitiesView.rdb.Tton(filter.MaybeActiveToTime),
+		)
+	}
+	if filter.MaybeFirstSeenFromTime != nil {
+		stmtBuilder = stmtBuilder.Where(
+			"first_seen_block_time >= ?", activitiesView.rdb.Tton(filter.MaybeFirstSeenFromTime),
+		)
+	}
+	if filter.MaybeMinMessages != nil {
+		stmtBuilder = stmtBuilder.Where("messages >= ?", *filter.MaybeMinMessages)
+	}
+
+	if order.MaybeLastActiveBlockHeight != nil {
+		stmtBuilder = orderBy(stmtBuilder, "last_active_block_height", *order.MaybeLastActiveBlockHeight)
+	} else if order.MaybeFirstSeenBlockHeight != nil {
+		stmtBuilder = orderBy(stmtBuilder, "first_seen_block_height", *order.MaybeFirstSeenBlockHeight)
+	} else if order.MaybeMessages != nil {
+		stmtBuilder = orderBy(stmtBuilder, "messages", *order.MaybeMessages)
+	} else {
+		stmtBuilder = orderBy(stmtBuilder, "last_active_block_height", view.ORDER_DESC)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		activitiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building account activities select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := activitiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing account activities select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	activities := make([]AccountActivityRow, 0)
+	for rowsResult.Next() {
+		activity, scanErr := activitiesView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		activities = append(activities, *activity)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return activities, paginationResult, nil
+}
+
+// orderBy orders by the column and then by address in the same direction
+func orderBy(stmtBuilder sq.SelectBuilder, column string, order view.ORDER) sq.SelectBuilder {
+	if order == view.ORDER_DESC {
+		return stmtBuilder.OrderBy(fmt.Sprintf("%s DESC", column), "address DESC")
+	}
+	return stmtBuilder.OrderBy(column, "address")
+}
+
+func (activitiesView *AccountActivities) selectStmtBuilder() sq.SelectBuilder {
+	return activitiesView.rdb.StmtBuilder.Select(
+		"address",
+		"first_seen_block_height",
+		"first_seen_block_time",
+		"last_active_block_height",
+		"last_active_block_time",
+		"messages",
+		"fees_paid",
+		"total_sent",
+		"total_received",
+	).From(
+		"view_account_activities",
+	)
+}
+
+func (activitiesView *AccountActivities) scan(rowsResult rdb.RowsResult) (*AccountActivityRow, error) {
+	var activity AccountActivityRow
+	firstSeenBlockTimeReader := activitiesView.rdb.NtotReader()
+	lastActiveBlockTimeReader := activitiesView.rdb.NtotReader()
+	feesPaidReader := activitiesView.rdb.NtobReader()
+	totalSentReader := activitiesView.rdb.NtobReader()
+	totalReceivedReader := activitiesView.rdb.NtobReader()
+	if err := rowsResult.Scan(
+		&activity.Address,
+		&activity.FirstSeenBlockHeight,
+		firstSeenBlockTimeReader.ScannableArg(),
+		&activity.LastActiveBlockHeight,
+		lastActiveBlockTimeReader.ScannableArg(),
+		&activity.Messages,
+		feesPaidReader.ScannableArg(),
+		totalSentReader.ScannableArg(),
+		totalReceivedReader.ScannableArg(),
+	); err != nil {
+		return nil, fmt.Errorf("error scanning account activity row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	firstSeenBlockTime, err := firstSeenBlockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account first seen block time: %v: %w", err, rdb.ErrQuery)
+	}
+	activity.FirstSeenBlockTime = *firstSeenBlockTime
+	lastActiveBlockTime, err := lastActiveBlockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account last active block time: %v: %w", err, rdb.ErrQuery)
+	}
+	activity.LastActiveBlockTime = *lastActiveBlockTime
+	feesPaid, err := feesPaidReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account fees paid: %v: %w", err, rdb.ErrQuery)
+	}
+	activity.FeesPaid = feesPaid.String()
+	totalSent, err := totalSentReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account total sent: %v: %w", err, rdb.ErrQuery)
+	}
+	activity.TotalSent = totalSent.String()
+	totalReceived, err := totalReceivedReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account total received: %v: %w", err, rdb.ErrQuery)
+	}
+	activity.TotalReceived = totalReceived.String()
+
+	return &activity, nil
+}
+
+// AccountActivityRow amounts are in base denom. Messages include the messages of failed transactions,
+// while the totals sent and received only include the successful transfers.
+type AccountActivityRow struct {
+	Address               string          `json:"address"`
+	FirstSeenBlockHeight  int64           `json:"firstSeenBlockHeight"`
+	FirstSeenBlockTime    utctime.UTCTime `json:"firstSeenBlockTime"`
+	LastActiveBlockHeight int64           `json:"lastActiveBlockHeight"`
+	LastActiveBlockTime   utctime.UTCTime `json:"lastActiveBlockTime"`
+	Messages              int64           `json:"messages"`
+	FeesPaid              string          `json:"feesPaid"`
+	TotalSent             string          `json:"totalSent"`
+	TotalReceived         string          `json:"totalReceived"`
+}
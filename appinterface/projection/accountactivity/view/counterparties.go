@@ -0,0 +1,116 @@
+package view
+
+import (
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// AccountCounterparties keeps the number of successful transfers between each pair of accounts. Each
+// transfer is recorded on both accounts.
+type AccountCounterparties struct {
+	rdb *rdb.Handle
+}
+
+func NewAccountCounterparties(handle *rdb.Handle) *AccountCounterparties {
+	return &AccountCounterparties{
+		handle,
+	}
+}
+
+func (counterpartiesView *AccountCounterparties) Increment(
+	address string,
+	counterparty string,
+	transfers int64,
+	blockHeight int64,
+) error {
+	sql, sqlArgs, err := counterpartiesView.rdb.StmtBuilder.Insert(
+		"view_account_counterparties AS counterparties",
+	).Columns(
+		"address",
+		"counterparty",
+		"transfers",
+		"last_transferred_block_height",
+	).Values(
+		address,
+		counterparty,
+		transfers,
+		blockHeight,
+	).Suffix(`ON CONFLICT (address, counterparty) DO UPDATE SET
+		transfers = counterparties.transfers + EXCLUDED.transfers,
+		last_transferred_block_height = EXCLUDED.last_transferred_block_height
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building account counterparty upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := counterpartiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting account counterparty into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting account counterparty into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// ListByAddress returns the counterparties of the account with the most transfers first
+func (counterpartiesView *AccountCounterparties) ListByAddress(
+	address string,
+	pagination *pagination_interface.Pagination,
+) ([]AccountCounterpartyRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := counterpartiesView.rdb.StmtBuilder.Select(
+		"counterparty",
+		"transfers",
+		"last_transferred_block_height",
+	).From(
+		"view_account_counterparties",
+	).Where(
+		"address = ?", address,
+	).OrderBy(
+		"transfers DESC", "counterparty",
+	)
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		counterpartiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building account counterparties select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := counterpartiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing account counterparties select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	counterparties := make([]AccountCounterpartyRow, 0)
+	for rowsResult.Next() {
+		var counterparty AccountCounterpartyRow
+		if scanErr := rowsResult.Scan(
+			&counterparty.Counterparty,
+			&counterparty.Transfers,
+			&counterparty.LastTransferredBlockHeight,
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning account counterparty row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		counterparties = append(counterparties, counterparty)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return counterparties, paginationResult, nil
+}
+
+type AccountCounterpartyRow struct {
+	Counterparty               string `json:"counterparty"`
+	Transfers                  int64  `json:"transfers"`
+	LastTransferredBlockHeight int64  `json:"lastTransferredBlockHeight"`
+}
@@ -0,0 +1,86 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// AccountMessageTypes keeps the number of messages of each type each account is involved in
+type AccountMessageTypes struct {
+	rdb *rdb.Handle
+}
+
+func NewAccountMessageTypes(handle *rdb.Handle) *AccountMessageTypes {
+	return &AccountMessageTypes{
+		handle,
+	}
+}
+
+func (messageTypesView *AccountMessageTypes) Increment(address string, msgType string, total int64) error {
+	sql, sqlArgs, err := messageTypesView.rdb.StmtBuilder.Insert(
+		"view_account_activity_message_types AS message_types",
+	).Columns(
+		"address",
+		"msg_type",
+		"total",
+	).Values(
+		address,
+		msgType,
+		total,
+	).Suffix(
+		"ON CONFLICT (address, msg_type) DO UPDATE SET total = message_types.total + EXCLUDED.total",
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building account message type upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := messageTypesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting account message type into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting account message type into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// ListAllByAddress returns the message counts of the account by type, most frequent first
+func (messageTypesView *AccountMessageTypes) ListAllByAddress(address string) ([]AccountMessageTypeRow, error) {
+	sql, sqlArgs, err := messageTypesView.rdb.StmtBuilder.Select(
+		"msg_type",
+		"total",
+	).From(
+		"view_account_activity_message_types",
+	).Where(
+		"address = ?", address,
+	).OrderBy(
+		"total DESC", "msg_type",
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building account message types select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := messageTypesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing account message types select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	messageTypes := make([]AccountMessageTypeRow, 0)
+	for rowsResult.Next() {
+		var messageType AccountMessageTypeRow
+		if scanErr := rowsResult.Scan(&messageType.MsgType, &messageType.Total); scanErr != nil {
+			return nil, fmt.Errorf("error scanning account message type row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		messageTypes = append(messageTypes, messageType)
+	}
+
+	return messageTypes, nil
+}
+
+type AccountMessageTypeRow struct {
+	MsgType string `json:"msgType"`
+	Total   int64  `json:"total"`
+}
@@ -0,0 +1,187 @@
+package balance
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &Balance{}
+
+// Balance keeps the base denom balance of every account computed purely from the genesis balances and
+// the transfers, so replaying the events always gives the same result without querying the node.
+//
+// Coins minted or burnt without a `transfer` event are not seen, so module accounts minting coins end
+// up with a negative balance. Use the Reconciler to compare the balances against the node.
+type Balance struct {
+	*rdbprojectionbase.Base
+
+	rdbConn   rdb.Conn
+	logger    applogger.Logger
+	baseDenom string
+}
+
+func NewBalance(logger applogger.Logger, rdbConn rdb.Conn, baseDenom string) *Balance {
+	return &Balance{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "Balance"),
+
+		rdbConn,
+		logger,
+		baseDenom,
+	}
+}
+
+func (_ *Balance) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.ACCOUNT_TRANSFERRED,
+	}
+}
+
+func (projection *Balance) OnInit() error {
+	return nil
+}
+
+func (projection *Balance) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var blockTime utctime.UTCTime
+	changes := newBalanceChanges()
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			blockTime = blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			projection.logger.Debug("handling GenesisCreated event")
+			genesisTime, parseErr := utctime.Parse(time.RFC3339, genesisCreatedEvent.Genesis.GenesisTime)
+			if parseErr != nil {
+				return fmt.Errorf("error parsing genesis time: %v", parseErr)
+			}
+			blockTime = genesisTime
+
+			for _, balance := range genesisCreatedEvent.Genesis.AppState.Bank.Balances {
+				for _, genesisCoin := range balance.Coins {
+					if genesisCoin.Denom != projection.baseDenom {
+						continue
+					}
+					amount, ok := new(big.Int).SetString(genesisCoin.Amount, 10)
+					if !ok {
+						return fmt.Errorf("error parsing genesis balance of %s: %s", balance.Address, genesisCoin.Amount)
+					}
+					changes.add(balance.Address, amount)
+				}
+			}
+		} else if accountTransferredEvent, ok := event.(*event_usecase.AccountTransferred); ok {
+			projection.logger.Debug("handling AccountTransferred event")
+			amount := accountTransferredEvent.Amount.ToBigInt()
+			if accountTransferredEvent.Sender != "" {
+				changes.add(accountTransferredEvent.Sender, new(big.Int).Neg(amount))
+			}
+			changes.add(accountTransferredEvent.Recipient, amount)
+		}
+	}
+
+	if err := projection.applyChanges(rdbTxHandle, height, blockTime, changes); err != nil {
+		return fmt.Errorf("error applying balance changes: %v", err)
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *Balance) applyChanges(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	changes *balanceChanges,
+) error {
+	balancesView := view.NewBalances(rdbTxHandle)
+	historiesView := view.NewBalanceHistories(rdbTxHandle)
+
+	for _, address := range changes.addresses {
+		change := changes.amounts[address]
+
+		balance := big.NewInt(0)
+		existing, err := balancesView.FindBy(address)
+		if err == nil {
+			var ok bool
+			if balance, ok = new(big.Int).SetString(existing.Balance, 10); !ok {
+				return fmt.Errorf("error parsing existing balance of %s: %s", address, existing.Balance)
+			}
+		} else if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting existing balance of %s: %v", address, err)
+		}
+		balance = new(big.Int).Add(balance, change)
+
+		if err := balancesView.Upsert(&view.BalanceRow{
+			Address:                address,
+			Balance:                balance.String(),
+			LastUpdatedBlockHeight: blockHeight,
+		}); err != nil {
+			return fmt.Errorf("error upserting balance: %v", err)
+		}
+		if err := historiesView.Insert(&view.BalanceHistoryRow{
+			Address:     address,
+			BlockHeight: blockHeight,
+			BlockTime:   blockTime,
+			Change:      change.String(),
+			Balance:     balance.String(),
+		}); err != nil {
+			return fmt.Errorf("error inserting balance history: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// balanceChanges accumulates the net balance change of each account in a block, keeping the order the
+// accounts are first seen
+type balanceChanges struct {
+	addresses []string
+	amounts   map[string]*big.Int
+}
+
+func newBalanceChanges() *balanceChanges {
+	return &balanceChanges{
+		addresses: make([]string, 0),
+		amounts:   make(map[string]*big.Int),
+	}
+}
+
+func (changes *balanceChanges) add(address string, amount *big.Int) {
+	existing, ok := changes.amounts[address]
+	if !ok {
+		changes.addresses = append(changes.addresses, address)
+		existing = big.NewInt(0)
+	}
+	changes.amounts[address] = new(big.Int).Add(existing, amount)
+}
@@ -0,0 +1,235 @@
+package balance_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("Balance", func() {
+	var conn rdb.Conn
+	var projection *balance.Balance
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = balance.NewBalance(NewFakeLogger(), conn, "basetcro")
+	})
+
+	genesisEvents := func() []event_entity.Event {
+		return []event_entity.Event{NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+			anyGenesis.AppState.Bank.Balances = []genesis.Balance{
+				{
+					Address: "tcro1alice",
+					Coins: []genesis.MinDeposit{
+						{Denom: "basetcro", Amount: "1000"},
+						{Denom: "othercoin", Amount: "5"},
+					},
+				},
+				{
+					Address: "tcro1bob",
+					Coins:   []genesis.MinDeposit{{Denom: "basetcro", Amount: "200"}},
+				},
+			}
+		})}
+	}
+
+	transfer := func(height int64, sender string, recipient string, amount int64) event_entity.Event {
+		return event_usecase.NewAccountTransferred(height, usecase_model.AccountTransferParams{
+			Sender:    sender,
+			Recipient: recipient,
+			Amount:    coin.MustNewCoinFromInt(amount),
+		})
+	}
+
+	findBalance := func(address string) string {
+		row, err := view.NewBalances(conn.ToHandle()).FindBy(address)
+		Expect(err).To(BeNil())
+		return row.Balance
+	}
+
+	It("should project the genesis base denom balances", func() {
+		MustReplayEvents(projection, genesisEvents())
+
+		Expect(findBalance("tcro1alice")).To(Equal("1000"))
+		Expect(findBalance("tcro1bob")).To(Equal("200"))
+	})
+
+	It("should apply the transfers and record the net change of each block", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)),
+			transfer(1, "tcro1alice", "tcro1bob", 300),
+			transfer(1, "tcro1bob", "tcro1carol", 50),
+			transfer(1, "tcro1alice", "tcro1carol", 10),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)),
+			transfer(2, "tcro1carol", "tcro1alice", 60),
+		})
+
+		Expect(findBalance("tcro1alice")).To(Equal("750"))
+		Expect(findBalance("tcro1bob")).To(Equal("450"))
+		Expect(findBalance("tcro1carol")).To(Equal("0"))
+
+		histories, paginationResult, err := view.NewBalanceHistories(conn.ToHandle()).List(
+			view.BalanceHistoriesListFilter{Address: "tcro1alice"},
+			view.BalanceHistoriesListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(3)))
+		Expect(histories).To(Equal([]view.BalanceHistoryRow{
+			{Address: "tcro1alice", BlockHeight: 0, BlockTime: GENESIS_TIME, Change: "1000", Balance: "1000"},
+			{Address: "tcro1alice", BlockHeight: 1, BlockTime: BlockTimeAt(1), Change: "-310", Balance: "690"},
+			{Address: "tcro1alice", BlockHeight: 2, BlockTime: BlockTimeAt(2), Change: "60", Balance: "750"},
+		}))
+	})
+
+	It("should find the balance at a block height or time", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(5, BlockTimeAt(5)),
+			transfer(5, "tcro1alice", "tcro1bob", 100),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(9, BlockTimeAt(9)),
+			transfer(9, "tcro1alice", "tcro1bob", 100),
+		})
+
+		historiesView := view.NewBalanceHistories(conn.ToHandle())
+		findAtHeight := func(height int64) *view.BalanceHistoryRow {
+			history, err := historiesView.FindLatestBy(view.BalanceHistoryIdentity{
+				Address:          "tcro1alice",
+				MaybeBlockHeight: &height,
+			})
+			Expect(err).To(BeNil())
+			return history
+		}
+		Expect(findAtHeight(0).Balance).To(Equal("1000"))
+		Expect(findAtHeight(4).Balance).To(Equal("1000"))
+		Expect(findAtHeight(5).Balance).To(Equal("900"))
+		Expect(findAtHeight(8).BlockHeight).To(Equal(int64(5)))
+		Expect(findAtHeight(100).Balance).To(Equal("800"))
+
+		beforeFirstTransfer := utctime.FromUnixNano(BlockTimeAt(5).UnixNano() - 1)
+		history, err := historiesView.FindLatestBy(view.BalanceHistoryIdentity{
+			Address:        "tcro1alice",
+			MaybeBlockTime: &beforeFirstTransfer,
+		})
+		Expect(err).To(BeNil())
+		Expect(history.Balance).To(Equal("1000"))
+
+		atSecondTransfer := BlockTimeAt(9)
+		history, err = historiesView.FindLatestBy(view.BalanceHistoryIdentity{
+			Address:        "tcro1alice",
+			MaybeBlockTime: &atSecondTransfer,
+		})
+		Expect(err).To(BeNil())
+		Expect(history.Balance).To(Equal("800"))
+
+		beforeGenesis := utctime.FromUnixNano(GENESIS_TIME.UnixNano() - 1)
+		_, err = historiesView.FindLatestBy(view.BalanceHistoryIdentity{
+			Address:        "tcro1alice",
+			MaybeBlockTime: &beforeGenesis,
+		})
+		Expect(err).To(Equal(rdb.ErrNoRows))
+	})
+
+	It("should list the closing balance of every bucket without gaps", func() {
+		atHour := func(hours int64) utctime.UTCTime {
+			return TimeAt(time.Duration(hours) * time.Hour)
+		}
+
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, atHour(2)),
+			transfer(1, "tcro1alice", "tcro1bob", 100),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, atHour(2)),
+			transfer(2, "tcro1alice", "tcro1bob", 50),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, atHour(4)),
+			transfer(3, "tcro1bob", "tcro1alice", 30),
+		})
+
+		series, err := view.NewBalanceHistories(conn.ToHandle()).ListSeries(view.BalanceSeriesFilter{
+			Address:  "tcro1alice",
+			Interval: view.BALANCE_SERIES_INTERVAL_HOUR,
+			From:     TimeAt(time.Hour + 30*time.Minute),
+			To:       atHour(6),
+		})
+		Expect(err).To(BeNil())
+
+		heightOf := func(height int64) *int64 {
+			return &height
+		}
+		Expect(series).To(Equal([]view.BalanceSeriesPoint{
+			{BucketStart: atHour(1), Balance: "1000", MaybeBlockHeight: heightOf(0)},
+			{BucketStart: atHour(2), Balance: "850", MaybeBlockHeight: heightOf(2)},
+			{BucketStart: atHour(3), Balance: "850", MaybeBlockHeight: heightOf(2)},
+			{BucketStart: atHour(4), Balance: "880", MaybeBlockHeight: heightOf(3)},
+			{BucketStart: atHour(5), Balance: "880", MaybeBlockHeight: heightOf(3)},
+		}))
+	})
+
+	It("should start the week buckets on Monday and month buckets on the first day", func() {
+		// Wednesday
+		wednesday := utctime.FromTime(time.Date(2021, 1, 13, 15, 4, 5, 0, time.UTC))
+
+		Expect(view.BalanceSeriesBucketStart(wednesday, view.BALANCE_SERIES_INTERVAL_WEEK)).To(
+			Equal(utctime.FromTime(time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC))),
+		)
+		Expect(view.BalanceSeriesBucketStart(wednesday, view.BALANCE_SERIES_INTERVAL_MONTH)).To(
+			Equal(utctime.FromTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))),
+		)
+		Expect(view.NextBalanceSeriesBucketStart(
+			utctime.FromTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)), view.BALANCE_SERIES_INTERVAL_MONTH,
+		)).To(Equal(utctime.FromTime(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC))))
+	})
+
+	It("should give negative balance to module accounts minting coins", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)),
+			transfer(1, "tcro1mint", "tcro1feecollector", 100),
+		})
+
+		Expect(findBalance("tcro1mint")).To(Equal("-100"))
+		Expect(findBalance("tcro1feecollector")).To(Equal("100"))
+	})
+
+	It("should count each begin block transfer of a real block once", func() {
+		const mintAddress = "tcro1m3h30wlvsf8llruxtpukdvsy0km2kum87lx9mq"
+		const feeCollectorAddress = "tcro17xpfvakm2amg962yls6f84z3kell8c5lxhzaha"
+		const distributionAddress = "tcro1jv65s3grqf6v6jl3dp4t6c9t9rk99cd8339p4l"
+
+		MustReplayEvents(projection, MustParseBlockFixtureEvents(parser.NewTxDecoder("basetcro"), NewBlockFixture(
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESULTS_RESP,
+		)))
+
+		Expect(findBalance(mintAddress)).To(Equal("-17477215277"))
+		Expect(findBalance(distributionAddress)).To(Equal("17477255277"))
+		// Keeps the fee of the block transaction and forwards the minted coins together with the fees of the
+		// previous block to the distribution module
+		Expect(findBalance(feeCollectorAddress)).To(Equal("7960000"))
+	})
+})
@@ -0,0 +1,148 @@
+package balance
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	cosmosapp_interface "github.com/crypto-com/chain-indexing/appinterface/cosmosapp"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+const DEFAULT_RECONCILE_INTERVAL = 10 * time.Minute
+const DEFAULT_RECONCILE_BATCH_SIZE = uint64(100)
+
+// Reconciler periodically compares the indexed balances against the balances reported by the node and
+// records the drifts. It goes through the accounts in batches, one batch every interval.
+//
+// The node reports the balance at its latest height, so the comparison is only meaningful when the
+// Balance projection has caught up with the chain.
+type Reconciler struct {
+	logger applogger.Logger
+
+	balancesView        *view.Balances
+	reconciliationsView *view.BalanceReconciliations
+	projectionBase      *rdbprojectionbase.Base
+	cosmosAppClient     cosmosapp_interface.Client
+
+	baseDenom string
+	interval  time.Duration
+	batchSize uint64
+
+	// Last reconciled address of the current round
+	cursor string
+
+	now func() utctime.UTCTime
+}
+
+type ReconcilerConfig struct {
+	BaseDenom string
+	Interval  time.Duration
+	BatchSize uint64
+}
+
+func NewReconciler(
+	logger applogger.Logger,
+	rdbHandle *rdb.Handle,
+	cosmosAppClient cosmosapp_interface.Client,
+	config ReconcilerConfig,
+) *Reconciler {
+	return &Reconciler{
+		logger: logger.WithFields(applogger.LogFields{
+			"module": "BalanceReconciler",
+		}),
+
+		balancesView:        view.NewBalances(rdbHandle),
+		reconciliationsView: view.NewBalanceReconciliations(rdbHandle),
+		projectionBase:      rdbprojectionbase.NewRDbBase(rdbHandle, "Balance"),
+		cosmosAppClient:     cosmosAppClient,
+
+		baseDenom: config.BaseDenom,
+		interval:  config.Interval,
+		batchSize: config.BatchSize,
+
+		now: utctime.Now,
+	}
+}
+
+// Run reconciles a batch every interval until the context is cancelled
+func (reconciler *Reconciler) Run(ctx context.Context) error {
+	for {
+		if drifted, err := reconciler.ReconcileBatch(); err != nil {
+			reconciler.logger.Errorf("error reconciling balances: %v", err)
+		} else if drifted > 0 {
+			reconciler.logger.Errorf("found %d balances drifted from the node", drifted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconciler.interval):
+		}
+	}
+}
+
+// ReconcileBatch reconciles the next batch of accounts and returns the number of drifted balances in
+// the batch. A new round starts from the first account once all accounts are reconciled.
+func (reconciler *Reconciler) ReconcileBatch() (int, error) {
+	maybeIndexedHeight, err := reconciler.projectionBase.GetLastHandledEventHeight()
+	if err != nil {
+		return 0, fmt.Errorf("error getting Balance projection last handled event height: %v", err)
+	}
+	if maybeIndexedHeight == nil {
+		return 0, nil
+	}
+
+	balances, err := reconciler.balancesView.ListAfter(reconciler.cursor, reconciler.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error listing balances: %v", err)
+	}
+	if uint64(len(balances)) < reconciler.batchSize {
+		reconciler.cursor = ""
+	} else {
+		reconciler.cursor = balances[len(balances)-1].Address
+	}
+
+	drifted := 0
+	for _, balance := range balances {
+		nodeBalance, err := reconciler.cosmosAppClient.Balance(balance.Address, reconciler.baseDenom)
+		if err != nil {
+			return drifted, fmt.Errorf("error getting balance of %s from node: %v", balance.Address, err)
+		}
+		nodeAmount, ok := new(big.Int).SetString(nodeBalance.AccountAmount, 10)
+		if !ok {
+			return drifted, fmt.Errorf("error parsing node balance of %s: %s", balance.Address, nodeBalance.AccountAmount)
+		}
+		indexedAmount, ok := new(big.Int).SetString(balance.Balance, 10)
+		if !ok {
+			return drifted, fmt.Errorf("error parsing indexed balance of %s: %s", balance.Address, balance.Balance)
+		}
+
+		drift := new(big.Int).Sub(nodeAmount, indexedAmount)
+		if drift.Sign() != 0 {
+			drifted += 1
+			reconciler.logger.Debugf(
+				"balance of %s drifted by %s: indexed %s, node %s",
+				balance.Address, drift.String(), indexedAmount.String(), nodeAmount.String(),
+			)
+		}
+
+		if err := reconciler.reconciliationsView.Upsert(&view.BalanceReconciliationRow{
+			Address:            balance.Address,
+			IndexedBalance:     indexedAmount.String(),
+			IndexedBlockHeight: *maybeIndexedHeight,
+			NodeBalance:        nodeAmount.String(),
+			Drift:              drift.String(),
+			CheckedAt:          reconciler.now(),
+		}); err != nil {
+			return drifted, fmt.Errorf("error upserting balance reconciliation: %v", err)
+		}
+	}
+
+	return drifted, nil
+}
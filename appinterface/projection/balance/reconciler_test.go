@@ -0,0 +1,106 @@
+package balance_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/cosmosapp"
+	cosmosapp_test "github.com/crypto-com/chain-indexing/appinterface/cosmosapp/test"
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+)
+
+var _ = Describe("Reconciler", func() {
+	var conn rdb.Conn
+	var mockClient *cosmosapp_test.MockClient
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		mockClient = cosmosapp_test.NewMockClient()
+
+		MustReplayEvents(
+			balance.NewBalance(NewFakeLogger(), conn, "basetcro"),
+			[]event_entity.Event{NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+				anyGenesis.AppState.Bank.Balances = []genesis.Balance{
+					{Address: "tcro1alice", Coins: []genesis.MinDeposit{{Denom: "basetcro", Amount: "1000"}}},
+					{Address: "tcro1bob", Coins: []genesis.MinDeposit{{Denom: "basetcro", Amount: "200"}}},
+					{Address: "tcro1carol", Coins: []genesis.MinDeposit{{Denom: "basetcro", Amount: "300"}}},
+				}
+			})},
+		)
+	})
+
+	nodeBalance := func(amount string) *cosmosapp.AccountBalance {
+		return &cosmosapp.AccountBalance{AccountAmount: amount, AccountDenom: "basetcro"}
+	}
+
+	listDrifted := func() []view.BalanceReconciliationRow {
+		reconciliations, _, err := view.NewBalanceReconciliations(conn.ToHandle()).List(
+			view.BalanceReconciliationsListFilter{DriftedOnly: true},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		return reconciliations
+	}
+
+	It("should record the drifted balances batch by batch", func() {
+		mockClient.On("Balance", "tcro1alice", "basetcro").Return(nodeBalance("1000"), nil)
+		mockClient.On("Balance", "tcro1bob", "basetcro").Return(nodeBalance("250"), nil)
+		mockClient.On("Balance", "tcro1carol", "basetcro").Return(nodeBalance("300"), nil)
+
+		reconciler := balance.NewReconciler(NewFakeLogger(), conn.ToHandle(), mockClient, balance.ReconcilerConfig{
+			BaseDenom: "basetcro",
+			Interval:  time.Minute,
+			BatchSize: 2,
+		})
+
+		drifted, err := reconciler.ReconcileBatch()
+		Expect(err).To(BeNil())
+		Expect(drifted).To(Equal(1))
+		mockClient.AssertNotCalled(GinkgoT(), "Balance", "tcro1carol", "basetcro")
+
+		drifts := listDrifted()
+		Expect(drifts).To(HaveLen(1))
+		Expect(drifts[0].Address).To(Equal("tcro1bob"))
+		Expect(drifts[0].IndexedBalance).To(Equal("200"))
+		Expect(drifts[0].NodeBalance).To(Equal("250"))
+		Expect(drifts[0].Drift).To(Equal("50"))
+		Expect(drifts[0].IndexedBlockHeight).To(Equal(int64(0)))
+		Expect(drifts[0].CheckedAt.UnixNano()).To(BeNumerically("<=", utctime.Now().UnixNano()))
+
+		drifted, err = reconciler.ReconcileBatch()
+		Expect(err).To(BeNil())
+		Expect(drifted).To(Equal(0))
+		mockClient.AssertCalled(GinkgoT(), "Balance", "tcro1carol", "basetcro")
+	})
+
+	It("should clear the drift once the balances match", func() {
+		mockClient.On("Balance", "tcro1alice", "basetcro").Return(nodeBalance("999"), nil).Once()
+		mockClient.On("Balance", "tcro1alice", "basetcro").Return(nodeBalance("1000"), nil)
+		mockClient.On("Balance", "tcro1bob", "basetcro").Return(nodeBalance("200"), nil)
+		mockClient.On("Balance", "tcro1carol", "basetcro").Return(nodeBalance("300"), nil)
+
+		reconciler := balance.NewReconciler(NewFakeLogger(), conn.ToHandle(), mockClient, balance.ReconcilerConfig{
+			BaseDenom: "basetcro",
+			Interval:  time.Minute,
+			BatchSize: 10,
+		})
+
+		_, err := reconciler.ReconcileBatch()
+		Expect(err).To(BeNil())
+		Expect(listDrifted()).To(HaveLen(1))
+
+		_, err = reconciler.ReconcileBatch()
+		Expect(err).To(BeNil())
+		Expect(listDrifted()).To(HaveLen(0))
+	})
+})
@@ -0,0 +1,231 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// BalanceHistories records the balance of an account at every height it changes
+type BalanceHistories struct {
+	rdb *rdb.Handle
+}
+
+func NewBalanceHistories(handle *rdb.Handle) *BalanceHistories {
+	return &BalanceHistories{
+		handle,
+	}
+}
+
+func (historiesView *BalanceHistories) Insert(history *BalanceHistoryRow) error {
+	change, ok := new(big.Int).SetString(history.Change, 10)
+	if !ok {
+		return fmt.Errorf("error parsing balance change: %s", history.Change)
+	}
+	balance, ok := new(big.Int).SetString(history.Balance, 10)
+	if !ok {
+		return fmt.Errorf("error parsing balance: %s", history.Balance)
+	}
+
+	sql, sqlArgs, err := historiesView.rdb.StmtBuilder.Insert(
+		"view_balance_histories",
+	).Columns(
+		"address",
+		"block_height",
+		"block_time",
+		"change",
+		"balance",
+	).Values(
+		history.Address,
+		history.BlockHeight,
+		historiesView.rdb.Tton(&history.BlockTime),
+		historiesView.rdb.Bton(change),
+		historiesView.rdb.Bton(balance),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building balance history insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := historiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting balance history into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting balance history into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type BalanceHistoryIdentity struct {
+	Address string
+	// Find the balance at the end of the block height
+	MaybeBlockHeight *int64
+	// Find the balance at the time, i.e. after the last block created at or before the time
+	MaybeBlockTime *utctime.UTCTime
+}
+
+// FindLatestBy finds the last balance change of the address at or before the block height or time.
+// Returns rdb.ErrNoRows when the balance has never changed by then.
+func (historiesView *BalanceHistories) FindLatestBy(identity BalanceHistoryIdentity) (*BalanceHistoryRow, error) {
+	stmtBuilder := historiesView.rdb.StmtBuilder.Select(
+		"address",
+		"block_height",
+		"block_time",
+		"change",
+		"balance",
+	).From(
+		"view_balance_histories",
+	).Where(
+		"address = ?", identity.Address,
+	).OrderBy("block_height DESC").Limit(1)
+
+	if identity.MaybeBlockHeight != nil {
+		stmtBuilder = stmtBuilder.Where("block_height <= ?", *identity.MaybeBlockHeight)
+	}
+	if identity.MaybeBlockTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time <= ?", historiesView.rdb.Tton(identity.MaybeBlockTime))
+	}
+
+	sql, sqlArgs, err := stmtBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building balance history selection sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	var history BalanceHistoryRow
+	blockTimeReader := historiesView.rdb.NtotReader()
+	changeReader := historiesView.rdb.NtobReader()
+	balanceReader := historiesView.rdb.NtobReader()
+	if err = historiesView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&history.Address,
+		&history.BlockHeight,
+		blockTimeReader.ScannableArg(),
+		changeReader.ScannableArg(),
+		balanceReader.ScannableArg(),
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning balance history row: %v: %w", err, rdb.ErrQuery)
+	}
+	blockTime, err := blockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing balance history block time: %v: %w", err, rdb.ErrQuery)
+	}
+	history.BlockTime = *blockTime
+	change, err := changeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing balance history change: %v: %w", err, rdb.ErrQuery)
+	}
+	history.Change = change.String()
+	balance, err := balanceReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing balance history balance: %v: %w", err, rdb.ErrQuery)
+	}
+	history.Balance = balance.String()
+
+	return &history, nil
+}
+
+type BalanceHistoriesListFilter struct {
+	Address string
+}
+
+type BalanceHistoriesListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (historiesView *BalanceHistories) List(
+	filter BalanceHistoriesListFilter,
+	order BalanceHistoriesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]BalanceHistoryRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := historiesView.rdb.StmtBuilder.Select(
+		"address",
+		"block_height",
+		"block_time",
+		"change",
+		"balance",
+	).From(
+		"view_balance_histories",
+	).Where(
+		"address = ?", filter.Address,
+	)
+
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		historiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building balance histories select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing balance histories select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	histories := make([]BalanceHistoryRow, 0)
+	for rowsResult.Next() {
+		var history BalanceHistoryRow
+		blockTimeReader := historiesView.rdb.NtotReader()
+		changeReader := historiesView.rdb.NtobReader()
+		balanceReader := historiesView.rdb.NtobReader()
+		if scanErr := rowsResult.Scan(
+			&history.Address,
+			&history.BlockHeight,
+			blockTimeReader.ScannableArg(),
+			changeReader.ScannableArg(),
+			balanceReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning balance history row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing balance history block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		history.BlockTime = *blockTime
+		change, parseErr := changeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing balance history change: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		history.Change = change.String()
+		balance, parseErr := balanceReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing balance history balance: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		history.Balance = balance.String()
+
+		histories = append(histories, history)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return histories, paginationResult, nil
+}
+
+type BalanceHistoryRow struct {
+	Address     string          `json:"address"`
+	BlockHeight int64           `json:"blockHeight"`
+	BlockTime   utctime.UTCTime `json:"blockTime"`
+	// Net change of the balance in the block
+	Change string `json:"change"`
+	// Balance after the block
+	Balance string `json:"balance"`
+}
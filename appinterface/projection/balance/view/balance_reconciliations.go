@@ -0,0 +1,175 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// BalanceReconciliations keeps the latest comparison of each indexed balance against the node
+type BalanceReconciliations struct {
+	rdb *rdb.Handle
+}
+
+func NewBalanceReconciliations(handle *rdb.Handle) *BalanceReconciliations {
+	return &BalanceReconciliations{
+		handle,
+	}
+}
+
+func (reconciliationsView *BalanceReconciliations) Upsert(reconciliation *BalanceReconciliationRow) error {
+	indexedBalance, ok := new(big.Int).SetString(reconciliation.IndexedBalance, 10)
+	if !ok {
+		return fmt.Errorf("error parsing indexed balance: %s", reconciliation.IndexedBalance)
+	}
+	nodeBalance, ok := new(big.Int).SetString(reconciliation.NodeBalance, 10)
+	if !ok {
+		return fmt.Errorf("error parsing node balance: %s", reconciliation.NodeBalance)
+	}
+	drift, ok := new(big.Int).SetString(reconciliation.Drift, 10)
+	if !ok {
+		return fmt.Errorf("error parsing drift: %s", reconciliation.Drift)
+	}
+
+	sql, sqlArgs, err := reconciliationsView.rdb.StmtBuilder.Insert(
+		"view_balance_reconciliations",
+	).Columns(
+		"address",
+		"indexed_balance",
+		"indexed_block_height",
+		"node_balance",
+		"drift",
+		"checked_at",
+	).Values(
+		reconciliation.Address,
+		reconciliationsView.rdb.Bton(indexedBalance),
+		reconciliation.IndexedBlockHeight,
+		reconciliationsView.rdb.Bton(nodeBalance),
+		reconciliationsView.rdb.Bton(drift),
+		reconciliationsView.rdb.Tton(&reconciliation.CheckedAt),
+	).Suffix(`ON CONFLICT (address) DO UPDATE SET
+		indexed_balance = EXCLUDED.indexed_balance,
+		indexed_block_height = EXCLUDED.indexed_block_height,
+		node_balance = EXCLUDED.node_balance,
+		drift = EXCLUDED.drift,
+		checked_at = EXCLUDED.checked_at
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building balance reconciliation upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := reconciliationsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting balance reconciliation into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting balance reconciliation into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type BalanceReconciliationsListFilter struct {
+	// Only list the balances which differ from the node
+	DriftedOnly bool
+}
+
+func (reconciliationsView *BalanceReconciliations) List(
+	filter BalanceReconciliationsListFilter,
+	pagination *pagination_interface.Pagination,
+) ([]BalanceReconciliationRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := reconciliationsView.rdb.StmtBuilder.Select(
+		"address",
+		"indexed_balance",
+		"indexed_block_height",
+		"node_balance",
+		"drift",
+		"checked_at",
+	).From(
+		"view_balance_reconciliations",
+	).OrderBy("address")
+
+	if filter.DriftedOnly {
+		stmtBuilder = stmtBuilder.Where("drift <> 0")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		reconciliationsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error building balance reconciliations select SQL: %v, %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	rowsResult, err := reconciliationsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing balance reconciliations select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	reconciliations := make([]BalanceReconciliationRow, 0)
+	for rowsResult.Next() {
+		var reconciliation BalanceReconciliationRow
+		indexedBalanceReader := reconciliationsView.rdb.NtobReader()
+		nodeBalanceReader := reconciliationsView.rdb.NtobReader()
+		driftReader := reconciliationsView.rdb.NtobReader()
+		checkedAtReader := reconciliationsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&reconciliation.Address,
+			indexedBalanceReader.ScannableArg(),
+			&reconciliation.IndexedBlockHeight,
+			nodeBalanceReader.ScannableArg(),
+			driftReader.ScannableArg(),
+			checkedAtReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning balance reconciliation row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+
+		indexedBalance, parseErr := indexedBalanceReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing indexed balance: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		reconciliation.IndexedBalance = indexedBalance.String()
+		nodeBalance, parseErr := nodeBalanceReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing node balance: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		reconciliation.NodeBalance = nodeBalance.String()
+		drift, parseErr := driftReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing drift: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		reconciliation.Drift = drift.String()
+		checkedAt, parseErr := checkedAtReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing checked at time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		reconciliation.CheckedAt = *checkedAt
+
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return reconciliations, paginationResult, nil
+}
+
+type BalanceReconciliationRow struct {
+	Address        string `json:"address"`
+	IndexedBalance string `json:"indexedBalance"`
+	// Last handled height of the balance projection when the balance is checked
+	IndexedBlockHeight int64  `json:"indexedBlockHeight"`
+	NodeBalance        string `json:"nodeBalance"`
+	// Node balance minus indexed balance
+	Drift     string          `json:"drift"`
+	CheckedAt utctime.UTCTime `json:"checkedAt"`
+}
@@ -0,0 +1,167 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type BalanceSeriesInterval = string
+
+const (
+	BALANCE_SERIES_INTERVAL_HOUR  BalanceSeriesInterval = "hour"
+	BALANCE_SERIES_INTERVAL_DAY   BalanceSeriesInterval = "day"
+	BALANCE_SERIES_INTERVAL_WEEK  BalanceSeriesInterval = "week"
+	BALANCE_SERIES_INTERVAL_MONTH BalanceSeriesInterval = "month"
+)
+
+func IsValidBalanceSeriesInterval(interval string) bool {
+	switch interval {
+	case BALANCE_SERIES_INTERVAL_HOUR,
+		BALANCE_SERIES_INTERVAL_DAY,
+		BALANCE_SERIES_INTERVAL_WEEK,
+		BALANCE_SERIES_INTERVAL_MONTH:
+		return true
+	default:
+		return false
+	}
+}
+
+// BalanceSeriesBucketStart returns the start of the UTC bucket containing the time. Weeks start on Monday.
+func BalanceSeriesBucketStart(t utctime.UTCTime, interval BalanceSeriesInterval) utctime.UTCTime {
+	goTime := time.Unix(0, t.UnixNano()).UTC()
+	year, month, day := goTime.Date()
+
+	switch interval {
+	case BALANCE_SERIES_INTERVAL_HOUR:
+		return utctime.FromTime(goTime.Truncate(time.Hour))
+	case BALANCE_SERIES_INTERVAL_WEEK:
+		daysSinceMonday := (int(goTime.Weekday()) + 6) % 7
+		return utctime.FromTime(time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, time.UTC))
+	case BALANCE_SERIES_INTERVAL_MONTH:
+		return utctime.FromTime(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+	default:
+		return utctime.FromTime(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+}
+
+// NextBalanceSeriesBucketStart returns the start of the bucket following the one starting at bucketStart
+func NextBalanceSeriesBucketStart(bucketStart utctime.UTCTime, interval BalanceSeriesInterval) utctime.UTCTime {
+	goTime := time.Unix(0, bucketStart.UnixNano()).UTC()
+
+	switch interval {
+	case BALANCE_SERIES_INTERVAL_HOUR:
+		return utctime.FromTime(goTime.Add(time.Hour))
+	case BALANCE_SERIES_INTERVAL_WEEK:
+		return utctime.FromTime(goTime.AddDate(0, 0, 7))
+	case BALANCE_SERIES_INTERVAL_MONTH:
+		return utctime.FromTime(goTime.AddDate(0, 1, 0))
+	default:
+		return utctime.FromTime(goTime.AddDate(0, 0, 1))
+	}
+}
+
+type BalanceSeriesFilter struct {
+	Address  string
+	Interval BalanceSeriesInterval
+	// Inclusive. Rounded down to the start of its bucket.
+	From utctime.UTCTime
+	// Exclusive
+	To utctime.UTCTime
+}
+
+// ListSeries returns the closing balance of the address in every bucket between From and To. Buckets
+// without any balance change carry the balance of the previous bucket, so the series has no gaps.
+func (historiesView *BalanceHistories) ListSeries(filter BalanceSeriesFilter) ([]BalanceSeriesPoint, error) {
+	from := BalanceSeriesBucketStart(filter.From, filter.Interval)
+
+	openingBalance := "0"
+	var maybeOpeningBlockHeight *int64
+	beforeFrom := utctime.FromUnixNano(from.UnixNano() - 1)
+	opening, err := historiesView.FindLatestBy(BalanceHistoryIdentity{
+		Address:        filter.Address,
+		MaybeBlockTime: &beforeFrom,
+	})
+	if err == nil {
+		openingBalance = opening.Balance
+		maybeOpeningBlockHeight = &opening.BlockHeight
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, fmt.Errorf("error finding opening balance: %v", err)
+	}
+
+	sql, sqlArgs, err := historiesView.rdb.StmtBuilder.Select(
+		"block_height",
+		"block_time",
+		"balance",
+	).From(
+		"view_balance_histories",
+	).Where(
+		"address = ? AND block_time >= ? AND block_time < ?",
+		filter.Address,
+		historiesView.rdb.Tton(&from),
+		historiesView.rdb.Tton(&filter.To),
+	).OrderBy("block_height").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building balance series select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing balance series select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	series := make([]BalanceSeriesPoint, 0)
+	current := BalanceSeriesPoint{
+		BucketStart:      from,
+		Balance:          openingBalance,
+		MaybeBlockHeight: maybeOpeningBlockHeight,
+	}
+	nextBucketStart := NextBalanceSeriesBucketStart(from, filter.Interval)
+	for rowsResult.Next() {
+		var blockHeight int64
+		blockTimeReader := historiesView.rdb.NtotReader()
+		balanceReader := historiesView.rdb.NtobReader()
+		if scanErr := rowsResult.Scan(
+			&blockHeight,
+			blockTimeReader.ScannableArg(),
+			balanceReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, fmt.Errorf("error scanning balance series row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing balance history block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		balance, parseErr := balanceReader.Parse()
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing balance history balance: %v: %w", parseErr, rdb.ErrQuery)
+		}
+
+		for blockTime.UnixNano() >= nextBucketStart.UnixNano() {
+			series = append(series, current)
+			current.BucketStart = nextBucketStart
+			nextBucketStart = NextBalanceSeriesBucketStart(nextBucketStart, filter.Interval)
+		}
+		current.Balance = balance.String()
+		current.MaybeBlockHeight = &blockHeight
+	}
+	for current.BucketStart.UnixNano() < filter.To.UnixNano() {
+		series = append(series, current)
+		current.BucketStart = nextBucketStart
+		nextBucketStart = NextBalanceSeriesBucketStart(nextBucketStart, filter.Interval)
+	}
+
+	return series, nil
+}
+
+type BalanceSeriesPoint struct {
+	BucketStart utctime.UTCTime `json:"bucketStart"`
+	// Balance at the end of the bucket
+	Balance string `json:"balance"`
+	// Height of the last balance change at or before the end of the bucket. Null when never changed.
+	MaybeBlockHeight *int64 `json:"blockHeight"`
+}
@@ -0,0 +1,144 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// Balances keeps the current base denom balance of each account
+type Balances struct {
+	rdb *rdb.Handle
+}
+
+func NewBalances(handle *rdb.Handle) *Balances {
+	return &Balances{
+		handle,
+	}
+}
+
+func (balancesView *Balances) Upsert(balance *BalanceRow) error {
+	amount, ok := new(big.Int).SetString(balance.Balance, 10)
+	if !ok {
+		return fmt.Errorf("error parsing balance: %s", balance.Balance)
+	}
+
+	sql, sqlArgs, err := balancesView.rdb.StmtBuilder.Insert(
+		"view_balances",
+	).Columns(
+		"address",
+		"balance",
+		"last_updated_block_height",
+	).Values(
+		balance.Address,
+		balancesView.rdb.Bton(amount),
+		balance.LastUpdatedBlockHeight,
+	).Suffix(`ON CONFLICT (address) DO UPDATE SET
+		balance = EXCLUDED.balance,
+		last_updated_block_height = EXCLUDED.last_updated_block_height
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building balance upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := balancesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting balance into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting balance into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (balancesView *Balances) FindBy(address string) (*BalanceRow, error) {
+	sql, sqlArgs, err := balancesView.rdb.StmtBuilder.Select(
+		"address",
+		"balance",
+		"last_updated_block_height",
+	).From(
+		"view_balances",
+	).Where(
+		"address = ?", address,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building balance selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var balance BalanceRow
+	balanceReader := balancesView.rdb.NtobReader()
+	if err = balancesView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&balance.Address,
+		balanceReader.ScannableArg(),
+		&balance.LastUpdatedBlockHeight,
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning balance row: %v: %w", err, rdb.ErrQuery)
+	}
+	amount, err := balanceReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing balance: %v: %w", err, rdb.ErrQuery)
+	}
+	balance.Balance = amount.String()
+
+	return &balance, nil
+}
+
+// ListAfter returns at most limit balances with address greater than the given address, ordered by
+// address. Used to iterate through all balances in batches.
+func (balancesView *Balances) ListAfter(address string, limit uint64) ([]BalanceRow, error) {
+	sql, sqlArgs, err := balancesView.rdb.StmtBuilder.Select(
+		"address",
+		"balance",
+		"last_updated_block_height",
+	).From(
+		"view_balances",
+	).Where(
+		"address > ?", address,
+	).OrderBy(
+		"address",
+	).Limit(limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building balances select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := balancesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing balances select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	balances := make([]BalanceRow, 0)
+	for rowsResult.Next() {
+		var balance BalanceRow
+		balanceReader := balancesView.rdb.NtobReader()
+		if scanErr := rowsResult.Scan(
+			&balance.Address,
+			balanceReader.ScannableArg(),
+			&balance.LastUpdatedBlockHeight,
+		); scanErr != nil {
+			return nil, fmt.Errorf("error scanning balance row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		amount, parseErr := balanceReader.Parse()
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing balance: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		balance.Balance = amount.String()
+
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
+
+type BalanceRow struct {
+	Address string `json:"address"`
+	// Base denom balance. Module accounts which mint or burn coins without transfers can be negative.
+	Balance                string `json:"balance"`
+	LastUpdatedBlockHeight int64  `json:"lastUpdatedBlockHeight"`
+}
@@ -0,0 +1,252 @@
+package chainstats
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/chainstats/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &ChainStats{}
+
+var STATS_INTERVALS = []view.StatsInterval{
+	view.STATS_INTERVAL_HOUR,
+	view.STATS_INTERVAL_DAY,
+}
+
+// ChainStats rolls the blocks, transactions, transfers and minting up into hourly and daily buckets.
+//
+// An address is active in a bucket when it sends or receives a transfer, or pays a transaction fee. It
+// is new in the bucket it is first active in.
+type ChainStats struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+}
+
+func NewChainStats(logger applogger.Logger, rdbConn rdb.Conn) *ChainStats {
+	return &ChainStats{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "ChainStats"),
+
+		rdbConn,
+		logger,
+	}
+}
+
+func (_ *ChainStats) GetEventsToListen() []string {
+	return []string{
+		event_usecase.BLOCK_CREATED,
+		event_usecase.TRANSACTION_CREATED,
+		event_usecase.TRANSACTION_FAILED,
+		event_usecase.ACCOUNT_TRANSFERRED,
+		event_usecase.MINTED,
+	}
+}
+
+func (projection *ChainStats) OnInit() error {
+	return nil
+}
+
+// blockStats are the statistics of a single block to add into the buckets
+type blockStats struct {
+	maybeBlockInterval *int64
+	transactions       int64
+	failedTransactions int64
+	fees               *big.Int
+	gasUsed            int64
+	transferVolume     *big.Int
+	minted             *big.Int
+	addresses          map[string]bool
+}
+
+func (stats *blockStats) addAddress(address string) {
+	if address != "" {
+		stats.addresses[address] = true
+	}
+}
+
+func (projection *ChainStats) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var maybeBlockTime *utctime.UTCTime
+	stats := blockStats{
+		fees:           new(big.Int),
+		transferVolume: new(big.Int),
+		minted:         new(big.Int),
+		addresses:      make(map[string]bool),
+	}
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if transactionCreatedEvent, ok := event.(*event_usecase.TransactionCreated); ok {
+			stats.transactions += 1
+			stats.fees.Add(stats.fees, transactionCreatedEvent.Fee.ToBigInt())
+			stats.gasUsed += int64(transactionCreatedEvent.GasUsed)
+			stats.addAddress(transactionCreatedEvent.FeePayer)
+		} else if transactionFailedEvent, ok := event.(*event_usecase.TransactionFailed); ok {
+			stats.transactions += 1
+			stats.failedTransactions += 1
+			stats.fees.Add(stats.fees, transactionFailedEvent.Fee.ToBigInt())
+			stats.gasUsed += int64(transactionFailedEvent.GasUsed)
+			stats.addAddress(transactionFailedEvent.FeePayer)
+		} else if accountTransferredEvent, ok := event.(*event_usecase.AccountTransferred); ok {
+			stats.transferVolume.Add(stats.transferVolume, accountTransferredEvent.Amount.ToBigInt())
+			stats.addAddress(accountTransferredEvent.Sender)
+			stats.addAddress(accountTransferredEvent.Recipient)
+		} else if mintedEvent, ok := event.(*event_usecase.Minted); ok {
+			minted, ok := new(big.Int).SetString(mintedEvent.Amount, 10)
+			if !ok {
+				return fmt.Errorf("error parsing minted amount: %s", mintedEvent.Amount)
+			}
+			stats.minted.Add(stats.minted, minted)
+		}
+	}
+
+	// Genesis has no block, nothing to roll up
+	if maybeBlockTime != nil {
+		paramsView := view.NewParams(rdbTxHandle)
+		lastBlockTime, err := paramsView.FindBy(view.PARAM_LAST_BLOCK_TIME)
+		if err == nil {
+			lastBlockTimeUnixNano, parseErr := strconv.ParseInt(lastBlockTime, 10, 64)
+			if parseErr != nil {
+				return fmt.Errorf("error parsing last block time: %v", parseErr)
+			}
+			blockInterval := maybeBlockTime.UnixNano() - lastBlockTimeUnixNano
+			stats.maybeBlockInterval = &blockInterval
+		} else if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting last block time: %v", err)
+		}
+
+		if err := projection.rollUp(rdbTxHandle, height, *maybeBlockTime, &stats); err != nil {
+			return fmt.Errorf("error rolling up block statistics: %v", err)
+		}
+
+		if err := paramsView.Set(
+			view.PARAM_LAST_BLOCK_TIME, strconv.FormatInt(maybeBlockTime.UnixNano(), 10),
+		); err != nil {
+			return fmt.Errorf("error setting last block time: %v", err)
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *ChainStats) rollUp(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	stats *blockStats,
+) error {
+	bucketsView := view.NewStatsBuckets(rdbTxHandle)
+	addressesView := view.NewAddresses(rdbTxHandle)
+
+	// Sorted for deterministic write order
+	addresses := make([]string, 0, len(stats.addresses))
+	for address := range stats.addresses {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	newAddresses := int64(0)
+	for _, address := range addresses {
+		isNew, err := addressesView.InsertIfNotSeen(address, blockHeight)
+		if err != nil {
+			return fmt.Errorf("error recording seen address: %v", err)
+		}
+		if isNew {
+			newAddresses += 1
+		}
+	}
+
+	for _, interval := range STATS_INTERVALS {
+		bucketStart := view.StatsBucketStart(blockTime, interval)
+		mutBucket, err := bucketsView.FindBy(interval, bucketStart)
+		if err != nil {
+			if !errors.Is(err, rdb.ErrNoRows) {
+				return fmt.Errorf("error getting existing stats bucket: %v", err)
+			}
+			mutBucket = view.NewStatsBucketRow(interval, bucketStart)
+
+			// The block time has passed the previous buckets, their addresses are no longer needed
+			if err := addressesView.DeleteBucketAddressesBefore(interval, bucketStart); err != nil {
+				return fmt.Errorf("error pruning addresses of the previous stats buckets: %v", err)
+			}
+		}
+
+		activeAddresses := int64(0)
+		for _, address := range addresses {
+			isActive, err := addressesView.InsertIfNotSeenInBucket(interval, bucketStart, address)
+			if err != nil {
+				return fmt.Errorf("error recording active address: %v", err)
+			}
+			if isActive {
+				activeAddresses += 1
+			}
+		}
+
+		mutBucket.Blocks += 1
+		if stats.maybeBlockInterval != nil {
+			mutBucket.BlockIntervals += 1
+			mutBucket.TotalBlockInterval += *stats.maybeBlockInterval
+		}
+		mutBucket.Transactions += stats.transactions
+		mutBucket.FailedTransactions += stats.failedTransactions
+		mutBucket.GasUsed += stats.gasUsed
+		mutBucket.ActiveAddresses += activeAddresses
+		mutBucket.NewAddresses += newAddresses
+		if mutBucket.Fees, err = addIntString(mutBucket.Fees, stats.fees); err != nil {
+			return fmt.Errorf("error adding fees: %v", err)
+		}
+		if mutBucket.TransferVolume, err = addIntString(mutBucket.TransferVolume, stats.transferVolume); err != nil {
+			return fmt.Errorf("error adding transfer volume: %v", err)
+		}
+		if mutBucket.Minted, err = addIntString(mutBucket.Minted, stats.minted); err != nil {
+			return fmt.Errorf("error adding minted: %v", err)
+		}
+
+		if err := bucketsView.Upsert(mutBucket); err != nil {
+			return fmt.Errorf("error upserting stats bucket: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func addIntString(stored string, value *big.Int) (string, error) {
+	storedValue, ok := new(big.Int).SetString(stored, 10)
+	if !ok {
+		return "", fmt.Errorf("error parsing integer: %s", stored)
+	}
+	return storedValue.Add(storedValue, value).String(), nil
+}
@@ -0,0 +1,13 @@
+package chainstats_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestChainStats(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ChainStats Suite")
+}
@@ -0,0 +1,174 @@
+package chainstats_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/chainstats"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/chainstats/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("ChainStats", func() {
+	var conn rdb.Conn
+	var projection *chainstats.ChainStats
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = chainstats.NewChainStats(NewFakeLogger(), conn)
+	})
+
+	hourOf := func(hour int, minute int) utctime.UTCTime {
+		return TimeAt(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	transactionCreated := func(height int64, fee int64, gasUsed int) event_entity.Event {
+		return event_usecase.NewTransactionCreated(height, usecase_model.CreateTransactionParams{
+			TxHash:  "tx",
+			Fee:     coin.MustNewCoinFromInt(fee),
+			GasUsed: gasUsed,
+		})
+	}
+	transactionFailed := func(height int64, fee int64, gasUsed int) event_entity.Event {
+		return event_usecase.NewTransactionFailed(height, usecase_model.CreateTransactionParams{
+			TxHash:  "failed-tx",
+			Code:    1,
+			Fee:     coin.MustNewCoinFromInt(fee),
+			GasUsed: gasUsed,
+		})
+	}
+	accountTransferred := func(height int64, sender string, recipient string, amount int64) event_entity.Event {
+		return event_usecase.NewAccountTransferred(height, usecase_model.AccountTransferParams{
+			Sender:    sender,
+			Recipient: recipient,
+			Amount:    coin.MustNewCoinFromInt(amount),
+		})
+	}
+
+	It("should roll the block statistics up into hour and day buckets", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, hourOf(10, 0)),
+			transactionCreated(1, 10, 100),
+			accountTransferred(1, "tcro1a", "tcro1b", 1000),
+			event_usecase.NewMinted(1, usecase_model.MintParams{Amount: "50"}),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, hourOf(10, 30)),
+			transactionFailed(2, 5, 20),
+			accountTransferred(2, "tcro1b", "tcro1c", 500),
+			event_usecase.NewMinted(2, usecase_model.MintParams{Amount: "50"}),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, hourOf(11, 30)),
+			accountTransferred(3, "tcro1a", "tcro1b", 1),
+		})
+
+		bucketsView := view.NewStatsBuckets(conn.ToHandle())
+		hourBucket, err := bucketsView.FindBy(view.STATS_INTERVAL_HOUR, hourOf(10, 0))
+		Expect(err).To(BeNil())
+		Expect(hourBucket).To(Equal(&view.StatsBucketRow{
+			Interval:           view.STATS_INTERVAL_HOUR,
+			BucketStart:        hourOf(10, 0),
+			Blocks:             2,
+			BlockIntervals:     1,
+			TotalBlockInterval: int64(30 * time.Minute),
+			Transactions:       2,
+			FailedTransactions: 1,
+			Fees:               "15",
+			GasUsed:            120,
+			ActiveAddresses:    3,
+			NewAddresses:       3,
+			TransferVolume:     "1500",
+			Minted:             "100",
+		}))
+
+		nextHourBucket, err := bucketsView.FindBy(view.STATS_INTERVAL_HOUR, hourOf(11, 0))
+		Expect(err).To(BeNil())
+		Expect(nextHourBucket.ActiveAddresses).To(Equal(int64(2)))
+		Expect(nextHourBucket.NewAddresses).To(Equal(int64(0)))
+
+		dayBucket, err := bucketsView.FindBy(view.STATS_INTERVAL_DAY, hourOf(0, 0))
+		Expect(err).To(BeNil())
+		Expect(dayBucket.Blocks).To(Equal(int64(3)))
+		Expect(dayBucket.ActiveAddresses).To(Equal(int64(3)))
+		Expect(dayBucket.MetricValue(view.STATS_METRIC_AVG_BLOCK_TIME)).To(Equal(primptr.String("2700.000")))
+	})
+
+	It("should count each transfer of a real block once, including the module account transfers", func() {
+		events := MustParseBlockFixtureEvents(parser.NewTxDecoder("basetcro"), NewBlockFixture(
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESULTS_RESP,
+		))
+		MustReplayEvents(projection, events)
+
+		var blockTime utctime.UTCTime
+		for _, event := range events {
+			if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+				blockTime = blockCreatedEvent.Block.Time
+			}
+		}
+		hourBucket, err := view.NewStatsBuckets(conn.ToHandle()).FindBy(
+			view.STATS_INTERVAL_HOUR, view.StatsBucketStart(blockTime, view.STATS_INTERVAL_HOUR),
+		)
+		Expect(err).To(BeNil())
+		// 17477215277 minted, 17477255277 forwarded to the distribution module, 8000000 of transaction fee and
+		// 1000000000 sent
+		Expect(hourBucket.TransferVolume).To(Equal("35962470554"))
+	})
+
+	It("should prune the addresses of the buckets the block time has passed", func() {
+		countBucketAddresses := func(interval view.StatsInterval) int64 {
+			var count int64
+			Expect(conn.QueryRow(
+				"SELECT COUNT(*) FROM view_chain_stats_bucket_addresses WHERE bucket_interval = $1", interval,
+			).Scan(&count)).To(Succeed())
+			return count
+		}
+
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, hourOf(10, 0)),
+			accountTransferred(1, "tcro1a", "tcro1b", 1000),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, hourOf(11, 0)),
+			accountTransferred(2, "tcro1a", "tcro1c", 1000),
+		})
+
+		Expect(countBucketAddresses(view.STATS_INTERVAL_HOUR)).To(Equal(int64(2)))
+		Expect(countBucketAddresses(view.STATS_INTERVAL_DAY)).To(Equal(int64(3)))
+	})
+
+	It("should fill the buckets without activity in the series", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, hourOf(10, 0)),
+			transactionCreated(1, 10, 100),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, hourOf(12, 0)),
+		})
+
+		series, err := view.NewStatsBuckets(conn.ToHandle()).ListSeries(view.StatsSeriesFilter{
+			Metric:   view.STATS_METRIC_TRANSACTIONS,
+			Interval: view.STATS_INTERVAL_HOUR,
+			From:     hourOf(10, 15),
+			To:       hourOf(13, 0),
+		})
+		Expect(err).To(BeNil())
+		Expect(series).To(Equal([]view.StatsSeriesPoint{
+			{BucketStart: hourOf(10, 0), MaybeValue: primptr.String("1")},
+			{BucketStart: hourOf(11, 0), MaybeValue: primptr.String("0")},
+			{BucketStart: hourOf(12, 0), MaybeValue: primptr.String("0")},
+		}))
+	})
+})
@@ -0,0 +1,97 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Addresses keeps the addresses ever seen on chain and the addresses seen in every stats bucket, which
+// are counted as the new and active addresses
+type Addresses struct {
+	rdb *rdb.Handle
+}
+
+func NewAddresses(handle *rdb.Handle) *Addresses {
+	return &Addresses{
+		handle,
+	}
+}
+
+// InsertIfNotSeen records the address as seen at the block height. Returns true when the address is
+// first seen.
+func (addressesView *Addresses) InsertIfNotSeen(address string, blockHeight int64) (bool, error) {
+	sql, sqlArgs, err := addressesView.rdb.StmtBuilder.Insert(
+		"view_chain_stats_addresses",
+	).Columns(
+		"address",
+		"first_seen_block_height",
+	).Values(
+		address,
+		blockHeight,
+	).Suffix("ON CONFLICT (address) DO NOTHING").ToSql()
+	if err != nil {
+		return false, fmt.Errorf("error building chain stats address insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := addressesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return false, fmt.Errorf("error inserting chain stats address into the table: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// InsertIfNotSeenInBucket records the address as seen in the bucket. Returns true when the address is
+// first seen in the bucket.
+func (addressesView *Addresses) InsertIfNotSeenInBucket(
+	interval StatsInterval,
+	bucketStart utctime.UTCTime,
+	address string,
+) (bool, error) {
+	sql, sqlArgs, err := addressesView.rdb.StmtBuilder.Insert(
+		"view_chain_stats_bucket_addresses",
+	).Columns(
+		"bucket_interval",
+		"bucket_start",
+		"address",
+	).Values(
+		interval,
+		addressesView.rdb.Tton(&bucketStart),
+		address,
+	).Suffix("ON CONFLICT (bucket_interval, bucket_start, address) DO NOTHING").ToSql()
+	if err != nil {
+		return false, fmt.Errorf(
+			"error building chain stats bucket address insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	result, err := addressesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return false, fmt.Errorf("error inserting chain stats bucket address into the table: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// DeleteBucketAddressesBefore forgets the addresses seen in the buckets of the interval starting before
+// the bucket start
+func (addressesView *Addresses) DeleteBucketAddressesBefore(interval StatsInterval, bucketStart utctime.UTCTime) error {
+	sql, sqlArgs, err := addressesView.rdb.StmtBuilder.Delete(
+		"view_chain_stats_bucket_addresses",
+	).Where(
+		"bucket_interval = ? AND bucket_start < ?", interval, addressesView.rdb.Tton(&bucketStart),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf(
+			"error building chain stats bucket addresses deletion sql: %v: %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	if _, err = addressesView.rdb.Exec(sql, sqlArgs...); err != nil {
+		return fmt.Errorf("error deleting chain stats bucket addresses: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type StatsInterval = string
+
+const (
+	STATS_INTERVAL_HOUR StatsInterval = "hour"
+	STATS_INTERVAL_DAY  StatsInterval = "day"
+)
+
+func IsValidStatsInterval(interval string) bool {
+	return interval == STATS_INTERVAL_HOUR || interval == STATS_INTERVAL_DAY
+}
+
+// StatsBucketStart returns the start of the UTC hour or day bucket containing the time
+func StatsBucketStart(t utctime.UTCTime, interval StatsInterval) utctime.UTCTime {
+	goTime := time.Unix(0, t.UnixNano()).UTC()
+	if interval == STATS_INTERVAL_HOUR {
+		return utctime.FromTime(goTime.Truncate(time.Hour))
+	}
+
+	year, month, day := goTime.Date()
+	return utctime.FromTime(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// NextStatsBucketStart returns the start of the bucket following the one starting at bucketStart
+func NextStatsBucketStart(bucketStart utctime.UTCTime, interval StatsInterval) utctime.UTCTime {
+	goTime := time.Unix(0, bucketStart.UnixNano()).UTC()
+	if interval == STATS_INTERVAL_HOUR {
+		return utctime.FromTime(goTime.Add(time.Hour))
+	}
+
+	return utctime.FromTime(goTime.AddDate(0, 0, 1))
+}
+
+// StatsBuckets keeps the chain statistics rolled up by the hour and by the day in UTC
+type StatsBuckets struct {
+	rdb *rdb.Handle
+}
+
+func NewStatsBuckets(handle *rdb.Handle) *StatsBuckets {
+	return &StatsBuckets{
+		handle,
+	}
+}
+
+func (bucketsView *StatsBuckets) Upsert(bucket *StatsBucketRow) error {
+	fees, ok := new(big.Int).SetString(bucket.Fees, 10)
+	if !ok {
+		return fmt.Errorf("error parsing stats bucket fees: %s", bucket.Fees)
+	}
+	transferVolume, ok := new(big.Int).SetString(bucket.TransferVolume, 10)
+	if !ok {
+		return fmt.Errorf("error parsing stats bucket transfer volume: %s", bucket.TransferVolume)
+	}
+	minted, ok := new(big.Int).SetString(bucket.Minted, 10)
+	if !ok {
+		return fmt.Errorf("error parsing stats bucket minted: %s", bucket.Minted)
+	}
+
+	sql, sqlArgs, err := bucketsView.rdb.StmtBuilder.Insert(
+		"view_chain_stats_buckets",
+	).Columns(
+		"bucket_interval",
+		"bucket_start",
+		"blocks",
+		"block_intervals",
+		"total_block_interval",
+		"transactions",
+		"failed_transactions",
+		"fees",
+		"gas_used",
+		"active_addresses",
+		"new_addresses",
+		"transfer_volume",
+		"minted",
+	).Values(
+		bucket.Interval,
+		bucketsView.rdb.Tton(&bucket.BucketStart),
+		bucket.Blocks,
+		bucket.BlockIntervals,
+		bucket.TotalBlockInterval,
+		bucket.Transactions,
+		bucket.FailedTransactions,
+		bucketsView.rdb.Bton(fees),
+		bucket.GasUsed,
+		bucket.ActiveAddresses,
+		bucket.NewAddresses,
+		bucketsView.rdb.Bton(transferVolume),
+		bucketsView.rdb.Bton(minted),
+	).Suffix(`ON CONFLICT (bucket_interval, bucket_start) DO UPDATE SET
+		blocks = EXCLUDED.blocks,
+		block_intervals = EXCLUDED.block_intervals,
+		total_block_interval = EXCLUDED.total_block_interval,
+		transactions = EXCLUDED.transactions,
+		failed_transactions = EXCLUDED.failed_transactions,
+		fees = EXCLUDED.fees,
+		gas_used = EXCLUDED.gas_used,
+		active_addresses = EXCLUDED.active_addresses,
+		new_addresses = EXCLUDED.new_addresses,
+		transfer_volume = EXCLUDED.transfer_volume,
+		minted = EXCLUDED.minted
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building chain stats bucket upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := bucketsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting chain stats bucket into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting chain stats bucket into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when nothing has happened in the bucket
+func (bucketsView *StatsBuckets) FindBy(interval StatsInterval, bucketStart utctime.UTCTime) (*StatsBucketRow, error) {
+	sql, sqlArgs, err := bucketsView.selectStmtBuilder().Where(
+		"bucket_interval = ? AND bucket_start = ?", interval, bucketsView.rdb.Tton(&bucketStart),
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building chain stats bucket selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := bucketsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing chain stats bucket selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return bucketsView.scan(rowsResult)
+}
+
+type StatsBucketsListFilter struct {
+	Interval StatsInterval
+	// Inclusive
+	From utctime.UTCTime
+	// Exclusive
+	To utctime.UTCTime
+}
+
+// ListAll returns the buckets with activity in the time range in time order
+func (bucketsView *StatsBuckets) ListAll(filter StatsBucketsListFilter) ([]StatsBucketRow, error) {
+	sql, sqlArgs, err := bucketsView.selectStmtBuilder().Where(
+		"bucket_interval = ? AND bucket_start >= ? AND bucket_start < ?",
+		filter.Interval,
+		bucketsView.rdb.Tton(&filter.From),
+		bucketsView.rdb.Tton(&filter.To),
+	).OrderBy("bucket_start").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building chain stats buckets select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := bucketsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing chain stats buckets select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	buckets := make([]StatsBucketRow, 0)
+	for rowsResult.Next() {
+		bucket, scanErr := bucketsView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		buckets = append(buckets, *bucket)
+	}
+
+	return buckets, nil
+}
+
+func (bucketsView *StatsBuckets) selectStmtBuilder() sq.SelectBuilder {
+	return bucketsView.rdb.StmtBuilder.Select(
+		"bucket_interval",
+		"bucket_start",
+		"blocks",
+		"block_intervals",
+		"total_block_interval",
+		"transactions",
+		"failed_transactions",
+		"fees",
+		"gas_used",
+		"active_addresses",
+		"new_addresses",
+		"transfer_volume",
+		"minted",
+	).From(
+		"view_chain_stats_buckets",
+	)
+}
+
+func (bucketsView *StatsBuckets) scan(rowsResult rdb.RowsResult) (*StatsBucketRow, error) {
+	var bucket StatsBucketRow
+	bucketStartReader := bucketsView.rdb.NtotReader()
+	feesReader := bucketsView.rdb.NtobReader()
+	transferVolumeReader := bucketsView.rdb.NtobReader()
+	mintedReader := bucketsView.rdb.NtobReader()
+	if err := rowsResult.Scan(
+		&bucket.Interval,
+		bucketStartReader.ScannableArg(),
+		&bucket.Blocks,
+		&bucket.BlockIntervals,
+		&bucket.TotalBlockInterval,
+		&bucket.Transactions,
+		&bucket.FailedTransactions,
+		feesReader.ScannableArg(),
+		&bucket.GasUsed,
+		&bucket.ActiveAddresses,
+		&bucket.NewAddresses,
+		transferVolumeReader.ScannableArg(),
+		mintedReader.ScannableArg(),
+	); err != nil {
+		return nil, fmt.Errorf("error scanning chain stats bucket row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	bucketStart, err := bucketStartReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing chain stats bucket start: %v: %w", err, rdb.ErrQuery)
+	}
+	bucket.BucketStart = *bucketStart
+	fees, err := feesReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing chain stats bucket fees: %v: %w", err, rdb.ErrQuery)
+	}
+	bucket.Fees = fees.String()
+	transferVolume, err := transferVolumeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing chain stats bucket transfer volume: %v: %w", err, rdb.ErrQuery)
+	}
+	bucket.TransferVolume = transferVolume.String()
+	minted, err := mintedReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing chain stats bucket minted: %v: %w", err, rdb.ErrQuery)
+	}
+	bucket.Minted = minted.String()
+
+	return &bucket, nil
+}
+
+// NewStatsBucketRow returns an empty bucket
+func NewStatsBucketRow(interval StatsInterval, bucketStart utctime.UTCTime) *StatsBucketRow {
+	return &StatsBucketRow{
+		Interval:       interval,
+		BucketStart:    bucketStart,
+		Fees:           "0",
+		TransferVolume: "0",
+		Minted:         "0",
+	}
+}
+
+// StatsBucketRow amounts are in base denom
+type StatsBucketRow struct {
+	Interval    StatsInterval
+	BucketStart utctime.UTCTime
+	Blocks      int64
+	// Number of blocks in the bucket with a known previous block, and the sum of the time since it in
+	// nanoseconds, to average the block time
+	BlockIntervals     int64
+	TotalBlockInterval int64
+	// Including the failed transactions
+	Transactions       int64
+	FailedTransactions int64
+	Fees               string
+	GasUsed            int64
+	ActiveAddresses    int64
+	NewAddresses       int64
+	TransferVolume     string
+	Minted             string
+}
@@ -0,0 +1,135 @@
+package view
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type StatsMetric = string
+
+const (
+	STATS_METRIC_BLOCKS              StatsMetric = "blocks"
+	STATS_METRIC_AVG_BLOCK_TIME      StatsMetric = "avg_block_time"
+	STATS_METRIC_TRANSACTIONS        StatsMetric = "transactions"
+	STATS_METRIC_FAILED_TRANSACTIONS StatsMetric = "failed_transactions"
+	STATS_METRIC_FEES                StatsMetric = "fees"
+	STATS_METRIC_GAS_USED            StatsMetric = "gas_used"
+	STATS_METRIC_ACTIVE_ADDRESSES    StatsMetric = "active_addresses"
+	STATS_METRIC_NEW_ADDRESSES       StatsMetric = "new_addresses"
+	STATS_METRIC_TRANSFER_VOLUME     StatsMetric = "transfer_volume"
+	STATS_METRIC_MINTED              StatsMetric = "minted"
+)
+
+var STATS_METRICS = []StatsMetric{
+	STATS_METRIC_BLOCKS,
+	STATS_METRIC_AVG_BLOCK_TIME,
+	STATS_METRIC_TRANSACTIONS,
+	STATS_METRIC_FAILED_TRANSACTIONS,
+	STATS_METRIC_FEES,
+	STATS_METRIC_GAS_USED,
+	STATS_METRIC_ACTIVE_ADDRESSES,
+	STATS_METRIC_NEW_ADDRESSES,
+	STATS_METRIC_TRANSFER_VOLUME,
+	STATS_METRIC_MINTED,
+}
+
+func IsValidStatsMetric(metric string) bool {
+	for _, validMetric := range STATS_METRICS {
+		if metric == validMetric {
+			return true
+		}
+	}
+	return false
+}
+
+// Decimal places of the average block time in seconds
+const AVG_BLOCK_TIME_PRECISION = 3
+
+var nanosecondsPerSecond = big.NewInt(1000000000)
+
+// MetricValue returns the metric of the bucket. Amounts are in base denom and the average block time
+// is in seconds. Returns nil when the metric is not available, i.e. average block time without blocks.
+func (bucket *StatsBucketRow) MetricValue(metric StatsMetric) *string {
+	var value string
+	switch metric {
+	case STATS_METRIC_BLOCKS:
+		value = strconv.FormatInt(bucket.Blocks, 10)
+	case STATS_METRIC_AVG_BLOCK_TIME:
+		if bucket.BlockIntervals == 0 {
+			return nil
+		}
+		avgBlockTime := new(big.Rat).SetFrac(
+			big.NewInt(bucket.TotalBlockInterval),
+			new(big.Int).Mul(big.NewInt(bucket.BlockIntervals), nanosecondsPerSecond),
+		)
+		value = avgBlockTime.FloatString(AVG_BLOCK_TIME_PRECISION)
+	case STATS_METRIC_TRANSACTIONS:
+		value = strconv.FormatInt(bucket.Transactions, 10)
+	case STATS_METRIC_FAILED_TRANSACTIONS:
+		value = strconv.FormatInt(bucket.FailedTransactions, 10)
+	case STATS_METRIC_FEES:
+		value = bucket.Fees
+	case STATS_METRIC_GAS_USED:
+		value = strconv.FormatInt(bucket.GasUsed, 10)
+	case STATS_METRIC_ACTIVE_ADDRESSES:
+		value = strconv.FormatInt(bucket.ActiveAddresses, 10)
+	case STATS_METRIC_NEW_ADDRESSES:
+		value = strconv.FormatInt(bucket.NewAddresses, 10)
+	case STATS_METRIC_TRANSFER_VOLUME:
+		value = bucket.TransferVolume
+	case STATS_METRIC_MINTED:
+		value = bucket.Minted
+	default:
+		return nil
+	}
+	return &value
+}
+
+type StatsSeriesFilter struct {
+	Metric   StatsMetric
+	Interval StatsInterval
+	// Inclusive. Rounded down to the start of its bucket.
+	From utctime.UTCTime
+	// Exclusive
+	To utctime.UTCTime
+}
+
+// ListSeries returns the metric in every bucket of the time range. Buckets without activity are
+// filled with the metric of an empty bucket.
+func (bucketsView *StatsBuckets) ListSeries(filter StatsSeriesFilter) ([]StatsSeriesPoint, error) {
+	from := StatsBucketStart(filter.From, filter.Interval)
+	buckets, err := bucketsView.ListAll(StatsBucketsListFilter{
+		Interval: filter.Interval,
+		From:     from,
+		To:       filter.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]StatsSeriesPoint, 0)
+	bucketIndex := 0
+	for bucketStart := from; bucketStart.UnixNano() < filter.To.UnixNano(); bucketStart = NextStatsBucketStart(
+		bucketStart, filter.Interval,
+	) {
+		bucket := NewStatsBucketRow(filter.Interval, bucketStart)
+		if bucketIndex < len(buckets) && buckets[bucketIndex].BucketStart.UnixNano() == bucketStart.UnixNano() {
+			bucket = &buckets[bucketIndex]
+			bucketIndex += 1
+		}
+
+		series = append(series, StatsSeriesPoint{
+			BucketStart: bucketStart,
+			MaybeValue:  bucket.MetricValue(filter.Metric),
+		})
+	}
+
+	return series, nil
+}
+
+type StatsSeriesPoint struct {
+	BucketStart utctime.UTCTime `json:"bucketStart"`
+	MaybeValue  *string         `json:"value"`
+}
@@ -0,0 +1,12 @@
+package constants
+
+const CREATE_VALIDATOR HistoryType = "CREATE_VALIDATOR"
+const DELEGATE HistoryType = "DELEGATE"
+const UNDELEGATE HistoryType = "UNDELEGATE"
+
+// REDELEGATE_OUT and REDELEGATE_IN are recorded against the source and destination validator of a
+// redelegation respectively
+const REDELEGATE_OUT HistoryType = "REDELEGATE_OUT"
+const REDELEGATE_IN HistoryType = "REDELEGATE_IN"
+
+type HistoryType = string
@@ -0,0 +1,623 @@
+package delegation
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegation/constants"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegation/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &Delegation{}
+
+// errMissingDelegation is returned when unbonding from a delegation the ledger has not seen, such as
+// a delegation carried over from an exported genesis
+var errMissingDelegation = errors.New("missing delegation")
+
+// Slash reasons in the `slash` begin block events
+const SLASH_REASON_MISSING_SIGNATURE = "missing_signature"
+const SLASH_REASON_DOUBLE_SIGN = "double_sign"
+
+// Delegation keeps a ledger of the delegations, unbonding delegations and redelegations of every
+// delegator on every validator.
+//
+// Slashing burns the slash fraction of the validator tokens at the time the slash event is seen. Unlike
+// the chain, unbonding delegations and redelegations created after the infraction are not slashed, so
+// the ledger is an approximation around slashes.
+type Delegation struct {
+	*rdbprojectionbase.Base
+
+	rdbConn              rdb.Conn
+	logger               applogger.Logger
+	conNodeAddressPrefix string
+}
+
+func NewDelegation(logger applogger.Logger, rdbConn rdb.Conn, conNodeAddressPrefix string) *Delegation {
+	return &Delegation{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "Delegation"),
+
+		rdbConn,
+		logger,
+		conNodeAddressPrefix,
+	}
+}
+
+func (_ *Delegation) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_CREATE_VALIDATOR_CREATED,
+		event_usecase.MSG_DELEGATE_CREATED,
+		event_usecase.MSG_UNDELEGATE_CREATED,
+		event_usecase.MSG_BEGIN_REDELEGATE_CREATED,
+		event_usecase.VALIDATOR_SLASHED,
+	}
+}
+
+func (projection *Delegation) OnInit() error {
+	return nil
+}
+
+func (projection *Delegation) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+	paramsView := view.NewParams(rdbTxHandle)
+
+	var blockTime utctime.UTCTime
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			blockTime = blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			genesisTime, parseErr := utctime.Parse(time.RFC3339, genesisCreatedEvent.Genesis.GenesisTime)
+			if parseErr != nil {
+				return fmt.Errorf("error parsing genesis time: %v", parseErr)
+			}
+			blockTime = genesisTime
+
+			if err := projection.projectParams(paramsView, genesisCreatedEvent); err != nil {
+				return fmt.Errorf("error projecting delegation params: %v", err)
+			}
+		}
+	}
+
+	// Unbonding entries and redelegations expire by their completion time, the same way the chain
+	// matures them at the end block, without depending on the end block events
+	if err := view.NewUnbondingDelegations(rdbTxHandle).DeleteCompleted(blockTime); err != nil {
+		return fmt.Errorf("error deleting completed unbonding delegations: %v", err)
+	}
+	if err := view.NewRedelegations(rdbTxHandle).DeleteCompleted(blockTime); err != nil {
+		return fmt.Errorf("error deleting completed redelegations: %v", err)
+	}
+
+	ledger, err := projection.newLedger(rdbTxHandle, height, blockTime)
+	if err != nil {
+		return fmt.Errorf("error preparing delegation ledger: %v", err)
+	}
+	for _, event := range events {
+		if msgCreateValidatorEvent, ok := event.(*event_usecase.MsgCreateValidator); ok {
+			projection.logger.Debug("handling MsgCreateValidator event")
+			if err := projection.handleCreateValidator(ledger, msgCreateValidatorEvent); err != nil {
+				return fmt.Errorf("error handling MsgCreateValidator: %v", err)
+			}
+		} else if msgDelegateEvent, ok := event.(*event_usecase.MsgDelegate); ok {
+			projection.logger.Debug("handling MsgDelegate event")
+			if _, err := ledger.delegate(
+				msgDelegateEvent.DelegatorAddress,
+				msgDelegateEvent.ValidatorAddress,
+				msgDelegateEvent.Amount.ToBigInt(),
+				msgDelegateEvent.MsgTxHash,
+				constants.DELEGATE,
+			); err != nil {
+				return fmt.Errorf("error handling MsgDelegate: %v", err)
+			}
+		} else if msgUndelegateEvent, ok := event.(*event_usecase.MsgUndelegate); ok {
+			projection.logger.Debug("handling MsgUndelegate event")
+			if err := projection.handleUndelegate(ledger, msgUndelegateEvent); err != nil {
+				return fmt.Errorf("error handling MsgUndelegate: %v", err)
+			}
+		} else if msgBeginRedelegateEvent, ok := event.(*event_usecase.MsgBeginRedelegate); ok {
+			projection.logger.Debug("handling MsgBeginRedelegate event")
+			if err := projection.handleBeginRedelegate(ledger, msgBeginRedelegateEvent); err != nil {
+				return fmt.Errorf("error handling MsgBeginRedelegate: %v", err)
+			}
+		} else if validatorSlashedEvent, ok := event.(*event_usecase.ValidatorSlashed); ok {
+			projection.logger.Debug("handling ValidatorSlashed event")
+			if err := projection.handleValidatorSlashed(ledger, validatorSlashedEvent); err != nil {
+				return fmt.Errorf("error handling ValidatorSlashed: %v", err)
+			}
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *Delegation) projectParams(
+	paramsView *view.Params,
+	genesisCreatedEvent *event_usecase.GenesisCreated,
+) error {
+	appState := genesisCreatedEvent.Genesis.AppState
+
+	if err := paramsView.Set(view.PARAM_UNBONDING_TIME, appState.Staking.Params.UnbondingTime); err != nil {
+		return fmt.Errorf("error setting unbonding time: %v", err)
+	}
+	if err := paramsView.Set(
+		view.PARAM_SLASH_FRACTION_DOUBLE_SIGN, appState.Slashing.Params.SlashFractionDoubleSign,
+	); err != nil {
+		return fmt.Errorf("error setting double sign slash fraction: %v", err)
+	}
+	if err := paramsView.Set(
+		view.PARAM_SLASH_FRACTION_DOWNTIME, appState.Slashing.Params.SlashFractionDowntime,
+	); err != nil {
+		return fmt.Errorf("error setting downtime slash fraction: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *Delegation) handleCreateValidator(
+	ledger *ledger,
+	msgCreateValidatorEvent *event_usecase.MsgCreateValidator,
+) error {
+	pubKey, err := base64.StdEncoding.DecodeString(msgCreateValidatorEvent.TendermintPubkey)
+	if err != nil {
+		return fmt.Errorf("error base64 decoding Tendermint node pubkey: %v", err)
+	}
+	consensusNodeAddress, err := tmcosmosutils.ConsensusNodeAddressFromTmPubKey(
+		projection.conNodeAddressPrefix, pubKey,
+	)
+	if err != nil {
+		return fmt.Errorf("error converting Tendermint node pubkey to address: %v", err)
+	}
+
+	if err := ledger.validatorsView.Insert(&view.ValidatorRow{
+		OperatorAddress:      msgCreateValidatorEvent.ValidatorAddress,
+		ConsensusNodeAddress: consensusNodeAddress,
+		Tokens:               "0",
+		Shares:               formatShares(new(big.Rat)),
+	}); err != nil {
+		return fmt.Errorf("error inserting validator: %v", err)
+	}
+
+	if _, err := ledger.delegate(
+		msgCreateValidatorEvent.DelegatorAddress,
+		msgCreateValidatorEvent.ValidatorAddress,
+		msgCreateValidatorEvent.Amount.ToBigInt(),
+		msgCreateValidatorEvent.MsgTxHash,
+		constants.CREATE_VALIDATOR,
+	); err != nil {
+		return fmt.Errorf("error delegating self-delegation: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *Delegation) handleUndelegate(
+	ledger *ledger,
+	msgUndelegateEvent *event_usecase.MsgUndelegate,
+) error {
+	tokens, err := ledger.unbond(
+		msgUndelegateEvent.DelegatorAddress,
+		msgUndelegateEvent.ValidatorAddress,
+		msgUndelegateEvent.Amount.ToBigInt(),
+		msgUndelegateEvent.MsgTxHash,
+		constants.UNDELEGATE,
+	)
+	if errors.Is(err, errMissingDelegation) {
+		projection.logger.Errorf("skipping MsgUndelegate %s: %v", msgUndelegateEvent.MsgTxHash, err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error unbonding delegation: %v", err)
+	}
+
+	var completionTime utctime.UTCTime
+	if msgUndelegateEvent.MaybeUnbondCompleteAt != nil {
+		completionTime = *msgUndelegateEvent.MaybeUnbondCompleteAt
+	} else {
+		completionTime = ledger.unbondingCompletionTime()
+	}
+	if err := ledger.unbondingDelegationsView.Insert(&view.UnbondingDelegationRow{
+		DelegatorAddress:    msgUndelegateEvent.DelegatorAddress,
+		ValidatorAddress:    msgUndelegateEvent.ValidatorAddress,
+		Amount:              tokens.String(),
+		TransactionHash:     msgUndelegateEvent.MsgTxHash,
+		CreationBlockHeight: ledger.blockHeight,
+		CompletionTime:      completionTime,
+	}); err != nil {
+		return fmt.Errorf("error inserting unbonding delegation: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *Delegation) handleBeginRedelegate(
+	ledger *ledger,
+	msgBeginRedelegateEvent *event_usecase.MsgBeginRedelegate,
+) error {
+	tokens, err := ledger.unbond(
+		msgBeginRedelegateEvent.DelegatorAddress,
+		msgBeginRedelegateEvent.ValidatorSrcAddress,
+		msgBeginRedelegateEvent.Amount.ToBigInt(),
+		msgBeginRedelegateEvent.MsgTxHash,
+		constants.REDELEGATE_OUT,
+	)
+	if errors.Is(err, errMissingDelegation) {
+		projection.logger.Errorf("skipping MsgBeginRedelegate %s: %v", msgBeginRedelegateEvent.MsgTxHash, err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error unbonding delegation from source validator: %v", err)
+	}
+
+	sharesDst, err := ledger.delegate(
+		msgBeginRedelegateEvent.DelegatorAddress,
+		msgBeginRedelegateEvent.ValidatorDstAddress,
+		tokens,
+		msgBeginRedelegateEvent.MsgTxHash,
+		constants.REDELEGATE_IN,
+	)
+	if err != nil {
+		return fmt.Errorf("error delegating to destination validator: %v", err)
+	}
+
+	if err := ledger.redelegationsView.Insert(&view.RedelegationRow{
+		DelegatorAddress:    msgBeginRedelegateEvent.DelegatorAddress,
+		ValidatorSrcAddress: msgBeginRedelegateEvent.ValidatorSrcAddress,
+		ValidatorDstAddress: msgBeginRedelegateEvent.ValidatorDstAddress,
+		Amount:              tokens.String(),
+		SharesDst:           formatShares(sharesDst),
+		TransactionHash:     msgBeginRedelegateEvent.MsgTxHash,
+		CreationBlockHeight: ledger.blockHeight,
+		CompletionTime:      ledger.unbondingCompletionTime(),
+	}); err != nil {
+		return fmt.Errorf("error inserting redelegation: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *Delegation) handleValidatorSlashed(
+	ledger *ledger,
+	validatorSlashedEvent *event_usecase.ValidatorSlashed,
+) error {
+	var maybeFraction *big.Rat
+	switch validatorSlashedEvent.Reason {
+	case SLASH_REASON_MISSING_SIGNATURE:
+		maybeFraction = ledger.maybeSlashFractionDowntime
+	case SLASH_REASON_DOUBLE_SIGN:
+		maybeFraction = ledger.maybeSlashFractionDoubleSign
+	default:
+		projection.logger.Errorf("skipping validator slash with unrecognized reason: %s", validatorSlashedEvent.Reason)
+		return nil
+	}
+	if maybeFraction == nil {
+		projection.logger.Errorf(
+			"skipping validator slash because slash fraction of reason %s is unknown", validatorSlashedEvent.Reason,
+		)
+		return nil
+	}
+
+	if err := ledger.slash(validatorSlashedEvent.ConsensusNodeAddress, maybeFraction); err != nil {
+		return fmt.Errorf("error slashing validator: %v", err)
+	}
+
+	return nil
+}
+
+// ledger applies delegation changes at a block to the views
+type ledger struct {
+	validatorsView           *view.Validators
+	delegationsView          *view.Delegations
+	unbondingDelegationsView *view.UnbondingDelegations
+	redelegationsView        *view.Redelegations
+	historiesView            *view.Histories
+
+	blockHeight int64
+	blockTime   utctime.UTCTime
+
+	// Params are nil when the genesis has not been projected
+	maybeUnbondingTime           *time.Duration
+	maybeSlashFractionDoubleSign *big.Rat
+	maybeSlashFractionDowntime   *big.Rat
+}
+
+func (projection *Delegation) newLedger(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+) (*ledger, error) {
+	ledger := &ledger{
+		validatorsView:           view.NewValidators(rdbTxHandle),
+		delegationsView:          view.NewDelegations(rdbTxHandle),
+		unbondingDelegationsView: view.NewUnbondingDelegations(rdbTxHandle),
+		redelegationsView:        view.NewRedelegations(rdbTxHandle),
+		historiesView:            view.NewHistories(rdbTxHandle),
+
+		blockHeight: blockHeight,
+		blockTime:   blockTime,
+	}
+
+	paramsView := view.NewParams(rdbTxHandle)
+	unbondingTime, err := paramsView.FindBy(view.PARAM_UNBONDING_TIME)
+	if err == nil {
+		duration, parseErr := time.ParseDuration(unbondingTime)
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing unbonding time: %v", parseErr)
+		}
+		ledger.maybeUnbondingTime = &duration
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, fmt.Errorf("error getting unbonding time: %v", err)
+	}
+
+	slashFractionDoubleSign, err := paramsView.FindBy(view.PARAM_SLASH_FRACTION_DOUBLE_SIGN)
+	if err == nil {
+		if ledger.maybeSlashFractionDoubleSign, err = parseShares(slashFractionDoubleSign); err != nil {
+			return nil, fmt.Errorf("error parsing double sign slash fraction: %v", err)
+		}
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, fmt.Errorf("error getting double sign slash fraction: %v", err)
+	}
+
+	slashFractionDowntime, err := paramsView.FindBy(view.PARAM_SLASH_FRACTION_DOWNTIME)
+	if err == nil {
+		if ledger.maybeSlashFractionDowntime, err = parseShares(slashFractionDowntime); err != nil {
+			return nil, fmt.Errorf("error parsing downtime slash fraction: %v", err)
+		}
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, fmt.Errorf("error getting downtime slash fraction: %v", err)
+	}
+
+	return ledger, nil
+}
+
+// unbondingCompletionTime returns the completion time of unbonding started at the block. Unbonding
+// completes immediately when the unbonding time is unknown.
+func (ledger *ledger) unbondingCompletionTime() utctime.UTCTime {
+	if ledger.maybeUnbondingTime == nil {
+		return ledger.blockTime
+	}
+	return utctime.FromUnixNano(ledger.blockTime.UnixNano() + ledger.maybeUnbondingTime.Nanoseconds())
+}
+
+// delegate bonds the tokens to the validator and returns the shares issued to the delegator
+func (ledger *ledger) delegate(
+	delegatorAddress string,
+	validatorAddress string,
+	tokens *big.Int,
+	txHash string,
+	historyType constants.HistoryType,
+) (*big.Rat, error) {
+	validator, validatorTokens, validatorShares, err := ledger.findValidator(view.ValidatorIdentity{
+		MaybeOperatorAddress: &validatorAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	delegationShares, err := ledger.findDelegationShares(delegatorAddress, validatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	issuedShares := sharesFromTokens(tokens, validatorTokens, validatorShares)
+	validatorTokens = new(big.Int).Add(validatorTokens, tokens)
+	validatorShares = new(big.Rat).Add(validatorShares, issuedShares)
+	delegationShares = new(big.Rat).Add(delegationShares, issuedShares)
+
+	validator.Tokens = validatorTokens.String()
+	validator.Shares = formatShares(validatorShares)
+	if err := ledger.validatorsView.Update(validator); err != nil {
+		return nil, fmt.Errorf("error updating validator: %v", err)
+	}
+	if err := ledger.delegationsView.Upsert(&view.DelegationRow{
+		DelegatorAddress:       delegatorAddress,
+		ValidatorAddress:       validatorAddress,
+		Shares:                 formatShares(delegationShares),
+		Amount:                 tokensFromShares(delegationShares, validatorTokens, validatorShares).String(),
+		LastUpdatedBlockHeight: ledger.blockHeight,
+	}); err != nil {
+		return nil, fmt.Errorf("error upserting delegation: %v", err)
+	}
+
+	if err := ledger.insertHistory(
+		delegatorAddress, validatorAddress, historyType, tokens, delegationShares, txHash,
+	); err != nil {
+		return nil, err
+	}
+
+	return issuedShares, nil
+}
+
+// unbond removes the shares worth the tokens from the delegation and returns the tokens actually
+// unbonded. The shares removed are capped at the delegation shares.
+func (ledger *ledger) unbond(
+	delegatorAddress string,
+	validatorAddress string,
+	tokens *big.Int,
+	txHash string,
+	historyType constants.HistoryType,
+) (*big.Int, error) {
+	validator, validatorTokens, validatorShares, err := ledger.findValidator(view.ValidatorIdentity{
+		MaybeOperatorAddress: &validatorAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	delegationShares, err := ledger.findDelegationShares(delegatorAddress, validatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if delegationShares.Sign() == 0 {
+		return nil, fmt.Errorf(
+			"no delegation from %s to validator %s: %w", delegatorAddress, validatorAddress, errMissingDelegation,
+		)
+	}
+
+	removedShares := sharesFromTokens(tokens, validatorTokens, validatorShares)
+	if removedShares.Cmp(delegationShares) > 0 {
+		removedShares = delegationShares
+	}
+
+	var removedTokens *big.Int
+	remainingValidatorShares := new(big.Rat).Sub(validatorShares, removedShares)
+	if remainingValidatorShares.Sign() == 0 {
+		removedTokens = validatorTokens
+	} else {
+		removedTokens = tokensFromShares(removedShares, validatorTokens, validatorShares)
+	}
+	validatorTokens = new(big.Int).Sub(validatorTokens, removedTokens)
+	validatorShares = remainingValidatorShares
+	delegationShares = new(big.Rat).Sub(delegationShares, removedShares)
+
+	validator.Tokens = validatorTokens.String()
+	validator.Shares = formatShares(validatorShares)
+	if err := ledger.validatorsView.Update(validator); err != nil {
+		return nil, fmt.Errorf("error updating validator: %v", err)
+	}
+	if delegationShares.Sign() == 0 {
+		if err := ledger.delegationsView.Delete(delegatorAddress, validatorAddress); err != nil {
+			return nil, fmt.Errorf("error deleting delegation: %v", err)
+		}
+	} else if err := ledger.delegationsView.Upsert(&view.DelegationRow{
+		DelegatorAddress:       delegatorAddress,
+		ValidatorAddress:       validatorAddress,
+		Shares:                 formatShares(delegationShares),
+		Amount:                 tokensFromShares(delegationShares, validatorTokens, validatorShares).String(),
+		LastUpdatedBlockHeight: ledger.blockHeight,
+	}); err != nil {
+		return nil, fmt.Errorf("error upserting delegation: %v", err)
+	}
+
+	if err := ledger.insertHistory(
+		delegatorAddress, validatorAddress, historyType, removedTokens, delegationShares, txHash,
+	); err != nil {
+		return nil, err
+	}
+
+	return removedTokens, nil
+}
+
+// slash burns the fraction of the validator tokens and revalues all its delegations
+func (ledger *ledger) slash(consensusNodeAddress string, fraction *big.Rat) error {
+	validator, validatorTokens, validatorShares, err := ledger.findValidator(view.ValidatorIdentity{
+		MaybeConsensusNodeAddress: &consensusNodeAddress,
+	})
+	if err != nil {
+		return err
+	}
+
+	burnt := new(big.Rat).Mul(new(big.Rat).SetInt(validatorTokens), fraction)
+	validatorTokens = new(big.Int).Sub(validatorTokens, new(big.Int).Quo(burnt.Num(), burnt.Denom()))
+
+	validator.Tokens = validatorTokens.String()
+	if err := ledger.validatorsView.Update(validator); err != nil {
+		return fmt.Errorf("error updating validator: %v", err)
+	}
+
+	delegations, err := ledger.delegationsView.ListAllByValidator(validator.OperatorAddress)
+	if err != nil {
+		return fmt.Errorf("error listing validator delegations: %v", err)
+	}
+	for i := range delegations {
+		delegationShares, err := parseShares(delegations[i].Shares)
+		if err != nil {
+			return fmt.Errorf("error parsing delegation shares: %v", err)
+		}
+		delegations[i].Amount = tokensFromShares(delegationShares, validatorTokens, validatorShares).String()
+		delegations[i].LastUpdatedBlockHeight = ledger.blockHeight
+		if err := ledger.delegationsView.Upsert(&delegations[i]); err != nil {
+			return fmt.Errorf("error upserting slashed delegation: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (ledger *ledger) findValidator(
+	identity view.ValidatorIdentity,
+) (*view.ValidatorRow, *big.Int, *big.Rat, error) {
+	validator, err := ledger.validatorsView.FindBy(identity)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting validator from view: %v", err)
+	}
+	tokens, err := parseTokens(validator.Tokens)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing validator tokens: %v", err)
+	}
+	shares, err := parseShares(validator.Shares)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing validator shares: %v", err)
+	}
+
+	return validator, tokens, shares, nil
+}
+
+// findDelegationShares returns zero shares when the delegation does not exist
+func (ledger *ledger) findDelegationShares(delegatorAddress string, validatorAddress string) (*big.Rat, error) {
+	delegation, err := ledger.delegationsView.FindBy(delegatorAddress, validatorAddress)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return new(big.Rat), nil
+		}
+		return nil, fmt.Errorf("error getting delegation from view: %v", err)
+	}
+	shares, err := parseShares(delegation.Shares)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing delegation shares: %v", err)
+	}
+
+	return shares, nil
+}
+
+func (ledger *ledger) insertHistory(
+	delegatorAddress string,
+	validatorAddress string,
+	historyType constants.HistoryType,
+	tokens *big.Int,
+	delegationShares *big.Rat,
+	txHash string,
+) error {
+	if err := ledger.historiesView.Insert(&view.HistoryRow{
+		DelegatorAddress: delegatorAddress,
+		ValidatorAddress: validatorAddress,
+		Type:             historyType,
+		Amount:           tokens.String(),
+		Shares:           formatShares(delegationShares),
+		TransactionHash:  txHash,
+		BlockHeight:      ledger.blockHeight,
+		BlockTime:        ledger.blockTime,
+	}); err != nil {
+		return fmt.Errorf("error inserting delegation history: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package delegation_test
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegation"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegation/constants"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegation/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("Delegation", func() {
+	const conNodeAddressPrefix = "tcrocnclcons"
+	const validatorA = "tcrocncl1validatora"
+	const validatorB = "tcrocncl1validatorb"
+	const validatorAPubkey = "Kpox5fS2po0sJUHmzllExuJ4uZ5nm0bbCp6UQKESsnE="
+	const validatorBPubkey = "wWw0e9tZcVmev/NyJlZv5Apd7U5IONoyx3U/9rD5fHI="
+	const delegator = "tcro1delegator"
+
+	var conn rdb.Conn
+	var projection *delegation.Delegation
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = delegation.NewDelegation(NewFakeLogger(), conn, conNodeAddressPrefix)
+	})
+
+	createValidator := func(operatorAddress string, pubkey string, amount int64) event_entity.Event {
+		return event_usecase.NewMsgCreateValidator(
+			NewMsgCommonParams(0, "genesis-gentxs-"+operatorAddress),
+			usecase_model.MsgCreateValidatorParams{
+				DelegatorAddress: "tcro1self" + operatorAddress,
+				ValidatorAddress: operatorAddress,
+				TendermintPubkey: pubkey,
+				Amount:           coin.MustNewCoinFromInt(amount),
+			},
+		)
+	}
+
+	genesisEvents := func() []event_entity.Event {
+		return []event_entity.Event{
+			NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+				anyGenesis.AppState.Staking.Params.UnbondingTime = "100s"
+				anyGenesis.AppState.Slashing.Params.SlashFractionDoubleSign = "0.050000000000000000"
+				anyGenesis.AppState.Slashing.Params.SlashFractionDowntime = "0.000100000000000000"
+			}),
+			createValidator(validatorA, validatorAPubkey, 1000),
+			createValidator(validatorB, validatorBPubkey, 1000),
+		}
+	}
+
+	delegate := func(height int64, validatorAddress string, amount int64) event_entity.Event {
+		return event_usecase.NewMsgDelegate(NewMsgCommonParams(height, "delegate-tx"), usecase_model.MsgDelegateParams{
+			DelegatorAddress: delegator,
+			ValidatorAddress: validatorAddress,
+			Amount:           coin.MustNewCoinFromInt(amount),
+		})
+	}
+
+	undelegate := func(height int64, validatorAddress string, amount int64) event_entity.Event {
+		completeAt := BlockTimeAt(height + 100)
+		return event_usecase.NewMsgUndelegate(NewMsgCommonParams(height, "undelegate-tx"), usecase_model.MsgUndelegateParams{
+			DelegatorAddress:      delegator,
+			ValidatorAddress:      validatorAddress,
+			Amount:                coin.MustNewCoinFromInt(amount),
+			MaybeUnbondCompleteAt: &completeAt,
+		})
+	}
+
+	redelegate := func(height int64, amount int64) event_entity.Event {
+		return event_usecase.NewMsgBeginRedelegate(
+			NewMsgCommonParams(height, "redelegate-tx"),
+			usecase_model.MsgBeginRedelegateParams{
+				DelegatorAddress:    delegator,
+				ValidatorSrcAddress: validatorA,
+				ValidatorDstAddress: validatorB,
+				Amount:              coin.MustNewCoinFromInt(amount),
+			},
+		)
+	}
+
+	findDelegation := func(validatorAddress string) (*view.DelegationRow, error) {
+		return view.NewDelegations(conn.ToHandle()).FindBy(delegator, validatorAddress)
+	}
+
+	findValidator := func(operatorAddress string) *view.ValidatorRow {
+		validator, err := view.NewValidators(conn.ToHandle()).FindBy(view.ValidatorIdentity{
+			MaybeOperatorAddress: primptr.String(operatorAddress),
+		})
+		Expect(err).To(BeNil())
+		return validator
+	}
+
+	It("should record the self-delegations of the genesis validators", func() {
+		MustReplayEvents(projection, genesisEvents())
+
+		pubKey, err := base64.StdEncoding.DecodeString(validatorAPubkey)
+		Expect(err).To(BeNil())
+		consensusNodeAddress, err := tmcosmosutils.ConsensusNodeAddressFromTmPubKey(conNodeAddressPrefix, pubKey)
+		Expect(err).To(BeNil())
+
+		Expect(*findValidator(validatorA)).To(Equal(view.ValidatorRow{
+			OperatorAddress:      validatorA,
+			ConsensusNodeAddress: consensusNodeAddress,
+			Tokens:               "1000",
+			Shares:               "1000.000000000000000000",
+		}))
+
+		delegations, paginationResult, err := view.NewDelegations(conn.ToHandle()).List(
+			view.DelegationsListFilter{MaybeValidatorAddress: primptr.String(validatorA)},
+			view.DelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(1)))
+		Expect(delegations[0].DelegatorAddress).To(Equal("tcro1self" + validatorA))
+		Expect(delegations[0].Amount).To(Equal("1000"))
+	})
+
+	It("should track delegate, undelegate and redelegate", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), delegate(1, validatorA, 500),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)), undelegate(2, validatorA, 200),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, BlockTimeAt(3)), redelegate(3, 100),
+		})
+
+		delegationA, err := findDelegation(validatorA)
+		Expect(err).To(BeNil())
+		Expect(delegationA.Shares).To(Equal("200.000000000000000000"))
+		Expect(delegationA.Amount).To(Equal("200"))
+		delegationB, err := findDelegation(validatorB)
+		Expect(err).To(BeNil())
+		Expect(delegationB.Amount).To(Equal("100"))
+		Expect(findValidator(validatorA).Tokens).To(Equal("1200"))
+		Expect(findValidator(validatorB).Tokens).To(Equal("1100"))
+
+		unbondingDelegations, _, err := view.NewUnbondingDelegations(conn.ToHandle()).List(
+			view.UnbondingDelegationsListFilter{MaybeDelegatorAddress: primptr.String(delegator)},
+			view.UnbondingDelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(unbondingDelegations).To(Equal([]view.UnbondingDelegationRow{{
+			DelegatorAddress:    delegator,
+			ValidatorAddress:    validatorA,
+			Amount:              "200",
+			TransactionHash:     "undelegate-tx",
+			CreationBlockHeight: 2,
+			CompletionTime:      BlockTimeAt(102),
+		}}))
+
+		redelegations, _, err := view.NewRedelegations(conn.ToHandle()).List(
+			view.RedelegationsListFilter{MaybeValidatorAddress: primptr.String(validatorB)},
+			view.RedelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(redelegations).To(Equal([]view.RedelegationRow{{
+			DelegatorAddress:    delegator,
+			ValidatorSrcAddress: validatorA,
+			ValidatorDstAddress: validatorB,
+			Amount:              "100",
+			SharesDst:           "100.000000000000000000",
+			TransactionHash:     "redelegate-tx",
+			CreationBlockHeight: 3,
+			CompletionTime:      BlockTimeAt(103),
+		}}))
+
+		histories, _, err := view.NewHistories(conn.ToHandle()).List(
+			view.HistoriesListFilter{MaybeDelegatorAddress: primptr.String(delegator)},
+			view.HistoriesListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(histories).To(HaveLen(4))
+		Expect(histories[0].Type).To(Equal(constants.DELEGATE))
+		Expect(histories[1].Type).To(Equal(constants.UNDELEGATE))
+		Expect(histories[1].Shares).To(Equal("300.000000000000000000"))
+		Expect(histories[2].Type).To(Equal(constants.REDELEGATE_OUT))
+		Expect(histories[3].Type).To(Equal(constants.REDELEGATE_IN))
+		Expect(histories[3].ValidatorAddress).To(Equal(validatorB))
+	})
+
+	It("should delete the delegation when all shares are undelegated", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), delegate(1, validatorA, 500),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)), undelegate(2, validatorA, 500),
+		})
+
+		_, err := findDelegation(validatorA)
+		Expect(err).To(Equal(rdb.ErrNoRows))
+		Expect(findValidator(validatorA).Tokens).To(Equal("1000"))
+	})
+
+	It("should revalue the delegations when the validator is slashed", func() {
+		pubKey, err := base64.StdEncoding.DecodeString(validatorAPubkey)
+		Expect(err).To(BeNil())
+		consensusNodeAddress, err := tmcosmosutils.ConsensusNodeAddressFromTmPubKey(conNodeAddressPrefix, pubKey)
+		Expect(err).To(BeNil())
+
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), delegate(1, validatorA, 1000),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)),
+			event_usecase.NewValidatorSlashed(2, usecase_model.SlashValidatorParams{
+				ConsensusNodeAddress: consensusNodeAddress,
+				SlashedPower:         "2",
+				Reason:               delegation.SLASH_REASON_DOUBLE_SIGN,
+			}),
+		})
+
+		Expect(findValidator(validatorA).Tokens).To(Equal("1900"))
+		delegationA, err := findDelegation(validatorA)
+		Expect(err).To(BeNil())
+		Expect(delegationA.Shares).To(Equal("1000.000000000000000000"))
+		Expect(delegationA.Amount).To(Equal("950"))
+
+		// Delegating after the slash issues more shares per token
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, BlockTimeAt(3)), delegate(3, validatorA, 95),
+		})
+		delegationA, err = findDelegation(validatorA)
+		Expect(err).To(BeNil())
+		Expect(delegationA.Shares).To(Equal("1100.000000000000000000"))
+		Expect(delegationA.Amount).To(Equal("1045"))
+	})
+
+	It("should remove the unbonding delegations and redelegations once their completion time passes", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), delegate(1, validatorA, 500),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)), undelegate(2, validatorA, 200),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, BlockTimeAt(3)), redelegate(3, 100),
+		})
+
+		MustReplayEvents(projection, []event_entity.Event{NewBlockCreatedEvent(101, BlockTimeAt(101))})
+		_, paginationResult, err := view.NewUnbondingDelegations(conn.ToHandle()).List(
+			view.UnbondingDelegationsListFilter{},
+			view.UnbondingDelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(1)))
+
+		// No BondingCompleted end block event is needed to expire the unbonding entry
+		MustReplayEvents(projection, []event_entity.Event{NewBlockCreatedEvent(102, BlockTimeAt(102))})
+		_, paginationResult, err = view.NewUnbondingDelegations(conn.ToHandle()).List(
+			view.UnbondingDelegationsListFilter{},
+			view.UnbondingDelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(0)))
+
+		_, paginationResult, err = view.NewRedelegations(conn.ToHandle()).List(
+			view.RedelegationsListFilter{},
+			view.RedelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(1)))
+
+		MustReplayEvents(projection, []event_entity.Event{NewBlockCreatedEvent(103, BlockTimeAt(103))})
+		_, paginationResult, err = view.NewRedelegations(conn.ToHandle()).List(
+			view.RedelegationsListFilter{},
+			view.RedelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(0)))
+	})
+
+	It("should skip undelegating and redelegating from a delegation it has not seen", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), undelegate(1, validatorA, 200), redelegate(1, 100),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)), delegate(2, validatorA, 500),
+		})
+
+		delegationA, err := findDelegation(validatorA)
+		Expect(err).To(BeNil())
+		Expect(delegationA.Amount).To(Equal("500"))
+		Expect(findValidator(validatorA).Tokens).To(Equal("1500"))
+		Expect(findValidator(validatorB).Tokens).To(Equal("1000"))
+
+		_, paginationResult, err := view.NewUnbondingDelegations(conn.ToHandle()).List(
+			view.UnbondingDelegationsListFilter{},
+			view.UnbondingDelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(0)))
+	})
+
+	It("should track the delegation of a real block", func() {
+		const fixtureValidator = "tcrocncl1fs8r6zxmr5nc86j8cpcmjmccf8s2cafxzt5alq"
+		const fixtureValidatorPubkey = "8W0F7GspJI0sYa2x6SY/eOT3us4blVAUotF4cs/kBk0="
+		const fixtureDelegator = "tcro1fs8r6zxmr5nc86j8cpcmjmccf8s2cafxh5hy8r"
+
+		MustReplayEvents(projection, append(
+			genesisEvents(), createValidator(fixtureValidator, fixtureValidatorPubkey, 1000),
+		))
+		MustReplayEvents(projection, MustParseBlockFixtureEvents(parser.NewTxDecoder("basetcro"), NewBlockFixture(
+			usecase_parser_test.TX_MSG_DELEGATE_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_DELEGATE_BLOCK_RESULTS_RESP,
+		)))
+
+		delegations, _, err := view.NewDelegations(conn.ToHandle()).List(
+			view.DelegationsListFilter{MaybeDelegatorAddress: primptr.String(fixtureDelegator)},
+			view.DelegationsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(delegations).To(HaveLen(1))
+		Expect(delegations[0].ValidatorAddress).To(Equal(fixtureValidator))
+		Expect(delegations[0].Amount).To(Equal("27464382775"))
+		Expect(findValidator(fixtureValidator).Tokens).To(Equal("27464383775"))
+	})
+
+	It("should return error when delegating to an unknown validator", func() {
+		MustReplayEvents(projection, genesisEvents())
+
+		err := ReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), delegate(1, "tcrocncl1unknown", 500),
+		})
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,62 @@
+package delegation
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Delegation shares are decimals with the same precision as the Cosmos SDK `sdk.Dec`
+const SHARES_PRECISION = 18
+
+var sharesPrecisionMultiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(SHARES_PRECISION), nil)
+
+func parseShares(shares string) (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(shares)
+	if !ok {
+		return nil, fmt.Errorf("error parsing shares: %s", shares)
+	}
+	return value, nil
+}
+
+func parseTokens(tokens string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(tokens, 10)
+	if !ok {
+		return nil, fmt.Errorf("error parsing tokens: %s", tokens)
+	}
+	return value, nil
+}
+
+// truncateShares truncates the shares to the shares precision
+func truncateShares(shares *big.Rat) *big.Rat {
+	scaled := new(big.Int).Mul(shares.Num(), sharesPrecisionMultiplier)
+	scaled.Quo(scaled, shares.Denom())
+	return new(big.Rat).SetFrac(scaled, sharesPrecisionMultiplier)
+}
+
+func formatShares(shares *big.Rat) string {
+	return truncateShares(shares).FloatString(SHARES_PRECISION)
+}
+
+// sharesFromTokens returns the shares the validator issues for the tokens. A validator without tokens
+// issues shares one-to-one.
+func sharesFromTokens(tokens *big.Int, validatorTokens *big.Int, validatorShares *big.Rat) *big.Rat {
+	if validatorTokens.Sign() == 0 {
+		return new(big.Rat).SetInt(tokens)
+	}
+
+	shares := new(big.Rat).SetInt(tokens)
+	shares.Mul(shares, validatorShares)
+	shares.Quo(shares, new(big.Rat).SetInt(validatorTokens))
+	return truncateShares(shares)
+}
+
+// tokensFromShares returns the tokens the shares are worth, rounded down
+func tokensFromShares(shares *big.Rat, validatorTokens *big.Int, validatorShares *big.Rat) *big.Int {
+	if validatorShares.Sign() == 0 {
+		return big.NewInt(0)
+	}
+
+	tokens := new(big.Rat).Mul(shares, new(big.Rat).SetInt(validatorTokens))
+	tokens.Quo(tokens, validatorShares)
+	return new(big.Int).Quo(tokens.Num(), tokens.Denom())
+}
@@ -0,0 +1,229 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// Delegations keeps the current shares and token amount of each delegator on each validator
+type Delegations struct {
+	rdb *rdb.Handle
+}
+
+func NewDelegations(handle *rdb.Handle) *Delegations {
+	return &Delegations{
+		handle,
+	}
+}
+
+func (delegationsView *Delegations) Upsert(delegation *DelegationRow) error {
+	sql, sqlArgs, err := delegationsView.rdb.StmtBuilder.Insert(
+		"view_delegations",
+	).Columns(
+		"delegator_address",
+		"validator_address",
+		"shares",
+		"amount",
+		"last_updated_block_height",
+	).Values(
+		delegation.DelegatorAddress,
+		delegation.ValidatorAddress,
+		delegation.Shares,
+		delegation.Amount,
+		delegation.LastUpdatedBlockHeight,
+	).Suffix(`ON CONFLICT (delegator_address, validator_address) DO UPDATE SET
+		shares = EXCLUDED.shares,
+		amount = EXCLUDED.amount,
+		last_updated_block_height = EXCLUDED.last_updated_block_height
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delegation upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := delegationsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting delegation into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting delegation into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (delegationsView *Delegations) Delete(delegatorAddress string, validatorAddress string) error {
+	sql, sqlArgs, err := delegationsView.rdb.StmtBuilder.Delete(
+		"view_delegations",
+	).Where(
+		"delegator_address = ? AND validator_address = ?", delegatorAddress, validatorAddress,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delegation deletion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := delegationsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error deleting delegation: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error deleting delegation: no rows deleted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (delegationsView *Delegations) FindBy(delegatorAddress string, validatorAddress string) (*DelegationRow, error) {
+	sql, sqlArgs, err := delegationsView.selectStmtBuilder().Where(
+		"delegator_address = ? AND validator_address = ?", delegatorAddress, validatorAddress,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building delegation selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var delegation DelegationRow
+	if err = delegationsView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&delegation.DelegatorAddress,
+		&delegation.ValidatorAddress,
+		&delegation.Shares,
+		&delegation.Amount,
+		&delegation.LastUpdatedBlockHeight,
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning delegation row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &delegation, nil
+}
+
+// ListAllByValidator returns all delegations on the validator without pagination
+func (delegationsView *Delegations) ListAllByValidator(validatorAddress string) ([]DelegationRow, error) {
+	sql, sqlArgs, err := delegationsView.selectStmtBuilder().Where(
+		"validator_address = ?", validatorAddress,
+	).OrderBy("id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building delegations select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	return delegationsView.query(sql, sqlArgs...)
+}
+
+// ListAllByDelegator returns all delegations of the delegator without pagination
+func (delegationsView *Delegations) ListAllByDelegator(delegatorAddress string) ([]DelegationRow, error) {
+	sql, sqlArgs, err := delegationsView.selectStmtBuilder().Where(
+		"delegator_address = ?", delegatorAddress,
+	).OrderBy("id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building delegations select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	return delegationsView.query(sql, sqlArgs...)
+}
+
+type DelegationsListFilter struct {
+	MaybeDelegatorAddress *string
+	MaybeValidatorAddress *string
+}
+
+type DelegationsListOrder struct {
+	MaybeLastUpdatedBlockHeight *view.ORDER
+}
+
+func (delegationsView *Delegations) List(
+	filter DelegationsListFilter,
+	order DelegationsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]DelegationRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := delegationsView.selectStmtBuilder()
+
+	if order.MaybeLastUpdatedBlockHeight == nil {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	} else if *order.MaybeLastUpdatedBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("last_updated_block_height", "id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("last_updated_block_height DESC", "id DESC")
+	}
+
+	if filter.MaybeDelegatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("delegator_address = ?", *filter.MaybeDelegatorAddress)
+	}
+	if filter.MaybeValidatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("validator_address = ?", *filter.MaybeValidatorAddress)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		delegationsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building delegations select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	delegations, err := delegationsView.query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return delegations, paginationResult, nil
+}
+
+func (delegationsView *Delegations) selectStmtBuilder() sq.SelectBuilder {
+	return delegationsView.rdb.StmtBuilder.Select(
+		"delegator_address",
+		"validator_address",
+		"shares",
+		"amount",
+		"last_updated_block_height",
+	).From(
+		"view_delegations",
+	)
+}
+
+func (delegationsView *Delegations) query(sql string, sqlArgs ...interface{}) ([]DelegationRow, error) {
+	rowsResult, err := delegationsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing delegations select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	delegations := make([]DelegationRow, 0)
+	for rowsResult.Next() {
+		var delegation DelegationRow
+		if scanErr := rowsResult.Scan(
+			&delegation.DelegatorAddress,
+			&delegation.ValidatorAddress,
+			&delegation.Shares,
+			&delegation.Amount,
+			&delegation.LastUpdatedBlockHeight,
+		); scanErr != nil {
+			return nil, fmt.Errorf("error scanning delegation row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+
+		delegations = append(delegations, delegation)
+	}
+
+	return delegations, nil
+}
+
+type DelegationRow struct {
+	DelegatorAddress string `json:"delegatorAddress"`
+	ValidatorAddress string `json:"validatorAddress"`
+	// Delegation shares, as decimal string
+	Shares string `json:"shares"`
+	// Tokens the shares are worth at the current validator exchange rate
+	Amount                 string `json:"amount"`
+	LastUpdatedBlockHeight int64  `json:"lastUpdatedBlockHeight"`
+}
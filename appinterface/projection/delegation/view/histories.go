@@ -0,0 +1,162 @@
+package view
+
+import (
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegation/constants"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Histories records every change made by a delegator to its delegation on a validator
+type Histories struct {
+	rdb *rdb.Handle
+}
+
+func NewHistories(handle *rdb.Handle) *Histories {
+	return &Histories{
+		handle,
+	}
+}
+
+func (historiesView *Histories) Insert(history *HistoryRow) error {
+	sql, sqlArgs, err := historiesView.rdb.StmtBuilder.Insert(
+		"view_delegation_histories",
+	).Columns(
+		"delegator_address",
+		"validator_address",
+		"type",
+		"amount",
+		"shares",
+		"transaction_hash",
+		"block_height",
+		"block_time",
+	).Values(
+		history.DelegatorAddress,
+		history.ValidatorAddress,
+		history.Type,
+		history.Amount,
+		history.Shares,
+		history.TransactionHash,
+		history.BlockHeight,
+		historiesView.rdb.Tton(&history.BlockTime),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delegation history insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := historiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting delegation history into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting delegation history into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type HistoriesListFilter struct {
+	MaybeDelegatorAddress *string
+	MaybeValidatorAddress *string
+}
+
+type HistoriesListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (historiesView *Histories) List(
+	filter HistoriesListFilter,
+	order HistoriesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]HistoryRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := historiesView.rdb.StmtBuilder.Select(
+		"delegator_address",
+		"validator_address",
+		"type",
+		"amount",
+		"shares",
+		"transaction_hash",
+		"block_height",
+		"block_time",
+	).From(
+		"view_delegation_histories",
+	)
+
+	if order.MaybeBlockHeight == nil {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	} else if *order.MaybeBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height", "id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC", "id DESC")
+	}
+
+	if filter.MaybeDelegatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("delegator_address = ?", *filter.MaybeDelegatorAddress)
+	}
+	if filter.MaybeValidatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("validator_address = ?", *filter.MaybeValidatorAddress)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		historiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building delegation histories select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing delegation histories select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	histories := make([]HistoryRow, 0)
+	for rowsResult.Next() {
+		var history HistoryRow
+		blockTimeReader := historiesView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&history.DelegatorAddress,
+			&history.ValidatorAddress,
+			&history.Type,
+			&history.Amount,
+			&history.Shares,
+			&history.TransactionHash,
+			&history.BlockHeight,
+			blockTimeReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning delegation history row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing delegation history block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		history.BlockTime = *blockTime
+
+		histories = append(histories, history)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return histories, paginationResult, nil
+}
+
+type HistoryRow struct {
+	DelegatorAddress string                `json:"delegatorAddress"`
+	ValidatorAddress string                `json:"validatorAddress"`
+	Type             constants.HistoryType `json:"type"`
+	// Tokens moved by the change
+	Amount string `json:"amount"`
+	// Delegation shares after the change
+	Shares          string          `json:"shares"`
+	TransactionHash string          `json:"transactionHash"`
+	BlockHeight     int64           `json:"blockHeight"`
+	BlockTime       utctime.UTCTime `json:"blockTime"`
+}
@@ -0,0 +1,71 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+const PARAM_UNBONDING_TIME = "UnbondingTime"
+const PARAM_SLASH_FRACTION_DOUBLE_SIGN = "SlashFractionDoubleSign"
+const PARAM_SLASH_FRACTION_DOWNTIME = "SlashFractionDowntime"
+
+// Params keeps the staking and slashing parameters from the genesis
+type Params struct {
+	rdb *rdb.Handle
+}
+
+func NewParams(handle *rdb.Handle) *Params {
+	return &Params{
+		handle,
+	}
+}
+
+func (paramsView *Params) Set(key string, value string) error {
+	sql, sqlArgs, err := paramsView.rdb.StmtBuilder.Insert(
+		"view_delegation_params",
+	).Columns(
+		"key",
+		"value",
+	).Values(key, value).Suffix(
+		"ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value",
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delegation param upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := paramsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting delegation param into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting delegation param into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when the param is not set
+func (paramsView *Params) FindBy(key string) (string, error) {
+	sql, sqlArgs, err := paramsView.rdb.StmtBuilder.Select(
+		"value",
+	).From(
+		"view_delegation_params",
+	).Where(
+		"key = ?", key,
+	).ToSql()
+	if err != nil {
+		return "", fmt.Errorf("error building delegation param selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var value string
+	if err = paramsView.rdb.QueryRow(sql, sqlArgs...).Scan(&value); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return "", rdb.ErrNoRows
+		}
+		return "", fmt.Errorf("error scanning delegation param row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,182 @@
+package view
+
+import (
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Redelegations keeps the redelegation entries which have not yet completed
+type Redelegations struct {
+	rdb *rdb.Handle
+}
+
+func NewRedelegations(handle *rdb.Handle) *Redelegations {
+	return &Redelegations{
+		handle,
+	}
+}
+
+func (redelegationsView *Redelegations) Insert(redelegation *RedelegationRow) error {
+	sql, sqlArgs, err := redelegationsView.rdb.StmtBuilder.Insert(
+		"view_redelegations",
+	).Columns(
+		"delegator_address",
+		"validator_src_address",
+		"validator_dst_address",
+		"amount",
+		"shares_dst",
+		"transaction_hash",
+		"creation_block_height",
+		"completion_time",
+	).Values(
+		redelegation.DelegatorAddress,
+		redelegation.ValidatorSrcAddress,
+		redelegation.ValidatorDstAddress,
+		redelegation.Amount,
+		redelegation.SharesDst,
+		redelegation.TransactionHash,
+		redelegation.CreationBlockHeight,
+		redelegationsView.rdb.Tton(&redelegation.CompletionTime),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building redelegation insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := redelegationsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting redelegation into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting redelegation into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// DeleteCompleted deletes all redelegation entries which complete at or before the given time
+func (redelegationsView *Redelegations) DeleteCompleted(until utctime.UTCTime) error {
+	sql, sqlArgs, err := redelegationsView.rdb.StmtBuilder.Delete(
+		"view_redelegations",
+	).Where(
+		"completion_time <= ?", redelegationsView.rdb.Tton(&until),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building redelegation deletion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	if _, err = redelegationsView.rdb.Exec(sql, sqlArgs...); err != nil {
+		return fmt.Errorf("error deleting redelegations: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type RedelegationsListFilter struct {
+	MaybeDelegatorAddress *string
+	// Matches either the source or the destination validator
+	MaybeValidatorAddress *string
+}
+
+type RedelegationsListOrder struct {
+	MaybeCompletionTime *view.ORDER
+}
+
+func (redelegationsView *Redelegations) List(
+	filter RedelegationsListFilter,
+	order RedelegationsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]RedelegationRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := redelegationsView.rdb.StmtBuilder.Select(
+		"delegator_address",
+		"validator_src_address",
+		"validator_dst_address",
+		"amount",
+		"shares_dst",
+		"transaction_hash",
+		"creation_block_height",
+		"completion_time",
+	).From(
+		"view_redelegations",
+	)
+
+	if order.MaybeCompletionTime == nil {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	} else if *order.MaybeCompletionTime == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("completion_time", "id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("completion_time DESC", "id DESC")
+	}
+
+	if filter.MaybeDelegatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("delegator_address = ?", *filter.MaybeDelegatorAddress)
+	}
+	if filter.MaybeValidatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where(
+			"(validator_src_address = ? OR validator_dst_address = ?)",
+			*filter.MaybeValidatorAddress, *filter.MaybeValidatorAddress,
+		)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		redelegationsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building redelegations select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := redelegationsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing redelegations select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	redelegations := make([]RedelegationRow, 0)
+	for rowsResult.Next() {
+		var redelegation RedelegationRow
+		completionTimeReader := redelegationsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&redelegation.DelegatorAddress,
+			&redelegation.ValidatorSrcAddress,
+			&redelegation.ValidatorDstAddress,
+			&redelegation.Amount,
+			&redelegation.SharesDst,
+			&redelegation.TransactionHash,
+			&redelegation.CreationBlockHeight,
+			completionTimeReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning redelegation row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		completionTime, parseErr := completionTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing redelegation completion time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		redelegation.CompletionTime = *completionTime
+
+		redelegations = append(redelegations, redelegation)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return redelegations, paginationResult, nil
+}
+
+type RedelegationRow struct {
+	DelegatorAddress    string `json:"delegatorAddress"`
+	ValidatorSrcAddress string `json:"validatorSrcAddress"`
+	ValidatorDstAddress string `json:"validatorDstAddress"`
+	Amount              string `json:"amount"`
+	// Shares issued by the destination validator for the redelegated tokens
+	SharesDst           string          `json:"sharesDst"`
+	TransactionHash     string          `json:"transactionHash"`
+	CreationBlockHeight int64           `json:"creationBlockHeight"`
+	CompletionTime      utctime.UTCTime `json:"completionTime"`
+}
@@ -0,0 +1,171 @@
+package view
+
+import (
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// UnbondingDelegations keeps the unbonding entries which have not yet completed
+type UnbondingDelegations struct {
+	rdb *rdb.Handle
+}
+
+func NewUnbondingDelegations(handle *rdb.Handle) *UnbondingDelegations {
+	return &UnbondingDelegations{
+		handle,
+	}
+}
+
+func (unbondingDelegationsView *UnbondingDelegations) Insert(unbondingDelegation *UnbondingDelegationRow) error {
+	sql, sqlArgs, err := unbondingDelegationsView.rdb.StmtBuilder.Insert(
+		"view_unbonding_delegations",
+	).Columns(
+		"delegator_address",
+		"validator_address",
+		"amount",
+		"transaction_hash",
+		"creation_block_height",
+		"completion_time",
+	).Values(
+		unbondingDelegation.DelegatorAddress,
+		unbondingDelegation.ValidatorAddress,
+		unbondingDelegation.Amount,
+		unbondingDelegation.TransactionHash,
+		unbondingDelegation.CreationBlockHeight,
+		unbondingDelegationsView.rdb.Tton(&unbondingDelegation.CompletionTime),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building unbonding delegation insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := unbondingDelegationsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting unbonding delegation into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting unbonding delegation into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// DeleteCompleted deletes all the unbonding entries which complete at or before the given time
+func (unbondingDelegationsView *UnbondingDelegations) DeleteCompleted(until utctime.UTCTime) error {
+	sql, sqlArgs, err := unbondingDelegationsView.rdb.StmtBuilder.Delete(
+		"view_unbonding_delegations",
+	).Where(
+		"completion_time <= ?", unbondingDelegationsView.rdb.Tton(&until),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building unbonding delegation deletion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	if _, err = unbondingDelegationsView.rdb.Exec(sql, sqlArgs...); err != nil {
+		return fmt.Errorf("error deleting unbonding delegations: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type UnbondingDelegationsListFilter struct {
+	MaybeDelegatorAddress *string
+	MaybeValidatorAddress *string
+}
+
+type UnbondingDelegationsListOrder struct {
+	MaybeCompletionTime *view.ORDER
+}
+
+func (unbondingDelegationsView *UnbondingDelegations) List(
+	filter UnbondingDelegationsListFilter,
+	order UnbondingDelegationsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]UnbondingDelegationRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := unbondingDelegationsView.rdb.StmtBuilder.Select(
+		"delegator_address",
+		"validator_address",
+		"amount",
+		"transaction_hash",
+		"creation_block_height",
+		"completion_time",
+	).From(
+		"view_unbonding_delegations",
+	)
+
+	if order.MaybeCompletionTime == nil {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	} else if *order.MaybeCompletionTime == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("completion_time", "id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("completion_time DESC", "id DESC")
+	}
+
+	if filter.MaybeDelegatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("delegator_address = ?", *filter.MaybeDelegatorAddress)
+	}
+	if filter.MaybeValidatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("validator_address = ?", *filter.MaybeValidatorAddress)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		unbondingDelegationsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error building unbonding delegations select SQL: %v, %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	rowsResult, err := unbondingDelegationsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing unbonding delegations select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	unbondingDelegations := make([]UnbondingDelegationRow, 0)
+	for rowsResult.Next() {
+		var unbondingDelegation UnbondingDelegationRow
+		completionTimeReader := unbondingDelegationsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&unbondingDelegation.DelegatorAddress,
+			&unbondingDelegation.ValidatorAddress,
+			&unbondingDelegation.Amount,
+			&unbondingDelegation.TransactionHash,
+			&unbondingDelegation.CreationBlockHeight,
+			completionTimeReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning unbonding delegation row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		completionTime, parseErr := completionTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf(
+				"error parsing unbonding delegation completion time: %v: %w", parseErr, rdb.ErrQuery,
+			)
+		}
+		unbondingDelegation.CompletionTime = *completionTime
+
+		unbondingDelegations = append(unbondingDelegations, unbondingDelegation)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return unbondingDelegations, paginationResult, nil
+}
+
+type UnbondingDelegationRow struct {
+	DelegatorAddress    string          `json:"delegatorAddress"`
+	ValidatorAddress    string          `json:"validatorAddress"`
+	Amount              string          `json:"amount"`
+	TransactionHash     string          `json:"transactionHash"`
+	CreationBlockHeight int64           `json:"creationBlockHeight"`
+	CompletionTime      utctime.UTCTime `json:"completionTime"`
+}
@@ -0,0 +1,125 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// Validators keeps the bonded tokens and the total delegator shares of each validator, which together
+// give the exchange rate between delegation shares and tokens
+type Validators struct {
+	rdb *rdb.Handle
+}
+
+func NewValidators(handle *rdb.Handle) *Validators {
+	return &Validators{
+		handle,
+	}
+}
+
+func (validatorsView *Validators) Insert(validator *ValidatorRow) error {
+	sql, sqlArgs, err := validatorsView.rdb.StmtBuilder.Insert(
+		"view_delegation_validators",
+	).Columns(
+		"operator_address",
+		"consensus_node_address",
+		"tokens",
+		"shares",
+	).Values(
+		validator.OperatorAddress,
+		validator.ConsensusNodeAddress,
+		validator.Tokens,
+		validator.Shares,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delegation validator insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := validatorsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting delegation validator into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting delegation validator into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// Update updates the tokens and shares of the validator
+func (validatorsView *Validators) Update(validator *ValidatorRow) error {
+	sql, sqlArgs, err := validatorsView.rdb.StmtBuilder.Update(
+		"view_delegation_validators",
+	).SetMap(map[string]interface{}{
+		"tokens": validator.Tokens,
+		"shares": validator.Shares,
+	}).Where(
+		"operator_address = ?", validator.OperatorAddress,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delegation validator update sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := validatorsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error updating delegation validator: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error updating delegation validator: no rows updated: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type ValidatorIdentity struct {
+	MaybeOperatorAddress      *string
+	MaybeConsensusNodeAddress *string
+}
+
+func (validatorsView *Validators) FindBy(identity ValidatorIdentity) (*ValidatorRow, error) {
+	stmtBuilder := validatorsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"consensus_node_address",
+		"tokens",
+		"shares",
+	).From(
+		"view_delegation_validators",
+	)
+	if identity.MaybeOperatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("operator_address = ?", *identity.MaybeOperatorAddress)
+	}
+	if identity.MaybeConsensusNodeAddress != nil {
+		stmtBuilder = stmtBuilder.Where("consensus_node_address = ?", *identity.MaybeConsensusNodeAddress)
+	}
+
+	sql, sqlArgs, err := stmtBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building delegation validator selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var validator ValidatorRow
+	if err = validatorsView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&validator.OperatorAddress,
+		&validator.ConsensusNodeAddress,
+		&validator.Tokens,
+		&validator.Shares,
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning delegation validator row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &validator, nil
+}
+
+type ValidatorRow struct {
+	OperatorAddress      string `json:"operatorAddress"`
+	ConsensusNodeAddress string `json:"consensusNodeAddress"`
+	// Tokens bonded to the validator, including the self-delegation
+	Tokens string `json:"tokens"`
+	// Total delegator shares issued by the validator, as decimal string
+	Shares string `json:"shares"`
+}
@@ -0,0 +1,107 @@
+package delegatorreward
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegatorreward/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &DelegatorReward{}
+
+// DelegatorReward records the staking rewards received by the delegators, i.e. the successful reward
+// withdrawals with the amount parsed from the transaction logs.
+type DelegatorReward struct {
+	*rdbprojectionbase.Base
+
+	rdbConn   rdb.Conn
+	logger    applogger.Logger
+	baseDenom string
+}
+
+func NewDelegatorReward(logger applogger.Logger, rdbConn rdb.Conn, baseDenom string) *DelegatorReward {
+	return &DelegatorReward{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "DelegatorReward"),
+
+		rdbConn,
+		logger,
+		baseDenom,
+	}
+}
+
+func (_ *DelegatorReward) GetEventsToListen() []string {
+	return []string{
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_WITHDRAW_DELEGATOR_REWARD_CREATED,
+	}
+}
+
+func (projection *DelegatorReward) OnInit() error {
+	return nil
+}
+
+func (projection *DelegatorReward) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var maybeBlockTime *utctime.UTCTime
+	withdrawals := make([]*event_usecase.MsgWithdrawDelegatorReward, 0)
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if withdrawEvent, ok := event.(*event_usecase.MsgWithdrawDelegatorReward); ok {
+			withdrawals = append(withdrawals, withdrawEvent)
+		}
+	}
+
+	if len(withdrawals) > 0 {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling reward withdrawals: missing BlockCreated event at height %d", height)
+		}
+
+		withdrawalsView := view.NewRewardWithdrawals(rdbTxHandle)
+		for _, withdrawal := range withdrawals {
+			projection.logger.Debug("handling MsgWithdrawDelegatorReward event")
+			if err := withdrawalsView.Insert(&view.RewardWithdrawalRow{
+				DelegatorAddress: withdrawal.DelegatorAddress,
+				ValidatorAddress: withdrawal.ValidatorAddress,
+				RecipientAddress: withdrawal.RecipientAddress,
+				Amount:           withdrawal.Amount.String(),
+				Denom:            projection.baseDenom,
+				BlockHeight:      height,
+				BlockTime:        *maybeBlockTime,
+				TransactionHash:  withdrawal.MsgTxHash,
+				MsgIndex:         withdrawal.MsgIndex,
+			}); err != nil {
+				return fmt.Errorf("error inserting reward withdrawal: %v", err)
+			}
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
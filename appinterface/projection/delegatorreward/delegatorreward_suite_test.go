@@ -0,0 +1,13 @@
+package delegatorreward_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDelegatorReward(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DelegatorReward Suite")
+}
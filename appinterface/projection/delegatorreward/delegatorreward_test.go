@@ -0,0 +1,133 @@
+package delegatorreward_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegatorreward"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegatorreward/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("DelegatorReward", func() {
+	const delegator = "tcro1delegator"
+	const validatorA = "tcrocncl1validatora"
+	const validatorB = "tcrocncl1validatorb"
+
+	var conn rdb.Conn
+	var projection *delegatorreward.DelegatorReward
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = delegatorreward.NewDelegatorReward(NewFakeLogger(), conn, "basetcro")
+	})
+
+	withdrawReward := func(height int64, msgIndex int, validatorAddress string, amount int64) event_entity.Event {
+		msgCommonParams := NewMsgCommonParams(height, "withdraw-tx")
+		msgCommonParams.MsgIndex = msgIndex
+		return event_usecase.NewMsgWithdrawDelegatorReward(
+			msgCommonParams,
+			usecase_model.MsgWithdrawDelegatorRewardParams{
+				DelegatorAddress: delegator,
+				ValidatorAddress: validatorAddress,
+				RecipientAddress: delegator,
+				Amount:           coin.MustNewCoinFromInt(amount),
+			},
+		)
+	}
+
+	It("should record the reward withdrawals of the delegator", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)),
+			withdrawReward(1, 0, validatorA, 100),
+			withdrawReward(1, 1, validatorB, 200),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, BlockTimeAt(3)),
+			withdrawReward(3, 0, validatorA, 300),
+		})
+
+		withdrawalsView := view.NewRewardWithdrawals(conn.ToHandle())
+		withdrawals, err := withdrawalsView.ListAll(view.RewardWithdrawalsListFilter{
+			MaybeDelegatorAddress: primptr.String(delegator),
+		}, 10)
+		Expect(err).To(BeNil())
+		Expect(withdrawals).To(Equal([]view.RewardWithdrawalRow{
+			{
+				DelegatorAddress: delegator,
+				ValidatorAddress: validatorA,
+				RecipientAddress: delegator,
+				Amount:           "100",
+				Denom:            "basetcro",
+				BlockHeight:      1,
+				BlockTime:        BlockTimeAt(1),
+				TransactionHash:  "withdraw-tx",
+				MsgIndex:         0,
+			},
+			{
+				DelegatorAddress: delegator,
+				ValidatorAddress: validatorB,
+				RecipientAddress: delegator,
+				Amount:           "200",
+				Denom:            "basetcro",
+				BlockHeight:      1,
+				BlockTime:        BlockTimeAt(1),
+				TransactionHash:  "withdraw-tx",
+				MsgIndex:         1,
+			},
+			{
+				DelegatorAddress: delegator,
+				ValidatorAddress: validatorA,
+				RecipientAddress: delegator,
+				Amount:           "300",
+				Denom:            "basetcro",
+				BlockHeight:      3,
+				BlockTime:        BlockTimeAt(3),
+				TransactionHash:  "withdraw-tx",
+				MsgIndex:         0,
+			},
+		}))
+
+		fromTime := BlockTimeAt(2)
+		toTime := BlockTimeAt(4)
+		withdrawals, err = withdrawalsView.ListAll(view.RewardWithdrawalsListFilter{
+			MaybeDelegatorAddress: primptr.String(delegator),
+			MaybeValidatorAddress: primptr.String(validatorA),
+			MaybeFromTime:         &fromTime,
+			MaybeToTime:           &toTime,
+		}, 10)
+		Expect(err).To(BeNil())
+		Expect(withdrawals).To(HaveLen(1))
+		Expect(withdrawals[0].Amount).To(Equal("300"))
+	})
+
+	It("should record the reward withdrawal of a real block", func() {
+		const fixtureDelegator = "tcro15grftg88l0gdw4mg9t9pwnl0pde2asjzvfpkp4"
+
+		MustReplayEvents(projection, MustParseBlockFixtureEvents(parser.NewTxDecoder("basetcro"), NewBlockFixture(
+			usecase_parser_test.TX_MSGS_WITHDRAW_DELEGATOR_REWARD_WITHDRAW_VALIDATOR_COMMISSION_BLOCK_RESP,
+			usecase_parser_test.TX_MSGS_WITHDRAW_DELEGATOR_REWARD_WITHDRAW_VALIDATOR_COMMISSION_BLOCK_RESULTS_RESP,
+		)))
+
+		withdrawals, err := view.NewRewardWithdrawals(conn.ToHandle()).ListAll(view.RewardWithdrawalsListFilter{
+			MaybeDelegatorAddress: primptr.String(fixtureDelegator),
+		}, 10)
+		Expect(err).To(BeNil())
+		Expect(withdrawals).To(HaveLen(1))
+		Expect(withdrawals[0].ValidatorAddress).To(Equal("tcrocncl15grftg88l0gdw4mg9t9pwnl0pde2asjzekz0ek"))
+		Expect(withdrawals[0].Amount).To(Equal("33934701990"))
+		Expect(withdrawals[0].BlockHeight).To(Equal(int64(435599)))
+	})
+})
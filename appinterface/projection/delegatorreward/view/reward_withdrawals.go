@@ -0,0 +1,219 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// RewardWithdrawals records the staking rewards withdrawn by the delegators from each validator
+type RewardWithdrawals struct {
+	rdb *rdb.Handle
+}
+
+func NewRewardWithdrawals(handle *rdb.Handle) *RewardWithdrawals {
+	return &RewardWithdrawals{
+		handle,
+	}
+}
+
+func (withdrawalsView *RewardWithdrawals) Insert(withdrawal *RewardWithdrawalRow) error {
+	amount, ok := new(big.Int).SetString(withdrawal.Amount, 10)
+	if !ok {
+		return fmt.Errorf("error parsing reward withdrawal amount: %s", withdrawal.Amount)
+	}
+
+	sql, sqlArgs, err := withdrawalsView.rdb.StmtBuilder.Insert(
+		"view_delegator_reward_withdrawals",
+	).Columns(
+		"delegator_address",
+		"validator_address",
+		"recipient_address",
+		"amount",
+		"denom",
+		"block_height",
+		"block_time",
+		"transaction_hash",
+		"msg_index",
+	).Values(
+		withdrawal.DelegatorAddress,
+		withdrawal.ValidatorAddress,
+		withdrawal.RecipientAddress,
+		withdrawalsView.rdb.Bton(amount),
+		withdrawal.Denom,
+		withdrawal.BlockHeight,
+		withdrawalsView.rdb.Tton(&withdrawal.BlockTime),
+		withdrawal.TransactionHash,
+		withdrawal.MsgIndex,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building reward withdrawal insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := withdrawalsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting reward withdrawal into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting reward withdrawal into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type RewardWithdrawalsListFilter struct {
+	MaybeDelegatorAddress *string
+	MaybeValidatorAddress *string
+	// Inclusive
+	MaybeFromTime *utctime.UTCTime
+	// Exclusive
+	MaybeToTime *utctime.UTCTime
+}
+
+type RewardWithdrawalsListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (withdrawalsView *RewardWithdrawals) List(
+	filter RewardWithdrawalsListFilter,
+	order RewardWithdrawalsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]RewardWithdrawalRow, *pagination_interface.PaginationResult, error) {
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		withdrawalsView.rdb,
+	).BuildStmt(withdrawalsView.listStmtBuilder(filter, order))
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error building reward withdrawals select SQL: %v, %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	withdrawals, err := withdrawalsView.query(sql, sqlArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return withdrawals, paginationResult, nil
+}
+
+// ListAll returns at most `limit` withdrawals matching the filter in block height order
+func (withdrawalsView *RewardWithdrawals) ListAll(
+	filter RewardWithdrawalsListFilter,
+	limit uint64,
+) ([]RewardWithdrawalRow, error) {
+	sql, sqlArgs, err := withdrawalsView.listStmtBuilder(
+		filter, RewardWithdrawalsListOrder{},
+	).Limit(limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building reward withdrawals select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	return withdrawalsView.query(sql, sqlArgs)
+}
+
+func (withdrawalsView *RewardWithdrawals) listStmtBuilder(
+	filter RewardWithdrawalsListFilter,
+	order RewardWithdrawalsListOrder,
+) sq.SelectBuilder {
+	stmtBuilder := withdrawalsView.rdb.StmtBuilder.Select(
+		"delegator_address",
+		"validator_address",
+		"recipient_address",
+		"amount",
+		"denom",
+		"block_height",
+		"block_time",
+		"transaction_hash",
+		"msg_index",
+	).From(
+		"view_delegator_reward_withdrawals",
+	)
+
+	if filter.MaybeDelegatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("delegator_address = ?", *filter.MaybeDelegatorAddress)
+	}
+	if filter.MaybeValidatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("validator_address = ?", *filter.MaybeValidatorAddress)
+	}
+	if filter.MaybeFromTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time >= ?", withdrawalsView.rdb.Tton(filter.MaybeFromTime))
+	}
+	if filter.MaybeToTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time < ?", withdrawalsView.rdb.Tton(filter.MaybeToTime))
+	}
+
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC", "msg_index DESC", "id DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height", "msg_index", "id")
+	}
+
+	return stmtBuilder
+}
+
+func (withdrawalsView *RewardWithdrawals) query(sql string, sqlArgs []interface{}) ([]RewardWithdrawalRow, error) {
+	rowsResult, err := withdrawalsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing reward withdrawals select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	withdrawals := make([]RewardWithdrawalRow, 0)
+	for rowsResult.Next() {
+		var withdrawal RewardWithdrawalRow
+		amountReader := withdrawalsView.rdb.NtobReader()
+		blockTimeReader := withdrawalsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&withdrawal.DelegatorAddress,
+			&withdrawal.ValidatorAddress,
+			&withdrawal.RecipientAddress,
+			amountReader.ScannableArg(),
+			&withdrawal.Denom,
+			&withdrawal.BlockHeight,
+			blockTimeReader.ScannableArg(),
+			&withdrawal.TransactionHash,
+			&withdrawal.MsgIndex,
+		); scanErr != nil {
+			return nil, fmt.Errorf("error scanning reward withdrawal row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		amount, parseErr := amountReader.Parse()
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing reward withdrawal amount: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		withdrawal.Amount = amount.String()
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing reward withdrawal block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		withdrawal.BlockTime = *blockTime
+
+		withdrawals = append(withdrawals, withdrawal)
+	}
+
+	return withdrawals, nil
+}
+
+type RewardWithdrawalRow struct {
+	DelegatorAddress string          `json:"delegatorAddress"`
+	ValidatorAddress string          `json:"validatorAddress"`
+	RecipientAddress string          `json:"recipientAddress"`
+	Amount           string          `json:"amount"`
+	Denom            string          `json:"denom"`
+	BlockHeight      int64           `json:"blockHeight"`
+	BlockTime        utctime.UTCTime `json:"blockTime"`
+	TransactionHash  string          `json:"transactionHash"`
+	MsgIndex         int             `json:"msgIndex"`
+}
@@ -0,0 +1,355 @@
+package feemarket
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/feemarket/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &FeeMarket{}
+
+// FeeMarket aggregates the fees and gas of the transactions by block, by hour and by message type.
+//
+// The gas price of a transaction is its fee per gas wanted, which is what the fee is charged against.
+// The block fullness is the gas used against the consensus max gas from the genesis.
+type FeeMarket struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+}
+
+func NewFeeMarket(logger applogger.Logger, rdbConn rdb.Conn) *FeeMarket {
+	return &FeeMarket{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "FeeMarket"),
+
+		rdbConn,
+		logger,
+	}
+}
+
+func (_ *FeeMarket) GetEventsToListen() []string {
+	return append([]string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.TRANSACTION_CREATED,
+		event_usecase.TRANSACTION_FAILED,
+	}, event_usecase.MSG_EVENTS...)
+}
+
+func (projection *FeeMarket) OnInit() error {
+	return nil
+}
+
+type feeTransaction struct {
+	hash      string
+	success   bool
+	msgCount  int
+	fee       coin.Coin
+	gasWanted int
+	gasUsed   int
+}
+
+func (projection *FeeMarket) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+	paramsView := view.NewParams(rdbTxHandle)
+
+	var maybeBlockTime *utctime.UTCTime
+	txs := make([]feeTransaction, 0)
+	txMsgTypes := make(map[string][]string)
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			projection.logger.Debug("handling GenesisCreated event")
+			if err := paramsView.Set(
+				view.PARAM_MAX_GAS, genesisCreatedEvent.Genesis.ConsensusParams.Block.MaxGas,
+			); err != nil {
+				return fmt.Errorf("error setting max gas: %v", err)
+			}
+		} else if transactionCreatedEvent, ok := event.(*event_usecase.TransactionCreated); ok {
+			txs = append(txs, feeTransaction{
+				hash:      transactionCreatedEvent.TxHash,
+				success:   true,
+				msgCount:  transactionCreatedEvent.MsgCount,
+				fee:       transactionCreatedEvent.Fee,
+				gasWanted: transactionCreatedEvent.GasWanted,
+				gasUsed:   transactionCreatedEvent.GasUsed,
+			})
+		} else if transactionFailedEvent, ok := event.(*event_usecase.TransactionFailed); ok {
+			txs = append(txs, feeTransaction{
+				hash:      transactionFailedEvent.TxHash,
+				success:   false,
+				msgCount:  transactionFailedEvent.MsgCount,
+				fee:       transactionFailedEvent.Fee,
+				gasWanted: transactionFailedEvent.GasWanted,
+				gasUsed:   transactionFailedEvent.GasUsed,
+			})
+		} else if msgEvent, ok := event.(event_usecase.MsgEvent); ok {
+			txMsgTypes[msgEvent.TxHash()] = append(txMsgTypes[msgEvent.TxHash()], msgEvent.MsgType())
+		}
+	}
+
+	// Genesis has no block, nothing to aggregate
+	if maybeBlockTime != nil {
+		maybeMaxGas, err := findMaxGas(paramsView)
+		if err != nil {
+			return fmt.Errorf("error getting max gas: %v", err)
+		}
+
+		if err := projection.handleBlock(rdbTxHandle, height, *maybeBlockTime, maybeMaxGas, txs); err != nil {
+			return fmt.Errorf("error aggregating block fees: %v", err)
+		}
+
+		if err := projection.handleMessageGas(rdbTxHandle, txs, txMsgTypes); err != nil {
+			return fmt.Errorf("error aggregating message gas: %v", err)
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+// findMaxGas returns nil when the max gas is unlimited or unknown
+func findMaxGas(paramsView *view.Params) (*int64, error) {
+	rawMaxGas, err := paramsView.FindBy(view.PARAM_MAX_GAS)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if rawMaxGas == "" {
+		return nil, nil
+	}
+
+	maxGas, err := strconv.ParseInt(rawMaxGas, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing max gas: %v", err)
+	}
+	if maxGas <= 0 {
+		return nil, nil
+	}
+	return &maxGas, nil
+}
+
+func (projection *FeeMarket) handleBlock(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	maybeMaxGas *int64,
+	txs []feeTransaction,
+) error {
+	pendingGasPricesView := view.NewPendingGasPrices(rdbTxHandle)
+
+	block := view.FeeBlockRow{
+		BlockHeight:  blockHeight,
+		BlockTime:    blockTime,
+		Transactions: int64(len(txs)),
+		MaybeMaxGas:  maybeMaxGas,
+	}
+	fees := new(big.Int)
+	gasPrices := make([]*big.Rat, 0, len(txs))
+	for _, tx := range txs {
+		block.GasWanted += int64(tx.gasWanted)
+		block.GasUsed += int64(tx.gasUsed)
+		fees.Add(fees, tx.fee.ToBigInt())
+
+		if gasPrice := gasPriceOf(tx.fee.ToBigInt(), tx.gasWanted); gasPrice != nil {
+			gasPrices = append(gasPrices, gasPrice)
+		}
+	}
+	block.Fees = fees.String()
+	block.MaybeFullness = fullnessOf(block.GasUsed, maybeMaxGas)
+	percentiles := NewGasPricePercentiles(gasPrices)
+	block.MaybeGasPriceP25 = percentiles.MaybeP25
+	block.MaybeGasPriceP50 = percentiles.MaybeP50
+	block.MaybeGasPriceP75 = percentiles.MaybeP75
+
+	if err := view.NewFeeBlocks(rdbTxHandle).Insert(&block); err != nil {
+		return fmt.Errorf("error inserting fee block: %v", err)
+	}
+
+	hourStart := view.FeeHourStart(blockTime)
+	if err := projection.finalizeOpenHour(rdbTxHandle, hourStart); err != nil {
+		return fmt.Errorf("error finalizing open hour: %v", err)
+	}
+
+	for _, tx := range txs {
+		if gasPrice := gasPriceOf(tx.fee.ToBigInt(), tx.gasWanted); gasPrice != nil {
+			if err := pendingGasPricesView.Insert(
+				blockHeight, tx.hash, gasPrice.FloatString(DECIMAL_PRECISION),
+			); err != nil {
+				return fmt.Errorf("error inserting pending gas price: %v", err)
+			}
+		}
+	}
+
+	return projection.addToHour(rdbTxHandle, hourStart, &block)
+}
+
+// finalizeOpenHour computes the gas price percentiles of the open hour when the block is in a later
+// hour, and opens the hour of the block
+func (projection *FeeMarket) finalizeOpenHour(rdbTxHandle *rdb.Handle, hourStart utctime.UTCTime) error {
+	paramsView := view.NewParams(rdbTxHandle)
+	hoursView := view.NewFeeHours(rdbTxHandle)
+	pendingGasPricesView := view.NewPendingGasPrices(rdbTxHandle)
+
+	rawOpenHour, err := paramsView.FindBy(view.PARAM_OPEN_HOUR)
+	if err != nil && !errors.Is(err, rdb.ErrNoRows) {
+		return fmt.Errorf("error getting open hour: %v", err)
+	}
+	if err == nil {
+		openHourUnixNano, parseErr := strconv.ParseInt(rawOpenHour, 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("error parsing open hour: %v", parseErr)
+		}
+		if openHourUnixNano == hourStart.UnixNano() {
+			return nil
+		}
+
+		openHour, err := hoursView.FindBy(utctime.FromUnixNano(openHourUnixNano))
+		if err != nil {
+			return fmt.Errorf("error getting open fee hour: %v", err)
+		}
+		rawGasPrices, err := pendingGasPricesView.ListAll()
+		if err != nil {
+			return fmt.Errorf("error listing pending gas prices: %v", err)
+		}
+		gasPrices := make([]*big.Rat, 0, len(rawGasPrices))
+		for _, rawGasPrice := range rawGasPrices {
+			gasPrice, err := parseGasPrice(rawGasPrice)
+			if err != nil {
+				return fmt.Errorf("error parsing pending gas price: %v", err)
+			}
+			gasPrices = append(gasPrices, gasPrice)
+		}
+
+		percentiles := NewGasPricePercentiles(gasPrices)
+		openHour.MaybeGasPriceP25 = percentiles.MaybeP25
+		openHour.MaybeGasPriceP50 = percentiles.MaybeP50
+		openHour.MaybeGasPriceP75 = percentiles.MaybeP75
+		openHour.Finalized = true
+		if err := hoursView.Upsert(openHour); err != nil {
+			return fmt.Errorf("error finalizing fee hour: %v", err)
+		}
+		if err := pendingGasPricesView.DeleteAll(); err != nil {
+			return fmt.Errorf("error deleting pending gas prices: %v", err)
+		}
+	}
+
+	if err := paramsView.Set(view.PARAM_OPEN_HOUR, strconv.FormatInt(hourStart.UnixNano(), 10)); err != nil {
+		return fmt.Errorf("error setting open hour: %v", err)
+	}
+	return nil
+}
+
+func (projection *FeeMarket) addToHour(
+	rdbTxHandle *rdb.Handle,
+	hourStart utctime.UTCTime,
+	block *view.FeeBlockRow,
+) error {
+	hoursView := view.NewFeeHours(rdbTxHandle)
+
+	mutHour, err := hoursView.FindBy(hourStart)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting fee hour: %v", err)
+		}
+		mutHour = &view.FeeHourRow{
+			HourStart:   hourStart,
+			Fees:        "0",
+			MaybeMaxGas: block.MaybeMaxGas,
+		}
+	} else if mutHour.MaybeMaxGas != nil && block.MaybeMaxGas != nil {
+		maxGas := *mutHour.MaybeMaxGas + *block.MaybeMaxGas
+		mutHour.MaybeMaxGas = &maxGas
+	} else {
+		mutHour.MaybeMaxGas = nil
+	}
+
+	mutHour.Blocks += 1
+	mutHour.Transactions += block.Transactions
+	mutHour.GasWanted += block.GasWanted
+	mutHour.GasUsed += block.GasUsed
+	mutHour.MaybeFullness = fullnessOf(mutHour.GasUsed, mutHour.MaybeMaxGas)
+
+	hourFees, ok := new(big.Int).SetString(mutHour.Fees, 10)
+	if !ok {
+		return fmt.Errorf("error parsing fee hour fees: %s", mutHour.Fees)
+	}
+	blockFees, ok := new(big.Int).SetString(block.Fees, 10)
+	if !ok {
+		return fmt.Errorf("error parsing fee block fees: %s", block.Fees)
+	}
+	mutHour.Fees = hourFees.Add(hourFees, blockFees).String()
+
+	if err := hoursView.Upsert(mutHour); err != nil {
+		return fmt.Errorf("error upserting fee hour: %v", err)
+	}
+	return nil
+}
+
+func (projection *FeeMarket) handleMessageGas(
+	rdbTxHandle *rdb.Handle,
+	txs []feeTransaction,
+	txMsgTypes map[string][]string,
+) error {
+	messageGasView := view.NewMessageGas(rdbTxHandle)
+
+	for _, tx := range txs {
+		msgTypes := txMsgTypes[tx.hash]
+		if !tx.success || tx.msgCount != 1 || len(msgTypes) != 1 {
+			continue
+		}
+
+		mutMessageGas, err := messageGasView.FindBy(msgTypes[0])
+		if err != nil {
+			if !errors.Is(err, rdb.ErrNoRows) {
+				return fmt.Errorf("error getting message gas: %v", err)
+			}
+			mutMessageGas = &view.MessageGasRow{
+				MsgType: msgTypes[0],
+			}
+		}
+		mutMessageGas.Transactions += 1
+		mutMessageGas.TotalGasWanted += int64(tx.gasWanted)
+		mutMessageGas.TotalGasUsed += int64(tx.gasUsed)
+
+		if err := messageGasView.Upsert(mutMessageGas); err != nil {
+			return fmt.Errorf("error upserting message gas: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package feemarket_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFeeMarket(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "FeeMarket Suite")
+}
@@ -0,0 +1,191 @@
+package feemarket_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/feemarket"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/feemarket/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("FeeMarket", func() {
+	var conn rdb.Conn
+	var projection *feemarket.FeeMarket
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = feemarket.NewFeeMarket(NewFakeLogger(), conn)
+	})
+
+	hourOf := func(hour int, minute int) utctime.UTCTime {
+		return TimeAt(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	genesisCreated := func(maxGas string) event_entity.Event {
+		return NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+			anyGenesis.ConsensusParams.Block.MaxGas = maxGas
+		})
+	}
+	transactionParams := func(hash string, msgCount int, fee int64, gasUsed int) usecase_model.CreateTransactionParams {
+		return usecase_model.CreateTransactionParams{
+			TxHash:    hash,
+			MsgCount:  msgCount,
+			Fee:       coin.MustNewCoinFromInt(fee),
+			GasWanted: 1000,
+			GasUsed:   gasUsed,
+		}
+	}
+	msgSend := func(height int64, txHash string, msgIndex int) event_entity.Event {
+		msgCommonParams := NewMsgCommonParams(height, txHash)
+		msgCommonParams.MsgIndex = msgIndex
+		return event_usecase.NewMsgSend(
+			msgCommonParams,
+			event_usecase.MsgSendCreatedParams{
+				FromAddress: "tcro1a",
+				ToAddress:   "tcro1b",
+				Amount:      coin.MustNewCoinFromInt(1),
+			},
+		)
+	}
+
+	replayBlocks := func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			genesisCreated("1000"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, hourOf(10, 0)),
+			event_usecase.NewTransactionCreated(1, transactionParams("a", 1, 100, 300)),
+			msgSend(1, "a", 0),
+			event_usecase.NewTransactionCreated(1, transactionParams("b", 2, 300, 200)),
+			msgSend(1, "b", 0),
+			msgSend(1, "b", 1),
+			event_usecase.NewTransactionFailed(1, transactionParams("c", 1, 200, 100)),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, hourOf(10, 30)),
+			event_usecase.NewTransactionCreated(2, transactionParams("d", 1, 400, 500)),
+			msgSend(2, "d", 0),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, hourOf(11, 10)),
+		})
+	}
+
+	It("should compute the gas price percentiles and the fullness of every block", func() {
+		replayBlocks()
+
+		blocks, err := view.NewFeeBlocks(conn.ToHandle()).ListLatestPriced(10)
+		Expect(err).To(BeNil())
+		Expect(blocks).To(HaveLen(2))
+		Expect(blocks[1]).To(Equal(view.FeeBlockRow{
+			BlockHeight:      1,
+			BlockTime:        hourOf(10, 0),
+			Transactions:     3,
+			GasWanted:        3000,
+			GasUsed:          600,
+			MaybeMaxGas:      primptr.Int64(1000),
+			MaybeFullness:    primptr.String("0.600000000000000000"),
+			Fees:             "600",
+			MaybeGasPriceP25: primptr.String("0.100000000000000000"),
+			MaybeGasPriceP50: primptr.String("0.200000000000000000"),
+			MaybeGasPriceP75: primptr.String("0.300000000000000000"),
+		}))
+	})
+
+	It("should finalize the gas price percentiles of an hour when a later hour begins", func() {
+		replayBlocks()
+
+		hoursView := view.NewFeeHours(conn.ToHandle())
+		hour, err := hoursView.FindBy(hourOf(10, 0))
+		Expect(err).To(BeNil())
+		Expect(hour).To(Equal(&view.FeeHourRow{
+			HourStart:        hourOf(10, 0),
+			Blocks:           2,
+			Transactions:     4,
+			GasWanted:        4000,
+			GasUsed:          1100,
+			MaybeMaxGas:      primptr.Int64(2000),
+			MaybeFullness:    primptr.String("0.550000000000000000"),
+			Fees:             "1000",
+			MaybeGasPriceP25: primptr.String("0.100000000000000000"),
+			MaybeGasPriceP50: primptr.String("0.200000000000000000"),
+			MaybeGasPriceP75: primptr.String("0.300000000000000000"),
+			Finalized:        true,
+		}))
+
+		openHour, err := hoursView.FindBy(hourOf(11, 0))
+		Expect(err).To(BeNil())
+		Expect(openHour.Blocks).To(Equal(int64(1)))
+		Expect(openHour.Finalized).To(BeFalse())
+		Expect(openHour.MaybeGasPriceP50).To(BeNil())
+	})
+
+	It("should average the gas of the successful single-message transactions by message type", func() {
+		replayBlocks()
+
+		messageGases, err := view.NewMessageGas(conn.ToHandle()).ListAll()
+		Expect(err).To(BeNil())
+		Expect(messageGases).To(Equal([]view.MessageGasRow{
+			{
+				MsgType:        event_usecase.MSG_SEND,
+				Transactions:   2,
+				TotalGasWanted: 2000,
+				TotalGasUsed:   800,
+			},
+		}))
+		Expect(messageGases[0].AvgGasUsed()).To(Equal("400.00"))
+	})
+
+	It("should estimate the gas prices from the medians of the block percentiles", func() {
+		replayBlocks()
+
+		blocks, err := view.NewFeeBlocks(conn.ToHandle()).ListLatestPriced(10)
+		Expect(err).To(BeNil())
+		estimate, err := feemarket.EstimateGasPrices(blocks)
+		Expect(err).To(BeNil())
+		Expect(estimate).To(Equal(&feemarket.GasPriceEstimate{
+			Low:               "0.100000000000000000",
+			Medium:            "0.200000000000000000",
+			High:              "0.300000000000000000",
+			Blocks:            2,
+			LatestBlockHeight: 2,
+		}))
+
+		estimate, err = feemarket.EstimateGasPrices([]view.FeeBlockRow{})
+		Expect(err).To(BeNil())
+		Expect(estimate).To(BeNil())
+	})
+
+	It("should price the transactions of a real block", func() {
+		MustReplayEvents(projection, []event_entity.Event{genesisCreated("-1")})
+		MustReplayEvents(projection, MustParseBlockFixtureEvents(parser.NewTxDecoder("basetcro"), NewBlockFixture(
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESULTS_RESP,
+		)))
+
+		blocks, err := view.NewFeeBlocks(conn.ToHandle()).ListLatestPriced(10)
+		Expect(err).To(BeNil())
+		Expect(blocks).To(HaveLen(1))
+		Expect(blocks[0].Transactions).To(Equal(int64(1)))
+		Expect(blocks[0].GasWanted).To(Equal(int64(80000000)))
+		Expect(blocks[0].GasUsed).To(Equal(int64(62582)))
+		Expect(blocks[0].Fees).To(Equal("8000000"))
+		// The gas price is the fee per gas wanted
+		Expect(blocks[0].MaybeGasPriceP50).To(Equal(primptr.String("0.100000000000000000")))
+		// An unlimited max gas leaves the fullness unknown
+		Expect(blocks[0].MaybeFullness).To(BeNil())
+	})
+})
@@ -0,0 +1,124 @@
+package feemarket
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/feemarket/view"
+)
+
+// Gas prices and fullness are decimals with the same precision as the Cosmos SDK `sdk.Dec`
+const DECIMAL_PRECISION = 18
+
+// GasPricePercentiles are nil when there is no gas price
+type GasPricePercentiles struct {
+	MaybeP25 *string
+	MaybeP50 *string
+	MaybeP75 *string
+}
+
+// NewGasPricePercentiles returns the nearest-rank percentiles of the gas prices
+func NewGasPricePercentiles(gasPrices []*big.Rat) GasPricePercentiles {
+	if len(gasPrices) == 0 {
+		return GasPricePercentiles{}
+	}
+
+	sorted := make([]*big.Rat, len(gasPrices))
+	copy(sorted, gasPrices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+
+	return GasPricePercentiles{
+		MaybeP25: nearestRank(sorted, 25),
+		MaybeP50: nearestRank(sorted, 50),
+		MaybeP75: nearestRank(sorted, 75),
+	}
+}
+
+func nearestRank(sorted []*big.Rat, percentile int) *string {
+	// ceil(percentile / 100 * n) - 1
+	index := (percentile*len(sorted)+99)/100 - 1
+	if index < 0 {
+		index = 0
+	}
+	value := sorted[index].FloatString(DECIMAL_PRECISION)
+	return &value
+}
+
+// gasPriceOf returns the fee paid per gas wanted. Returns nil when the transaction wants no gas.
+func gasPriceOf(fee *big.Int, gasWanted int) *big.Rat {
+	if gasWanted <= 0 {
+		return nil
+	}
+	return new(big.Rat).SetFrac(fee, big.NewInt(int64(gasWanted)))
+}
+
+// fullnessOf returns the gas used against the max gas. Returns nil when the max gas is unlimited.
+func fullnessOf(gasUsed int64, maybeMaxGas *int64) *string {
+	if maybeMaxGas == nil || *maybeMaxGas <= 0 {
+		return nil
+	}
+	fullness := big.NewRat(gasUsed, *maybeMaxGas).FloatString(DECIMAL_PRECISION)
+	return &fullness
+}
+
+type GasPriceEstimate struct {
+	Low    string `json:"low"`
+	Medium string `json:"medium"`
+	High   string `json:"high"`
+	// Number of blocks the estimate is based on and the latest of them
+	Blocks            int   `json:"blocks"`
+	LatestBlockHeight int64 `json:"latestBlockHeight"`
+}
+
+// EstimateGasPrices suggests the low, medium and high gas prices as the medians of the 25th, 50th and
+// 75th gas price percentiles of the blocks. Returns nil when no block is priced.
+func EstimateGasPrices(blocks []view.FeeBlockRow) (*GasPriceEstimate, error) {
+	p25s := make([]*big.Rat, 0, len(blocks))
+	p50s := make([]*big.Rat, 0, len(blocks))
+	p75s := make([]*big.Rat, 0, len(blocks))
+	estimate := GasPriceEstimate{}
+	for _, block := range blocks {
+		if block.MaybeGasPriceP25 == nil || block.MaybeGasPriceP50 == nil || block.MaybeGasPriceP75 == nil {
+			continue
+		}
+		p25, err := parseGasPrice(*block.MaybeGasPriceP25)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing 25th percentile gas price of block %d: %v", block.BlockHeight, err)
+		}
+		p50, err := parseGasPrice(*block.MaybeGasPriceP50)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing 50th percentile gas price of block %d: %v", block.BlockHeight, err)
+		}
+		p75, err := parseGasPrice(*block.MaybeGasPriceP75)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing 75th percentile gas price of block %d: %v", block.BlockHeight, err)
+		}
+		p25s = append(p25s, p25)
+		p50s = append(p50s, p50)
+		p75s = append(p75s, p75)
+
+		estimate.Blocks += 1
+		if block.BlockHeight > estimate.LatestBlockHeight {
+			estimate.LatestBlockHeight = block.BlockHeight
+		}
+	}
+	if estimate.Blocks == 0 {
+		return nil, nil
+	}
+
+	estimate.Low = *NewGasPricePercentiles(p25s).MaybeP50
+	estimate.Medium = *NewGasPricePercentiles(p50s).MaybeP50
+	estimate.High = *NewGasPricePercentiles(p75s).MaybeP50
+	return &estimate, nil
+}
+
+func parseGasPrice(gasPrice string) (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(gasPrice)
+	if !ok {
+		return nil, fmt.Errorf("invalid gas price: %s", gasPrice)
+	}
+	return value, nil
+}
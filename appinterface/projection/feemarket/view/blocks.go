@@ -0,0 +1,230 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// FeeBlocks keeps the fee and gas statistics of every block
+type FeeBlocks struct {
+	rdb *rdb.Handle
+}
+
+func NewFeeBlocks(handle *rdb.Handle) *FeeBlocks {
+	return &FeeBlocks{
+		handle,
+	}
+}
+
+func (blocksView *FeeBlocks) Insert(block *FeeBlockRow) error {
+	fees, ok := new(big.Int).SetString(block.Fees, 10)
+	if !ok {
+		return fmt.Errorf("error parsing fee block fees: %s", block.Fees)
+	}
+
+	sql, sqlArgs, err := blocksView.rdb.StmtBuilder.Insert(
+		"view_fee_market_blocks",
+	).Columns(
+		"block_height",
+		"block_time",
+		"transactions",
+		"gas_wanted",
+		"gas_used",
+		"max_gas",
+		"fullness",
+		"fees",
+		"gas_price_p25",
+		"gas_price_p50",
+		"gas_price_p75",
+	).Values(
+		block.BlockHeight,
+		blocksView.rdb.Tton(&block.BlockTime),
+		block.Transactions,
+		block.GasWanted,
+		block.GasUsed,
+		block.MaybeMaxGas,
+		block.MaybeFullness,
+		blocksView.rdb.Bton(fees),
+		block.MaybeGasPriceP25,
+		block.MaybeGasPriceP50,
+		block.MaybeGasPriceP75,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building fee block insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := blocksView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting fee block into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting fee block into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// ListLatestPriced returns the latest blocks with at least one priced transaction, most recent first
+func (blocksView *FeeBlocks) ListLatestPriced(limit uint64) ([]FeeBlockRow, error) {
+	sql, sqlArgs, err := blocksView.selectStmtBuilder().Where(
+		"gas_price_p50 IS NOT NULL",
+	).OrderBy("block_height DESC").Limit(limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building fee blocks select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := blocksView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing fee blocks select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	blocks := make([]FeeBlockRow, 0)
+	for rowsResult.Next() {
+		block, scanErr := blocksView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		blocks = append(blocks, *block)
+	}
+
+	return blocks, nil
+}
+
+type FeeBlocksListFilter struct {
+	// Inclusive
+	MaybeFromTime *utctime.UTCTime
+	// Exclusive
+	MaybeToTime *utctime.UTCTime
+}
+
+type FeeBlocksListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (blocksView *FeeBlocks) List(
+	filter FeeBlocksListFilter,
+	order FeeBlocksListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]FeeBlockRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := blocksView.selectStmtBuilder()
+	if filter.MaybeFromTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time >= ?", blocksView.rdb.Tton(filter.MaybeFromTime))
+	}
+	if filter.MaybeToTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time < ?", blocksView.rdb.Tton(filter.MaybeToTime))
+	}
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		blocksView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building fee blocks select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := blocksView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing fee blocks select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	blocks := make([]FeeBlockRow, 0)
+	for rowsResult.Next() {
+		block, scanErr := blocksView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		blocks = append(blocks, *block)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return blocks, paginationResult, nil
+}
+
+func (blocksView *FeeBlocks) selectStmtBuilder() sq.SelectBuilder {
+	return blocksView.rdb.StmtBuilder.Select(
+		"block_height",
+		"block_time",
+		"transactions",
+		"gas_wanted",
+		"gas_used",
+		"max_gas",
+		"fullness",
+		"fees",
+		"gas_price_p25",
+		"gas_price_p50",
+		"gas_price_p75",
+	).From(
+		"view_fee_market_blocks",
+	)
+}
+
+func (blocksView *FeeBlocks) scan(rowsResult rdb.RowsResult) (*FeeBlockRow, error) {
+	var block FeeBlockRow
+	blockTimeReader := blocksView.rdb.NtotReader()
+	feesReader := blocksView.rdb.NtobReader()
+	if err := rowsResult.Scan(
+		&block.BlockHeight,
+		blockTimeReader.ScannableArg(),
+		&block.Transactions,
+		&block.GasWanted,
+		&block.GasUsed,
+		&block.MaybeMaxGas,
+		&block.MaybeFullness,
+		feesReader.ScannableArg(),
+		&block.MaybeGasPriceP25,
+		&block.MaybeGasPriceP50,
+		&block.MaybeGasPriceP75,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning fee block row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	blockTime, err := blockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing fee block time: %v: %w", err, rdb.ErrQuery)
+	}
+	block.BlockTime = *blockTime
+	fees, err := feesReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing fee block fees: %v: %w", err, rdb.ErrQuery)
+	}
+	block.Fees = fees.String()
+
+	return &block, nil
+}
+
+// FeeBlockRow fees are in base denom and gas prices are in base denom per gas wanted
+type FeeBlockRow struct {
+	BlockHeight  int64           `json:"blockHeight"`
+	BlockTime    utctime.UTCTime `json:"blockTime"`
+	Transactions int64           `json:"transactions"`
+	GasWanted    int64           `json:"gasWanted"`
+	GasUsed      int64           `json:"gasUsed"`
+	// Consensus max gas of the block. Null when unlimited.
+	MaybeMaxGas *int64 `json:"maxGas"`
+	// Gas used against the max gas. Null when unlimited.
+	MaybeFullness *string `json:"fullness"`
+	Fees          string  `json:"fees"`
+	// Null when no transaction is priced
+	MaybeGasPriceP25 *string `json:"gasPriceP25"`
+	MaybeGasPriceP50 *string `json:"gasPriceP50"`
+	MaybeGasPriceP75 *string `json:"gasPriceP75"`
+}
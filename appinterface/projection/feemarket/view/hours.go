@@ -0,0 +1,248 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// FeeHourStart returns the start of the hour the time is in
+func FeeHourStart(t utctime.UTCTime) utctime.UTCTime {
+	return utctime.FromTime(time.Unix(0, t.UnixNano()).UTC().Truncate(time.Hour))
+}
+
+// FeeHours keeps the fee and gas statistics rolled up by the hour. The gas price percentiles of an hour
+// are only available once it is finalized, when the first block of a later hour is handled.
+type FeeHours struct {
+	rdb *rdb.Handle
+}
+
+func NewFeeHours(handle *rdb.Handle) *FeeHours {
+	return &FeeHours{
+		handle,
+	}
+}
+
+func (hoursView *FeeHours) Upsert(hour *FeeHourRow) error {
+	fees, ok := new(big.Int).SetString(hour.Fees, 10)
+	if !ok {
+		return fmt.Errorf("error parsing fee hour fees: %s", hour.Fees)
+	}
+
+	sql, sqlArgs, err := hoursView.rdb.StmtBuilder.Insert(
+		"view_fee_market_hours",
+	).Columns(
+		"hour_start",
+		"blocks",
+		"transactions",
+		"gas_wanted",
+		"gas_used",
+		"max_gas",
+		"fullness",
+		"fees",
+		"gas_price_p25",
+		"gas_price_p50",
+		"gas_price_p75",
+		"finalized",
+	).Values(
+		hoursView.rdb.Tton(&hour.HourStart),
+		hour.Blocks,
+		hour.Transactions,
+		hour.GasWanted,
+		hour.GasUsed,
+		hour.MaybeMaxGas,
+		hour.MaybeFullness,
+		hoursView.rdb.Bton(fees),
+		hour.MaybeGasPriceP25,
+		hour.MaybeGasPriceP50,
+		hour.MaybeGasPriceP75,
+		hour.Finalized,
+	).Suffix(`ON CONFLICT (hour_start) DO UPDATE SET
+		blocks = EXCLUDED.blocks,
+		transactions = EXCLUDED.transactions,
+		gas_wanted = EXCLUDED.gas_wanted,
+		gas_used = EXCLUDED.gas_used,
+		max_gas = EXCLUDED.max_gas,
+		fullness = EXCLUDED.fullness,
+		fees = EXCLUDED.fees,
+		gas_price_p25 = EXCLUDED.gas_price_p25,
+		gas_price_p50 = EXCLUDED.gas_price_p50,
+		gas_price_p75 = EXCLUDED.gas_price_p75,
+		finalized = EXCLUDED.finalized
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building fee hour upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := hoursView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting fee hour into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting fee hour into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when there is no block in the hour
+func (hoursView *FeeHours) FindBy(hourStart utctime.UTCTime) (*FeeHourRow, error) {
+	sql, sqlArgs, err := hoursView.selectStmtBuilder().Where(
+		"hour_start = ?", hoursView.rdb.Tton(&hourStart),
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building fee hour selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := hoursView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing fee hour selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return hoursView.scan(rowsResult)
+}
+
+type FeeHoursListFilter struct {
+	// Inclusive
+	MaybeFromTime *utctime.UTCTime
+	// Exclusive
+	MaybeToTime *utctime.UTCTime
+}
+
+type FeeHoursListOrder struct {
+	MaybeHourStart *view.ORDER
+}
+
+func (hoursView *FeeHours) List(
+	filter FeeHoursListFilter,
+	order FeeHoursListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]FeeHourRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := hoursView.selectStmtBuilder()
+	if filter.MaybeFromTime != nil {
+		stmtBuilder = stmtBuilder.Where("hour_start >= ?", hoursView.rdb.Tton(filter.MaybeFromTime))
+	}
+	if filter.MaybeToTime != nil {
+		stmtBuilder = stmtBuilder.Where("hour_start < ?", hoursView.rdb.Tton(filter.MaybeToTime))
+	}
+	if order.MaybeHourStart != nil && *order.MaybeHourStart == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("hour_start DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("hour_start")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		hoursView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building fee hours select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := hoursView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing fee hours select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	hours := make([]FeeHourRow, 0)
+	for rowsResult.Next() {
+		hour, scanErr := hoursView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		hours = append(hours, *hour)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return hours, paginationResult, nil
+}
+
+func (hoursView *FeeHours) selectStmtBuilder() sq.SelectBuilder {
+	return hoursView.rdb.StmtBuilder.Select(
+		"hour_start",
+		"blocks",
+		"transactions",
+		"gas_wanted",
+		"gas_used",
+		"max_gas",
+		"fullness",
+		"fees",
+		"gas_price_p25",
+		"gas_price_p50",
+		"gas_price_p75",
+		"finalized",
+	).From(
+		"view_fee_market_hours",
+	)
+}
+
+func (hoursView *FeeHours) scan(rowsResult rdb.RowsResult) (*FeeHourRow, error) {
+	var hour FeeHourRow
+	hourStartReader := hoursView.rdb.NtotReader()
+	feesReader := hoursView.rdb.NtobReader()
+	if err := rowsResult.Scan(
+		hourStartReader.ScannableArg(),
+		&hour.Blocks,
+		&hour.Transactions,
+		&hour.GasWanted,
+		&hour.GasUsed,
+		&hour.MaybeMaxGas,
+		&hour.MaybeFullness,
+		feesReader.ScannableArg(),
+		&hour.MaybeGasPriceP25,
+		&hour.MaybeGasPriceP50,
+		&hour.MaybeGasPriceP75,
+		&hour.Finalized,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning fee hour row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	hourStart, err := hourStartReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing fee hour start: %v: %w", err, rdb.ErrQuery)
+	}
+	hour.HourStart = *hourStart
+	fees, err := feesReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing fee hour fees: %v: %w", err, rdb.ErrQuery)
+	}
+	hour.Fees = fees.String()
+
+	return &hour, nil
+}
+
+// FeeHourRow fees are in base denom and gas prices are in base denom per gas wanted
+type FeeHourRow struct {
+	HourStart    utctime.UTCTime `json:"hourStart"`
+	Blocks       int64           `json:"blocks"`
+	Transactions int64           `json:"transactions"`
+	GasWanted    int64           `json:"gasWanted"`
+	GasUsed      int64           `json:"gasUsed"`
+	// Sum of the consensus max gas of the blocks. Null when unlimited.
+	MaybeMaxGas *int64 `json:"maxGas"`
+	// Gas used against the max gas. Null when unlimited.
+	MaybeFullness *string `json:"fullness"`
+	Fees          string  `json:"fees"`
+	// Null when the hour is not finalized or no transaction is priced
+	MaybeGasPriceP25 *string `json:"gasPriceP25"`
+	MaybeGasPriceP50 *string `json:"gasPriceP50"`
+	MaybeGasPriceP75 *string `json:"gasPriceP75"`
+	Finalized        bool    `json:"finalized"`
+}
@@ -0,0 +1,150 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// Decimal places of the average gas
+const AVG_GAS_PRECISION = 2
+
+// MessageGas keeps the gas of the successful single-message transactions by message type. Gas of the
+// multi-message transactions cannot be attributed to the individual messages.
+type MessageGas struct {
+	rdb *rdb.Handle
+}
+
+func NewMessageGas(handle *rdb.Handle) *MessageGas {
+	return &MessageGas{
+		handle,
+	}
+}
+
+func (messageGasView *MessageGas) Upsert(messageGas *MessageGasRow) error {
+	sql, sqlArgs, err := messageGasView.rdb.StmtBuilder.Insert(
+		"view_fee_market_message_gas",
+	).Columns(
+		"msg_type",
+		"transactions",
+		"total_gas_wanted",
+		"total_gas_used",
+	).Values(
+		messageGas.MsgType,
+		messageGas.Transactions,
+		messageGas.TotalGasWanted,
+		messageGas.TotalGasUsed,
+	).Suffix(`ON CONFLICT (msg_type) DO UPDATE SET
+		transactions = EXCLUDED.transactions,
+		total_gas_wanted = EXCLUDED.total_gas_wanted,
+		total_gas_used = EXCLUDED.total_gas_used
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building message gas upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := messageGasView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting message gas into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting message gas into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when the message type is never seen
+func (messageGasView *MessageGas) FindBy(msgType string) (*MessageGasRow, error) {
+	sql, sqlArgs, err := messageGasView.selectStmtBuilder().Where("msg_type = ?", msgType).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building message gas selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := messageGasView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing message gas selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return messageGasView.scan(rowsResult)
+}
+
+func (messageGasView *MessageGas) ListAll() ([]MessageGasRow, error) {
+	sql, sqlArgs, err := messageGasView.selectStmtBuilder().OrderBy("msg_type").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building message gas select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := messageGasView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing message gas select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	messageGases := make([]MessageGasRow, 0)
+	for rowsResult.Next() {
+		messageGas, scanErr := messageGasView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		messageGases = append(messageGases, *messageGas)
+	}
+
+	return messageGases, nil
+}
+
+func (messageGasView *MessageGas) selectStmtBuilder() sq.SelectBuilder {
+	return messageGasView.rdb.StmtBuilder.Select(
+		"msg_type",
+		"transactions",
+		"total_gas_wanted",
+		"total_gas_used",
+	).From(
+		"view_fee_market_message_gas",
+	)
+}
+
+func (messageGasView *MessageGas) scan(rowsResult rdb.RowsResult) (*MessageGasRow, error) {
+	var messageGas MessageGasRow
+	if err := rowsResult.Scan(
+		&messageGas.MsgType,
+		&messageGas.Transactions,
+		&messageGas.TotalGasWanted,
+		&messageGas.TotalGasUsed,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning message gas row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &messageGas, nil
+}
+
+type MessageGasRow struct {
+	MsgType        string `json:"msgType"`
+	Transactions   int64  `json:"transactions"`
+	TotalGasWanted int64  `json:"totalGasWanted"`
+	TotalGasUsed   int64  `json:"totalGasUsed"`
+}
+
+// AvgGasWanted returns the average gas wanted per transaction
+func (messageGas *MessageGasRow) AvgGasWanted() string {
+	return avgGas(messageGas.TotalGasWanted, messageGas.Transactions)
+}
+
+// AvgGasUsed returns the average gas used per transaction
+func (messageGas *MessageGasRow) AvgGasUsed() string {
+	return avgGas(messageGas.TotalGasUsed, messageGas.Transactions)
+}
+
+func avgGas(totalGas int64, transactions int64) string {
+	if transactions == 0 {
+		return "0"
+	}
+	return big.NewRat(totalGas, transactions).FloatString(AVG_GAS_PRECISION)
+}
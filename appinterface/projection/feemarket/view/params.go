@@ -0,0 +1,73 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+const (
+	PARAM_MAX_GAS   = "MaxGas"
+	PARAM_OPEN_HOUR = "OpenHour"
+)
+
+// Params keeps the state carried between heights, such as the consensus max gas and the hour whose
+// gas prices are pending
+type Params struct {
+	rdb *rdb.Handle
+}
+
+func NewParams(handle *rdb.Handle) *Params {
+	return &Params{
+		handle,
+	}
+}
+
+func (paramsView *Params) Set(key string, value string) error {
+	sql, sqlArgs, err := paramsView.rdb.StmtBuilder.Insert(
+		"view_fee_market_params",
+	).Columns(
+		"key",
+		"value",
+	).Values(key, value).Suffix(
+		"ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value",
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building fee market param upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := paramsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting fee market param into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting fee market param into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when the param is not set
+func (paramsView *Params) FindBy(key string) (string, error) {
+	sql, sqlArgs, err := paramsView.rdb.StmtBuilder.Select(
+		"value",
+	).From(
+		"view_fee_market_params",
+	).Where(
+		"key = ?", key,
+	).ToSql()
+	if err != nil {
+		return "", fmt.Errorf("error building fee market param selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var value string
+	if err = paramsView.rdb.QueryRow(sql, sqlArgs...).Scan(&value); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return "", rdb.ErrNoRows
+		}
+		return "", fmt.Errorf("error scanning fee market param row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,88 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// PendingGasPrices keeps the gas prices of the transactions in the open hour until the hour is finalized
+type PendingGasPrices struct {
+	rdb *rdb.Handle
+}
+
+func NewPendingGasPrices(handle *rdb.Handle) *PendingGasPrices {
+	return &PendingGasPrices{
+		handle,
+	}
+}
+
+func (pendingView *PendingGasPrices) Insert(blockHeight int64, transactionHash string, gasPrice string) error {
+	sql, sqlArgs, err := pendingView.rdb.StmtBuilder.Insert(
+		"view_fee_market_pending_gas_prices",
+	).Columns(
+		"block_height",
+		"transaction_hash",
+		"gas_price",
+	).Values(
+		blockHeight,
+		transactionHash,
+		gasPrice,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building pending gas price insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := pendingView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting pending gas price into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting pending gas price into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (pendingView *PendingGasPrices) ListAll() ([]string, error) {
+	sql, sqlArgs, err := pendingView.rdb.StmtBuilder.Select(
+		"gas_price",
+	).From(
+		"view_fee_market_pending_gas_prices",
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building pending gas prices select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := pendingView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing pending gas prices select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	gasPrices := make([]string, 0)
+	for rowsResult.Next() {
+		var gasPrice string
+		if err = rowsResult.Scan(&gasPrice); err != nil {
+			return nil, fmt.Errorf("error scanning pending gas price row: %v: %w", err, rdb.ErrQuery)
+		}
+		gasPrices = append(gasPrices, gasPrice)
+	}
+
+	return gasPrices, nil
+}
+
+func (pendingView *PendingGasPrices) DeleteAll() error {
+	sql, sqlArgs, err := pendingView.rdb.StmtBuilder.Delete(
+		"view_fee_market_pending_gas_prices",
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building pending gas prices deletion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	if _, err := pendingView.rdb.Exec(sql, sqlArgs...); err != nil {
+		return fmt.Errorf("error deleting pending gas prices: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return nil
+}
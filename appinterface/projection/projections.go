@@ -0,0 +1,100 @@
+package projection
+
+import (
+	"github.com/crypto-com/chain-indexing/appinterface/projection/account"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/account_message"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/accountactivity"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/block"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/blockevent"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/chainstats"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegation"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/delegatorreward"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/feemarket"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposerstats"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/slashing"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/stakingapr"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/supply"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/transaction"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validator"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorhistory"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorreward"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorstats"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatoruptime"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/votingpower"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
+)
+
+// RegisterProjections registers all the projections shipped with chain-indexing to the registry
+func RegisterProjections(registry *Registry) {
+	registry.Register("Block", func(params *InitParams) (entity_projection.Projection, error) {
+		return block.NewBlock(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("Transaction", func(params *InitParams) (entity_projection.Projection, error) {
+		return transaction.NewTransaction(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("BlockEvent", func(params *InitParams) (entity_projection.Projection, error) {
+		return blockevent.NewBlockEvent(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("Validator", func(params *InitParams) (entity_projection.Projection, error) {
+		return validator.NewValidator(params.Logger, params.RdbConn, params.ConNodeAddressPrefix), nil
+	})
+	registry.Register("ValidatorStats", func(params *InitParams) (entity_projection.Projection, error) {
+		return validatorstats.NewValidatorStats(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("AccountMessage", func(params *InitParams) (entity_projection.Projection, error) {
+		return account_message.NewAccountMessage(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("Account", func(params *InitParams) (entity_projection.Projection, error) {
+		return account.NewAccount(params.Logger, params.RdbConn, params.CosmosAppClient, params.BaseDenom), nil
+	})
+
+	registry.Register("Proposal", func(params *InitParams) (entity_projection.Projection, error) {
+		return proposal.NewProposal(params.Logger, params.RdbConn, params.BaseDenom), nil
+	})
+	registry.Register("Delegation", func(params *InitParams) (entity_projection.Projection, error) {
+		return delegation.NewDelegation(params.Logger, params.RdbConn, params.ConNodeAddressPrefix), nil
+	})
+	registry.Register("Balance", func(params *InitParams) (entity_projection.Projection, error) {
+		return balance.NewBalance(params.Logger, params.RdbConn, params.BaseDenom), nil
+	})
+	registry.Register("ValidatorUptime", func(params *InitParams) (entity_projection.Projection, error) {
+		return validatoruptime.NewValidatorUptime(params.Logger, params.RdbConn, params.ConNodeAddressPrefix), nil
+	})
+	registry.Register("ValidatorReward", func(params *InitParams) (entity_projection.Projection, error) {
+		return validatorreward.NewValidatorReward(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("DelegatorReward", func(params *InitParams) (entity_projection.Projection, error) {
+		return delegatorreward.NewDelegatorReward(params.Logger, params.RdbConn, params.BaseDenom), nil
+	})
+	registry.Register("ChainStats", func(params *InitParams) (entity_projection.Projection, error) {
+		return chainstats.NewChainStats(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("Supply", func(params *InitParams) (entity_projection.Projection, error) {
+		return supply.NewSupply(params.Logger, params.RdbConn, params.BaseDenom), nil
+	})
+	registry.Register("StakingAPR", func(params *InitParams) (entity_projection.Projection, error) {
+		return stakingapr.NewStakingAPR(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("FeeMarket", func(params *InitParams) (entity_projection.Projection, error) {
+		return feemarket.NewFeeMarket(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("AccountActivity", func(params *InitParams) (entity_projection.Projection, error) {
+		return accountactivity.NewAccountActivity(params.Logger, params.RdbConn, params.AccountAddressPrefix), nil
+	})
+	registry.Register("ValidatorHistory", func(params *InitParams) (entity_projection.Projection, error) {
+		return validatorhistory.NewValidatorHistory(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("VotingPower", func(params *InitParams) (entity_projection.Projection, error) {
+		return votingpower.NewVotingPower(params.Logger, params.RdbConn, params.ConNodeAddressPrefix), nil
+	})
+	registry.Register("ProposerStats", func(params *InitParams) (entity_projection.Projection, error) {
+		return proposerstats.NewProposerStats(params.Logger, params.RdbConn), nil
+	})
+	registry.Register("Slashing", func(params *InitParams) (entity_projection.Projection, error) {
+		return slashing.NewSlashing(params.Logger, params.RdbConn, params.ConNodeAddressPrefix), nil
+	})
+
+	// register more projections here
+}
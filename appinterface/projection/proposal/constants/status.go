@@ -0,0 +1,13 @@
+package constants
+
+const DEPOSIT_PERIOD Status = "DEPOSIT_PERIOD"
+const VOTING_PERIOD Status = "VOTING_PERIOD"
+const PASSED Status = "PASSED"
+const REJECTED Status = "REJECTED"
+const FAILED Status = "FAILED"
+
+// INACTIVE proposal did not reach the min deposit within the deposit period and its deposits are
+// burnt
+const INACTIVE Status = "INACTIVE"
+
+type Status = string
@@ -0,0 +1,8 @@
+package constants
+
+const VOTE_OPTION_YES VoteOption = "VOTE_OPTION_YES"
+const VOTE_OPTION_NO VoteOption = "VOTE_OPTION_NO"
+const VOTE_OPTION_NO_WITH_VETO VoteOption = "VOTE_OPTION_NO_WITH_VETO"
+const VOTE_OPTION_ABSTAIN VoteOption = "VOTE_OPTION_ABSTAIN"
+
+type VoteOption = string
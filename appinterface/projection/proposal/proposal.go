@@ -0,0 +1,526 @@
+package proposal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal/constants"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &Proposal{}
+
+// Proposal results in the `active_proposal` and `inactive_proposal` end block events
+const RESULT_PASSED = "proposal_passed"
+const RESULT_REJECTED = "proposal_rejected"
+const RESULT_FAILED = "proposal_failed"
+const RESULT_DROPPED = "proposal_dropped"
+
+type Proposal struct {
+	*rdbprojectionbase.Base
+
+	rdbConn   rdb.Conn
+	logger    applogger.Logger
+	baseDenom string
+}
+
+func NewProposal(logger applogger.Logger, rdbConn rdb.Conn, baseDenom string) *Proposal {
+	return &Proposal{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "Proposal"),
+
+		rdbConn,
+		logger,
+		baseDenom,
+	}
+}
+
+func (_ *Proposal) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_SUBMIT_TEXT_PROPOSAL_CREATED,
+		event_usecase.MSG_SUBMIT_PARAM_CHANGE_PROPOSAL_CREATED,
+		event_usecase.MSG_SUBMIT_COMMUNITY_POOL_SPEND_PROPOSAL_CREATED,
+		event_usecase.MSG_SUBMIT_SOFTWARE_UPGRADE_PROPOSAL_CREATED,
+		event_usecase.MSG_SUBMIT_CANCEL_SOFTWARE_UPGRADE_PROPOSAL_CREATED,
+		event_usecase.MSG_DEPOSIT_CREATED,
+		event_usecase.MSG_VOTE_CREATED,
+		event_usecase.PROPOSAL_ENDED,
+		event_usecase.PROPOSAL_INACTIVED,
+	}
+}
+
+func (projection *Proposal) OnInit() error {
+	return nil
+}
+
+func (projection *Proposal) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+	paramsView := view.NewParams(rdbTxHandle)
+
+	var blockTime utctime.UTCTime
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			blockTime = blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			if err := projection.projectGovParams(paramsView, genesisCreatedEvent); err != nil {
+				return fmt.Errorf("error projecting governance params: %v", err)
+			}
+		}
+	}
+
+	// Messages are handled before the end block events which end the proposals
+	if err := projection.projectMsgs(rdbTxHandle, height, blockTime, events); err != nil {
+		return fmt.Errorf("error projecting proposal messages: %v", err)
+	}
+	if err := projection.projectProposalResults(rdbTxHandle, height, events); err != nil {
+		return fmt.Errorf("error projecting proposal results: %v", err)
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *Proposal) projectGovParams(
+	paramsView *view.Params,
+	genesisCreatedEvent *event_usecase.GenesisCreated,
+) error {
+	gov := genesisCreatedEvent.Genesis.AppState.Gov
+
+	// Deposits are only parsed in the base denom
+	minDeposit := big.NewInt(0)
+	for _, deposit := range gov.DepositParams.MinDeposit {
+		if deposit.Denom != projection.baseDenom {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(deposit.Amount, 10)
+		if !ok {
+			return fmt.Errorf("error parsing min deposit amount: %s", deposit.Amount)
+		}
+		minDeposit = new(big.Int).Add(minDeposit, amount)
+	}
+
+	if err := paramsView.Set(view.PARAM_MAX_DEPOSIT_PERIOD, gov.DepositParams.MaxDepositPeriod); err != nil {
+		return fmt.Errorf("error setting max deposit period: %v", err)
+	}
+	if err := paramsView.Set(view.PARAM_MIN_DEPOSIT, minDeposit.String()); err != nil {
+		return fmt.Errorf("error setting min deposit: %v", err)
+	}
+	if err := paramsView.Set(view.PARAM_VOTING_PERIOD, gov.VotingParams.VotingPeriod); err != nil {
+		return fmt.Errorf("error setting voting period: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *Proposal) projectMsgs(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	events []event_entity.Event,
+) error {
+	proposalsView := view.NewProposals(rdbTxHandle)
+	depositsView := view.NewDeposits(rdbTxHandle)
+	votesView := view.NewVotes(rdbTxHandle)
+
+	govParams, err := projection.getGovParams(view.NewParams(rdbTxHandle))
+	if err != nil {
+		return fmt.Errorf("error getting governance params: %v", err)
+	}
+
+	for _, event := range events {
+		var maybeSubmittedProposal *submittedProposal
+		if typedEvent, ok := event.(*event_usecase.MsgSubmitTextProposal); ok {
+			maybeSubmittedProposal = &submittedProposal{
+				typedEvent.MsgTxHash,
+				typedEvent.MaybeProposalId,
+				typedEvent.Content.Title,
+				typedEvent.Content.Description,
+				typedEvent.Content.Type,
+				typedEvent.Content,
+				typedEvent.ProposerAddress,
+				typedEvent.InitialDeposit,
+			}
+		} else if typedEvent, ok := event.(*event_usecase.MsgSubmitParamChangeProposal); ok {
+			maybeSubmittedProposal = &submittedProposal{
+				typedEvent.MsgTxHash,
+				typedEvent.MaybeProposalId,
+				typedEvent.Content.Title,
+				typedEvent.Content.Description,
+				typedEvent.Content.Type,
+				typedEvent.Content,
+				typedEvent.ProposerAddress,
+				typedEvent.InitialDeposit,
+			}
+		} else if typedEvent, ok := event.(*event_usecase.MsgSubmitCommunityPoolSpendProposal); ok {
+			maybeSubmittedProposal = &submittedProposal{
+				typedEvent.MsgTxHash,
+				typedEvent.MaybeProposalId,
+				typedEvent.Content.Title,
+				typedEvent.Content.Description,
+				typedEvent.Content.Type,
+				typedEvent.Content,
+				typedEvent.ProposerAddress,
+				typedEvent.InitialDeposit,
+			}
+		} else if typedEvent, ok := event.(*event_usecase.MsgSubmitSoftwareUpgradeProposal); ok {
+			maybeSubmittedProposal = &submittedProposal{
+				typedEvent.MsgTxHash,
+				typedEvent.MaybeProposalId,
+				typedEvent.Content.Title,
+				typedEvent.Content.Description,
+				typedEvent.Content.Type,
+				typedEvent.Content,
+				typedEvent.ProposerAddress,
+				typedEvent.InitialDeposit,
+			}
+		} else if typedEvent, ok := event.(*event_usecase.MsgSubmitCancelSoftwareUpgradeProposal); ok {
+			maybeSubmittedProposal = &submittedProposal{
+				typedEvent.MsgTxHash,
+				typedEvent.MaybeProposalId,
+				typedEvent.Content.Title,
+				typedEvent.Content.Description,
+				typedEvent.Content.Type,
+				typedEvent.Content,
+				typedEvent.ProposerAddress,
+				typedEvent.InitialDeposit,
+			}
+		} else if typedEvent, ok := event.(*event_usecase.MsgDeposit); ok {
+			projection.logger.Debug("handling MsgDeposit event")
+			if err := projection.handleDeposit(
+				proposalsView, depositsView, govParams, blockHeight, blockTime,
+				typedEvent.MsgTxHash, typedEvent.ProposalId, typedEvent.Depositor, typedEvent.Amount,
+			); err != nil {
+				return fmt.Errorf("error handling MsgDeposit: %v", err)
+			}
+		} else if typedEvent, ok := event.(*event_usecase.MsgVote); ok {
+			projection.logger.Debug("handling MsgVote event")
+			if err := projection.handleVote(proposalsView, votesView, blockHeight, blockTime, typedEvent); err != nil {
+				return fmt.Errorf("error handling MsgVote: %v", err)
+			}
+		}
+
+		if maybeSubmittedProposal != nil {
+			projection.logger.Debug("handling MsgSubmitProposal event")
+			if err := projection.handleSubmit(
+				proposalsView, depositsView, govParams, blockHeight, blockTime, maybeSubmittedProposal,
+			); err != nil {
+				return fmt.Errorf("error handling %s: %v", event.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+type submittedProposal struct {
+	txHash          string
+	maybeProposalId *string
+	title           string
+	description     string
+	proposalType    string
+	content         interface{}
+	proposerAddress string
+	initialDeposit  coin.Coin
+}
+
+func (projection *Proposal) handleSubmit(
+	proposalsView *view.Proposals,
+	depositsView *view.Deposits,
+	govParams *govParams,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	submitted *submittedProposal,
+) error {
+	if submitted.maybeProposalId == nil {
+		return errors.New("missing proposal id in successful proposal submission")
+	}
+	proposalId := *submitted.maybeProposalId
+
+	content, err := jsoniter.MarshalToString(submitted.content)
+	if err != nil {
+		return fmt.Errorf("error JSON marshalling proposal content: %v", err)
+	}
+
+	var maybeDepositEndTime *utctime.UTCTime
+	if govParams.maybeMaxDepositPeriod != nil {
+		depositEndTime := utctime.FromUnixNano(blockTime.UnixNano() + govParams.maybeMaxDepositPeriod.Nanoseconds())
+		maybeDepositEndTime = &depositEndTime
+	}
+
+	proposal := view.ProposalRow{
+		ProposalId:      proposalId,
+		Title:           submitted.title,
+		Description:     submitted.description,
+		Type:            submitted.proposalType,
+		Status:          constants.DEPOSIT_PERIOD,
+		ProposerAddress: submitted.proposerAddress,
+		Data:            content,
+		InitialDeposit:  submitted.initialDeposit.String(),
+		TotalDeposit:    submitted.initialDeposit.String(),
+		TotalVoteCount:  0,
+		VoteCounts:      view.ProposalVoteCounts{},
+
+		TransactionHash:     submitted.txHash,
+		SubmitBlockHeight:   blockHeight,
+		SubmitTime:          blockTime,
+		MaybeDepositEndTime: maybeDepositEndTime,
+	}
+	projection.startVotingIfMinDepositReached(&proposal, govParams, blockHeight, blockTime)
+	if err := proposalsView.Insert(&proposal); err != nil {
+		return fmt.Errorf("error inserting proposal: %v", err)
+	}
+
+	if submitted.initialDeposit.ToBigInt().Sign() > 0 {
+		if err := depositsView.Insert(&view.DepositRow{
+			ProposalId:           proposalId,
+			DepositorAddress:     submitted.proposerAddress,
+			Amount:               submitted.initialDeposit.String(),
+			TransactionHash:      submitted.txHash,
+			DepositAtBlockHeight: blockHeight,
+			DepositAtBlockTime:   blockTime,
+		}); err != nil {
+			return fmt.Errorf("error inserting initial deposit: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (projection *Proposal) handleDeposit(
+	proposalsView *view.Proposals,
+	depositsView *view.Deposits,
+	govParams *govParams,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	txHash string,
+	proposalId string,
+	depositor string,
+	amount coin.Coin,
+) error {
+	mutProposal, err := proposalsView.FindBy(proposalId)
+	if err != nil {
+		return fmt.Errorf("error getting existing proposal `%s` from view: %v", proposalId, err)
+	}
+
+	totalDeposit, err := coin.NewCoinFromString(mutProposal.TotalDeposit)
+	if err != nil {
+		return fmt.Errorf("error parsing proposal total deposit: %v", err)
+	}
+	if totalDeposit, err = totalDeposit.Add(amount); err != nil {
+		return fmt.Errorf("error adding deposit to proposal total deposit: %v", err)
+	}
+	mutProposal.TotalDeposit = totalDeposit.String()
+	projection.startVotingIfMinDepositReached(mutProposal, govParams, blockHeight, blockTime)
+	if err := proposalsView.Update(mutProposal); err != nil {
+		return fmt.Errorf("error updating proposal: %v", err)
+	}
+
+	if err := depositsView.Insert(&view.DepositRow{
+		ProposalId:           proposalId,
+		DepositorAddress:     depositor,
+		Amount:               amount.String(),
+		TransactionHash:      txHash,
+		DepositAtBlockHeight: blockHeight,
+		DepositAtBlockTime:   blockTime,
+	}); err != nil {
+		return fmt.Errorf("error inserting deposit: %v", err)
+	}
+
+	return nil
+}
+
+// startVotingIfMinDepositReached moves the proposal in deposit period to voting period once its total
+// deposit reaches the min deposit
+func (projection *Proposal) startVotingIfMinDepositReached(
+	mutProposal *view.ProposalRow,
+	govParams *govParams,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+) {
+	if mutProposal.Status != constants.DEPOSIT_PERIOD || govParams.maybeMinDeposit == nil {
+		return
+	}
+	totalDeposit, ok := new(big.Int).SetString(mutProposal.TotalDeposit, 10)
+	if !ok || totalDeposit.Cmp(govParams.maybeMinDeposit) < 0 {
+		return
+	}
+
+	mutProposal.Status = constants.VOTING_PERIOD
+	votingStartBlockHeight := blockHeight
+	mutProposal.MaybeVotingStartBlockHeight = &votingStartBlockHeight
+	votingStartTime := blockTime
+	mutProposal.MaybeVotingStartTime = &votingStartTime
+	if govParams.maybeVotingPeriod != nil {
+		votingEndTime := utctime.FromUnixNano(blockTime.UnixNano() + govParams.maybeVotingPeriod.Nanoseconds())
+		mutProposal.MaybeVotingEndTime = &votingEndTime
+	}
+}
+
+func (projection *Proposal) handleVote(
+	proposalsView *view.Proposals,
+	votesView *view.Votes,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	msgVoteEvent *event_usecase.MsgVote,
+) error {
+	mutProposal, err := proposalsView.FindBy(msgVoteEvent.ProposalId)
+	if err != nil {
+		return fmt.Errorf("error getting existing proposal `%s` from view: %v", msgVoteEvent.ProposalId, err)
+	}
+
+	// Re-voting replaces the previous vote of the voter in the vote counts
+	previousVote, err := votesView.FindBy(msgVoteEvent.ProposalId, msgVoteEvent.Voter)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting previous vote: %v", err)
+		}
+		mutProposal.TotalVoteCount += 1
+	} else if err := mutProposal.VoteCounts.Add(previousVote.Answer, -1); err != nil {
+		return fmt.Errorf("error removing previous vote from vote counts: %v", err)
+	}
+	if err := mutProposal.VoteCounts.Add(msgVoteEvent.Option, 1); err != nil {
+		return fmt.Errorf("error adding vote to vote counts: %v", err)
+	}
+	if err := proposalsView.Update(mutProposal); err != nil {
+		return fmt.Errorf("error updating proposal: %v", err)
+	}
+
+	if err := votesView.Upsert(&view.VoteRow{
+		ProposalId:        msgVoteEvent.ProposalId,
+		VoterAddress:      msgVoteEvent.Voter,
+		Answer:            msgVoteEvent.Option,
+		TransactionHash:   msgVoteEvent.MsgTxHash,
+		VoteAtBlockHeight: blockHeight,
+		VoteAtBlockTime:   blockTime,
+	}); err != nil {
+		return fmt.Errorf("error upserting vote: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *Proposal) projectProposalResults(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	events []event_entity.Event,
+) error {
+	proposalsView := view.NewProposals(rdbTxHandle)
+
+	for _, event := range events {
+		var proposalId string
+		var result string
+		if proposalEndedEvent, ok := event.(*event_usecase.ProposalEnded); ok {
+			proposalId = proposalEndedEvent.ProposalId
+			result = proposalEndedEvent.Result
+		} else if proposalInactivedEvent, ok := event.(*event_usecase.ProposalInactived); ok {
+			proposalId = proposalInactivedEvent.ProposalId
+			result = proposalInactivedEvent.Result
+		} else {
+			continue
+		}
+
+		mutProposal, err := proposalsView.FindBy(proposalId)
+		if err != nil {
+			return fmt.Errorf("error getting existing proposal `%s` from view: %v", proposalId, err)
+		}
+
+		switch result {
+		case RESULT_PASSED:
+			mutProposal.Status = constants.PASSED
+		case RESULT_REJECTED:
+			mutProposal.Status = constants.REJECTED
+		case RESULT_FAILED:
+			mutProposal.Status = constants.FAILED
+		case RESULT_DROPPED:
+			mutProposal.Status = constants.INACTIVE
+		default:
+			return fmt.Errorf("unrecognized proposal result: %s", result)
+		}
+		endBlockHeight := blockHeight
+		mutProposal.MaybeEndBlockHeight = &endBlockHeight
+
+		if err := proposalsView.Update(mutProposal); err != nil {
+			return fmt.Errorf("error updating proposal result: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// govParams are nil when the genesis has not been projected
+type govParams struct {
+	maybeMaxDepositPeriod *time.Duration
+	maybeMinDeposit       *big.Int
+	maybeVotingPeriod     *time.Duration
+}
+
+func (projection *Proposal) getGovParams(paramsView *view.Params) (*govParams, error) {
+	var params govParams
+
+	maxDepositPeriod, err := paramsView.FindBy(view.PARAM_MAX_DEPOSIT_PERIOD)
+	if err == nil {
+		duration, parseErr := time.ParseDuration(maxDepositPeriod)
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing max deposit period: %v", parseErr)
+		}
+		params.maybeMaxDepositPeriod = &duration
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, fmt.Errorf("error getting max deposit period: %v", err)
+	}
+
+	minDeposit, err := paramsView.FindBy(view.PARAM_MIN_DEPOSIT)
+	if err == nil {
+		amount, ok := new(big.Int).SetString(minDeposit, 10)
+		if !ok {
+			return nil, fmt.Errorf("error parsing min deposit: %s", minDeposit)
+		}
+		params.maybeMinDeposit = amount
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, fmt.Errorf("error getting min deposit: %v", err)
+	}
+
+	votingPeriod, err := paramsView.FindBy(view.PARAM_VOTING_PERIOD)
+	if err == nil {
+		duration, parseErr := time.ParseDuration(votingPeriod)
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing voting period: %v", parseErr)
+		}
+		params.maybeVotingPeriod = &duration
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, fmt.Errorf("error getting voting period: %v", err)
+	}
+
+	return &params, nil
+}
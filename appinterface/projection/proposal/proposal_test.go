@@ -0,0 +1,238 @@
+package proposal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal/constants"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("Proposal", func() {
+	var conn rdb.Conn
+	var projection *proposal.Proposal
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = proposal.NewProposal(NewFakeLogger(), conn, "basetcro")
+	})
+
+	genesisEvents := func() []event_entity.Event {
+		return []event_entity.Event{NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+			anyGenesis.AppState.Gov.DepositParams.MaxDepositPeriod = "100s"
+			anyGenesis.AppState.Gov.DepositParams.MinDeposit = []genesis.MinDeposit{
+				{Denom: "basetcro", Amount: "1000"},
+				{Denom: "othercoin", Amount: "5000"},
+			}
+			anyGenesis.AppState.Gov.VotingParams.VotingPeriod = "50s"
+		})}
+	}
+
+	submitTextProposal := func(height int64, proposalId string, initialDeposit int64) event_entity.Event {
+		return event_usecase.NewMsgSubmitTextProposal(
+			NewMsgCommonParams(height, "submit-tx"),
+			usecase_model.MsgSubmitTextProposalParams{
+				MaybeProposalId: primptr.String(proposalId),
+				Content: usecase_model.MsgSubmitTextProposalContent{
+					Type:        "/cosmos.gov.v1beta1.TextProposal",
+					Title:       "Title",
+					Description: "Description",
+				},
+				ProposerAddress: "tcro1proposer",
+				InitialDeposit:  coin.MustNewCoinFromInt(initialDeposit),
+			},
+		)
+	}
+
+	deposit := func(height int64, proposalId string, depositor string, amount int64) event_entity.Event {
+		return event_usecase.NewMsgDeposit(NewMsgCommonParams(height, "deposit-tx"), usecase_model.MsgDepositParams{
+			ProposalId: proposalId,
+			Depositor:  depositor,
+			Amount:     coin.MustNewCoinFromInt(amount),
+		})
+	}
+
+	vote := func(height int64, proposalId string, voter string, option string) event_entity.Event {
+		return event_usecase.NewMsgVote(NewMsgCommonParams(height, "vote-tx"), usecase_model.MsgVoteParams{
+			ProposalId: proposalId,
+			Voter:      voter,
+			Option:     option,
+		})
+	}
+
+	It("should implement projection", func() {
+		Expect(projection.GetEventsToListen()).To(ContainElement(event_usecase.MSG_VOTE_CREATED))
+	})
+
+	It("should keep the proposal in deposit period until the min deposit is reached", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), submitTextProposal(1, "1", 400),
+		})
+
+		proposalsView := view.NewProposals(conn.ToHandle())
+		actual, err := proposalsView.FindBy("1")
+		Expect(err).To(BeNil())
+		Expect(actual.Status).To(Equal(constants.DEPOSIT_PERIOD))
+		Expect(actual.Title).To(Equal("Title"))
+		Expect(actual.Type).To(Equal("/cosmos.gov.v1beta1.TextProposal"))
+		Expect(actual.TotalDeposit).To(Equal("400"))
+		Expect(actual.SubmitTime).To(Equal(BlockTimeAt(1)))
+		// One block per second and a max deposit period of 100s
+		Expect(*actual.MaybeDepositEndTime).To(Equal(BlockTimeAt(1 + 100)))
+		Expect(actual.MaybeVotingStartTime).To(BeNil())
+
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)), deposit(2, "1", "tcro1depositor", 600),
+		})
+
+		actual, err = proposalsView.FindBy("1")
+		Expect(err).To(BeNil())
+		Expect(actual.Status).To(Equal(constants.VOTING_PERIOD))
+		Expect(actual.TotalDeposit).To(Equal("1000"))
+		Expect(actual.MaybeVotingStartBlockHeight).To(Equal(primptr.Int64(2)))
+		Expect(*actual.MaybeVotingStartTime).To(Equal(BlockTimeAt(2)))
+		// One block per second and a voting period of 50s
+		Expect(*actual.MaybeVotingEndTime).To(Equal(BlockTimeAt(2 + 50)))
+
+		deposits, _, err := view.NewDeposits(conn.ToHandle()).List(view.DepositsListFilter{
+			MaybeProposalId: primptr.String("1"),
+		}, view.DepositsListOrder{}, pagination_interface.NewOffsetPagination(1, 10))
+		Expect(err).To(BeNil())
+		Expect(deposits).To(HaveLen(2))
+		Expect(deposits[0].DepositorAddress).To(Equal("tcro1proposer"))
+		Expect(deposits[0].Amount).To(Equal("400"))
+		Expect(deposits[1].DepositorAddress).To(Equal("tcro1depositor"))
+		Expect(deposits[1].Amount).To(Equal("600"))
+	})
+
+	It("should count the latest vote of each voter and record the final result", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), submitTextProposal(1, "1", 1000),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)),
+			vote(2, "1", "tcro1voterA", constants.VOTE_OPTION_YES),
+			vote(2, "1", "tcro1voterB", constants.VOTE_OPTION_NO),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, BlockTimeAt(3)),
+			vote(3, "1", "tcro1voterB", constants.VOTE_OPTION_YES),
+			vote(3, "1", "tcro1voterC", constants.VOTE_OPTION_ABSTAIN),
+		})
+
+		proposalsView := view.NewProposals(conn.ToHandle())
+		actual, err := proposalsView.FindBy("1")
+		Expect(err).To(BeNil())
+		Expect(actual.Status).To(Equal(constants.VOTING_PERIOD))
+		Expect(actual.TotalVoteCount).To(Equal(int64(3)))
+		Expect(actual.VoteCounts).To(Equal(view.ProposalVoteCounts{
+			Yes:        2,
+			No:         0,
+			NoWithVeto: 0,
+			Abstain:    1,
+		}))
+
+		votesView := view.NewVotes(conn.ToHandle())
+		voterB, err := votesView.FindBy("1", "tcro1voterB")
+		Expect(err).To(BeNil())
+		Expect(voterB.Answer).To(Equal(constants.VOTE_OPTION_YES))
+		Expect(voterB.VoteCount).To(Equal(int64(2)))
+		Expect(voterB.VoteAtBlockHeight).To(Equal(int64(3)))
+
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(4, BlockTimeAt(4)),
+			event_usecase.NewProposalEnded(4, "1", proposal.RESULT_PASSED),
+		})
+
+		actual, err = proposalsView.FindBy("1")
+		Expect(err).To(BeNil())
+		Expect(actual.Status).To(Equal(constants.PASSED))
+		Expect(actual.MaybeEndBlockHeight).To(Equal(primptr.Int64(4)))
+	})
+
+	It("should mark the proposal inactive when it is dropped", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)), submitTextProposal(1, "1", 1),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)),
+			event_usecase.NewProposalInactived(2, "1", proposal.RESULT_DROPPED),
+		})
+
+		actual, err := view.NewProposals(conn.ToHandle()).FindBy("1")
+		Expect(err).To(BeNil())
+		Expect(actual.Status).To(Equal(constants.INACTIVE))
+	})
+
+	It("should record the final results of the end block events of real blocks", func() {
+		txDecoder := parser.NewTxDecoder("basetcro")
+		events := append(
+			genesisEvents(),
+			NewBlockCreatedEvent(1, BlockTimeAt(1)),
+			submitTextProposal(1, "1", 1000),
+			submitTextProposal(1, "2", 1),
+		)
+		// Rejects proposal 1 at height 21575 and drops proposal 2 at height 21541
+		events = append(events, MustParseBlockResultsFixtureEvents(
+			txDecoder, usecase_parser_test.END_BLOCK_PROPOSAL_REJECTED_BLOCK_RESULTS_RESP,
+		)...)
+		events = append(events, MustParseBlockResultsFixtureEvents(
+			txDecoder, usecase_parser_test.END_BLOCK_PROPOSAL_INACTIVED_BLOCK_RESULTS_RESP,
+		)...)
+		MustReplayEvents(projection, events)
+
+		proposalsView := view.NewProposals(conn.ToHandle())
+		rejected, err := proposalsView.FindBy("1")
+		Expect(err).To(BeNil())
+		Expect(rejected.Status).To(Equal(constants.REJECTED))
+		Expect(rejected.MaybeEndBlockHeight).To(Equal(primptr.Int64(21575)))
+		dropped, err := proposalsView.FindBy("2")
+		Expect(err).To(BeNil())
+		Expect(dropped.Status).To(Equal(constants.INACTIVE))
+		Expect(dropped.MaybeEndBlockHeight).To(Equal(primptr.Int64(21541)))
+	})
+
+	It("should list proposals by status", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)),
+			submitTextProposal(1, "1", 1),
+			submitTextProposal(1, "2", 1000),
+		})
+
+		proposalsView := view.NewProposals(conn.ToHandle())
+		proposals, paginationResult, err := proposalsView.List(view.ProposalsListFilter{
+			MaybeStatus: primptr.String(constants.VOTING_PERIOD),
+		}, view.ProposalsListOrder{}, pagination_interface.NewOffsetPagination(1, 10))
+		Expect(err).To(BeNil())
+		Expect(proposals).To(HaveLen(1))
+		Expect(proposals[0].ProposalId).To(Equal("2"))
+		Expect(paginationResult.OffsetResult().TotalRecord).To(Equal(int64(1)))
+	})
+
+	It("should return error when voting on an unknown proposal", func() {
+		MustReplayEvents(projection, genesisEvents())
+
+		err := ReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)),
+			vote(1, "1", "tcro1voterA", constants.VOTE_OPTION_YES),
+		})
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,147 @@
+package view
+
+import (
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Deposits keeps every deposit made to the proposals, including the initial deposit on submission
+type Deposits struct {
+	rdb *rdb.Handle
+}
+
+func NewDeposits(handle *rdb.Handle) *Deposits {
+	return &Deposits{
+		handle,
+	}
+}
+
+func (depositsView *Deposits) Insert(deposit *DepositRow) error {
+	sql, sqlArgs, err := depositsView.rdb.StmtBuilder.Insert(
+		"view_proposal_deposits",
+	).Columns(
+		"proposal_id",
+		"depositor_address",
+		"amount",
+		"transaction_hash",
+		"deposit_at_block_height",
+		"deposit_at_block_time",
+	).Values(
+		deposit.ProposalId,
+		deposit.DepositorAddress,
+		deposit.Amount,
+		deposit.TransactionHash,
+		deposit.DepositAtBlockHeight,
+		depositsView.rdb.Tton(&deposit.DepositAtBlockTime),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building deposit insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := depositsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting deposit into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting deposit into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type DepositsListFilter struct {
+	MaybeProposalId       *string
+	MaybeDepositorAddress *string
+}
+
+type DepositsListOrder struct {
+	MaybeDepositAtBlockHeight *view.ORDER
+}
+
+func (depositsView *Deposits) List(
+	filter DepositsListFilter,
+	order DepositsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]DepositRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := depositsView.rdb.StmtBuilder.Select(
+		"proposal_id",
+		"depositor_address",
+		"amount",
+		"transaction_hash",
+		"deposit_at_block_height",
+		"deposit_at_block_time",
+	).From(
+		"view_proposal_deposits",
+	)
+
+	if order.MaybeDepositAtBlockHeight != nil && *order.MaybeDepositAtBlockHeight == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("id DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	}
+
+	if filter.MaybeProposalId != nil {
+		stmtBuilder = stmtBuilder.Where("proposal_id = ?", *filter.MaybeProposalId)
+	}
+	if filter.MaybeDepositorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("depositor_address = ?", *filter.MaybeDepositorAddress)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		depositsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building deposits select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := depositsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing deposits select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	deposits := make([]DepositRow, 0)
+	for rowsResult.Next() {
+		var deposit DepositRow
+		depositAtBlockTimeReader := depositsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&deposit.ProposalId,
+			&deposit.DepositorAddress,
+			&deposit.Amount,
+			&deposit.TransactionHash,
+			&deposit.DepositAtBlockHeight,
+			depositAtBlockTimeReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning deposit row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		depositAtBlockTime, parseErr := depositAtBlockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing deposit block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		deposit.DepositAtBlockTime = *depositAtBlockTime
+
+		deposits = append(deposits, deposit)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return deposits, paginationResult, nil
+}
+
+type DepositRow struct {
+	ProposalId           string          `json:"proposalId"`
+	DepositorAddress     string          `json:"depositorAddress"`
+	Amount               string          `json:"amount"`
+	TransactionHash      string          `json:"transactionHash"`
+	DepositAtBlockHeight int64           `json:"depositAtBlockHeight"`
+	DepositAtBlockTime   utctime.UTCTime `json:"depositAtBlockTime"`
+}
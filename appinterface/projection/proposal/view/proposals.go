@@ -0,0 +1,350 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal/constants"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type Proposals struct {
+	rdb *rdb.Handle
+}
+
+func NewProposals(handle *rdb.Handle) *Proposals {
+	return &Proposals{
+		handle,
+	}
+}
+
+func (proposalsView *Proposals) Insert(proposal *ProposalRow) error {
+	sql, sqlArgs, err := proposalsView.rdb.StmtBuilder.Insert(
+		"view_proposals",
+	).Columns(
+		"proposal_id",
+		"title",
+		"description",
+		"type",
+		"status",
+		"proposer_address",
+		"data",
+		"initial_deposit",
+		"total_deposit",
+		"total_vote",
+		"yes_count",
+		"no_count",
+		"no_with_veto_count",
+		"abstain_count",
+		"transaction_hash",
+		"submit_block_height",
+		"submit_time",
+		"deposit_end_time",
+		"voting_start_block_height",
+		"voting_start_time",
+		"voting_end_time",
+		"end_block_height",
+	).Values(
+		proposal.ProposalId,
+		proposal.Title,
+		proposal.Description,
+		proposal.Type,
+		proposal.Status,
+		proposal.ProposerAddress,
+		proposal.Data,
+		proposal.InitialDeposit,
+		proposal.TotalDeposit,
+		proposal.TotalVoteCount,
+		proposal.VoteCounts.Yes,
+		proposal.VoteCounts.No,
+		proposal.VoteCounts.NoWithVeto,
+		proposal.VoteCounts.Abstain,
+		proposal.TransactionHash,
+		proposal.SubmitBlockHeight,
+		proposalsView.rdb.Tton(&proposal.SubmitTime),
+		proposalsView.maybeTton(proposal.MaybeDepositEndTime),
+		proposal.MaybeVotingStartBlockHeight,
+		proposalsView.maybeTton(proposal.MaybeVotingStartTime),
+		proposalsView.maybeTton(proposal.MaybeVotingEndTime),
+		proposal.MaybeEndBlockHeight,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building proposal insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := proposalsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting proposal into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting proposal into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// Update updates the mutable states of the proposal, including its status, deposit, vote counts and
+// periods
+func (proposalsView *Proposals) Update(proposal *ProposalRow) error {
+	sql, sqlArgs, err := proposalsView.rdb.StmtBuilder.Update(
+		"view_proposals",
+	).SetMap(map[string]interface{}{
+		"status":                    proposal.Status,
+		"total_deposit":             proposal.TotalDeposit,
+		"total_vote":                proposal.TotalVoteCount,
+		"yes_count":                 proposal.VoteCounts.Yes,
+		"no_count":                  proposal.VoteCounts.No,
+		"no_with_veto_count":        proposal.VoteCounts.NoWithVeto,
+		"abstain_count":             proposal.VoteCounts.Abstain,
+		"deposit_end_time":          proposalsView.maybeTton(proposal.MaybeDepositEndTime),
+		"voting_start_block_height": proposal.MaybeVotingStartBlockHeight,
+		"voting_start_time":         proposalsView.maybeTton(proposal.MaybeVotingStartTime),
+		"voting_end_time":           proposalsView.maybeTton(proposal.MaybeVotingEndTime),
+		"end_block_height":          proposal.MaybeEndBlockHeight,
+	}).Where(
+		"proposal_id = ?", proposal.ProposalId,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building proposal update sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := proposalsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error updating proposal into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error updating proposal: no rows updated: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (proposalsView *Proposals) FindBy(proposalId string) (*ProposalRow, error) {
+	sql, sqlArgs, err := proposalsView.selectStmtBuilder().Where(
+		"proposal_id = ?", proposalId,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building proposal selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	proposal, err := proposalsView.scanRow(proposalsView.rdb.QueryRow(sql, sqlArgs...))
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+type ProposalsListFilter struct {
+	MaybeStatus          *constants.Status
+	MaybeProposerAddress *string
+}
+
+type ProposalsListOrder struct {
+	MaybeSubmitBlockHeight *view.ORDER
+}
+
+func (proposalsView *Proposals) List(
+	filter ProposalsListFilter,
+	order ProposalsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]ProposalRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := proposalsView.selectStmtBuilder()
+
+	if order.MaybeSubmitBlockHeight != nil && *order.MaybeSubmitBlockHeight == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("id DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	}
+
+	if filter.MaybeStatus != nil {
+		stmtBuilder = stmtBuilder.Where("status = ?", *filter.MaybeStatus)
+	}
+	if filter.MaybeProposerAddress != nil {
+		stmtBuilder = stmtBuilder.Where("proposer_address = ?", *filter.MaybeProposerAddress)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		proposalsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building proposals select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := proposalsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing proposals select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	proposals := make([]ProposalRow, 0)
+	for rowsResult.Next() {
+		proposal, scanErr := proposalsView.scanRow(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		proposals = append(proposals, *proposal)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return proposals, paginationResult, nil
+}
+
+func (proposalsView *Proposals) selectStmtBuilder() sq.SelectBuilder {
+	return proposalsView.rdb.StmtBuilder.Select(
+		"proposal_id",
+		"title",
+		"description",
+		"type",
+		"status",
+		"proposer_address",
+		"data",
+		"initial_deposit",
+		"total_deposit",
+		"total_vote",
+		"yes_count",
+		"no_count",
+		"no_with_veto_count",
+		"abstain_count",
+		"transaction_hash",
+		"submit_block_height",
+		"submit_time",
+		"deposit_end_time",
+		"voting_start_block_height",
+		"voting_start_time",
+		"voting_end_time",
+		"end_block_height",
+	).From(
+		"view_proposals",
+	)
+}
+
+type scannable interface {
+	Scan(dest ...interface{}) error
+}
+
+func (proposalsView *Proposals) scanRow(row scannable) (*ProposalRow, error) {
+	var proposal ProposalRow
+	submitTimeReader := proposalsView.rdb.NtotReader()
+	depositEndTimeReader := proposalsView.rdb.NtotReader()
+	votingStartTimeReader := proposalsView.rdb.NtotReader()
+	votingEndTimeReader := proposalsView.rdb.NtotReader()
+	if err := row.Scan(
+		&proposal.ProposalId,
+		&proposal.Title,
+		&proposal.Description,
+		&proposal.Type,
+		&proposal.Status,
+		&proposal.ProposerAddress,
+		&proposal.Data,
+		&proposal.InitialDeposit,
+		&proposal.TotalDeposit,
+		&proposal.TotalVoteCount,
+		&proposal.VoteCounts.Yes,
+		&proposal.VoteCounts.No,
+		&proposal.VoteCounts.NoWithVeto,
+		&proposal.VoteCounts.Abstain,
+		&proposal.TransactionHash,
+		&proposal.SubmitBlockHeight,
+		submitTimeReader.ScannableArg(),
+		depositEndTimeReader.ScannableArg(),
+		&proposal.MaybeVotingStartBlockHeight,
+		votingStartTimeReader.ScannableArg(),
+		votingEndTimeReader.ScannableArg(),
+		&proposal.MaybeEndBlockHeight,
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning proposal row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	submitTime, err := submitTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing proposal submit time: %v: %w", err, rdb.ErrQuery)
+	}
+	proposal.SubmitTime = *submitTime
+	if proposal.MaybeDepositEndTime, err = depositEndTimeReader.Parse(); err != nil {
+		return nil, fmt.Errorf("error parsing proposal deposit end time: %v: %w", err, rdb.ErrQuery)
+	}
+	if proposal.MaybeVotingStartTime, err = votingStartTimeReader.Parse(); err != nil {
+		return nil, fmt.Errorf("error parsing proposal voting start time: %v: %w", err, rdb.ErrQuery)
+	}
+	if proposal.MaybeVotingEndTime, err = votingEndTimeReader.Parse(); err != nil {
+		return nil, fmt.Errorf("error parsing proposal voting end time: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &proposal, nil
+}
+
+func (proposalsView *Proposals) maybeTton(maybeTime *utctime.UTCTime) interface{} {
+	if maybeTime == nil {
+		return nil
+	}
+	return proposalsView.rdb.Tton(maybeTime)
+}
+
+type ProposalRow struct {
+	ProposalId      string           `json:"id"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	Type            string           `json:"type"`
+	Status          constants.Status `json:"status"`
+	ProposerAddress string           `json:"proposerAddress"`
+	// JSON encoded proposal content
+	Data           string             `json:"data"`
+	InitialDeposit string             `json:"initialDeposit"`
+	TotalDeposit   string             `json:"totalDeposit"`
+	TotalVoteCount int64              `json:"totalVoteCount"`
+	VoteCounts     ProposalVoteCounts `json:"voteCounts"`
+
+	TransactionHash             string           `json:"transactionHash"`
+	SubmitBlockHeight           int64            `json:"submitBlockHeight"`
+	SubmitTime                  utctime.UTCTime  `json:"submitTime"`
+	MaybeDepositEndTime         *utctime.UTCTime `json:"depositEndTime"`
+	MaybeVotingStartBlockHeight *int64           `json:"votingStartBlockHeight"`
+	MaybeVotingStartTime        *utctime.UTCTime `json:"votingStartTime"`
+	MaybeVotingEndTime          *utctime.UTCTime `json:"votingEndTime"`
+	MaybeEndBlockHeight         *int64           `json:"endBlockHeight"`
+}
+
+// ProposalVoteCounts counts the latest vote of each voter by option. It is not a tally: the voting power of
+// the voters is not taken into account.
+type ProposalVoteCounts struct {
+	Yes        int64 `json:"yes"`
+	No         int64 `json:"no"`
+	NoWithVeto int64 `json:"noWithVeto"`
+	Abstain    int64 `json:"abstain"`
+}
+
+// Add adds delta to the count of the vote option
+func (voteCounts *ProposalVoteCounts) Add(option constants.VoteOption, delta int64) error {
+	switch option {
+	case constants.VOTE_OPTION_YES:
+		voteCounts.Yes += delta
+	case constants.VOTE_OPTION_NO:
+		voteCounts.No += delta
+	case constants.VOTE_OPTION_NO_WITH_VETO:
+		voteCounts.NoWithVeto += delta
+	case constants.VOTE_OPTION_ABSTAIN:
+		voteCounts.Abstain += delta
+	default:
+		return fmt.Errorf("unrecognized vote option: %s", option)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal/constants"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Votes keeps the latest vote of each voter on each proposal
+type Votes struct {
+	rdb *rdb.Handle
+}
+
+func NewVotes(handle *rdb.Handle) *Votes {
+	return &Votes{
+		handle,
+	}
+}
+
+// Upsert inserts the vote, or replaces the previous vote of the voter on the proposal and increments
+// its vote count
+func (votesView *Votes) Upsert(vote *VoteRow) error {
+	sql, sqlArgs, err := votesView.rdb.StmtBuilder.Insert(
+		"view_proposal_votes",
+	).Columns(
+		"proposal_id",
+		"voter_address",
+		"answer",
+		"vote_count",
+		"transaction_hash",
+		"vote_at_block_height",
+		"vote_at_block_time",
+	).Values(
+		vote.ProposalId,
+		vote.VoterAddress,
+		vote.Answer,
+		1,
+		vote.TransactionHash,
+		vote.VoteAtBlockHeight,
+		votesView.rdb.Tton(&vote.VoteAtBlockTime),
+	).Suffix(`ON CONFLICT (proposal_id, voter_address) DO UPDATE SET
+		answer = EXCLUDED.answer,
+		vote_count = view_proposal_votes.vote_count + 1,
+		transaction_hash = EXCLUDED.transaction_hash,
+		vote_at_block_height = EXCLUDED.vote_at_block_height,
+		vote_at_block_time = EXCLUDED.vote_at_block_time
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building vote upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := votesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting vote into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting vote into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (votesView *Votes) FindBy(proposalId string, voterAddress string) (*VoteRow, error) {
+	sql, sqlArgs, err := votesView.rdb.StmtBuilder.Select(
+		"proposal_id",
+		"voter_address",
+		"answer",
+		"vote_count",
+		"transaction_hash",
+		"vote_at_block_height",
+		"vote_at_block_time",
+	).From(
+		"view_proposal_votes",
+	).Where(
+		"proposal_id = ? AND voter_address = ?", proposalId, voterAddress,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building vote selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var vote VoteRow
+	voteAtBlockTimeReader := votesView.rdb.NtotReader()
+	if err = votesView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&vote.ProposalId,
+		&vote.VoterAddress,
+		&vote.Answer,
+		&vote.VoteCount,
+		&vote.TransactionHash,
+		&vote.VoteAtBlockHeight,
+		voteAtBlockTimeReader.ScannableArg(),
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning vote row: %v: %w", err, rdb.ErrQuery)
+	}
+	voteAtBlockTime, err := voteAtBlockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing vote block time: %v: %w", err, rdb.ErrQuery)
+	}
+	vote.VoteAtBlockTime = *voteAtBlockTime
+
+	return &vote, nil
+}
+
+type VotesListFilter struct {
+	MaybeProposalId   *string
+	MaybeVoterAddress *string
+}
+
+type VotesListOrder struct {
+	MaybeVoteAtBlockHeight *view.ORDER
+}
+
+func (votesView *Votes) List(
+	filter VotesListFilter,
+	order VotesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]VoteRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := votesView.rdb.StmtBuilder.Select(
+		"proposal_id",
+		"voter_address",
+		"answer",
+		"vote_count",
+		"transaction_hash",
+		"vote_at_block_height",
+		"vote_at_block_time",
+	).From(
+		"view_proposal_votes",
+	)
+
+	if order.MaybeVoteAtBlockHeight == nil {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	} else if *order.MaybeVoteAtBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("vote_at_block_height", "id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("vote_at_block_height DESC", "id DESC")
+	}
+
+	if filter.MaybeProposalId != nil {
+		stmtBuilder = stmtBuilder.Where("proposal_id = ?", *filter.MaybeProposalId)
+	}
+	if filter.MaybeVoterAddress != nil {
+		stmtBuilder = stmtBuilder.Where("voter_address = ?", *filter.MaybeVoterAddress)
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		votesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building votes select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := votesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing votes select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	votes := make([]VoteRow, 0)
+	for rowsResult.Next() {
+		var vote VoteRow
+		voteAtBlockTimeReader := votesView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&vote.ProposalId,
+			&vote.VoterAddress,
+			&vote.Answer,
+			&vote.VoteCount,
+			&vote.TransactionHash,
+			&vote.VoteAtBlockHeight,
+			voteAtBlockTimeReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning vote row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		voteAtBlockTime, parseErr := voteAtBlockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing vote block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		vote.VoteAtBlockTime = *voteAtBlockTime
+
+		votes = append(votes, vote)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return votes, paginationResult, nil
+}
+
+type VoteRow struct {
+	ProposalId   string               `json:"proposalId"`
+	VoterAddress string               `json:"voterAddress"`
+	Answer       constants.VoteOption `json:"answer"`
+	// Number of times the voter has voted on the proposal
+	VoteCount         int64           `json:"voteCount"`
+	TransactionHash   string          `json:"transactionHash"`
+	VoteAtBlockHeight int64           `json:"voteAtBlockHeight"`
+	VoteAtBlockTime   utctime.UTCTime `json:"voteAtBlockTime"`
+}
@@ -0,0 +1,50 @@
+package proposerstats
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Expected proposals and proposer rewards are decimals with the same precision as the Cosmos SDK `sdk.Dec`
+const DECIMAL_PRECISION = 18
+
+// Decimal places of the proposing performance
+const PERFORMANCE_PRECISION = 6
+
+func parseDecimal(decimal string) (*big.Rat, error) {
+	if decimal == "" {
+		return new(big.Rat), nil
+	}
+
+	value, ok := new(big.Rat).SetString(decimal)
+	if !ok {
+		return nil, fmt.Errorf("error parsing decimal: %s", decimal)
+	}
+	return value, nil
+}
+
+// addDecimal returns the sum of the stored decimal string and the value, formatted for storage
+func addDecimal(stored string, value *big.Rat) (string, error) {
+	storedValue, err := parseDecimal(stored)
+	if err != nil {
+		return "", err
+	}
+	return storedValue.Add(storedValue, value).FloatString(DECIMAL_PRECISION), nil
+}
+
+// Performance returns the ratio of the blocks proposed to the proposals expected. Returns nil when no
+// proposal is expected.
+func Performance(proposedBlocks int64, expectedProposals string) (*string, error) {
+	expected, err := parseDecimal(expectedProposals)
+	if err != nil {
+		return nil, err
+	}
+	if expected.Sign() == 0 {
+		return nil, nil
+	}
+
+	performance := new(big.Rat).Quo(new(big.Rat).SetInt64(proposedBlocks), expected).FloatString(
+		PERFORMANCE_PRECISION,
+	)
+	return &performance, nil
+}
@@ -0,0 +1,435 @@
+package proposerstats
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposerstats/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &ProposerStats{}
+
+var BUCKET_INTERVALS = []view.ProposerBucketInterval{
+	view.PROPOSER_BUCKET_INTERVAL_HOUR,
+	view.PROPOSER_BUCKET_INTERVAL_DAY,
+}
+
+// Validator updates returned at the end of block H apply to the validator set of block H+2, which the
+// proposer of block H+2 is selected from
+const VALIDATOR_SET_UPDATE_DELAY = int64(2)
+
+// ProposerStats counts the blocks proposed by each validator by the hour and by the day, and compares
+// them against the proposals expected from the voting power. Tendermint selects the proposers in
+// proportion to their voting power, so every block adds the power share of each validator in the
+// validator set to its expected proposals.
+//
+// Powers are known from the PowerChanged events, except for the genesis validators whose powers are seeded
+// from their gentx self-delegations. Validators not created by MsgCreateValidator, e.g. from an exported
+// genesis, are kept without an operator address and their blocks are not counted until it is seen.
+// Proposer rewards are recorded at the block they are distributed in,
+// which is the block after the rewarded one.
+type ProposerStats struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+}
+
+func NewProposerStats(logger applogger.Logger, rdbConn rdb.Conn) *ProposerStats {
+	return &ProposerStats{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "ProposerStats"),
+
+		rdbConn,
+		logger,
+	}
+}
+
+func (_ *ProposerStats) GetEventsToListen() []string {
+	return []string{
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_CREATE_VALIDATOR_CREATED,
+		event_usecase.BLOCK_PROPOSER_REWARDED,
+		event_usecase.POWER_CHANGED,
+	}
+}
+
+func (projection *ProposerStats) OnInit() error {
+	return nil
+}
+
+// proposerStats is the proposing statistics of a validator within a block
+type proposerStats struct {
+	proposedBlocks    int64
+	expectedProposals *big.Rat
+	proposerRewards   *big.Rat
+}
+
+func (projection *ProposerStats) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+	validatorsView := view.NewProposerValidators(rdbTxHandle)
+
+	// MsgCreateValidator should be handled first
+	for _, event := range events {
+		if msgCreateValidatorEvent, ok := event.(*event_usecase.MsgCreateValidator); ok {
+			projection.logger.Debug("handling MsgCreateValidator event")
+			if err := projection.handleCreateValidator(validatorsView, height, msgCreateValidatorEvent); err != nil {
+				return fmt.Errorf("error handling MsgCreateValidator: %v", err)
+			}
+		}
+	}
+
+	statsByValidator := make(map[string]*proposerStats)
+	statsOf := func(operatorAddress string) *proposerStats {
+		if _, ok := statsByValidator[operatorAddress]; !ok {
+			statsByValidator[operatorAddress] = &proposerStats{
+				expectedProposals: new(big.Rat),
+				proposerRewards:   new(big.Rat),
+			}
+		}
+		return statsByValidator[operatorAddress]
+	}
+
+	var maybeBlockTime *utctime.UTCTime
+	var maybeProposer *string
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+
+			if err := projection.expectProposals(validatorsView, height, statsOf); err != nil {
+				return fmt.Errorf("error calculating expected proposals: %v", err)
+			}
+
+			proposer, findErr := findOrInsertValidator(
+				validatorsView, strings.ToUpper(blockCreatedEvent.Block.ProposerAddress), height,
+			)
+			if findErr != nil {
+				return fmt.Errorf(
+					"error getting block proposer %s: %v", blockCreatedEvent.Block.ProposerAddress, findErr,
+				)
+			}
+			if proposer.OperatorAddress == "" {
+				projection.logger.Debugf(
+					"skipping proposed block of validator %s without known operator address", proposer.TendermintAddress,
+				)
+			} else {
+				maybeProposer = &proposer.OperatorAddress
+				statsOf(proposer.OperatorAddress).proposedBlocks += 1
+			}
+		} else if proposerRewardedEvent, ok := event.(*event_usecase.BlockProposerRewarded); ok {
+			amount, parseErr := parseDecimal(proposerRewardedEvent.Amount)
+			if parseErr != nil {
+				return fmt.Errorf("error parsing BlockProposerRewarded amount: %v", parseErr)
+			}
+			proposerRewards := statsOf(proposerRewardedEvent.Validator).proposerRewards
+			proposerRewards.Add(proposerRewards, amount)
+		}
+	}
+
+	if len(statsByValidator) > 0 {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling proposer stats: missing BlockCreated event at height %d", height)
+		}
+		if err := projection.recordStats(
+			rdbTxHandle, height, *maybeBlockTime, maybeProposer, statsByValidator,
+		); err != nil {
+			return fmt.Errorf("error recording proposer stats: %v", err)
+		}
+	}
+
+	for _, event := range events {
+		if powerChangedEvent, ok := event.(*event_usecase.PowerChanged); ok {
+			projection.logger.Debug("handling PowerChanged event")
+			if err := projection.handlePowerChanged(validatorsView, height, powerChangedEvent); err != nil {
+				return fmt.Errorf("error handling PowerChanged: %v", err)
+			}
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *ProposerStats) handleCreateValidator(
+	validatorsView *view.ProposerValidators,
+	blockHeight int64,
+	msgCreateValidatorEvent *event_usecase.MsgCreateValidator,
+) error {
+	tendermintAddress, err := tendermintAddressOf(msgCreateValidatorEvent.TendermintPubkey)
+	if err != nil {
+		return err
+	}
+
+	mutValidatorRow, err := validatorsView.FindBy(tendermintAddress)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting existing proposer validator: %v", err)
+		}
+		mutValidatorRow = &view.ProposerValidatorRow{
+			TendermintAddress:         tendermintAddress,
+			PowerChangedAtBlockHeight: blockHeight,
+		}
+
+		// Genesis validators are in the validator set of the first block with their self-delegation
+		if blockHeight == 0 {
+			power, powerErr := tmcosmosutils.PowerFromTokens(msgCreateValidatorEvent.Amount)
+			if powerErr != nil {
+				return fmt.Errorf("error converting self-delegation to power: %v", powerErr)
+			}
+			mutValidatorRow.Power = power
+			mutValidatorRow.PreviousPower = power
+		}
+	}
+	mutValidatorRow.OperatorAddress = msgCreateValidatorEvent.ValidatorAddress
+
+	if err := validatorsView.Upsert(mutValidatorRow); err != nil {
+		return fmt.Errorf("error upserting proposer validator: %v", err)
+	}
+	return nil
+}
+
+func (projection *ProposerStats) handlePowerChanged(
+	validatorsView *view.ProposerValidators,
+	blockHeight int64,
+	powerChangedEvent *event_usecase.PowerChanged,
+) error {
+	tendermintAddress, err := tendermintAddressOf(powerChangedEvent.TendermintPubkey)
+	if err != nil {
+		return err
+	}
+	power, err := strconv.ParseInt(powerChangedEvent.Power, 10, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing power: %v", err)
+	}
+
+	mutValidatorRow, err := findOrInsertValidator(validatorsView, tendermintAddress, blockHeight)
+	if err != nil {
+		return fmt.Errorf("error getting existing proposer validator %s: %v", tendermintAddress, err)
+	}
+	mutValidatorRow.PreviousPower = effectivePower(mutValidatorRow, blockHeight)
+	mutValidatorRow.Power = power
+	mutValidatorRow.PowerChangedAtBlockHeight = blockHeight
+
+	if err := validatorsView.Upsert(mutValidatorRow); err != nil {
+		return fmt.Errorf("error upserting proposer validator: %v", err)
+	}
+	return nil
+}
+
+// expectProposals adds the power share of each validator in the validator set of the block to its
+// expected proposals
+func (projection *ProposerStats) expectProposals(
+	validatorsView *view.ProposerValidators,
+	blockHeight int64,
+	statsOf func(operatorAddress string) *proposerStats,
+) error {
+	validators, err := validatorsView.ListAll()
+	if err != nil {
+		return fmt.Errorf("error listing proposer validators: %v", err)
+	}
+
+	totalPower := new(big.Int)
+	for i := range validators {
+		totalPower.Add(totalPower, big.NewInt(effectivePower(&validators[i], blockHeight)))
+	}
+	if totalPower.Sign() == 0 {
+		projection.logger.Debug("skipping expected proposals of validator set without known power")
+		return nil
+	}
+
+	for i := range validators {
+		power := effectivePower(&validators[i], blockHeight)
+		// Validators without known operator address still count towards the total power
+		if power == 0 || validators[i].OperatorAddress == "" {
+			continue
+		}
+		expectedProposals := statsOf(validators[i].OperatorAddress).expectedProposals
+		expectedProposals.Add(expectedProposals, new(big.Rat).SetFrac(big.NewInt(power), totalPower))
+	}
+
+	return nil
+}
+
+func (projection *ProposerStats) recordStats(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	maybeProposer *string,
+	statsByValidator map[string]*proposerStats,
+) error {
+	bucketsView := view.NewProposerBuckets(rdbTxHandle)
+	totalsView := view.NewProposerTotals(rdbTxHandle)
+
+	if maybeProposer != nil {
+		if err := view.NewProposedBlocks(rdbTxHandle).Insert(&view.ProposedBlockRow{
+			OperatorAddress: *maybeProposer,
+			BlockHeight:     blockHeight,
+			BlockTime:       blockTime,
+		}); err != nil {
+			return fmt.Errorf("error inserting proposed block: %v", err)
+		}
+	}
+
+	// Sorted for deterministic write order
+	operatorAddresses := make([]string, 0, len(statsByValidator))
+	for operatorAddress := range statsByValidator {
+		operatorAddresses = append(operatorAddresses, operatorAddress)
+	}
+	sort.Strings(operatorAddresses)
+	for _, operatorAddress := range operatorAddresses {
+		stats := statsByValidator[operatorAddress]
+		for _, interval := range BUCKET_INTERVALS {
+			if err := addToBucket(bucketsView, blockTime, interval, operatorAddress, stats); err != nil {
+				return fmt.Errorf("error adding to proposer bucket: %v", err)
+			}
+		}
+		if err := addToTotal(totalsView, blockHeight, operatorAddress, stats); err != nil {
+			return fmt.Errorf("error adding to proposer total: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func addToBucket(
+	bucketsView *view.ProposerBuckets,
+	blockTime utctime.UTCTime,
+	interval view.ProposerBucketInterval,
+	operatorAddress string,
+	stats *proposerStats,
+) error {
+	bucketStart := view.ProposerBucketStart(blockTime, interval)
+	mutBucket, err := bucketsView.FindBy(view.ProposerBucketIdentity{
+		OperatorAddress: operatorAddress,
+		Interval:        interval,
+		BucketStart:     bucketStart,
+	})
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting existing proposer bucket: %v", err)
+		}
+		mutBucket = &view.ProposerBucketRow{
+			OperatorAddress: operatorAddress,
+			Interval:        interval,
+			BucketStart:     bucketStart,
+		}
+	}
+
+	mutBucket.ProposedBlocks += stats.proposedBlocks
+	if mutBucket.ExpectedProposals, err = addDecimal(mutBucket.ExpectedProposals, stats.expectedProposals); err != nil {
+		return fmt.Errorf("error adding bucket expected proposals: %v", err)
+	}
+	if mutBucket.ProposerRewards, err = addDecimal(mutBucket.ProposerRewards, stats.proposerRewards); err != nil {
+		return fmt.Errorf("error adding bucket proposer rewards: %v", err)
+	}
+
+	if err := bucketsView.Upsert(mutBucket); err != nil {
+		return fmt.Errorf("error upserting proposer bucket: %v", err)
+	}
+	return nil
+}
+
+func addToTotal(
+	totalsView *view.ProposerTotals,
+	blockHeight int64,
+	operatorAddress string,
+	stats *proposerStats,
+) error {
+	mutTotal, err := totalsView.FindBy(operatorAddress)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting existing proposer total: %v", err)
+		}
+		mutTotal = &view.ProposerTotalRow{
+			OperatorAddress: operatorAddress,
+		}
+	}
+
+	mutTotal.ProposedBlocks += stats.proposedBlocks
+	if stats.proposedBlocks > 0 {
+		mutTotal.MaybeLastProposedBlockHeight = &blockHeight
+	}
+	if mutTotal.ExpectedProposals, err = addDecimal(mutTotal.ExpectedProposals, stats.expectedProposals); err != nil {
+		return fmt.Errorf("error adding total expected proposals: %v", err)
+	}
+	if mutTotal.ProposerRewards, err = addDecimal(mutTotal.ProposerRewards, stats.proposerRewards); err != nil {
+		return fmt.Errorf("error adding total proposer rewards: %v", err)
+	}
+
+	if err := totalsView.Upsert(mutTotal); err != nil {
+		return fmt.Errorf("error upserting proposer total: %v", err)
+	}
+	return nil
+}
+
+// findOrInsertValidator returns the validator, inserting it without operator address and power when it is
+// not created by MsgCreateValidator, e.g. from the genesis validator set of an exported genesis. The
+// operator address is filled in when seen.
+func findOrInsertValidator(
+	validatorsView *view.ProposerValidators,
+	tendermintAddress string,
+	blockHeight int64,
+) (*view.ProposerValidatorRow, error) {
+	validator, err := validatorsView.FindBy(tendermintAddress)
+	if err == nil {
+		return validator, nil
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return nil, err
+	}
+
+	validator = &view.ProposerValidatorRow{
+		TendermintAddress:         tendermintAddress,
+		PowerChangedAtBlockHeight: blockHeight,
+	}
+	if err := validatorsView.Upsert(validator); err != nil {
+		return nil, fmt.Errorf("error inserting proposer validator: %v", err)
+	}
+	return validator, nil
+}
+
+// effectivePower returns the power of the validator in the validator set of the block
+func effectivePower(validator *view.ProposerValidatorRow, blockHeight int64) int64 {
+	if blockHeight >= validator.PowerChangedAtBlockHeight+VALIDATOR_SET_UPDATE_DELAY {
+		return validator.Power
+	}
+	return validator.PreviousPower
+}
+
+func tendermintAddressOf(tendermintPubKey string) (string, error) {
+	pubKey, err := base64.StdEncoding.DecodeString(tendermintPubKey)
+	if err != nil {
+		return "", fmt.Errorf("error base64 decoding Tendermint node pubkey: %v", err)
+	}
+	return strings.ToUpper(tmcosmosutils.TmAddressFromTmPubKey(pubKey)), nil
+}
@@ -0,0 +1,13 @@
+package proposerstats_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestProposerStats(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ProposerStats Suite")
+}
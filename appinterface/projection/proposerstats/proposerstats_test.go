@@ -0,0 +1,152 @@
+package proposerstats_test
+
+import (
+	"encoding/base64"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposerstats"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposerstats/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+)
+
+var _ = Describe("ProposerStats", func() {
+	const validatorA = "tcrocncl1fmprm0sjy6lz9llv7rltn0v2azzwcwzvr4ufus"
+	const validatorB = "tcrocncl1j7pej8kplem4wt50p4hfvndhuw5jprxxn5625q"
+
+	var conn rdb.Conn
+	var projection *proposerstats.ProposerStats
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = proposerstats.NewProposerStats(NewFakeLogger(), conn)
+	})
+
+	pubKeyOf := func(b byte) string {
+		pubKey := make([]byte, 32)
+		pubKey[0] = b
+		return base64.StdEncoding.EncodeToString(pubKey)
+	}
+	tendermintAddressOf := func(b byte) string {
+		pubKey, _ := base64.StdEncoding.DecodeString(pubKeyOf(b))
+		return tmcosmosutils.TmAddressFromTmPubKey(pubKey)
+	}
+	msgCreateValidator := func(height int64, b byte, operatorAddress string, amount int64) event_entity.Event {
+		return event_usecase.NewMsgCreateValidator(
+			NewMsgCommonParams(height, "create-validator-tx"),
+			usecase_model.MsgCreateValidatorParams{
+				ValidatorAddress: operatorAddress,
+				TendermintPubkey: pubKeyOf(b),
+				Amount:           coin.MustNewCoinFromInt(amount),
+			},
+		)
+	}
+	blockCreated := func(height int64, hour int, proposer byte) event_entity.Event {
+		blockTime := TimeAt(time.Duration(hour) * time.Hour)
+		blockCreated := NewBlockCreatedEvent(height, blockTime).(*event_usecase.BlockCreated)
+		blockCreated.Block.ProposerAddress = tendermintAddressOf(proposer)
+		return blockCreated
+	}
+	powerChanged := func(height int64, b byte, power string) event_entity.Event {
+		return event_usecase.NewPowerChanged(height, usecase_model.PowerChangeParams{
+			TendermintPubkey: pubKeyOf(b),
+			Power:            power,
+		})
+	}
+
+	It("should compare the blocks proposed against the power share after the validator set update", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			msgCreateValidator(0, 1, validatorA, 0),
+			msgCreateValidator(0, 2, validatorB, 0),
+			blockCreated(1, 0, 1),
+			powerChanged(1, 1, "30"),
+			powerChanged(1, 2, "10"),
+			blockCreated(2, 0, 1),
+			blockCreated(3, 1, 2),
+			event_usecase.NewProposerRewarded(3, validatorA, "10.5"),
+			blockCreated(4, 1, 1),
+		})
+
+		totalsView := view.NewProposerTotals(conn.ToHandle())
+		totalA, err := totalsView.FindBy(validatorA)
+		Expect(err).To(BeNil())
+		Expect(totalA.ProposedBlocks).To(Equal(int64(3)))
+		Expect(totalA.ExpectedProposals).To(Equal("1.500000000000000000"))
+		Expect(totalA.ProposerRewards).To(Equal("10.500000000000000000"))
+		Expect(totalA.MaybeLastProposedBlockHeight).To(Equal(primptr.Int64(4)))
+		totalB, err := totalsView.FindBy(validatorB)
+		Expect(err).To(BeNil())
+		Expect(totalB.ProposedBlocks).To(Equal(int64(1)))
+		Expect(totalB.ExpectedProposals).To(Equal("0.500000000000000000"))
+		Expect(proposerstats.Performance(totalB.ProposedBlocks, totalB.ExpectedProposals)).To(
+			Equal(primptr.String("2.000000")),
+		)
+
+		buckets, _, err := view.NewProposerBuckets(conn.ToHandle()).ListByBucket(
+			view.PROPOSER_BUCKET_INTERVAL_HOUR, TimeAt(time.Hour), pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(buckets).To(HaveLen(2))
+		Expect(buckets[0].OperatorAddress).To(Equal(validatorA))
+		Expect(buckets[0].ProposedBlocks).To(Equal(int64(1)))
+		Expect(buckets[1].ProposedBlocks).To(Equal(int64(1)))
+		Expect(buckets[1].ExpectedProposals).To(Equal("0.500000000000000000"))
+
+		blocks, _, err := view.NewProposedBlocks(conn.ToHandle()).ListByOperatorAddress(
+			validatorB, view.ProposedBlocksListOrder{}, pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(blocks).To(HaveLen(1))
+		Expect(blocks[0].BlockHeight).To(Equal(int64(3)))
+	})
+
+	It("should expect proposals of the genesis validators from their self-delegations", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			msgCreateValidator(0, 1, validatorA, 30000000),
+			msgCreateValidator(0, 2, validatorB, 10000000),
+			blockCreated(1, 0, 1),
+			blockCreated(2, 0, 2),
+		})
+
+		totalsView := view.NewProposerTotals(conn.ToHandle())
+		totalA, err := totalsView.FindBy(validatorA)
+		Expect(err).To(BeNil())
+		Expect(totalA.ProposedBlocks).To(Equal(int64(1)))
+		Expect(totalA.ExpectedProposals).To(Equal("1.500000000000000000"))
+		totalB, err := totalsView.FindBy(validatorB)
+		Expect(err).To(BeNil())
+		Expect(totalB.ProposedBlocks).To(Equal(int64(1)))
+		Expect(totalB.ExpectedProposals).To(Equal("0.500000000000000000"))
+	})
+
+	It("should keep validators not created by MsgCreateValidator until their operator address is seen", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			blockCreated(1, 0, 1),
+			powerChanged(1, 1, "30"),
+			powerChanged(1, 2, "10"),
+			blockCreated(2, 0, 1),
+			msgCreateValidator(2, 2, validatorB, 10000000),
+			blockCreated(3, 1, 2),
+			blockCreated(4, 1, 1),
+		})
+
+		totalsView := view.NewProposerTotals(conn.ToHandle())
+		totalB, err := totalsView.FindBy(validatorB)
+		Expect(err).To(BeNil())
+		Expect(totalB.ProposedBlocks).To(Equal(int64(1)))
+		Expect(totalB.ExpectedProposals).To(Equal("0.500000000000000000"))
+		_, err = totalsView.FindBy(validatorA)
+		Expect(err).To(Equal(rdb.ErrNoRows))
+	})
+})
@@ -0,0 +1,251 @@
+package view
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type ProposerBucketInterval = string
+
+const (
+	PROPOSER_BUCKET_INTERVAL_HOUR ProposerBucketInterval = "hour"
+	PROPOSER_BUCKET_INTERVAL_DAY  ProposerBucketInterval = "day"
+)
+
+func IsValidProposerBucketInterval(interval string) bool {
+	return interval == PROPOSER_BUCKET_INTERVAL_HOUR || interval == PROPOSER_BUCKET_INTERVAL_DAY
+}
+
+// ProposerBucketStart returns the start of the UTC hour or day bucket the time falls into
+func ProposerBucketStart(t utctime.UTCTime, interval ProposerBucketInterval) utctime.UTCTime {
+	goTime := time.Unix(0, t.UnixNano()).UTC()
+	if interval == PROPOSER_BUCKET_INTERVAL_HOUR {
+		return utctime.FromTime(goTime.Truncate(time.Hour))
+	}
+
+	year, month, day := goTime.Date()
+	return utctime.FromTime(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// ProposerBuckets keeps the blocks proposed, the proposals expected and the proposer rewards of each
+// validator summed by the hour and by the day in UTC
+type ProposerBuckets struct {
+	rdb *rdb.Handle
+}
+
+func NewProposerBuckets(handle *rdb.Handle) *ProposerBuckets {
+	return &ProposerBuckets{
+		handle,
+	}
+}
+
+func (bucketsView *ProposerBuckets) Upsert(bucket *ProposerBucketRow) error {
+	sql, sqlArgs, err := bucketsView.rdb.StmtBuilder.Insert(
+		"view_proposer_stats_buckets",
+	).Columns(
+		"operator_address",
+		"bucket_interval",
+		"bucket_start",
+		"proposed_blocks",
+		"expected_proposals",
+		"proposer_rewards",
+	).Values(
+		bucket.OperatorAddress,
+		bucket.Interval,
+		bucketsView.rdb.Tton(&bucket.BucketStart),
+		bucket.ProposedBlocks,
+		bucket.ExpectedProposals,
+		bucket.ProposerRewards,
+	).Suffix(`ON CONFLICT (operator_address, bucket_interval, bucket_start) DO UPDATE SET
+		proposed_blocks = EXCLUDED.proposed_blocks,
+		expected_proposals = EXCLUDED.expected_proposals,
+		proposer_rewards = EXCLUDED.proposer_rewards
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building proposer bucket upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := bucketsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting proposer bucket into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting proposer bucket into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type ProposerBucketIdentity struct {
+	OperatorAddress string
+	Interval        ProposerBucketInterval
+	BucketStart     utctime.UTCTime
+}
+
+// FindBy returns rdb.ErrNoRows when the bucket does not exist
+func (bucketsView *ProposerBuckets) FindBy(identity ProposerBucketIdentity) (*ProposerBucketRow, error) {
+	sql, sqlArgs, err := bucketsView.selectStmtBuilder().Where(
+		"operator_address = ? AND bucket_interval = ? AND bucket_start = ?",
+		identity.OperatorAddress,
+		identity.Interval,
+		bucketsView.rdb.Tton(&identity.BucketStart),
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building proposer bucket selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := bucketsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing proposer bucket selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return bucketsView.scan(rowsResult)
+}
+
+type ProposerBucketsListFilter struct {
+	OperatorAddress string
+	Interval        ProposerBucketInterval
+	// Inclusive
+	MaybeFrom *utctime.UTCTime
+	// Exclusive
+	MaybeTo *utctime.UTCTime
+}
+
+// Defaults to the earliest first
+type ProposerBucketsListOrder struct {
+	MaybeBucketStart *view.ORDER
+}
+
+// List returns the buckets of the validator
+func (bucketsView *ProposerBuckets) List(
+	filter ProposerBucketsListFilter,
+	order ProposerBucketsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]ProposerBucketRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := bucketsView.selectStmtBuilder().Where(
+		"operator_address = ? AND bucket_interval = ?", filter.OperatorAddress, filter.Interval,
+	)
+	if filter.MaybeFrom != nil {
+		stmtBuilder = stmtBuilder.Where("bucket_start >= ?", bucketsView.rdb.Tton(filter.MaybeFrom))
+	}
+	if filter.MaybeTo != nil {
+		stmtBuilder = stmtBuilder.Where("bucket_start < ?", bucketsView.rdb.Tton(filter.MaybeTo))
+	}
+
+	if order.MaybeBucketStart != nil && *order.MaybeBucketStart == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("bucket_start DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("bucket_start")
+	}
+
+	return bucketsView.list(stmtBuilder, pagination)
+}
+
+// ListByBucket returns the buckets of all validators starting at the bucket start, the most proposed
+// blocks first
+func (bucketsView *ProposerBuckets) ListByBucket(
+	interval ProposerBucketInterval,
+	bucketStart utctime.UTCTime,
+	pagination *pagination_interface.Pagination,
+) ([]ProposerBucketRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := bucketsView.selectStmtBuilder().Where(
+		"bucket_interval = ? AND bucket_start = ?", interval, bucketsView.rdb.Tton(&bucketStart),
+	).OrderBy(
+		"proposed_blocks DESC", "operator_address",
+	)
+
+	return bucketsView.list(stmtBuilder, pagination)
+}
+
+func (bucketsView *ProposerBuckets) list(
+	stmtBuilder sq.SelectBuilder,
+	pagination *pagination_interface.Pagination,
+) ([]ProposerBucketRow, *pagination_interface.PaginationResult, error) {
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		bucketsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building proposer buckets select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := bucketsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing proposer buckets select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	buckets := make([]ProposerBucketRow, 0)
+	for rowsResult.Next() {
+		bucket, scanErr := bucketsView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		buckets = append(buckets, *bucket)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return buckets, paginationResult, nil
+}
+
+func (bucketsView *ProposerBuckets) selectStmtBuilder() sq.SelectBuilder {
+	return bucketsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"bucket_interval",
+		"bucket_start",
+		"proposed_blocks",
+		"expected_proposals",
+		"proposer_rewards",
+	).From(
+		"view_proposer_stats_buckets",
+	)
+}
+
+func (bucketsView *ProposerBuckets) scan(rowsResult rdb.RowsResult) (*ProposerBucketRow, error) {
+	var bucket ProposerBucketRow
+	bucketStartReader := bucketsView.rdb.NtotReader()
+	if err := rowsResult.Scan(
+		&bucket.OperatorAddress,
+		&bucket.Interval,
+		bucketStartReader.ScannableArg(),
+		&bucket.ProposedBlocks,
+		&bucket.ExpectedProposals,
+		&bucket.ProposerRewards,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning proposer bucket row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	bucketStart, err := bucketStartReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing proposer bucket start: %v: %w", err, rdb.ErrQuery)
+	}
+	bucket.BucketStart = *bucketStart
+
+	return &bucket, nil
+}
+
+// ProposerBucketRow expected proposals and proposer rewards are decimal strings, the rewards in base denom
+type ProposerBucketRow struct {
+	OperatorAddress   string                 `json:"operatorAddress"`
+	Interval          ProposerBucketInterval `json:"interval"`
+	BucketStart       utctime.UTCTime        `json:"bucketStart"`
+	ProposedBlocks    int64                  `json:"proposedBlocks"`
+	ExpectedProposals string                 `json:"expectedProposals"`
+	ProposerRewards   string                 `json:"proposerRewards"`
+}
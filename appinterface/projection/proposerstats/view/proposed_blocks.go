@@ -0,0 +1,122 @@
+package view
+
+import (
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// ProposedBlocks keeps the blocks proposed by each validator
+type ProposedBlocks struct {
+	rdb *rdb.Handle
+}
+
+func NewProposedBlocks(handle *rdb.Handle) *ProposedBlocks {
+	return &ProposedBlocks{
+		handle,
+	}
+}
+
+func (blocksView *ProposedBlocks) Insert(block *ProposedBlockRow) error {
+	sql, sqlArgs, err := blocksView.rdb.StmtBuilder.Insert(
+		"view_proposer_stats_proposed_blocks",
+	).Columns(
+		"operator_address",
+		"block_height",
+		"block_time",
+	).Values(
+		block.OperatorAddress,
+		block.BlockHeight,
+		blocksView.rdb.Tton(&block.BlockTime),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building proposed block insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := blocksView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting proposed block into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting proposed block into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// Defaults to the latest block first
+type ProposedBlocksListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (blocksView *ProposedBlocks) ListByOperatorAddress(
+	operatorAddress string,
+	order ProposedBlocksListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]ProposedBlockRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := blocksView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"block_height",
+		"block_time",
+	).From(
+		"view_proposer_stats_proposed_blocks",
+	).Where(
+		"operator_address = ?", operatorAddress,
+	)
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		blocksView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building proposed blocks select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := blocksView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing proposed blocks select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	blocks := make([]ProposedBlockRow, 0)
+	for rowsResult.Next() {
+		var block ProposedBlockRow
+		blockTimeReader := blocksView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&block.OperatorAddress,
+			&block.BlockHeight,
+			blockTimeReader.ScannableArg(),
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning proposed block row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing proposed block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		block.BlockTime = *blockTime
+
+		blocks = append(blocks, block)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return blocks, paginationResult, nil
+}
+
+type ProposedBlockRow struct {
+	OperatorAddress string          `json:"operatorAddress"`
+	BlockHeight     int64           `json:"blockHeight"`
+	BlockTime       utctime.UTCTime `json:"blockTime"`
+}
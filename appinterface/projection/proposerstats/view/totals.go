@@ -0,0 +1,164 @@
+package view
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// ProposerTotals keeps the lifetime blocks proposed, proposals expected and proposer rewards of each
+// validator
+type ProposerTotals struct {
+	rdb *rdb.Handle
+}
+
+func NewProposerTotals(handle *rdb.Handle) *ProposerTotals {
+	return &ProposerTotals{
+		handle,
+	}
+}
+
+func (totalsView *ProposerTotals) Upsert(total *ProposerTotalRow) error {
+	sql, sqlArgs, err := totalsView.rdb.StmtBuilder.Insert(
+		"view_proposer_stats_totals",
+	).Columns(
+		"operator_address",
+		"proposed_blocks",
+		"expected_proposals",
+		"proposer_rewards",
+		"last_proposed_block_height",
+	).Values(
+		total.OperatorAddress,
+		total.ProposedBlocks,
+		total.ExpectedProposals,
+		total.ProposerRewards,
+		total.MaybeLastProposedBlockHeight,
+	).Suffix(`ON CONFLICT (operator_address) DO UPDATE SET
+		proposed_blocks = EXCLUDED.proposed_blocks,
+		expected_proposals = EXCLUDED.expected_proposals,
+		proposer_rewards = EXCLUDED.proposer_rewards,
+		last_proposed_block_height = EXCLUDED.last_proposed_block_height
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building proposer total upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := totalsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting proposer total into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting proposer total into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when the validator has no proposer statistics
+func (totalsView *ProposerTotals) FindBy(operatorAddress string) (*ProposerTotalRow, error) {
+	sql, sqlArgs, err := totalsView.selectStmtBuilder().Where(
+		"operator_address = ?", operatorAddress,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building proposer total selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := totalsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing proposer total selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return totalsView.scan(rowsResult)
+}
+
+// Defaults to the most proposed blocks first
+type ProposerTotalsListOrder struct {
+	MaybeProposedBlocks *view.ORDER
+}
+
+func (totalsView *ProposerTotals) List(
+	order ProposerTotalsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]ProposerTotalRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := totalsView.selectStmtBuilder()
+	if order.MaybeProposedBlocks != nil && *order.MaybeProposedBlocks == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("proposed_blocks", "operator_address")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("proposed_blocks DESC", "operator_address")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		totalsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building proposer totals select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := totalsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing proposer totals select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	totals := make([]ProposerTotalRow, 0)
+	for rowsResult.Next() {
+		total, scanErr := totalsView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		totals = append(totals, *total)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return totals, paginationResult, nil
+}
+
+func (totalsView *ProposerTotals) selectStmtBuilder() sq.SelectBuilder {
+	return totalsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"proposed_blocks",
+		"expected_proposals",
+		"proposer_rewards",
+		"last_proposed_block_height",
+	).From(
+		"view_proposer_stats_totals",
+	)
+}
+
+func (totalsView *ProposerTotals) scan(rowsResult rdb.RowsResult) (*ProposerTotalRow, error) {
+	var total ProposerTotalRow
+	if err := rowsResult.Scan(
+		&total.OperatorAddress,
+		&total.ProposedBlocks,
+		&total.ExpectedProposals,
+		&total.ProposerRewards,
+		&total.MaybeLastProposedBlockHeight,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning proposer total row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &total, nil
+}
+
+// ProposerTotalRow expected proposals and proposer rewards are decimal strings, the rewards in base denom
+type ProposerTotalRow struct {
+	OperatorAddress              string `json:"operatorAddress"`
+	ProposedBlocks               int64  `json:"proposedBlocks"`
+	ExpectedProposals            string `json:"expectedProposals"`
+	ProposerRewards              string `json:"proposerRewards"`
+	MaybeLastProposedBlockHeight *int64 `json:"lastProposedBlockHeight"`
+}
@@ -0,0 +1,139 @@
+package view
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// ProposerValidators keeps the operator address and the voting power of each validator by its Tendermint
+// address, which is the address of the block proposer
+type ProposerValidators struct {
+	rdb *rdb.Handle
+}
+
+func NewProposerValidators(handle *rdb.Handle) *ProposerValidators {
+	return &ProposerValidators{
+		handle,
+	}
+}
+
+func (validatorsView *ProposerValidators) Upsert(validator *ProposerValidatorRow) error {
+	sql, sqlArgs, err := validatorsView.rdb.StmtBuilder.Insert(
+		"view_proposer_stats_validators",
+	).Columns(
+		"tendermint_address",
+		"operator_address",
+		"power",
+		"previous_power",
+		"power_changed_at_block_height",
+	).Values(
+		validator.TendermintAddress,
+		validator.OperatorAddress,
+		validator.Power,
+		validator.PreviousPower,
+		validator.PowerChangedAtBlockHeight,
+	).Suffix(`ON CONFLICT (tendermint_address) DO UPDATE SET
+		operator_address = EXCLUDED.operator_address,
+		power = EXCLUDED.power,
+		previous_power = EXCLUDED.previous_power,
+		power_changed_at_block_height = EXCLUDED.power_changed_at_block_height
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building proposer validator upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := validatorsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting proposer validator into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting proposer validator into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when the validator is not created yet
+func (validatorsView *ProposerValidators) FindBy(tendermintAddress string) (*ProposerValidatorRow, error) {
+	sql, sqlArgs, err := validatorsView.selectStmtBuilder().Where(
+		"tendermint_address = ?", tendermintAddress,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building proposer validator selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := validatorsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing proposer validator selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return validatorsView.scan(rowsResult)
+}
+
+func (validatorsView *ProposerValidators) ListAll() ([]ProposerValidatorRow, error) {
+	sql, sqlArgs, err := validatorsView.selectStmtBuilder().OrderBy("tendermint_address").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building proposer validators select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := validatorsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing proposer validators select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	validators := make([]ProposerValidatorRow, 0)
+	for rowsResult.Next() {
+		validator, scanErr := validatorsView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		validators = append(validators, *validator)
+	}
+
+	return validators, nil
+}
+
+func (validatorsView *ProposerValidators) selectStmtBuilder() sq.SelectBuilder {
+	return validatorsView.rdb.StmtBuilder.Select(
+		"tendermint_address",
+		"operator_address",
+		"power",
+		"previous_power",
+		"power_changed_at_block_height",
+	).From(
+		"view_proposer_stats_validators",
+	)
+}
+
+func (validatorsView *ProposerValidators) scan(rowsResult rdb.RowsResult) (*ProposerValidatorRow, error) {
+	var validator ProposerValidatorRow
+	if err := rowsResult.Scan(
+		&validator.TendermintAddress,
+		&validator.OperatorAddress,
+		&validator.Power,
+		&validator.PreviousPower,
+		&validator.PowerChangedAtBlockHeight,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning proposer validator row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &validator, nil
+}
+
+// ProposerValidatorRow keeps the power before the last change until the change applies to the
+// validator set
+type ProposerValidatorRow struct {
+	TendermintAddress         string
+	OperatorAddress           string
+	Power                     int64
+	PreviousPower             int64
+	PowerChangedAtBlockHeight int64
+}
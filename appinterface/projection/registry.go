@@ -0,0 +1,87 @@
+package projection
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/cosmosapp"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+// Registry keeps the mapping of projection id to its Initializer. Projections have to be registered
+// before they can be enabled in the config.
+type Registry struct {
+	initializers map[string]Initializer
+	ids          []string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		initializers: make(map[string]Initializer),
+		ids:          make([]string, 0),
+	}
+}
+
+// Register adds a mapping of projection id to Initializer to the registry. It will overwrite existing
+// registration if any.
+func (registry *Registry) Register(projectionId string, initializer Initializer) {
+	if !registry.IsRegistered(projectionId) {
+		registry.ids = append(registry.ids, projectionId)
+	}
+	registry.initializers[projectionId] = initializer
+}
+
+// IsRegistered returns true when the projection id is already registered
+func (registry *Registry) IsRegistered(projectionId string) bool {
+	_, exist := registry.initializers[projectionId]
+	return exist
+}
+
+// Ids returns all the registered projection ids in registration order
+func (registry *Registry) Ids() []string {
+	ids := make([]string, len(registry.ids))
+	copy(ids, registry.ids)
+	return ids
+}
+
+// InitProjections initializes the projections of the provided ids in order. Returns error when any of
+// the projection is not registered or fails to initialize.
+func (registry *Registry) InitProjections(
+	projectionIds []string,
+	params *InitParams,
+) ([]entity_projection.Projection, error) {
+	projections := make([]entity_projection.Projection, 0, len(projectionIds))
+	for _, projectionId := range projectionIds {
+		if !registry.IsRegistered(projectionId) {
+			return nil, fmt.Errorf("unrecognized projection `%s`", projectionId)
+		}
+
+		projection, err := registry.initializers[projectionId](params)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing projection `%s`: %v", projectionId, err)
+		}
+		if projection.Id() != projectionId {
+			return nil, fmt.Errorf(
+				"mismatched projection id: registered as `%s` but initialized as `%s`", projectionId, projection.Id(),
+			)
+		}
+
+		projections = append(projections, projection)
+	}
+
+	return projections, nil
+}
+
+// InitParams are the dependencies and system configurations available to projection Initializer
+type InitParams struct {
+	Logger          applogger.Logger
+	RdbConn         rdb.Conn
+	CosmosAppClient cosmosapp.Client
+
+	BaseDenom            string
+	AccountAddressPrefix string
+	ConNodeAddressPrefix string
+}
+
+type Initializer = func(params *InitParams) (entity_projection.Projection, error)
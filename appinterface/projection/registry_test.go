@@ -0,0 +1,66 @@
+package projection_test
+
+import (
+	. "github.com/crypto-com/chain-indexing/entity/projection/test"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/crypto-com/chain-indexing/appinterface/rdb/test"
+
+	projection_interface "github.com/crypto-com/chain-indexing/appinterface/projection"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
+)
+
+var _ = Describe("Registry", func() {
+	Describe("Register", func() {
+		It("should register a projection initializer with the id", func() {
+			registry := projection_interface.NewRegistry()
+
+			Expect(registry.IsRegistered("FakeProjection")).To(BeFalse())
+
+			registry.Register("FakeProjection", initFakeProjection)
+
+			Expect(registry.IsRegistered("FakeProjection")).To(BeTrue())
+			Expect(registry.Ids()).To(Equal([]string{"FakeProjection"}))
+		})
+	})
+
+	Describe("InitProjections", func() {
+		It("should return Error when the projection is not registered", func() {
+			registry := projection_interface.NewRegistry()
+
+			_, err := registry.InitProjections([]string{"FakeProjection"}, &projection_interface.InitParams{})
+			Expect(err).To(MatchError("unrecognized projection `FakeProjection`"))
+		})
+
+		It("should return Error when the initialized projection id does not match with the registered id", func() {
+			registry := projection_interface.NewRegistry()
+			registry.Register("AnyProjection", initFakeProjection)
+
+			_, err := registry.InitProjections([]string{"AnyProjection"}, &projection_interface.InitParams{})
+			Expect(err).To(MatchError(
+				"mismatched projection id: registered as `AnyProjection` but initialized as `FakeProjection`",
+			))
+		})
+
+		It("should initialize only the provided projections", func() {
+			registry := projection_interface.NewRegistry()
+			projection_interface.RegisterProjections(registry)
+			registry.Register("FakeProjection", initFakeProjection)
+
+			projections, err := registry.InitProjections([]string{"Block", "FakeProjection"}, &projection_interface.InitParams{
+				Logger:  NewFakeLogger(),
+				RdbConn: NewFakeRDbConn(),
+			})
+			Expect(err).To(BeNil())
+			Expect(projections).To(HaveLen(2))
+			Expect(projections[0].Id()).To(Equal("Block"))
+			Expect(projections[1].Id()).To(Equal("FakeProjection"))
+		})
+	})
+})
+
+func initFakeProjection(_ *projection_interface.InitParams) (entity_projection.Projection, error) {
+	return NewFakeProjection(), nil
+}
@@ -0,0 +1,33 @@
+package slashing
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/slashing/view"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Decimal places of the reliability score
+const RELIABILITY_PRECISION = 6
+
+// Reliability returns the share of time the validator was not jailed between joining and asOf, from 0 to
+// 1. An ongoing downtime counts up to asOf.
+func Reliability(validator *view.SlashingValidatorRow, asOf utctime.UTCTime) string {
+	lifetime := asOf.UnixNano() - validator.JoinedAtBlockTime.UnixNano()
+	if lifetime <= 0 {
+		return new(big.Rat).SetInt64(1).FloatString(RELIABILITY_PRECISION)
+	}
+
+	downtime := validator.TotalDowntimeSeconds * int64(time.Second)
+	if validator.MaybeJailedSinceBlockTime != nil {
+		if ongoing := asOf.UnixNano() - validator.MaybeJailedSinceBlockTime.UnixNano(); ongoing > 0 {
+			downtime += ongoing
+		}
+	}
+	if downtime > lifetime {
+		downtime = lifetime
+	}
+
+	return big.NewRat(lifetime-downtime, lifetime).FloatString(RELIABILITY_PRECISION)
+}
@@ -0,0 +1,363 @@
+package slashing
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/slashing/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	"github.com/crypto-com/chain-indexing/usecase/model"
+)
+
+var _ projection_entity.Projection = &Slashing{}
+
+// Slashing records the slashing incidents of the validators and pairs each jailing with the following
+// successful unjail into a downtime period. Double sign incidents are linked to the duplicate vote
+// evidence included in the same block.
+type Slashing struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+
+	conNodeAddressPrefix string
+}
+
+func NewSlashing(logger applogger.Logger, rdbConn rdb.Conn, conNodeAddressPrefix string) *Slashing {
+	return &Slashing{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "Slashing"),
+
+		rdbConn,
+		logger,
+
+		conNodeAddressPrefix,
+	}
+}
+
+func (_ *Slashing) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_CREATE_VALIDATOR_CREATED,
+		event_usecase.VALIDATOR_SLASHED,
+		event_usecase.VALIDATOR_JAILED,
+		event_usecase.MSG_UNJAIL_CREATED,
+	}
+}
+
+func (projection *Slashing) OnInit() error {
+	return nil
+}
+
+func (projection *Slashing) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var maybeBlockTime *utctime.UTCTime
+	evidences := make([]model.BlockEvidence, 0)
+	msgCreateValidatorEvents := make([]*event_usecase.MsgCreateValidator, 0)
+	validatorSlashedEvents := make([]*event_usecase.ValidatorSlashed, 0)
+	validatorJailedEvents := make([]*event_usecase.ValidatorJailed, 0)
+	msgUnjailEvents := make([]*event_usecase.MsgUnjail, 0)
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+			evidences = append(evidences, blockCreatedEvent.Block.Evidences...)
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			genesisTime, parseErr := utctime.Parse(time.RFC3339, genesisCreatedEvent.Genesis.GenesisTime)
+			if parseErr != nil {
+				return fmt.Errorf("error parsing genesis time: %v", parseErr)
+			}
+			maybeBlockTime = &genesisTime
+		} else if msgCreateValidatorEvent, ok := event.(*event_usecase.MsgCreateValidator); ok {
+			msgCreateValidatorEvents = append(msgCreateValidatorEvents, msgCreateValidatorEvent)
+		} else if validatorSlashedEvent, ok := event.(*event_usecase.ValidatorSlashed); ok {
+			validatorSlashedEvents = append(validatorSlashedEvents, validatorSlashedEvent)
+		} else if validatorJailedEvent, ok := event.(*event_usecase.ValidatorJailed); ok {
+			validatorJailedEvents = append(validatorJailedEvents, validatorJailedEvent)
+		} else if msgUnjailEvent, ok := event.(*event_usecase.MsgUnjail); ok {
+			msgUnjailEvents = append(msgUnjailEvents, msgUnjailEvent)
+		}
+	}
+
+	hasValidatorEvents := len(msgCreateValidatorEvents) > 0 || len(validatorSlashedEvents) > 0 ||
+		len(validatorJailedEvents) > 0 || len(msgUnjailEvents) > 0
+	if hasValidatorEvents {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling validator events: missing block time at height %d", height)
+		}
+		blockTime := *maybeBlockTime
+		validatorsView := view.NewSlashingValidators(rdbTxHandle)
+		downtimesView := view.NewSlashingDowntimes(rdbTxHandle)
+
+		for _, msgCreateValidatorEvent := range msgCreateValidatorEvents {
+			projection.logger.Debug("handling MsgCreateValidator event")
+			if err := projection.handleMsgCreateValidator(
+				validatorsView, height, blockTime, msgCreateValidatorEvent,
+			); err != nil {
+				return fmt.Errorf("error handling MsgCreateValidator: %v", err)
+			}
+		}
+
+		// Slashing and jailing happen at the beginning of the block, before the unjail transactions
+		jailedConsensusNodeAddresses := make(map[string]bool)
+		for _, validatorJailedEvent := range validatorJailedEvents {
+			jailedConsensusNodeAddresses[validatorJailedEvent.ConsensusNodeAddress] = true
+		}
+		incidentsView := view.NewSlashingIncidents(rdbTxHandle)
+		for _, validatorSlashedEvent := range validatorSlashedEvents {
+			projection.logger.Debug("handling ValidatorSlashed event")
+			if err := projection.handleValidatorSlashed(
+				validatorsView,
+				incidentsView,
+				height,
+				blockTime,
+				evidences,
+				jailedConsensusNodeAddresses[validatorSlashedEvent.ConsensusNodeAddress],
+				validatorSlashedEvent,
+			); err != nil {
+				return fmt.Errorf("error handling ValidatorSlashed: %v", err)
+			}
+		}
+		for _, validatorJailedEvent := range validatorJailedEvents {
+			projection.logger.Debug("handling ValidatorJailed event")
+			if err := projection.handleValidatorJailed(
+				validatorsView, downtimesView, height, blockTime, validatorJailedEvent,
+			); err != nil {
+				return fmt.Errorf("error handling ValidatorJailed: %v", err)
+			}
+		}
+
+		for _, msgUnjailEvent := range msgUnjailEvents {
+			projection.logger.Debug("handling MsgUnjail event")
+			if err := projection.handleMsgUnjail(
+				validatorsView, downtimesView, height, blockTime, msgUnjailEvent,
+			); err != nil {
+				return fmt.Errorf("error handling MsgUnjail: %v", err)
+			}
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *Slashing) handleMsgCreateValidator(
+	validatorsView *view.SlashingValidators,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	event *event_usecase.MsgCreateValidator,
+) error {
+	pubKey, err := base64.StdEncoding.DecodeString(event.TendermintPubkey)
+	if err != nil {
+		return fmt.Errorf("error base64 decoding Tendermint node pubkey: %v", err)
+	}
+	consensusNodeAddress, err := tmcosmosutils.ConsensusNodeAddressFromTmPubKey(
+		projection.conNodeAddressPrefix, pubKey,
+	)
+	if err != nil {
+		return fmt.Errorf("error converting Tendermint node pubkey to address: %v", err)
+	}
+
+	if err := validatorsView.Upsert(&view.SlashingValidatorRow{
+		ConsensusNodeAddress:      consensusNodeAddress,
+		OperatorAddress:           event.ValidatorAddress,
+		TendermintAddress:         strings.ToUpper(tmcosmosutils.TmAddressFromTmPubKey(pubKey)),
+		JoinedAtBlockHeight:       blockHeight,
+		JoinedAtBlockTime:         blockTime,
+		SlashCount:                0,
+		DoubleSignCount:           0,
+		JailCount:                 0,
+		TotalDowntimeSeconds:      0,
+		MaybeJailedSinceBlockTime: nil,
+	}); err != nil {
+		return fmt.Errorf("error upserting slashing validator: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *Slashing) handleValidatorSlashed(
+	validatorsView *view.SlashingValidators,
+	incidentsView *view.SlashingIncidents,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	evidences []model.BlockEvidence,
+	jailed bool,
+	event *event_usecase.ValidatorSlashed,
+) error {
+	mutValidator, err := validatorsView.FindBy(view.SlashingValidatorIdentity{
+		MaybeConsensusNodeAddress: &event.ConsensusNodeAddress,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting slashed validator %s: %v", event.ConsensusNodeAddress, err)
+	}
+
+	var maybeEvidence *view.SlashingEvidence
+	if event.Reason == view.REASON_DOUBLE_SIGN {
+		mutValidator.DoubleSignCount += 1
+		if maybeEvidence, err = findDoubleSignEvidence(evidences, mutValidator.TendermintAddress); err != nil {
+			return fmt.Errorf("error finding double sign evidence: %v", err)
+		}
+	}
+
+	if err := incidentsView.Insert(&view.SlashingIncidentRow{
+		OperatorAddress:      mutValidator.OperatorAddress,
+		ConsensusNodeAddress: event.ConsensusNodeAddress,
+		BlockHeight:          blockHeight,
+		BlockTime:            blockTime,
+		Reason:               event.Reason,
+		SlashedPower:         event.SlashedPower,
+		Jailed:               jailed,
+		MaybeEvidence:        maybeEvidence,
+	}); err != nil {
+		return fmt.Errorf("error inserting slashing incident: %v", err)
+	}
+
+	mutValidator.SlashCount += 1
+	if err := validatorsView.Upsert(mutValidator); err != nil {
+		return fmt.Errorf("error upserting slashing validator: %v", err)
+	}
+
+	return nil
+}
+
+// findDoubleSignEvidence returns the evidence of the conflicting votes signed by the validator, nil when
+// the block contains no evidence of the validator
+func findDoubleSignEvidence(
+	evidences []model.BlockEvidence,
+	tendermintAddress string,
+) (*view.SlashingEvidence, error) {
+	for _, evidence := range evidences {
+		if strings.ToUpper(evidence.Value.VoteA.ValidatorAddress) != tendermintAddress {
+			continue
+		}
+
+		height, err := strconv.ParseInt(evidence.Value.VoteA.Height, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing evidence height: %v", err)
+		}
+		return &view.SlashingEvidence{
+			Type:           evidence.Type,
+			Height:         height,
+			VoteABlockHash: evidence.Value.VoteA.BlockID.Hash,
+			VoteBBlockHash: evidence.Value.VoteB.BlockID.Hash,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// handleValidatorJailed starts a downtime period. A validator already jailed keeps its ongoing downtime.
+func (projection *Slashing) handleValidatorJailed(
+	validatorsView *view.SlashingValidators,
+	downtimesView *view.SlashingDowntimes,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	event *event_usecase.ValidatorJailed,
+) error {
+	mutValidator, err := validatorsView.FindBy(view.SlashingValidatorIdentity{
+		MaybeConsensusNodeAddress: &event.ConsensusNodeAddress,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting jailed validator %s: %v", event.ConsensusNodeAddress, err)
+	}
+	if mutValidator.MaybeJailedSinceBlockTime != nil {
+		projection.logger.Debug("skipping ValidatorJailed of already jailed validator")
+		return nil
+	}
+
+	if err := downtimesView.Insert(&view.SlashingDowntimeRow{
+		OperatorAddress:            mutValidator.OperatorAddress,
+		ConsensusNodeAddress:       event.ConsensusNodeAddress,
+		Reason:                     event.Reason,
+		JailedAtBlockHeight:        blockHeight,
+		JailedAtBlockTime:          blockTime,
+		MaybeUnjailedAtBlockHeight: nil,
+		MaybeUnjailedAtBlockTime:   nil,
+		MaybeUnjailTransactionHash: nil,
+		MaybeDurationSeconds:       nil,
+	}); err != nil {
+		return fmt.Errorf("error inserting slashing downtime: %v", err)
+	}
+
+	mutValidator.JailCount += 1
+	mutValidator.MaybeJailedSinceBlockTime = &blockTime
+	if err := validatorsView.Upsert(mutValidator); err != nil {
+		return fmt.Errorf("error upserting slashing validator: %v", err)
+	}
+
+	return nil
+}
+
+// handleMsgUnjail ends the ongoing downtime period of the validator
+func (projection *Slashing) handleMsgUnjail(
+	validatorsView *view.SlashingValidators,
+	downtimesView *view.SlashingDowntimes,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	event *event_usecase.MsgUnjail,
+) error {
+	mutValidator, err := validatorsView.FindBy(view.SlashingValidatorIdentity{
+		MaybeOperatorAddress: &event.ValidatorAddr,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting unjailed validator %s: %v", event.ValidatorAddr, err)
+	}
+
+	mutDowntime, err := downtimesView.FindOngoingBy(event.ValidatorAddr)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			projection.logger.Debug("skipping MsgUnjail without ongoing downtime")
+			return nil
+		}
+		return fmt.Errorf("error getting ongoing downtime: %v", err)
+	}
+
+	durationSeconds := (blockTime.UnixNano() - mutDowntime.JailedAtBlockTime.UnixNano()) / int64(time.Second)
+	txHash := event.TxHash()
+	mutDowntime.MaybeUnjailedAtBlockHeight = &blockHeight
+	mutDowntime.MaybeUnjailedAtBlockTime = &blockTime
+	mutDowntime.MaybeUnjailTransactionHash = &txHash
+	mutDowntime.MaybeDurationSeconds = &durationSeconds
+	if err := downtimesView.End(mutDowntime); err != nil {
+		return fmt.Errorf("error ending slashing downtime: %v", err)
+	}
+
+	mutValidator.TotalDowntimeSeconds += durationSeconds
+	mutValidator.MaybeJailedSinceBlockTime = nil
+	if err := validatorsView.Upsert(mutValidator); err != nil {
+		return fmt.Errorf("error upserting slashing validator: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package slashing_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSlashing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Slashing Suite")
+}
@@ -0,0 +1,186 @@
+package slashing_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/slashing"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/slashing/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+)
+
+var _ = Describe("Slashing", func() {
+	const conNodeAddressPrefix = "tcrocnclcons"
+	const operatorAddress = "tcrocncl1operator"
+
+	timeOf := func(hour int) utctime.UTCTime {
+		return TimeAt(time.Duration(hour) * time.Hour)
+	}
+
+	Describe("Reliability", func() {
+		It("should count the finished and the ongoing downtime", func() {
+			validator := &view.SlashingValidatorRow{
+				JoinedAtBlockTime:         timeOf(0),
+				TotalDowntimeSeconds:      3600,
+				MaybeJailedSinceBlockTime: primptr.UTCTime(timeOf(8)),
+			}
+
+			Expect(slashing.Reliability(validator, timeOf(10))).To(Equal("0.700000"))
+		})
+
+		It("should be fully reliable before any time elapsed", func() {
+			validator := &view.SlashingValidatorRow{
+				JoinedAtBlockTime: timeOf(10),
+			}
+
+			Expect(slashing.Reliability(validator, timeOf(10))).To(Equal("1.000000"))
+		})
+	})
+
+	Describe("projection", func() {
+		var conn rdb.Conn
+		var projection *slashing.Slashing
+		BeforeEach(func() {
+			conn = MustNewTestRDbConn()
+			projection = slashing.NewSlashing(NewFakeLogger(), conn, conNodeAddressPrefix)
+		})
+
+		pubKey := make([]byte, 32)
+		pubKey[0] = 1
+		consensusNodeAddress, _ := tmcosmosutils.ConsensusNodeAddressFromTmPubKey(conNodeAddressPrefix, pubKey)
+		tendermintAddress := tmcosmosutils.TmAddressFromTmPubKey(pubKey)
+
+		blockCreated := func(height int64, hour int, evidences ...usecase_model.BlockEvidence) event_entity.Event {
+			blockCreated := NewBlockCreatedEvent(height, timeOf(hour)).(*event_usecase.BlockCreated)
+			blockCreated.Block.Evidences = evidences
+			return blockCreated
+		}
+		msgCreateValidator := func(height int64) event_entity.Event {
+			return event_usecase.NewMsgCreateValidator(
+				NewMsgCommonParams(height, "create-validator-tx"),
+				usecase_model.MsgCreateValidatorParams{
+					ValidatorAddress: operatorAddress,
+					TendermintPubkey: base64.StdEncoding.EncodeToString(pubKey),
+				},
+			)
+		}
+		validatorSlashed := func(height int64, reason string) event_entity.Event {
+			return event_usecase.NewValidatorSlashed(height, usecase_model.SlashValidatorParams{
+				ConsensusNodeAddress: consensusNodeAddress,
+				SlashedPower:         "100",
+				Reason:               reason,
+			})
+		}
+		validatorJailed := func(height int64, reason string) event_entity.Event {
+			return event_usecase.NewValidatorJailed(height, consensusNodeAddress, reason)
+		}
+		msgUnjail := func(height int64) event_entity.Event {
+			return event_usecase.NewMsgUnjail(NewMsgCommonParams(height, "unjailtx"), usecase_model.MsgUnjailParams{
+				ValidatorAddr: operatorAddress,
+			})
+		}
+
+		It("should pair the jailing with the unjail into a downtime period", func() {
+			MustReplayEvents(projection, []event_entity.Event{
+				blockCreated(1, 0),
+				msgCreateValidator(1),
+				blockCreated(2, 2),
+				validatorSlashed(2, view.REASON_MISSING_SIGNATURE),
+				validatorJailed(2, view.REASON_MISSING_SIGNATURE),
+				blockCreated(3, 5),
+				msgUnjail(3),
+				blockCreated(4, 6),
+				validatorSlashed(4, view.REASON_MISSING_SIGNATURE),
+				validatorJailed(4, view.REASON_MISSING_SIGNATURE),
+			})
+
+			downtimes, _, err := view.NewSlashingDowntimes(conn.ToHandle()).ListByOperatorAddress(
+				operatorAddress,
+				view.SlashingDowntimesListOrder{MaybeJailedAtBlockHeight: primptr.String("ASC")},
+				pagination_interface.NewOffsetPagination(1, 10),
+			)
+			Expect(err).To(BeNil())
+			Expect(downtimes).To(HaveLen(2))
+			Expect(downtimes[0].JailedAtBlockHeight).To(Equal(int64(2)))
+			Expect(*downtimes[0].MaybeUnjailedAtBlockHeight).To(Equal(int64(3)))
+			Expect(*downtimes[0].MaybeUnjailTransactionHash).To(Equal("unjailtx"))
+			Expect(*downtimes[0].MaybeDurationSeconds).To(Equal(int64(3 * 3600)))
+			Expect(downtimes[1].JailedAtBlockHeight).To(Equal(int64(4)))
+			Expect(downtimes[1].MaybeUnjailedAtBlockHeight).To(BeNil())
+			Expect(downtimes[1].MaybeDurationSeconds).To(BeNil())
+
+			validator, err := view.NewSlashingValidators(conn.ToHandle()).FindBy(view.SlashingValidatorIdentity{
+				MaybeOperatorAddress: primptr.String(operatorAddress),
+			})
+			Expect(err).To(BeNil())
+			Expect(validator.SlashCount).To(Equal(int64(2)))
+			Expect(validator.JailCount).To(Equal(int64(2)))
+			Expect(validator.TotalDowntimeSeconds).To(Equal(int64(3 * 3600)))
+			Expect(*validator.MaybeJailedSinceBlockTime).To(Equal(timeOf(6)))
+			Expect(slashing.Reliability(validator, timeOf(10))).To(Equal("0.300000"))
+
+			incidents, _, err := view.NewSlashingIncidents(conn.ToHandle()).List(
+				view.SlashingIncidentsListFilter{},
+				view.SlashingIncidentsListOrder{},
+				pagination_interface.NewOffsetPagination(1, 10),
+			)
+			Expect(err).To(BeNil())
+			Expect(incidents).To(HaveLen(2))
+			Expect(incidents[0].BlockHeight).To(Equal(int64(4)))
+			Expect(incidents[0].OperatorAddress).To(Equal(operatorAddress))
+			Expect(incidents[0].Jailed).To(BeTrue())
+			Expect(incidents[0].MaybeEvidence).To(BeNil())
+		})
+
+		It("should link the double sign incident to the evidence of the block", func() {
+			var evidence usecase_model.BlockEvidence
+			evidence.Type = "tendermint/DuplicateVoteEvidence"
+			evidence.Value.VoteA.Height = "2"
+			evidence.Value.VoteA.ValidatorAddress = strings.ToLower(tendermintAddress)
+			evidence.Value.VoteA.BlockID.Hash = "HASHA"
+			evidence.Value.VoteB.BlockID.Hash = "HASHB"
+
+			MustReplayEvents(projection, []event_entity.Event{
+				blockCreated(1, 0),
+				msgCreateValidator(1),
+				blockCreated(3, 1, evidence),
+				validatorSlashed(3, view.REASON_DOUBLE_SIGN),
+				validatorJailed(3, view.REASON_DOUBLE_SIGN),
+			})
+
+			incidents, _, err := view.NewSlashingIncidents(conn.ToHandle()).List(
+				view.SlashingIncidentsListFilter{MaybeReason: primptr.String(view.REASON_DOUBLE_SIGN)},
+				view.SlashingIncidentsListOrder{},
+				pagination_interface.NewOffsetPagination(1, 10),
+			)
+			Expect(err).To(BeNil())
+			Expect(incidents).To(HaveLen(1))
+			Expect(*incidents[0].MaybeEvidence).To(Equal(view.SlashingEvidence{
+				Type:           "tendermint/DuplicateVoteEvidence",
+				Height:         2,
+				VoteABlockHash: "HASHA",
+				VoteBBlockHash: "HASHB",
+			}))
+
+			validator, err := view.NewSlashingValidators(conn.ToHandle()).FindBy(view.SlashingValidatorIdentity{
+				MaybeConsensusNodeAddress: primptr.String(consensusNodeAddress),
+			})
+			Expect(err).To(BeNil())
+			Expect(validator.DoubleSignCount).To(Equal(int64(1)))
+		})
+	})
+})
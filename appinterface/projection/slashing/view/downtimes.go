@@ -0,0 +1,226 @@
+package view
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// SlashingDowntimes keeps the downtime periods of the validators, from the jailing to the unjailing
+type SlashingDowntimes struct {
+	rdb *rdb.Handle
+}
+
+func NewSlashingDowntimes(handle *rdb.Handle) *SlashingDowntimes {
+	return &SlashingDowntimes{
+		handle,
+	}
+}
+
+func (downtimesView *SlashingDowntimes) Insert(downtime *SlashingDowntimeRow) error {
+	sql, sqlArgs, err := downtimesView.rdb.StmtBuilder.Insert(
+		"view_slashing_downtimes",
+	).Columns(
+		"operator_address",
+		"consensus_node_address",
+		"reason",
+		"jailed_at_block_height",
+		"jailed_at_block_time",
+		"unjailed_at_block_height",
+		"unjailed_at_block_time",
+		"unjail_transaction_hash",
+		"duration_seconds",
+	).Values(
+		downtime.OperatorAddress,
+		downtime.ConsensusNodeAddress,
+		downtime.Reason,
+		downtime.JailedAtBlockHeight,
+		downtimesView.rdb.Tton(&downtime.JailedAtBlockTime),
+		downtime.MaybeUnjailedAtBlockHeight,
+		downtimesView.rdb.Tton(downtime.MaybeUnjailedAtBlockTime),
+		downtime.MaybeUnjailTransactionHash,
+		downtime.MaybeDurationSeconds,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building slashing downtime insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := downtimesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting slashing downtime into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting slashing downtime into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindOngoingBy returns the downtime of the validator not yet ended by an unjail. Returns rdb.ErrNoRows
+// when the validator is not jailed.
+func (downtimesView *SlashingDowntimes) FindOngoingBy(operatorAddress string) (*SlashingDowntimeRow, error) {
+	sql, sqlArgs, err := downtimesView.selectStmtBuilder().Where(
+		"operator_address = ?", operatorAddress,
+	).Where(
+		"unjailed_at_block_height IS NULL",
+	).OrderBy(
+		"id DESC",
+	).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building slashing downtime selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := downtimesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing slashing downtime selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return downtimesView.scan(rowsResult)
+}
+
+// End records the unjailing of the downtime
+func (downtimesView *SlashingDowntimes) End(downtime *SlashingDowntimeRow) error {
+	sql, sqlArgs, err := downtimesView.rdb.StmtBuilder.Update(
+		"view_slashing_downtimes",
+	).SetMap(map[string]interface{}{
+		"unjailed_at_block_height": downtime.MaybeUnjailedAtBlockHeight,
+		"unjailed_at_block_time":   downtimesView.rdb.Tton(downtime.MaybeUnjailedAtBlockTime),
+		"unjail_transaction_hash":  downtime.MaybeUnjailTransactionHash,
+		"duration_seconds":         downtime.MaybeDurationSeconds,
+	}).Where(
+		"id = ?", downtime.Id,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building slashing downtime update sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := downtimesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error updating slashing downtime: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error updating slashing downtime: no rows updated: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// Defaults to the latest downtime first
+type SlashingDowntimesListOrder struct {
+	MaybeJailedAtBlockHeight *view.ORDER
+}
+
+func (downtimesView *SlashingDowntimes) ListByOperatorAddress(
+	operatorAddress string,
+	order SlashingDowntimesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]SlashingDowntimeRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := downtimesView.selectStmtBuilder().Where("operator_address = ?", operatorAddress)
+	if order.MaybeJailedAtBlockHeight != nil && *order.MaybeJailedAtBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("id DESC")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		downtimesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building slashing downtimes select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := downtimesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing slashing downtimes select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	downtimes := make([]SlashingDowntimeRow, 0)
+	for rowsResult.Next() {
+		downtime, scanErr := downtimesView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		downtimes = append(downtimes, *downtime)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return downtimes, paginationResult, nil
+}
+
+func (downtimesView *SlashingDowntimes) selectStmtBuilder() sq.SelectBuilder {
+	return downtimesView.rdb.StmtBuilder.Select(
+		"id",
+		"operator_address",
+		"consensus_node_address",
+		"reason",
+		"jailed_at_block_height",
+		"jailed_at_block_time",
+		"unjailed_at_block_height",
+		"unjailed_at_block_time",
+		"unjail_transaction_hash",
+		"duration_seconds",
+	).From(
+		"view_slashing_downtimes",
+	)
+}
+
+func (downtimesView *SlashingDowntimes) scan(rowsResult rdb.RowsResult) (*SlashingDowntimeRow, error) {
+	var downtime SlashingDowntimeRow
+	jailedAtBlockTimeReader := downtimesView.rdb.NtotReader()
+	unjailedAtBlockTimeReader := downtimesView.rdb.NtotReader()
+	if err := rowsResult.Scan(
+		&downtime.Id,
+		&downtime.OperatorAddress,
+		&downtime.ConsensusNodeAddress,
+		&downtime.Reason,
+		&downtime.JailedAtBlockHeight,
+		jailedAtBlockTimeReader.ScannableArg(),
+		&downtime.MaybeUnjailedAtBlockHeight,
+		unjailedAtBlockTimeReader.ScannableArg(),
+		&downtime.MaybeUnjailTransactionHash,
+		&downtime.MaybeDurationSeconds,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning slashing downtime row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	jailedAtBlockTime, err := jailedAtBlockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing slashing downtime jailed time: %v: %w", err, rdb.ErrQuery)
+	}
+	downtime.JailedAtBlockTime = *jailedAtBlockTime
+	if downtime.MaybeUnjailedAtBlockTime, err = unjailedAtBlockTimeReader.Parse(); err != nil {
+		return nil, fmt.Errorf("error parsing slashing downtime unjailed time: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &downtime, nil
+}
+
+// SlashingDowntimeRow unjailed fields and duration are nil while the validator is still jailed
+type SlashingDowntimeRow struct {
+	Id                         int64            `json:"-"`
+	OperatorAddress            string           `json:"operatorAddress"`
+	ConsensusNodeAddress       string           `json:"consensusNodeAddress"`
+	Reason                     string           `json:"reason"`
+	JailedAtBlockHeight        int64            `json:"jailedAtBlockHeight"`
+	JailedAtBlockTime          utctime.UTCTime  `json:"jailedAtBlockTime"`
+	MaybeUnjailedAtBlockHeight *int64           `json:"unjailedAtBlockHeight"`
+	MaybeUnjailedAtBlockTime   *utctime.UTCTime `json:"unjailedAtBlockTime"`
+	MaybeUnjailTransactionHash *string          `json:"unjailTransactionHash"`
+	MaybeDurationSeconds       *int64           `json:"durationSeconds"`
+}
@@ -0,0 +1,233 @@
+package view
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Slashing reasons emitted by the Cosmos SDK slashing module
+const (
+	REASON_DOUBLE_SIGN       = "double_sign"
+	REASON_MISSING_SIGNATURE = "missing_signature"
+)
+
+// SlashingIncidents keeps every slashing of the validators
+type SlashingIncidents struct {
+	rdb *rdb.Handle
+}
+
+func NewSlashingIncidents(handle *rdb.Handle) *SlashingIncidents {
+	return &SlashingIncidents{
+		handle,
+	}
+}
+
+func (incidentsView *SlashingIncidents) Insert(incident *SlashingIncidentRow) error {
+	var maybeEvidenceType *string
+	var maybeEvidenceHeight *int64
+	var maybeEvidenceVoteABlockHash *string
+	var maybeEvidenceVoteBBlockHash *string
+	if incident.MaybeEvidence != nil {
+		maybeEvidenceType = &incident.MaybeEvidence.Type
+		maybeEvidenceHeight = &incident.MaybeEvidence.Height
+		maybeEvidenceVoteABlockHash = &incident.MaybeEvidence.VoteABlockHash
+		maybeEvidenceVoteBBlockHash = &incident.MaybeEvidence.VoteBBlockHash
+	}
+
+	sql, sqlArgs, err := incidentsView.rdb.StmtBuilder.Insert(
+		"view_slashing_incidents",
+	).Columns(
+		"operator_address",
+		"consensus_node_address",
+		"block_height",
+		"block_time",
+		"reason",
+		"slashed_power",
+		"jailed",
+		"evidence_type",
+		"evidence_height",
+		"evidence_vote_a_block_hash",
+		"evidence_vote_b_block_hash",
+	).Values(
+		incident.OperatorAddress,
+		incident.ConsensusNodeAddress,
+		incident.BlockHeight,
+		incidentsView.rdb.Tton(&incident.BlockTime),
+		incident.Reason,
+		incident.SlashedPower,
+		incident.Jailed,
+		maybeEvidenceType,
+		maybeEvidenceHeight,
+		maybeEvidenceVoteABlockHash,
+		maybeEvidenceVoteBBlockHash,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building slashing incident insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := incidentsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting slashing incident into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting slashing incident into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type SlashingIncidentsListFilter struct {
+	MaybeOperatorAddress *string
+	MaybeReason          *string
+	// Inclusive
+	MaybeFrom *utctime.UTCTime
+	// Exclusive
+	MaybeTo *utctime.UTCTime
+}
+
+// Defaults to the latest incident first
+type SlashingIncidentsListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (incidentsView *SlashingIncidents) List(
+	filter SlashingIncidentsListFilter,
+	order SlashingIncidentsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]SlashingIncidentRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := incidentsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"consensus_node_address",
+		"block_height",
+		"block_time",
+		"reason",
+		"slashed_power",
+		"jailed",
+		"evidence_type",
+		"evidence_height",
+		"evidence_vote_a_block_hash",
+		"evidence_vote_b_block_hash",
+	).From(
+		"view_slashing_incidents",
+	)
+	if filter.MaybeOperatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("operator_address = ?", *filter.MaybeOperatorAddress)
+	}
+	if filter.MaybeReason != nil {
+		stmtBuilder = stmtBuilder.Where("reason = ?", *filter.MaybeReason)
+	}
+	if filter.MaybeFrom != nil {
+		stmtBuilder = stmtBuilder.Where("block_time >= ?", incidentsView.rdb.Tton(filter.MaybeFrom))
+	}
+	if filter.MaybeTo != nil {
+		stmtBuilder = stmtBuilder.Where("block_time < ?", incidentsView.rdb.Tton(filter.MaybeTo))
+	}
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height", "id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC", "id DESC")
+	}
+
+	return incidentsView.list(stmtBuilder, pagination)
+}
+
+func (incidentsView *SlashingIncidents) list(
+	stmtBuilder sq.SelectBuilder,
+	pagination *pagination_interface.Pagination,
+) ([]SlashingIncidentRow, *pagination_interface.PaginationResult, error) {
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		incidentsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building slashing incidents select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := incidentsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing slashing incidents select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	incidents := make([]SlashingIncidentRow, 0)
+	for rowsResult.Next() {
+		var incident SlashingIncidentRow
+		var maybeEvidenceType *string
+		var maybeEvidenceHeight *int64
+		var maybeEvidenceVoteABlockHash *string
+		var maybeEvidenceVoteBBlockHash *string
+		blockTimeReader := incidentsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&incident.OperatorAddress,
+			&incident.ConsensusNodeAddress,
+			&incident.BlockHeight,
+			blockTimeReader.ScannableArg(),
+			&incident.Reason,
+			&incident.SlashedPower,
+			&incident.Jailed,
+			&maybeEvidenceType,
+			&maybeEvidenceHeight,
+			&maybeEvidenceVoteABlockHash,
+			&maybeEvidenceVoteBBlockHash,
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning slashing incident row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing slashing incident block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		incident.BlockTime = *blockTime
+
+		if maybeEvidenceType != nil {
+			incident.MaybeEvidence = &SlashingEvidence{
+				Type: *maybeEvidenceType,
+			}
+			if maybeEvidenceHeight != nil {
+				incident.MaybeEvidence.Height = *maybeEvidenceHeight
+			}
+			if maybeEvidenceVoteABlockHash != nil {
+				incident.MaybeEvidence.VoteABlockHash = *maybeEvidenceVoteABlockHash
+			}
+			if maybeEvidenceVoteBBlockHash != nil {
+				incident.MaybeEvidence.VoteBBlockHash = *maybeEvidenceVoteBBlockHash
+			}
+		}
+
+		incidents = append(incidents, incident)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return incidents, paginationResult, nil
+}
+
+// SlashingIncidentRow evidence is nil unless the validator is slashed for double signing and the
+// evidence is included in the block
+type SlashingIncidentRow struct {
+	OperatorAddress      string            `json:"operatorAddress"`
+	ConsensusNodeAddress string            `json:"consensusNodeAddress"`
+	BlockHeight          int64             `json:"blockHeight"`
+	BlockTime            utctime.UTCTime   `json:"blockTime"`
+	Reason               string            `json:"reason"`
+	SlashedPower         string            `json:"slashedPower"`
+	Jailed               bool              `json:"jailed"`
+	MaybeEvidence        *SlashingEvidence `json:"evidence"`
+}
+
+// SlashingEvidence is the double sign evidence of the conflicting votes
+type SlashingEvidence struct {
+	Type           string `json:"type"`
+	Height         int64  `json:"height"`
+	VoteABlockHash string `json:"voteABlockHash"`
+	VoteBBlockHash string `json:"voteBBlockHash"`
+}
@@ -0,0 +1,169 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// SlashingValidators keeps the addresses of each validator together with its slashing and jailing
+// counters and downtime
+type SlashingValidators struct {
+	rdb *rdb.Handle
+}
+
+func NewSlashingValidators(handle *rdb.Handle) *SlashingValidators {
+	return &SlashingValidators{
+		handle,
+	}
+}
+
+func (validatorsView *SlashingValidators) Upsert(validator *SlashingValidatorRow) error {
+	sql, sqlArgs, err := validatorsView.rdb.StmtBuilder.Insert(
+		"view_slashing_validators",
+	).Columns(
+		"consensus_node_address",
+		"operator_address",
+		"tendermint_address",
+		"joined_at_block_height",
+		"joined_at_block_time",
+		"slash_count",
+		"double_sign_count",
+		"jail_count",
+		"total_downtime_seconds",
+		"jailed_since_block_time",
+	).Values(
+		validator.ConsensusNodeAddress,
+		validator.OperatorAddress,
+		validator.TendermintAddress,
+		validator.JoinedAtBlockHeight,
+		validatorsView.rdb.Tton(&validator.JoinedAtBlockTime),
+		validator.SlashCount,
+		validator.DoubleSignCount,
+		validator.JailCount,
+		validator.TotalDowntimeSeconds,
+		validatorsView.rdb.Tton(validator.MaybeJailedSinceBlockTime),
+	).Suffix(`ON CONFLICT (consensus_node_address) DO UPDATE SET
+		operator_address = EXCLUDED.operator_address,
+		tendermint_address = EXCLUDED.tendermint_address,
+		joined_at_block_height = EXCLUDED.joined_at_block_height,
+		joined_at_block_time = EXCLUDED.joined_at_block_time,
+		slash_count = EXCLUDED.slash_count,
+		double_sign_count = EXCLUDED.double_sign_count,
+		jail_count = EXCLUDED.jail_count,
+		total_downtime_seconds = EXCLUDED.total_downtime_seconds,
+		jailed_since_block_time = EXCLUDED.jailed_since_block_time
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building slashing validator upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := validatorsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting slashing validator into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting slashing validator into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type SlashingValidatorIdentity struct {
+	MaybeConsensusNodeAddress *string
+	MaybeOperatorAddress      *string
+}
+
+// FindBy returns rdb.ErrNoRows when the validator is not created yet
+func (validatorsView *SlashingValidators) FindBy(identity SlashingValidatorIdentity) (*SlashingValidatorRow, error) {
+	stmtBuilder := validatorsView.selectStmtBuilder()
+	if identity.MaybeConsensusNodeAddress != nil {
+		stmtBuilder = stmtBuilder.Where("consensus_node_address = ?", *identity.MaybeConsensusNodeAddress)
+	} else if identity.MaybeOperatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("operator_address = ?", *identity.MaybeOperatorAddress)
+	} else {
+		return nil, fmt.Errorf("error building slashing validator selection sql: %v: %w",
+			errors.New("missing validator identity"), rdb.ErrPrepare,
+		)
+	}
+
+	sql, sqlArgs, err := stmtBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building slashing validator selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := validatorsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing slashing validator selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+
+	var validator SlashingValidatorRow
+	joinedAtBlockTimeReader := validatorsView.rdb.NtotReader()
+	jailedSinceBlockTimeReader := validatorsView.rdb.NtotReader()
+	if err := rowsResult.Scan(
+		&validator.ConsensusNodeAddress,
+		&validator.OperatorAddress,
+		&validator.TendermintAddress,
+		&validator.JoinedAtBlockHeight,
+		joinedAtBlockTimeReader.ScannableArg(),
+		&validator.SlashCount,
+		&validator.DoubleSignCount,
+		&validator.JailCount,
+		&validator.TotalDowntimeSeconds,
+		jailedSinceBlockTimeReader.ScannableArg(),
+	); err != nil {
+		return nil, fmt.Errorf("error scanning slashing validator row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	joinedAtBlockTime, err := joinedAtBlockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing slashing validator joined time: %v: %w", err, rdb.ErrQuery)
+	}
+	validator.JoinedAtBlockTime = *joinedAtBlockTime
+	if validator.MaybeJailedSinceBlockTime, err = jailedSinceBlockTimeReader.Parse(); err != nil {
+		return nil, fmt.Errorf("error parsing slashing validator jailed time: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &validator, nil
+}
+
+func (validatorsView *SlashingValidators) selectStmtBuilder() sq.SelectBuilder {
+	return validatorsView.rdb.StmtBuilder.Select(
+		"consensus_node_address",
+		"operator_address",
+		"tendermint_address",
+		"joined_at_block_height",
+		"joined_at_block_time",
+		"slash_count",
+		"double_sign_count",
+		"jail_count",
+		"total_downtime_seconds",
+		"jailed_since_block_time",
+	).From(
+		"view_slashing_validators",
+	)
+}
+
+// SlashingValidatorRow total downtime only includes the finished downtime periods, jailed since block
+// time is nil when the validator is not jailed
+type SlashingValidatorRow struct {
+	ConsensusNodeAddress      string           `json:"consensusNodeAddress"`
+	OperatorAddress           string           `json:"operatorAddress"`
+	TendermintAddress         string           `json:"tendermintAddress"`
+	JoinedAtBlockHeight       int64            `json:"joinedAtBlockHeight"`
+	JoinedAtBlockTime         utctime.UTCTime  `json:"joinedAtBlockTime"`
+	SlashCount                int64            `json:"slashCount"`
+	DoubleSignCount           int64            `json:"doubleSignCount"`
+	JailCount                 int64            `json:"jailCount"`
+	TotalDowntimeSeconds      int64            `json:"totalDowntimeSeconds"`
+	MaybeJailedSinceBlockTime *utctime.UTCTime `json:"jailedSinceBlockTime"`
+}
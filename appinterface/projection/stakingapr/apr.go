@@ -0,0 +1,83 @@
+package stakingapr
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// APR are decimals with the same precision as the Cosmos SDK `sdk.Dec`
+const APR_PRECISION = 18
+
+// EstimateNetworkAPR returns the yearly staking reward per bonded token before validator commission.
+//
+// The annual provisions are distributed to the bonded tokens after the community tax, and the bonded
+// tokens are the bonded ratio of the supply (annual provisions / inflation). Returns nil when nothing
+// is bonded or minted.
+func EstimateNetworkAPR(
+	annualProvisions string,
+	inflation string,
+	bondedRatio string,
+	communityTax string,
+) (*string, error) {
+	annualProvisionsValue, err := parseDecimal(annualProvisions)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing annual provisions: %v", err)
+	}
+	inflationValue, err := parseDecimal(inflation)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing inflation: %v", err)
+	}
+	bondedRatioValue, err := parseDecimal(bondedRatio)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing bonded ratio: %v", err)
+	}
+	communityTaxValue, err := parseDecimal(communityTax)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing community tax: %v", err)
+	}
+	if inflationValue.Sign() == 0 || bondedRatioValue.Sign() == 0 {
+		return nil, nil
+	}
+
+	supply := new(big.Rat).Quo(annualProvisionsValue, inflationValue)
+	bondedTokens := new(big.Rat).Mul(supply, bondedRatioValue)
+	if bondedTokens.Sign() == 0 {
+		return nil, nil
+	}
+	stakingProvisions := new(big.Rat).Mul(
+		annualProvisionsValue,
+		new(big.Rat).Sub(big.NewRat(1, 1), communityTaxValue),
+	)
+
+	apr := new(big.Rat).Quo(stakingProvisions, bondedTokens).FloatString(APR_PRECISION)
+	return &apr, nil
+}
+
+// EstimateValidatorAPR returns the APR of the delegators of a validator charging the commission rate
+func EstimateValidatorAPR(networkAPR string, commissionRate string) (string, error) {
+	networkAPRValue, err := parseDecimal(networkAPR)
+	if err != nil {
+		return "", fmt.Errorf("error parsing network APR: %v", err)
+	}
+	commissionRateValue, err := parseDecimal(commissionRate)
+	if err != nil {
+		return "", fmt.Errorf("error parsing commission rate: %v", err)
+	}
+
+	return new(big.Rat).Mul(
+		networkAPRValue,
+		new(big.Rat).Sub(big.NewRat(1, 1), commissionRateValue),
+	).FloatString(APR_PRECISION), nil
+}
+
+func parseDecimal(decimal string) (*big.Rat, error) {
+	if decimal == "" {
+		return new(big.Rat), nil
+	}
+
+	value, ok := new(big.Rat).SetString(decimal)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal: %s", decimal)
+	}
+	return value, nil
+}
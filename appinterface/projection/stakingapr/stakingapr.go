@@ -0,0 +1,142 @@
+package stakingapr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/stakingapr/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &StakingAPR{}
+
+// StakingAPR estimates the network-wide staking APR from the minting parameters of every Minted event
+// and the genesis community tax, and keeps the estimation of the last block of every day.
+//
+// The per-validator APR is the network-wide APR after the validator commission, and is estimated on
+// query against the commission rate in the Validator view.
+type StakingAPR struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+}
+
+func NewStakingAPR(logger applogger.Logger, rdbConn rdb.Conn) *StakingAPR {
+	return &StakingAPR{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "StakingAPR"),
+
+		rdbConn,
+		logger,
+	}
+}
+
+func (_ *StakingAPR) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MINTED,
+	}
+}
+
+func (projection *StakingAPR) OnInit() error {
+	return nil
+}
+
+func (projection *StakingAPR) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+	paramsView := view.NewParams(rdbTxHandle)
+
+	var maybeBlockTime *utctime.UTCTime
+	var maybeMintedEvent *event_usecase.Minted
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			projection.logger.Debug("handling GenesisCreated event")
+			if err := paramsView.Set(
+				view.PARAM_COMMUNITY_TAX,
+				genesisCreatedEvent.Genesis.AppState.Distribution.Params.CommunityTax,
+			); err != nil {
+				return fmt.Errorf("error setting community tax: %v", err)
+			}
+		} else if mintedEvent, ok := event.(*event_usecase.Minted); ok {
+			maybeMintedEvent = mintedEvent
+		}
+	}
+
+	if maybeMintedEvent != nil {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling Minted: missing BlockCreated event at height %d", height)
+		}
+		if err := projection.handleMinted(rdbTxHandle, height, *maybeBlockTime, maybeMintedEvent); err != nil {
+			return fmt.Errorf("error handling Minted: %v", err)
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *StakingAPR) handleMinted(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	mintedEvent *event_usecase.Minted,
+) error {
+	communityTax, err := view.NewParams(rdbTxHandle).FindBy(view.PARAM_COMMUNITY_TAX)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting community tax: %v", err)
+		}
+		// Genesis is not handled by this projection
+		communityTax = "0"
+	}
+
+	maybeAPR, err := EstimateNetworkAPR(
+		mintedEvent.AnnualProvisions, mintedEvent.Inflation, mintedEvent.BondedRatio, communityTax,
+	)
+	if err != nil {
+		return fmt.Errorf("error estimating network APR: %v", err)
+	}
+
+	if err := view.NewAPRHistories(rdbTxHandle).Upsert(&view.APRHistoryRow{
+		Day:              view.APRDayStart(blockTime),
+		BlockHeight:      blockHeight,
+		BlockTime:        blockTime,
+		AnnualProvisions: mintedEvent.AnnualProvisions,
+		BondedRatio:      mintedEvent.BondedRatio,
+		Inflation:        mintedEvent.Inflation,
+		CommunityTax:     communityTax,
+		MaybeAPR:         maybeAPR,
+	}); err != nil {
+		return fmt.Errorf("error upserting staking APR history: %v", err)
+	}
+
+	return nil
+}
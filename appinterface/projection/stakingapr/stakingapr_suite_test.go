@@ -0,0 +1,13 @@
+package stakingapr_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestStakingAPR(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "StakingAPR Suite")
+}
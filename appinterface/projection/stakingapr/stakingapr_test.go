@@ -0,0 +1,121 @@
+package stakingapr_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/stakingapr"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/stakingapr/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+)
+
+var _ = Describe("StakingAPR", func() {
+	var conn rdb.Conn
+	var projection *stakingapr.StakingAPR
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = stakingapr.NewStakingAPR(NewFakeLogger(), conn)
+	})
+
+	// The time of the hour on the day, counting from the genesis day as day 1
+	timeOf := func(day int, hour int) utctime.UTCTime {
+		return TimeAt(time.Duration(day-1)*24*time.Hour + time.Duration(hour)*time.Hour)
+	}
+	genesisCreated := func(communityTax string) event_entity.Event {
+		return NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+			anyGenesis.AppState.Distribution.Params.CommunityTax = communityTax
+		})
+	}
+	minted := func(height int64, annualProvisions string, inflation string, bondedRatio string) event_entity.Event {
+		return event_usecase.NewMinted(height, usecase_model.MintParams{
+			BondedRatio:      bondedRatio,
+			Inflation:        inflation,
+			AnnualProvisions: annualProvisions,
+			Amount:           "1",
+		})
+	}
+
+	Describe("EstimateNetworkAPR", func() {
+		It("should distribute the annual provisions after community tax to the bonded tokens", func() {
+			apr, err := stakingapr.EstimateNetworkAPR("1200", "0.12", "0.5", "0.02")
+			Expect(err).To(BeNil())
+			Expect(apr).To(Equal(primptr.String("0.235200000000000000")))
+		})
+
+		It("should return nil when nothing is bonded", func() {
+			apr, err := stakingapr.EstimateNetworkAPR("1200", "0.12", "0.000000000000000000", "0.02")
+			Expect(err).To(BeNil())
+			Expect(apr).To(BeNil())
+		})
+	})
+
+	Describe("EstimateValidatorAPR", func() {
+		It("should deduct the validator commission", func() {
+			apr, err := stakingapr.EstimateValidatorAPR("0.2352", "0.1")
+			Expect(err).To(BeNil())
+			Expect(apr).To(Equal("0.211680000000000000"))
+		})
+	})
+
+	It("should keep the estimation of the last Minted event of every day", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			genesisCreated("0.02"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, timeOf(1, 10)),
+			minted(1, "1200", "0.12", "0.6"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, timeOf(1, 20)),
+			minted(2, "1200", "0.12", "0.5"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, timeOf(2, 10)),
+			minted(3, "1300", "0.13", "0.5"),
+		})
+
+		historiesView := view.NewAPRHistories(conn.ToHandle())
+		histories, _, err := historiesView.List(
+			view.APRHistoriesListFilter{}, view.APRHistoriesListOrder{}, pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(histories).To(Equal([]view.APRHistoryRow{
+			{
+				Day:              timeOf(1, 0),
+				BlockHeight:      2,
+				BlockTime:        timeOf(1, 20),
+				AnnualProvisions: "1200",
+				BondedRatio:      "0.5",
+				Inflation:        "0.12",
+				CommunityTax:     "0.02",
+				MaybeAPR:         primptr.String("0.235200000000000000"),
+			},
+			{
+				Day:              timeOf(2, 0),
+				BlockHeight:      3,
+				BlockTime:        timeOf(2, 10),
+				AnnualProvisions: "1300",
+				BondedRatio:      "0.5",
+				Inflation:        "0.13",
+				CommunityTax:     "0.02",
+				MaybeAPR:         primptr.String("0.254800000000000000"),
+			},
+		}))
+
+		latest, err := historiesView.FindLatest()
+		Expect(err).To(BeNil())
+		Expect(latest.BlockHeight).To(Equal(int64(3)))
+	})
+})
@@ -0,0 +1,217 @@
+package view
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// APRDayStart returns the start of the day in UTC the time is in
+func APRDayStart(t utctime.UTCTime) utctime.UTCTime {
+	year, month, day := time.Unix(0, t.UnixNano()).UTC().Date()
+	return utctime.FromTime(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// APRHistories keeps the staking APR estimated from the last Minted event of every day in UTC
+type APRHistories struct {
+	rdb *rdb.Handle
+}
+
+func NewAPRHistories(handle *rdb.Handle) *APRHistories {
+	return &APRHistories{
+		handle,
+	}
+}
+
+func (historiesView *APRHistories) Upsert(history *APRHistoryRow) error {
+	sql, sqlArgs, err := historiesView.rdb.StmtBuilder.Insert(
+		"view_staking_apr_histories",
+	).Columns(
+		"day",
+		"block_height",
+		"block_time",
+		"annual_provisions",
+		"bonded_ratio",
+		"inflation",
+		"community_tax",
+		"apr",
+	).Values(
+		historiesView.rdb.Tton(&history.Day),
+		history.BlockHeight,
+		historiesView.rdb.Tton(&history.BlockTime),
+		history.AnnualProvisions,
+		history.BondedRatio,
+		history.Inflation,
+		history.CommunityTax,
+		history.MaybeAPR,
+	).Suffix(`ON CONFLICT (day) DO UPDATE SET
+		block_height = EXCLUDED.block_height,
+		block_time = EXCLUDED.block_time,
+		annual_provisions = EXCLUDED.annual_provisions,
+		bonded_ratio = EXCLUDED.bonded_ratio,
+		inflation = EXCLUDED.inflation,
+		community_tax = EXCLUDED.community_tax,
+		apr = EXCLUDED.apr
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building staking APR history upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := historiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting staking APR history into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting staking APR history into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindLatest returns rdb.ErrNoRows when nothing has been minted yet
+func (historiesView *APRHistories) FindLatest() (*APRHistoryRow, error) {
+	sql, sqlArgs, err := historiesView.selectStmtBuilder().OrderBy("day DESC").Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building staking APR history selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing staking APR history selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return historiesView.scan(rowsResult)
+}
+
+type APRHistoriesListFilter struct {
+	// Inclusive
+	MaybeFromDay *utctime.UTCTime
+	// Exclusive
+	MaybeToDay *utctime.UTCTime
+}
+
+type APRHistoriesListOrder struct {
+	MaybeDay *view.ORDER
+}
+
+func (historiesView *APRHistories) List(
+	filter APRHistoriesListFilter,
+	order APRHistoriesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]APRHistoryRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := historiesView.selectStmtBuilder()
+	if filter.MaybeFromDay != nil {
+		stmtBuilder = stmtBuilder.Where("day >= ?", historiesView.rdb.Tton(filter.MaybeFromDay))
+	}
+	if filter.MaybeToDay != nil {
+		stmtBuilder = stmtBuilder.Where("day < ?", historiesView.rdb.Tton(filter.MaybeToDay))
+	}
+	if order.MaybeDay != nil && *order.MaybeDay == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("day DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("day")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		historiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error building staking APR histories select SQL: %v, %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing staking APR histories select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	histories := make([]APRHistoryRow, 0)
+	for rowsResult.Next() {
+		history, scanErr := historiesView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		histories = append(histories, *history)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return histories, paginationResult, nil
+}
+
+func (historiesView *APRHistories) selectStmtBuilder() sq.SelectBuilder {
+	return historiesView.rdb.StmtBuilder.Select(
+		"day",
+		"block_height",
+		"block_time",
+		"annual_provisions",
+		"bonded_ratio",
+		"inflation",
+		"community_tax",
+		"apr",
+	).From(
+		"view_staking_apr_histories",
+	)
+}
+
+func (historiesView *APRHistories) scan(rowsResult rdb.RowsResult) (*APRHistoryRow, error) {
+	var history APRHistoryRow
+	dayReader := historiesView.rdb.NtotReader()
+	blockTimeReader := historiesView.rdb.NtotReader()
+	if err := rowsResult.Scan(
+		dayReader.ScannableArg(),
+		&history.BlockHeight,
+		blockTimeReader.ScannableArg(),
+		&history.AnnualProvisions,
+		&history.BondedRatio,
+		&history.Inflation,
+		&history.CommunityTax,
+		&history.MaybeAPR,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning staking APR history row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	day, err := dayReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing staking APR history day: %v: %w", err, rdb.ErrQuery)
+	}
+	history.Day = *day
+	blockTime, err := blockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing staking APR history block time: %v: %w", err, rdb.ErrQuery)
+	}
+	history.BlockTime = *blockTime
+
+	return &history, nil
+}
+
+type APRHistoryRow struct {
+	// Start of the day in UTC
+	Day utctime.UTCTime `json:"day"`
+	// Height and time of the last Minted event in the day
+	BlockHeight      int64           `json:"blockHeight"`
+	BlockTime        utctime.UTCTime `json:"blockTime"`
+	AnnualProvisions string          `json:"annualProvisions"`
+	BondedRatio      string          `json:"bondedRatio"`
+	Inflation        string          `json:"inflation"`
+	CommunityTax     string          `json:"communityTax"`
+	// Network-wide APR before validator commission. Null when nothing is bonded.
+	MaybeAPR *string `json:"apr"`
+}
@@ -0,0 +1,69 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+const PARAM_COMMUNITY_TAX = "CommunityTax"
+
+// Params keeps the distribution parameters from the genesis, such as the community tax
+type Params struct {
+	rdb *rdb.Handle
+}
+
+func NewParams(handle *rdb.Handle) *Params {
+	return &Params{
+		handle,
+	}
+}
+
+func (paramsView *Params) Set(key string, value string) error {
+	sql, sqlArgs, err := paramsView.rdb.StmtBuilder.Insert(
+		"view_staking_apr_params",
+	).Columns(
+		"key",
+		"value",
+	).Values(key, value).Suffix(
+		"ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value",
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building staking APR param upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := paramsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting staking APR param into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting staking APR param into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindBy returns rdb.ErrNoRows when the param is not set
+func (paramsView *Params) FindBy(key string) (string, error) {
+	sql, sqlArgs, err := paramsView.rdb.StmtBuilder.Select(
+		"value",
+	).From(
+		"view_staking_apr_params",
+	).Where(
+		"key = ?", key,
+	).ToSql()
+	if err != nil {
+		return "", fmt.Errorf("error building staking APR param selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var value string
+	if err = paramsView.rdb.QueryRow(sql, sqlArgs...).Scan(&value); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return "", rdb.ErrNoRows
+		}
+		return "", fmt.Errorf("error scanning staking APR param row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return value, nil
+}
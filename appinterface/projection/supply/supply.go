@@ -0,0 +1,225 @@
+package supply
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/supply/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+)
+
+var _ projection_entity.Projection = &Supply{}
+
+// Supply tracks the base denom total supply from the genesis bank supply and the coins minted every
+// block, together with the minting parameters and the coins funded to the community pool.
+//
+// The total supply is an approximation from above: the coins burned by slashing are not subtracted, as
+// the slashing events carry the slashed power but not the burned amount. Only `MsgFundCommunityPool` is
+// counted as community pool inflow. The community tax is not emitted as an event.
+type Supply struct {
+	*rdbprojectionbase.Base
+
+	rdbConn   rdb.Conn
+	logger    applogger.Logger
+	baseDenom string
+}
+
+func NewSupply(logger applogger.Logger, rdbConn rdb.Conn, baseDenom string) *Supply {
+	return &Supply{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "Supply"),
+
+		rdbConn,
+		logger,
+		baseDenom,
+	}
+}
+
+func (_ *Supply) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MINTED,
+		event_usecase.MSG_FUND_COMMUNITY_POOL_CREATED,
+	}
+}
+
+func (projection *Supply) OnInit() error {
+	return nil
+}
+
+func (projection *Supply) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+	historiesView := view.NewSupplyHistories(rdbTxHandle)
+
+	var maybeBlockTime *utctime.UTCTime
+	var maybeMintedEvent *event_usecase.Minted
+	minted := new(big.Int)
+	communityPoolInflow := new(big.Int)
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			projection.logger.Debug("handling GenesisCreated event")
+			if err := projection.handleGenesisCreated(historiesView, height, genesisCreatedEvent); err != nil {
+				return fmt.Errorf("error handling GenesisCreated: %v", err)
+			}
+		} else if mintedEvent, ok := event.(*event_usecase.Minted); ok {
+			amount, ok := new(big.Int).SetString(mintedEvent.Amount, 10)
+			if !ok {
+				return fmt.Errorf("error parsing minted amount: %s", mintedEvent.Amount)
+			}
+			minted.Add(minted, amount)
+			maybeMintedEvent = mintedEvent
+		} else if fundCommunityPoolEvent, ok := event.(*event_usecase.MsgFundCommunityPool); ok {
+			communityPoolInflow.Add(communityPoolInflow, fundCommunityPoolEvent.Amount.ToBigInt())
+		}
+	}
+
+	if maybeMintedEvent != nil || communityPoolInflow.Sign() != 0 {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling supply changes: missing BlockCreated event at height %d", height)
+		}
+
+		previous, err := historiesView.FindLatest(nil)
+		if err != nil {
+			if errors.Is(err, rdb.ErrNoRows) {
+				return errors.New("error handling supply changes: genesis supply is not handled")
+			}
+			return fmt.Errorf("error getting latest supply: %v", err)
+		}
+
+		history := view.SupplyHistoryRow{
+			BlockHeight:           height,
+			BlockTime:             *maybeBlockTime,
+			Minted:                minted.String(),
+			MaybeBondedRatio:      previous.MaybeBondedRatio,
+			MaybeInflation:        previous.MaybeInflation,
+			MaybeAnnualProvisions: previous.MaybeAnnualProvisions,
+			CommunityPoolInflow:   communityPoolInflow.String(),
+		}
+		if maybeMintedEvent != nil {
+			history.MaybeBondedRatio = &maybeMintedEvent.BondedRatio
+			history.MaybeInflation = &maybeMintedEvent.Inflation
+			history.MaybeAnnualProvisions = &maybeMintedEvent.AnnualProvisions
+		}
+		if history.TotalSupply, err = addIntString(previous.TotalSupply, minted); err != nil {
+			return fmt.Errorf("error adding minted to total supply: %v", err)
+		}
+		if history.TotalMinted, err = addIntString(previous.TotalMinted, minted); err != nil {
+			return fmt.Errorf("error adding minted to total minted: %v", err)
+		}
+		if history.TotalCommunityPoolInflow, err = addIntString(
+			previous.TotalCommunityPoolInflow, communityPoolInflow,
+		); err != nil {
+			return fmt.Errorf("error adding community pool inflow: %v", err)
+		}
+
+		if err := historiesView.Insert(&history); err != nil {
+			return fmt.Errorf("error inserting supply history: %v", err)
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *Supply) handleGenesisCreated(
+	historiesView *view.SupplyHistories,
+	blockHeight int64,
+	genesisCreatedEvent *event_usecase.GenesisCreated,
+) error {
+	genesisTime, err := utctime.Parse(time.RFC3339, genesisCreatedEvent.Genesis.GenesisTime)
+	if err != nil {
+		return fmt.Errorf("error parsing genesis time: %v", err)
+	}
+
+	totalSupply, err := GenesisSupply(&genesisCreatedEvent.Genesis, projection.baseDenom)
+	if err != nil {
+		return fmt.Errorf("error getting genesis supply: %v", err)
+	}
+
+	if err := historiesView.Insert(&view.SupplyHistoryRow{
+		BlockHeight:              blockHeight,
+		BlockTime:                genesisTime,
+		TotalSupply:              totalSupply.String(),
+		Minted:                   "0",
+		TotalMinted:              "0",
+		CommunityPoolInflow:      "0",
+		TotalCommunityPoolInflow: "0",
+	}); err != nil {
+		return fmt.Errorf("error inserting genesis supply: %v", err)
+	}
+
+	return nil
+}
+
+// GenesisSupply returns the base denom supply of the genesis bank module. The supply is the sum of the
+// genesis balances when the genesis leaves it empty.
+func GenesisSupply(anyGenesis *genesis.Genesis, baseDenom string) (*big.Int, error) {
+	for _, rawSupply := range anyGenesis.AppState.Bank.Supply {
+		supplyCoin, ok := rawSupply.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("error parsing genesis supply: unexpected type %T", rawSupply)
+		}
+		if denom, _ := supplyCoin["denom"].(string); denom != baseDenom {
+			continue
+		}
+		amountStr, _ := supplyCoin["amount"].(string)
+		amount, ok := new(big.Int).SetString(amountStr, 10)
+		if !ok {
+			return nil, fmt.Errorf("error parsing genesis supply amount: %s", amountStr)
+		}
+		return amount, nil
+	}
+
+	totalSupply := new(big.Int)
+	for _, balance := range anyGenesis.AppState.Bank.Balances {
+		for _, genesisCoin := range balance.Coins {
+			if genesisCoin.Denom != baseDenom {
+				continue
+			}
+			amount, ok := new(big.Int).SetString(genesisCoin.Amount, 10)
+			if !ok {
+				return nil, fmt.Errorf("error parsing genesis balance of %s: %s", balance.Address, genesisCoin.Amount)
+			}
+			totalSupply.Add(totalSupply, amount)
+		}
+	}
+	return totalSupply, nil
+}
+
+func addIntString(stored string, value *big.Int) (string, error) {
+	storedValue, ok := new(big.Int).SetString(stored, 10)
+	if !ok {
+		return "", fmt.Errorf("error parsing integer: %s", stored)
+	}
+	return storedValue.Add(storedValue, value).String(), nil
+}
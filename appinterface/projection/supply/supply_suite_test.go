@@ -0,0 +1,13 @@
+package supply_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSupply(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Supply Suite")
+}
@@ -0,0 +1,171 @@
+package supply_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance"
+	balance_view "github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/supply"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/supply/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("Supply", func() {
+	const baseDenom = "basetcro"
+
+	var conn rdb.Conn
+	var projection *supply.Supply
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = supply.NewSupply(NewFakeLogger(), conn, baseDenom)
+	})
+
+	genesisCreated := func(supplyCoins []interface{}, balances []genesis.Balance) event_entity.Event {
+		return NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+			anyGenesis.AppState.Bank.Supply = supplyCoins
+			anyGenesis.AppState.Bank.Balances = balances
+		})
+	}
+	minted := func(height int64, amount string, inflation string) event_entity.Event {
+		return event_usecase.NewMinted(height, usecase_model.MintParams{
+			BondedRatio:      "0.5",
+			Inflation:        inflation,
+			AnnualProvisions: "1000.0",
+			Amount:           amount,
+		})
+	}
+	fundCommunityPool := func(height int64, amount int64) event_entity.Event {
+		return event_usecase.NewMsgFundCommunityPool(
+			NewMsgCommonParams(height, "fund"),
+			usecase_model.MsgFundCommunityPoolParams{
+				Depositor: "tcro1depositor",
+				Amount:    coin.MustNewCoinFromInt(amount),
+			},
+		)
+	}
+
+	It("should take the genesis supply of the base denom", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			genesisCreated([]interface{}{
+				map[string]interface{}{"denom": "other", "amount": "1"},
+				map[string]interface{}{"denom": baseDenom, "amount": "100000"},
+			}, nil),
+		})
+
+		history, err := view.NewSupplyHistories(conn.ToHandle()).FindLatest(nil)
+		Expect(err).To(BeNil())
+		Expect(history).To(Equal(&view.SupplyHistoryRow{
+			BlockHeight:              0,
+			BlockTime:                GENESIS_TIME,
+			TotalSupply:              "100000",
+			Minted:                   "0",
+			TotalMinted:              "0",
+			CommunityPoolInflow:      "0",
+			TotalCommunityPoolInflow: "0",
+		}))
+	})
+
+	It("should sum the genesis balances when the genesis supply is empty", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			genesisCreated(nil, []genesis.Balance{
+				{Address: "tcro1a", Coins: []genesis.MinDeposit{{Denom: baseDenom, Amount: "300"}}},
+				{Address: "tcro1b", Coins: []genesis.MinDeposit{
+					{Denom: "other", Amount: "1"},
+					{Denom: baseDenom, Amount: "700"},
+				}},
+			}),
+		})
+
+		history, err := view.NewSupplyHistories(conn.ToHandle()).FindLatest(nil)
+		Expect(err).To(BeNil())
+		Expect(history.TotalSupply).To(Equal("1000"))
+	})
+
+	It("should accumulate the minted coins and the community pool inflows", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			genesisCreated([]interface{}{
+				map[string]interface{}{"denom": baseDenom, "amount": "100000"},
+			}, nil),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, BlockTimeAt(1)),
+			minted(1, "50", "0.12"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, BlockTimeAt(2)),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, BlockTimeAt(3)),
+			minted(3, "40", "0.11"),
+			fundCommunityPool(3, 25),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(4, BlockTimeAt(4)),
+			fundCommunityPool(4, 5),
+		})
+
+		historiesView := view.NewSupplyHistories(conn.ToHandle())
+		history, err := historiesView.FindLatest(nil)
+		Expect(err).To(BeNil())
+		Expect(history).To(Equal(&view.SupplyHistoryRow{
+			BlockHeight:              4,
+			BlockTime:                BlockTimeAt(4),
+			TotalSupply:              "100090",
+			Minted:                   "0",
+			TotalMinted:              "90",
+			MaybeBondedRatio:         primptr.String("0.5"),
+			MaybeInflation:           primptr.String("0.11"),
+			MaybeAnnualProvisions:    primptr.String("1000.0"),
+			CommunityPoolInflow:      "5",
+			TotalCommunityPoolInflow: "30",
+		}))
+
+		blockTime := BlockTimeAt(2)
+		history, err = historiesView.FindLatest(&blockTime)
+		Expect(err).To(BeNil())
+		Expect(history.BlockHeight).To(Equal(int64(1)))
+		Expect(history.TotalSupply).To(Equal("100050"))
+		Expect(history.MaybeInflation).To(Equal(primptr.String("0.12")))
+
+		histories, _, err := historiesView.List(
+			view.SupplyHistoriesListFilter{}, view.SupplyHistoriesListOrder{}, pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(histories).To(HaveLen(4))
+	})
+
+	It("should give the total supply and the non-circulating balance of a known chain state", func() {
+		const distributionAddress = "tcro1jv65s3grqf6v6jl3dp4t6c9t9rk99cd8339p4l"
+
+		events := MustParseGenesisFixtureEvents(usecase_parser_test.GENESIS_RESP)
+		events = append(events, MustParseBlockFixtureEvents(parser.NewTxDecoder(baseDenom), NewBlockFixture(
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESULTS_RESP,
+		))...)
+		MustReplayEvents(projection, events)
+		MustReplayEvents(balance.NewBalance(NewFakeLogger(), conn, baseDenom), events)
+
+		// The genesis balances sum up to 8027560000000000000 and the block mints 17477215277
+		history, err := view.NewSupplyHistories(conn.ToHandle()).FindLatest(nil)
+		Expect(err).To(BeNil())
+		Expect(history.TotalSupply).To(Equal("8027560017477215277"))
+		// The distribution module account starts empty and receives the minted coins together with the fees
+		// of the previous block once
+		distributionBalance, err := balance_view.NewBalances(conn.ToHandle()).FindBy(distributionAddress)
+		Expect(err).To(BeNil())
+		Expect(distributionBalance.Balance).To(Equal("17477255277"))
+	})
+})
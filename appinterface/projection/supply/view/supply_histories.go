@@ -0,0 +1,267 @@
+package view
+
+import (
+	"fmt"
+	"math/big"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// SupplyHistories records the supply and the minting parameters at every height the supply changes
+type SupplyHistories struct {
+	rdb *rdb.Handle
+}
+
+func NewSupplyHistories(handle *rdb.Handle) *SupplyHistories {
+	return &SupplyHistories{
+		handle,
+	}
+}
+
+func (historiesView *SupplyHistories) Insert(history *SupplyHistoryRow) error {
+	totalSupply, ok := new(big.Int).SetString(history.TotalSupply, 10)
+	if !ok {
+		return fmt.Errorf("error parsing total supply: %s", history.TotalSupply)
+	}
+	minted, ok := new(big.Int).SetString(history.Minted, 10)
+	if !ok {
+		return fmt.Errorf("error parsing minted: %s", history.Minted)
+	}
+	totalMinted, ok := new(big.Int).SetString(history.TotalMinted, 10)
+	if !ok {
+		return fmt.Errorf("error parsing total minted: %s", history.TotalMinted)
+	}
+	communityPoolInflow, ok := new(big.Int).SetString(history.CommunityPoolInflow, 10)
+	if !ok {
+		return fmt.Errorf("error parsing community pool inflow: %s", history.CommunityPoolInflow)
+	}
+	totalCommunityPoolInflow, ok := new(big.Int).SetString(history.TotalCommunityPoolInflow, 10)
+	if !ok {
+		return fmt.Errorf("error parsing total community pool inflow: %s", history.TotalCommunityPoolInflow)
+	}
+
+	sql, sqlArgs, err := historiesView.rdb.StmtBuilder.Insert(
+		"view_supply_histories",
+	).Columns(
+		"block_height",
+		"block_time",
+		"total_supply",
+		"minted",
+		"total_minted",
+		"bonded_ratio",
+		"inflation",
+		"annual_provisions",
+		"community_pool_inflow",
+		"total_community_pool_inflow",
+	).Values(
+		history.BlockHeight,
+		historiesView.rdb.Tton(&history.BlockTime),
+		historiesView.rdb.Bton(totalSupply),
+		historiesView.rdb.Bton(minted),
+		historiesView.rdb.Bton(totalMinted),
+		history.MaybeBondedRatio,
+		history.MaybeInflation,
+		history.MaybeAnnualProvisions,
+		historiesView.rdb.Bton(communityPoolInflow),
+		historiesView.rdb.Bton(totalCommunityPoolInflow),
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building supply history insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := historiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting supply history into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting supply history into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindLatest returns the latest supply, or the supply at the time when it is provided. Returns
+// rdb.ErrNoRows when there is no supply by then.
+func (historiesView *SupplyHistories) FindLatest(maybeBlockTime *utctime.UTCTime) (*SupplyHistoryRow, error) {
+	stmtBuilder := historiesView.selectStmtBuilder()
+	if maybeBlockTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time <= ?", historiesView.rdb.Tton(maybeBlockTime))
+	}
+	sql, sqlArgs, err := stmtBuilder.OrderBy("block_height DESC").Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building supply history selection sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing supply history selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return historiesView.scan(rowsResult)
+}
+
+type SupplyHistoriesListFilter struct {
+	// Inclusive
+	MaybeFromTime *utctime.UTCTime
+	// Exclusive
+	MaybeToTime *utctime.UTCTime
+}
+
+type SupplyHistoriesListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (historiesView *SupplyHistories) List(
+	filter SupplyHistoriesListFilter,
+	order SupplyHistoriesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]SupplyHistoryRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := historiesView.selectStmtBuilder()
+	if filter.MaybeFromTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time >= ?", historiesView.rdb.Tton(filter.MaybeFromTime))
+	}
+	if filter.MaybeToTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time < ?", historiesView.rdb.Tton(filter.MaybeToTime))
+	}
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		historiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error building supply histories select SQL: %v, %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing supply histories select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	histories := make([]SupplyHistoryRow, 0)
+	for rowsResult.Next() {
+		history, scanErr := historiesView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		histories = append(histories, *history)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return histories, paginationResult, nil
+}
+
+func (historiesView *SupplyHistories) selectStmtBuilder() sq.SelectBuilder {
+	return historiesView.rdb.StmtBuilder.Select(
+		"block_height",
+		"block_time",
+		"total_supply",
+		"minted",
+		"total_minted",
+		"bonded_ratio",
+		"inflation",
+		"annual_provisions",
+		"community_pool_inflow",
+		"total_community_pool_inflow",
+	).From(
+		"view_supply_histories",
+	)
+}
+
+func (historiesView *SupplyHistories) scan(rowsResult rdb.RowsResult) (*SupplyHistoryRow, error) {
+	var history SupplyHistoryRow
+	blockTimeReader := historiesView.rdb.NtotReader()
+	totalSupplyReader := historiesView.rdb.NtobReader()
+	mintedReader := historiesView.rdb.NtobReader()
+	totalMintedReader := historiesView.rdb.NtobReader()
+	communityPoolInflowReader := historiesView.rdb.NtobReader()
+	totalCommunityPoolInflowReader := historiesView.rdb.NtobReader()
+	if err := rowsResult.Scan(
+		&history.BlockHeight,
+		blockTimeReader.ScannableArg(),
+		totalSupplyReader.ScannableArg(),
+		mintedReader.ScannableArg(),
+		totalMintedReader.ScannableArg(),
+		&history.MaybeBondedRatio,
+		&history.MaybeInflation,
+		&history.MaybeAnnualProvisions,
+		communityPoolInflowReader.ScannableArg(),
+		totalCommunityPoolInflowReader.ScannableArg(),
+	); err != nil {
+		return nil, fmt.Errorf("error scanning supply history row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	blockTime, err := blockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing supply history block time: %v: %w", err, rdb.ErrQuery)
+	}
+	history.BlockTime = *blockTime
+	totalSupply, err := totalSupplyReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing supply history total supply: %v: %w", err, rdb.ErrQuery)
+	}
+	history.TotalSupply = totalSupply.String()
+	minted, err := mintedReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing supply history minted: %v: %w", err, rdb.ErrQuery)
+	}
+	history.Minted = minted.String()
+	totalMinted, err := totalMintedReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing supply history total minted: %v: %w", err, rdb.ErrQuery)
+	}
+	history.TotalMinted = totalMinted.String()
+	communityPoolInflow, err := communityPoolInflowReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing supply history community pool inflow: %v: %w", err, rdb.ErrQuery)
+	}
+	history.CommunityPoolInflow = communityPoolInflow.String()
+	totalCommunityPoolInflow, err := totalCommunityPoolInflowReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error parsing supply history total community pool inflow: %v: %w", err, rdb.ErrQuery,
+		)
+	}
+	history.TotalCommunityPoolInflow = totalCommunityPoolInflow.String()
+
+	return &history, nil
+}
+
+// SupplyHistoryRow amounts are in base denom
+type SupplyHistoryRow struct {
+	BlockHeight int64           `json:"blockHeight"`
+	BlockTime   utctime.UTCTime `json:"blockTime"`
+	TotalSupply string          `json:"totalSupply"`
+	// Minted in the block
+	Minted string `json:"minted"`
+	// Minted since genesis
+	TotalMinted string `json:"totalMinted"`
+	// Minting parameters of the last Minted event. Null before the first block.
+	MaybeBondedRatio      *string `json:"bondedRatio"`
+	MaybeInflation        *string `json:"inflation"`
+	MaybeAnnualProvisions *string `json:"annualProvisions"`
+	// Funded to the community pool in the block and since genesis
+	CommunityPoolInflow      string `json:"communityPoolInflow"`
+	TotalCommunityPoolInflow string `json:"totalCommunityPoolInflow"`
+}
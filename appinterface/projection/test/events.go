@@ -0,0 +1,54 @@
+package test
+
+import (
+	"time"
+
+	entity_event "github.com/crypto-com/chain-indexing/entity/event"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+)
+
+// GENESIS_TIME is the genesis time of the hand-made test chains
+var GENESIS_TIME = utctime.FromTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+// TimeAt returns the time at the offset from GENESIS_TIME
+func TimeAt(offset time.Duration) utctime.UTCTime {
+	return utctime.FromUnixNano(GENESIS_TIME.UnixNano() + offset.Nanoseconds())
+}
+
+// BlockTimeAt returns the time of the block at the height on a test chain producing one block per second
+func BlockTimeAt(height int64) utctime.UTCTime {
+	return TimeAt(time.Duration(height) * time.Second)
+}
+
+// NewGenesisCreatedEvent returns the GenesisCreated event of a genesis at the time. The optional setup fills
+// in the genesis fields the projection reads.
+func NewGenesisCreatedEvent(genesisTime utctime.UTCTime, maybeSetup func(*genesis.Genesis)) entity_event.Event {
+	var anyGenesis genesis.Genesis
+	anyGenesis.GenesisTime = time.Unix(0, genesisTime.UnixNano()).UTC().Format(time.RFC3339Nano)
+	if maybeSetup != nil {
+		maybeSetup(&anyGenesis)
+	}
+	return event_usecase.NewGenesisCreated(anyGenesis)
+}
+
+// NewBlockCreatedEvent returns the BlockCreated event of a block at the height and time
+func NewBlockCreatedEvent(height int64, blockTime utctime.UTCTime) entity_event.Event {
+	return event_usecase.NewBlockCreated(&usecase_model.Block{
+		Height: height,
+		Hash:   "hash",
+		Time:   blockTime,
+	})
+}
+
+// NewMsgCommonParams returns the common params of the first message of a successful transaction
+func NewMsgCommonParams(height int64, txHash string) event_usecase.MsgCommonParams {
+	return event_usecase.MsgCommonParams{
+		BlockHeight: height,
+		TxHash:      txHash,
+		TxSuccess:   true,
+		MsgIndex:    0,
+	}
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	entity_command "github.com/crypto-com/chain-indexing/entity/command"
+	entity_event "github.com/crypto-com/chain-indexing/entity/event"
+	"github.com/crypto-com/chain-indexing/infrastructure/tendermint"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+)
+
+// BlockFixture is a pair of raw Tendermint block and block results responses, as found in
+// `usecase/parser/test`
+type BlockFixture struct {
+	BlockResp        string
+	BlockResultsResp string
+}
+
+func NewBlockFixture(blockResp string, blockResultsResp string) BlockFixture {
+	return BlockFixture{
+		BlockResp:        blockResp,
+		BlockResultsResp: blockResultsResp,
+	}
+}
+
+// ParseBlockFixtureEvents parses the block fixture into the events the indexing service would store
+func ParseBlockFixtureEvents(txDecoder *parser.TxDecoder, fixture BlockFixture) ([]entity_event.Event, error) {
+	block, rawBlock, err := tendermint.ParseBlockResp(strings.NewReader(fixture.BlockResp))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing block response: %v", err)
+	}
+	blockResults, err := tendermint.ParseBlockResultsResp(strings.NewReader(fixture.BlockResultsResp))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing block results response: %v", err)
+	}
+
+	commands, err := parser.ParseBlockToCommands(txDecoder, block, rawBlock, blockResults)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing block to commands: %v", err)
+	}
+
+	return execCommands(commands)
+}
+
+func MustParseBlockFixtureEvents(txDecoder *parser.TxDecoder, fixture BlockFixture) []entity_event.Event {
+	events, err := ParseBlockFixtureEvents(txDecoder, fixture)
+	if err != nil {
+		panic(err)
+	}
+	return events
+}
+
+// ParseBlockResultsFixtureEvents parses the raw Tendermint block results response, as found for the begin
+// and end block events fixtures in `usecase/parser/test`, into the events the indexing service would store.
+// The block itself is not part of the fixture, so the block events only carry its height.
+func ParseBlockResultsFixtureEvents(
+	txDecoder *parser.TxDecoder,
+	blockResultsResp string,
+) ([]entity_event.Event, error) {
+	blockResults, err := tendermint.ParseBlockResultsResp(strings.NewReader(blockResultsResp))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing block results response: %v", err)
+	}
+	var rawBlock usecase_model.RawBlock
+	rawBlock.Block.Header.Height = strconv.FormatInt(blockResults.Height, 10)
+
+	commands, err := parser.ParseBlockToCommands(
+		txDecoder, &usecase_model.Block{Height: blockResults.Height}, &rawBlock, blockResults,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing block results to commands: %v", err)
+	}
+
+	return execCommands(commands)
+}
+
+func MustParseBlockResultsFixtureEvents(txDecoder *parser.TxDecoder, blockResultsResp string) []entity_event.Event {
+	events, err := ParseBlockResultsFixtureEvents(txDecoder, blockResultsResp)
+	if err != nil {
+		panic(err)
+	}
+	return events
+}
+
+// ParseGenesisFixtureEvents parses the raw Tendermint genesis response into genesis events
+func ParseGenesisFixtureEvents(genesisResp string) ([]entity_event.Event, error) {
+	genesis, err := tendermint.ParseGenesisResp(strings.NewReader(genesisResp))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing genesis response: %v", err)
+	}
+
+	commands, err := parser.ParseGenesisCommands(genesis)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing genesis to commands: %v", err)
+	}
+
+	return execCommands(commands)
+}
+
+func MustParseGenesisFixtureEvents(genesisResp string) []entity_event.Event {
+	events, err := ParseGenesisFixtureEvents(genesisResp)
+	if err != nil {
+		panic(err)
+	}
+	return events
+}
+
+func execCommands(commands []entity_command.Command) ([]entity_event.Event, error) {
+	events := make([]entity_event.Event, 0, len(commands))
+	for _, command := range commands {
+		event, execErr := command.Exec()
+		if execErr != nil {
+			return nil, fmt.Errorf("error executing command %s: %v", command.Name(), execErr)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"fmt"
+
+	entity_event "github.com/crypto-com/chain-indexing/entity/event"
+	eventtest "github.com/crypto-com/chain-indexing/entity/event/test"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
+)
+
+// ReplayEvents stores the events in a fresh in-memory event store and replays them through the
+// projection. See ReplayStore.
+func ReplayEvents(projection entity_projection.Projection, events []entity_event.Event) error {
+	eventStore := eventtest.NewInMemoryEventStore()
+	if err := eventStore.InsertAll(events); err != nil {
+		return fmt.Errorf("error inserting events: %v", err)
+	}
+
+	return ReplayStore(projection, eventStore)
+}
+
+// ReplayStore feeds the stored events to the projection in ascending height order following the
+// Projection contract: initialize the projection when it has not handled any event, skip the heights
+// already handled and pass only the subscribed events. Heights without subscribed events
+// are still handled so that the last handled event height advances.
+func ReplayStore(projection entity_projection.Projection, eventStore *eventtest.InMemoryEventStore) error {
+	maybeLastHandledHeight, err := projection.GetLastHandledEventHeight()
+	if err != nil {
+		return fmt.Errorf("error getting last handled event height of %s: %v", projection.Id(), err)
+	}
+	if maybeLastHandledHeight == nil {
+		if initErr := projection.OnInit(); initErr != nil {
+			return fmt.Errorf("error initializing %s: %v", projection.Id(), initErr)
+		}
+	}
+
+	subscriptions := entity_projection.SubscriptionsOf(projection)
+	for _, height := range eventStore.Heights() {
+		if maybeLastHandledHeight != nil && height <= *maybeLastHandledHeight {
+			continue
+		}
+
+		events, getErr := eventStore.GetAllByHeightWithFilters(height, entity_projection.FiltersOf(subscriptions))
+		if getErr != nil {
+			return fmt.Errorf("error getting events at height %d: %v", height, getErr)
+		}
+		events = entity_projection.FilterSubscribedEvents(events, subscriptions)
+
+		if handleErr := projection.HandleEvents(height, events); handleErr != nil {
+			return fmt.Errorf("error handling events at height %d by %s: %v", height, projection.Id(), handleErr)
+		}
+	}
+
+	return nil
+}
+
+func MustReplayEvents(projection entity_projection.Projection, events []entity_event.Event) {
+	if err := ReplayEvents(projection, events); err != nil {
+		panic(err)
+	}
+}
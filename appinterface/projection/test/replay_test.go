@@ -0,0 +1,60 @@
+package test_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/block"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/block/view"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("Replay", func() {
+	txDecoder := parser.NewTxDecoder("basetcro")
+
+	var conn rdb.Conn
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+	})
+
+	It("should project the block fixture events into the view", func() {
+		events := MustParseBlockFixtureEvents(txDecoder, NewBlockFixture(
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESULTS_RESP,
+		))
+		Expect(events).NotTo(BeEmpty())
+
+		projection := block.NewBlock(NewFakeLogger(), conn)
+		Expect(ReplayEvents(projection, events)).To(Succeed())
+
+		height := events[0].Height()
+		Expect(projection.GetLastHandledEventHeight()).To(Equal(primptr.Int64(height)))
+
+		blocksView := view.NewBlocks(conn.ToHandle())
+		actual, err := blocksView.FindBy(&view.BlockIdentity{
+			MaybeHeight: &height,
+		})
+		Expect(err).To(BeNil())
+		Expect(actual.Height).To(Equal(height))
+		Expect(actual.TransactionCount).To(Equal(1))
+		Expect(blocksView.Count()).To(Equal(height))
+	})
+
+	It("should skip heights already handled by the projection", func() {
+		events := MustParseBlockFixtureEvents(txDecoder, NewBlockFixture(
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_SEND_BLOCK_RESULTS_RESP,
+		))
+
+		projection := block.NewBlock(NewFakeLogger(), conn)
+		Expect(ReplayEvents(projection, events)).To(Succeed())
+		// Inserting the same block twice would violate the primary key
+		Expect(ReplayEvents(projection, events)).To(Succeed())
+	})
+})
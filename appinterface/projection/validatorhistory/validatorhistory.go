@@ -0,0 +1,245 @@
+package validatorhistory
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorhistory/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &ValidatorHistory{}
+
+const DO_NOT_MODIFY = "[do-not-modify]"
+
+// ValidatorHistory records the description and commission of each validator on creation and on every
+// successful edit that changes them. Genesis validators are recorded at height 0 with the genesis time.
+type ValidatorHistory struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+}
+
+func NewValidatorHistory(logger applogger.Logger, rdbConn rdb.Conn) *ValidatorHistory {
+	return &ValidatorHistory{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "ValidatorHistory"),
+
+		rdbConn,
+		logger,
+	}
+}
+
+func (_ *ValidatorHistory) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_CREATE_VALIDATOR_CREATED,
+		event_usecase.MSG_EDIT_VALIDATOR_CREATED,
+	}
+}
+
+func (projection *ValidatorHistory) OnInit() error {
+	return nil
+}
+
+func (projection *ValidatorHistory) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var maybeBlockTime *utctime.UTCTime
+	validatorEvents := make([]event_entity.Event, 0)
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			projection.logger.Debug("handling GenesisCreated event")
+			genesisTime, parseErr := utctime.Parse(time.RFC3339, genesisCreatedEvent.Genesis.GenesisTime)
+			if parseErr != nil {
+				return fmt.Errorf("error parsing genesis time: %v", parseErr)
+			}
+			maybeBlockTime = &genesisTime
+		} else {
+			validatorEvents = append(validatorEvents, event)
+		}
+	}
+
+	if len(validatorEvents) > 0 {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling validator events: missing block time at height %d", height)
+		}
+		historiesView := view.NewValidatorHistories(rdbTxHandle)
+		for _, event := range validatorEvents {
+			if msgCreateValidatorEvent, ok := event.(*event_usecase.MsgCreateValidator); ok {
+				projection.logger.Debug("handling MsgCreateValidator event")
+				if err := projection.handleMsgCreateValidator(
+					historiesView, *maybeBlockTime, msgCreateValidatorEvent,
+				); err != nil {
+					return fmt.Errorf("error handling MsgCreateValidator: %v", err)
+				}
+			} else if msgEditValidatorEvent, ok := event.(*event_usecase.MsgEditValidator); ok {
+				projection.logger.Debug("handling MsgEditValidator event")
+				if err := projection.handleMsgEditValidator(
+					historiesView, *maybeBlockTime, msgEditValidatorEvent,
+				); err != nil {
+					return fmt.Errorf("error handling MsgEditValidator: %v", err)
+				}
+			}
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *ValidatorHistory) handleMsgCreateValidator(
+	historiesView *view.ValidatorHistories,
+	blockTime utctime.UTCTime,
+	event *event_usecase.MsgCreateValidator,
+) error {
+	if err := historiesView.Insert(&view.ValidatorHistoryRow{
+		OperatorAddress:             event.ValidatorAddress,
+		BlockHeight:                 event.BlockHeight,
+		BlockTime:                   blockTime,
+		TransactionHash:             event.TxHash(),
+		Action:                      view.ACTION_CREATE,
+		Moniker:                     event.Description.Moniker,
+		Identity:                    event.Description.Identity,
+		Website:                     event.Description.Website,
+		SecurityContact:             event.Description.SecurityContact,
+		Details:                     event.Description.Details,
+		CommissionRate:              event.CommissionRates.Rate,
+		MaybePreviousCommissionRate: nil,
+		MinSelfDelegation:           event.MinSelfDelegation,
+		DescriptionChanged:          false,
+		CommissionChanged:           false,
+		CommissionIncreased:         false,
+	}); err != nil {
+		return fmt.Errorf("error inserting validator history: %v", err)
+	}
+
+	return nil
+}
+
+// handleMsgEditValidator applies the edit on the latest history of the validator. Edits changing
+// neither the description, the commission rate nor the min self delegation are not recorded.
+func (projection *ValidatorHistory) handleMsgEditValidator(
+	historiesView *view.ValidatorHistories,
+	blockTime utctime.UTCTime,
+	event *event_usecase.MsgEditValidator,
+) error {
+	var maybePreviousCommissionRate *string
+	mutHistory, err := historiesView.FindLatestBy(event.ValidatorAddress)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting latest validator history: %v", err)
+		}
+		// The creation of the validator is not indexed, the unmodified fields are unknown
+		mutHistory = &view.ValidatorHistoryRow{
+			OperatorAddress: event.ValidatorAddress,
+		}
+	} else {
+		previousCommissionRate := mutHistory.CommissionRate
+		maybePreviousCommissionRate = &previousCommissionRate
+	}
+
+	descriptionFields := []struct {
+		field *string
+		value string
+	}{
+		{&mutHistory.Moniker, event.Description.Moniker},
+		{&mutHistory.Identity, event.Description.Identity},
+		{&mutHistory.Website, event.Description.Website},
+		{&mutHistory.SecurityContact, event.Description.SecurityContact},
+		{&mutHistory.Details, event.Description.Details},
+	}
+	descriptionChanged := false
+	for _, descriptionField := range descriptionFields {
+		if descriptionField.value != DO_NOT_MODIFY && descriptionField.value != *descriptionField.field {
+			*descriptionField.field = descriptionField.value
+			descriptionChanged = true
+		}
+	}
+
+	commissionChanged := false
+	commissionIncreased := false
+	if event.MaybeCommissionRate != nil {
+		if maybePreviousCommissionRate == nil {
+			commissionChanged = true
+		} else {
+			comparison, compareErr := compareRates(*event.MaybeCommissionRate, *maybePreviousCommissionRate)
+			if compareErr != nil {
+				return fmt.Errorf("error comparing commission rates: %v", compareErr)
+			}
+			commissionChanged = comparison != 0
+			commissionIncreased = comparison > 0
+		}
+		mutHistory.CommissionRate = *event.MaybeCommissionRate
+	}
+
+	minSelfDelegationChanged := false
+	if event.MaybeMinSelfDelegation != nil && *event.MaybeMinSelfDelegation != mutHistory.MinSelfDelegation {
+		mutHistory.MinSelfDelegation = *event.MaybeMinSelfDelegation
+		minSelfDelegationChanged = true
+	}
+
+	if !descriptionChanged && !commissionChanged && !minSelfDelegationChanged {
+		projection.logger.Debug("skipping MsgEditValidator without changes")
+		return nil
+	}
+
+	mutHistory.BlockHeight = event.BlockHeight
+	mutHistory.BlockTime = blockTime
+	mutHistory.TransactionHash = event.TxHash()
+	mutHistory.Action = view.ACTION_EDIT
+	mutHistory.MaybePreviousCommissionRate = maybePreviousCommissionRate
+	mutHistory.DescriptionChanged = descriptionChanged
+	mutHistory.CommissionChanged = commissionChanged
+	mutHistory.CommissionIncreased = commissionIncreased
+	if err := historiesView.Insert(mutHistory); err != nil {
+		return fmt.Errorf("error inserting validator history: %v", err)
+	}
+
+	return nil
+}
+
+// compareRates compares two decimal rates, returns -1 when rate is lower than other, 0 when they are equal
+// and +1 when rate is higher
+func compareRates(rate string, other string) (int, error) {
+	rateRat, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return 0, fmt.Errorf("error parsing rate: %s", rate)
+	}
+	otherRat, ok := new(big.Rat).SetString(other)
+	if !ok {
+		return 0, fmt.Errorf("error parsing rate: %s", other)
+	}
+
+	return rateRat.Cmp(otherRat), nil
+}
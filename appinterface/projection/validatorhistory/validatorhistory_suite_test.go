@@ -0,0 +1,13 @@
+package validatorhistory_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestValidatorHistory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ValidatorHistory Suite")
+}
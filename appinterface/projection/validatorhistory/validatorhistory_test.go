@@ -0,0 +1,179 @@
+package validatorhistory_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorhistory"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorhistory/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("ValidatorHistory", func() {
+	const operatorAddress = "tcrocncl1fmprm0sjy6lz9llv7rltn0v2azzwcwzvr4ufus"
+
+	var conn rdb.Conn
+	var projection *validatorhistory.ValidatorHistory
+	var historiesView *view.ValidatorHistories
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = validatorhistory.NewValidatorHistory(NewFakeLogger(), conn)
+		historiesView = view.NewValidatorHistories(conn.ToHandle())
+	})
+
+	// The start of the day, counting from the genesis day as day 1
+	timeOf := func(day int) utctime.UTCTime {
+		return TimeAt(time.Duration(day-1) * 24 * time.Hour)
+	}
+	msgCreateValidator := func(height int64, commissionRate string) event_entity.Event {
+		return event_usecase.NewMsgCreateValidator(NewMsgCommonParams(height, ""), usecase_model.MsgCreateValidatorParams{
+			Description: usecase_model.MsgValidatorDescription{
+				Moniker: "Validator",
+				Website: "https://validator.example",
+			},
+			Commission: usecase_model.MsgValidatorCommission{
+				Rate:          commissionRate,
+				MaxRate:       "0.200000000000000000",
+				MaxChangeRate: "0.010000000000000000",
+			},
+			MinSelfDelegation: "1",
+			ValidatorAddress:  operatorAddress,
+		})
+	}
+	doNotModify := usecase_model.MsgValidatorDescription{
+		Moniker:         validatorhistory.DO_NOT_MODIFY,
+		Identity:        validatorhistory.DO_NOT_MODIFY,
+		Website:         validatorhistory.DO_NOT_MODIFY,
+		SecurityContact: validatorhistory.DO_NOT_MODIFY,
+		Details:         validatorhistory.DO_NOT_MODIFY,
+	}
+	msgEditValidator := func(
+		height int64,
+		description usecase_model.MsgValidatorDescription,
+		maybeCommissionRate *string,
+	) event_entity.Event {
+		return event_usecase.NewMsgEditValidator(NewMsgCommonParams(height, "hash"), usecase_model.MsgEditValidatorParams{
+			Description:         description,
+			ValidatorAddress:    operatorAddress,
+			MaybeCommissionRate: maybeCommissionRate,
+		})
+	}
+	listHistories := func() []view.ValidatorHistoryRow {
+		histories, _, err := historiesView.ListByOperatorAddress(
+			operatorAddress,
+			view.ValidatorHistoriesListOrder{MaybeId: primptr.String("ASC")},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		return histories
+	}
+
+	It("should record genesis validators with the genesis time", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewGenesisCreatedEvent(GENESIS_TIME, nil),
+			msgCreateValidator(0, "0.100000000000000000"),
+		})
+
+		histories := listHistories()
+		Expect(histories).To(HaveLen(1))
+		Expect(histories[0].Action).To(Equal(view.ACTION_CREATE))
+		Expect(histories[0].BlockHeight).To(Equal(int64(0)))
+		Expect(histories[0].BlockTime).To(Equal(GENESIS_TIME))
+		Expect(histories[0].Moniker).To(Equal("Validator"))
+		Expect(histories[0].CommissionRate).To(Equal("0.100000000000000000"))
+		Expect(histories[0].MaybePreviousCommissionRate).To(BeNil())
+	})
+
+	It("should keep the unmodified fields and record the previous commission rate", func() {
+		description := doNotModify
+		description.Moniker = "Renamed"
+		MustReplayEvents(projection, []event_entity.Event{
+			NewGenesisCreatedEvent(GENESIS_TIME, nil),
+			msgCreateValidator(0, "0.100000000000000000"),
+			NewBlockCreatedEvent(1, timeOf(2)),
+			msgEditValidator(1, description, nil),
+			NewBlockCreatedEvent(2, timeOf(3)),
+			msgEditValidator(2, doNotModify, primptr.String("0.110000000000000000")),
+			NewBlockCreatedEvent(3, timeOf(4)),
+			msgEditValidator(3, doNotModify, primptr.String("0.050000000000000000")),
+		})
+
+		histories := listHistories()
+		Expect(histories).To(HaveLen(4))
+
+		Expect(histories[1].Moniker).To(Equal("Renamed"))
+		Expect(histories[1].Website).To(Equal("https://validator.example"))
+		Expect(histories[1].CommissionRate).To(Equal("0.100000000000000000"))
+		Expect(histories[1].DescriptionChanged).To(BeTrue())
+		Expect(histories[1].CommissionChanged).To(BeFalse())
+
+		Expect(histories[2].Moniker).To(Equal("Renamed"))
+		Expect(histories[2].BlockTime).To(Equal(timeOf(3)))
+		Expect(histories[2].CommissionRate).To(Equal("0.110000000000000000"))
+		Expect(histories[2].MaybePreviousCommissionRate).To(Equal(primptr.String("0.100000000000000000")))
+		Expect(histories[2].DescriptionChanged).To(BeFalse())
+		Expect(histories[2].CommissionIncreased).To(BeTrue())
+
+		Expect(histories[3].CommissionChanged).To(BeTrue())
+		Expect(histories[3].CommissionIncreased).To(BeFalse())
+
+		increases, _, err := historiesView.ListCommissionIncreases(
+			view.ValidatorCommissionIncreasesListFilter{}, pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(increases).To(HaveLen(1))
+		Expect(increases[0].BlockHeight).To(Equal(int64(2)))
+
+		increases, _, err = historiesView.ListCommissionIncreases(
+			view.ValidatorCommissionIncreasesListFilter{MaybeSinceTime: primptr.UTCTime(timeOf(4))},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(increases).To(BeEmpty())
+	})
+
+	It("should skip edits without changes", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewGenesisCreatedEvent(GENESIS_TIME, nil),
+			msgCreateValidator(0, "0.100000000000000000"),
+			NewBlockCreatedEvent(1, timeOf(2)),
+			msgEditValidator(1, doNotModify, primptr.String("0.1")),
+		})
+
+		Expect(listHistories()).To(HaveLen(1))
+	})
+
+	It("should record the validator created in a real block", func() {
+		const fixtureOperatorAddress = "tcrocncl109ww3ss92v4vsaq470vvgw528mtqp98m4s04ex"
+
+		MustReplayEvents(projection, MustParseBlockFixtureEvents(parser.NewTxDecoder("basetcro"), NewBlockFixture(
+			usecase_parser_test.TX_MSG_CREATE_VALIDATOR_BLOCK_RESP,
+			usecase_parser_test.TX_MSG_CREATE_VALIDATOR_BLOCK_RESULTS_RESP,
+		)))
+
+		histories, _, err := historiesView.ListByOperatorAddress(
+			fixtureOperatorAddress,
+			view.ValidatorHistoriesListOrder{MaybeId: primptr.String("ASC")},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(histories).To(HaveLen(1))
+		Expect(histories[0].Action).To(Equal(view.ACTION_CREATE))
+		Expect(histories[0].BlockHeight).To(Equal(int64(76550)))
+		Expect(histories[0].Moniker).To(Equal("leo-node"))
+		Expect(histories[0].CommissionRate).To(Equal("0.100000000000000000"))
+	})
+})
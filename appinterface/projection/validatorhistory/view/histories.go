@@ -0,0 +1,256 @@
+package view
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+const (
+	ACTION_CREATE = "create"
+	ACTION_EDIT   = "edit"
+)
+
+// ValidatorHistories keeps every description and commission change of the validators. Each entry is
+// the full state of the validator after the change.
+type ValidatorHistories struct {
+	rdb *rdb.Handle
+}
+
+func NewValidatorHistories(handle *rdb.Handle) *ValidatorHistories {
+	return &ValidatorHistories{
+		handle,
+	}
+}
+
+func (historiesView *ValidatorHistories) Insert(history *ValidatorHistoryRow) error {
+	sql, sqlArgs, err := historiesView.rdb.StmtBuilder.Insert(
+		"view_validator_histories",
+	).Columns(
+		"operator_address",
+		"block_height",
+		"block_time",
+		"transaction_hash",
+		"action",
+		"moniker",
+		"identity",
+		"website",
+		"security_contact",
+		"details",
+		"commission_rate",
+		"previous_commission_rate",
+		"min_self_delegation",
+		"description_changed",
+		"commission_changed",
+		"commission_increased",
+	).Values(
+		history.OperatorAddress,
+		history.BlockHeight,
+		historiesView.rdb.Tton(&history.BlockTime),
+		history.TransactionHash,
+		history.Action,
+		history.Moniker,
+		history.Identity,
+		history.Website,
+		history.SecurityContact,
+		history.Details,
+		history.CommissionRate,
+		history.MaybePreviousCommissionRate,
+		history.MinSelfDelegation,
+		history.DescriptionChanged,
+		history.CommissionChanged,
+		history.CommissionIncreased,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building validator history insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := historiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator history into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting validator history into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindLatestBy returns rdb.ErrNoRows when the validator has no history
+func (historiesView *ValidatorHistories) FindLatestBy(operatorAddress string) (*ValidatorHistoryRow, error) {
+	sql, sqlArgs, err := historiesView.selectStmtBuilder().Where(
+		"operator_address = ?", operatorAddress,
+	).OrderBy(
+		"id DESC",
+	).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building validator history selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing validator history selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return historiesView.scan(rowsResult)
+}
+
+// Defaults to the latest change first
+type ValidatorHistoriesListOrder struct {
+	MaybeId *view.ORDER
+}
+
+func (historiesView *ValidatorHistories) ListByOperatorAddress(
+	operatorAddress string,
+	order ValidatorHistoriesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]ValidatorHistoryRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := historiesView.selectStmtBuilder().Where("operator_address = ?", operatorAddress)
+	if order.MaybeId != nil && *order.MaybeId == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("id")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("id DESC")
+	}
+
+	return historiesView.list(stmtBuilder, pagination)
+}
+
+type ValidatorCommissionIncreasesListFilter struct {
+	// Inclusive
+	MaybeSinceTime *utctime.UTCTime
+}
+
+// ListCommissionIncreases returns the commission increases of all validators, the latest first
+func (historiesView *ValidatorHistories) ListCommissionIncreases(
+	filter ValidatorCommissionIncreasesListFilter,
+	pagination *pagination_interface.Pagination,
+) ([]ValidatorHistoryRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := historiesView.selectStmtBuilder().Where("commission_increased = ?", true)
+	if filter.MaybeSinceTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time >= ?", historiesView.rdb.Tton(filter.MaybeSinceTime))
+	}
+	stmtBuilder = stmtBuilder.OrderBy("block_height DESC", "id DESC")
+
+	return historiesView.list(stmtBuilder, pagination)
+}
+
+func (historiesView *ValidatorHistories) list(
+	stmtBuilder sq.SelectBuilder,
+	pagination *pagination_interface.Pagination,
+) ([]ValidatorHistoryRow, *pagination_interface.PaginationResult, error) {
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		historiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building validator histories select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing validator histories select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	histories := make([]ValidatorHistoryRow, 0)
+	for rowsResult.Next() {
+		history, scanErr := historiesView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		histories = append(histories, *history)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return histories, paginationResult, nil
+}
+
+func (historiesView *ValidatorHistories) selectStmtBuilder() sq.SelectBuilder {
+	return historiesView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"block_height",
+		"block_time",
+		"transaction_hash",
+		"action",
+		"moniker",
+		"identity",
+		"website",
+		"security_contact",
+		"details",
+		"commission_rate",
+		"previous_commission_rate",
+		"min_self_delegation",
+		"description_changed",
+		"commission_changed",
+		"commission_increased",
+	).From(
+		"view_validator_histories",
+	)
+}
+
+func (historiesView *ValidatorHistories) scan(rowsResult rdb.RowsResult) (*ValidatorHistoryRow, error) {
+	var history ValidatorHistoryRow
+	blockTimeReader := historiesView.rdb.NtotReader()
+	if err := rowsResult.Scan(
+		&history.OperatorAddress,
+		&history.BlockHeight,
+		blockTimeReader.ScannableArg(),
+		&history.TransactionHash,
+		&history.Action,
+		&history.Moniker,
+		&history.Identity,
+		&history.Website,
+		&history.SecurityContact,
+		&history.Details,
+		&history.CommissionRate,
+		&history.MaybePreviousCommissionRate,
+		&history.MinSelfDelegation,
+		&history.DescriptionChanged,
+		&history.CommissionChanged,
+		&history.CommissionIncreased,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning validator history row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	blockTime, err := blockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing validator history block time: %v: %w", err, rdb.ErrQuery)
+	}
+	history.BlockTime = *blockTime
+
+	return &history, nil
+}
+
+// ValidatorHistoryRow previous commission rate is nil on creation or when the creation is not indexed
+type ValidatorHistoryRow struct {
+	OperatorAddress             string          `json:"operatorAddress"`
+	BlockHeight                 int64           `json:"blockHeight"`
+	BlockTime                   utctime.UTCTime `json:"blockTime"`
+	TransactionHash             string          `json:"transactionHash"`
+	Action                      string          `json:"action"`
+	Moniker                     string          `json:"moniker"`
+	Identity                    string          `json:"identity"`
+	Website                     string          `json:"website"`
+	SecurityContact             string          `json:"securityContact"`
+	Details                     string          `json:"details"`
+	CommissionRate              string          `json:"commissionRate"`
+	MaybePreviousCommissionRate *string         `json:"previousCommissionRate"`
+	MinSelfDelegation           string          `json:"minSelfDelegation"`
+	DescriptionChanged          bool            `json:"descriptionChanged"`
+	CommissionChanged           bool            `json:"commissionChanged"`
+	CommissionIncreased         bool            `json:"commissionIncreased"`
+}
@@ -0,0 +1,34 @@
+package validatorreward
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Reward and commission amounts are decimals with the same precision as the Cosmos SDK `sdk.Dec`
+const AMOUNT_PRECISION = 18
+
+func parseAmount(amount string) (*big.Rat, error) {
+	if amount == "" {
+		return new(big.Rat), nil
+	}
+
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("error parsing amount: %s", amount)
+	}
+	return value, nil
+}
+
+func formatAmount(amount *big.Rat) string {
+	return amount.FloatString(AMOUNT_PRECISION)
+}
+
+// addAmount returns the sum of the stored decimal string and the amount, formatted for storage
+func addAmount(stored string, amount *big.Rat) (string, error) {
+	value, err := parseAmount(stored)
+	if err != nil {
+		return "", err
+	}
+	return formatAmount(value.Add(value, amount)), nil
+}
@@ -0,0 +1,279 @@
+package validatorreward
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorreward/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &ValidatorReward{}
+
+var BUCKET_INTERVALS = []view.RewardBucketInterval{
+	view.REWARD_BUCKET_INTERVAL_HOUR,
+	view.REWARD_BUCKET_INTERVAL_DAY,
+}
+
+// ValidatorReward sums the rewards, proposer rewards and commission distributed to each validator by
+// the hour and by the day, and keeps track of the commission withdrawn and still outstanding.
+type ValidatorReward struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+}
+
+func NewValidatorReward(logger applogger.Logger, rdbConn rdb.Conn) *ValidatorReward {
+	return &ValidatorReward{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "ValidatorReward"),
+
+		rdbConn,
+		logger,
+	}
+}
+
+func (_ *ValidatorReward) GetEventsToListen() []string {
+	return []string{
+		event_usecase.BLOCK_CREATED,
+		event_usecase.BLOCK_REWARDED,
+		event_usecase.BLOCK_PROPOSER_REWARDED,
+		event_usecase.BLOCK_COMMISSIONED,
+		event_usecase.MSG_WITHDRAW_VALIDATOR_COMMISSION_CREATED,
+	}
+}
+
+func (projection *ValidatorReward) OnInit() error {
+	return nil
+}
+
+type earnings struct {
+	rewards         *big.Rat
+	proposerRewards *big.Rat
+	commissions     *big.Rat
+}
+
+func newEarnings() *earnings {
+	return &earnings{
+		rewards:         new(big.Rat),
+		proposerRewards: new(big.Rat),
+		commissions:     new(big.Rat),
+	}
+}
+
+func (projection *ValidatorReward) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var maybeBlockTime *utctime.UTCTime
+	earningsByValidator := make(map[string]*earnings)
+	earningsOf := func(operatorAddress string) *earnings {
+		if _, ok := earningsByValidator[operatorAddress]; !ok {
+			earningsByValidator[operatorAddress] = newEarnings()
+		}
+		return earningsByValidator[operatorAddress]
+	}
+	withdrawals := make([]*event_usecase.MsgWithdrawValidatorCommission, 0)
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if blockRewardedEvent, ok := event.(*event_usecase.BlockRewarded); ok {
+			amount, err := parseAmount(blockRewardedEvent.Amount)
+			if err != nil {
+				return fmt.Errorf("error parsing BlockRewarded amount: %v", err)
+			}
+			rewards := earningsOf(blockRewardedEvent.Validator).rewards
+			rewards.Add(rewards, amount)
+		} else if proposerRewardedEvent, ok := event.(*event_usecase.BlockProposerRewarded); ok {
+			amount, err := parseAmount(proposerRewardedEvent.Amount)
+			if err != nil {
+				return fmt.Errorf("error parsing BlockProposerRewarded amount: %v", err)
+			}
+			proposerRewards := earningsOf(proposerRewardedEvent.Validator).proposerRewards
+			proposerRewards.Add(proposerRewards, amount)
+		} else if commissionedEvent, ok := event.(*event_usecase.BlockCommissioned); ok {
+			amount, err := parseAmount(commissionedEvent.Amount)
+			if err != nil {
+				return fmt.Errorf("error parsing BlockCommissioned amount: %v", err)
+			}
+			commissions := earningsOf(commissionedEvent.Validator).commissions
+			commissions.Add(commissions, amount)
+		} else if withdrawEvent, ok := event.(*event_usecase.MsgWithdrawValidatorCommission); ok {
+			withdrawals = append(withdrawals, withdrawEvent)
+		}
+	}
+
+	if len(earningsByValidator) > 0 || len(withdrawals) > 0 {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling validator rewards: missing BlockCreated event at height %d", height)
+		}
+
+		bucketsView := view.NewRewardBuckets(rdbTxHandle)
+		totalsView := view.NewRewardTotals(rdbTxHandle)
+
+		// Sorted for deterministic write order
+		operatorAddresses := make([]string, 0, len(earningsByValidator))
+		for operatorAddress := range earningsByValidator {
+			operatorAddresses = append(operatorAddresses, operatorAddress)
+		}
+		sort.Strings(operatorAddresses)
+		for _, operatorAddress := range operatorAddresses {
+			validatorEarnings := earningsByValidator[operatorAddress]
+			if err := projection.recordEarnings(
+				bucketsView, *maybeBlockTime, operatorAddress, validatorEarnings,
+			); err != nil {
+				return fmt.Errorf("error recording validator earnings: %v", err)
+			}
+			if err := projection.addToTotal(
+				totalsView, height, operatorAddress, validatorEarnings, new(big.Rat),
+			); err != nil {
+				return fmt.Errorf("error updating validator reward total: %v", err)
+			}
+		}
+
+		withdrawalsView := view.NewCommissionWithdrawals(rdbTxHandle)
+		for _, withdrawal := range withdrawals {
+			projection.logger.Debug("handling MsgWithdrawValidatorCommission event")
+			if err := withdrawalsView.Insert(&view.CommissionWithdrawalRow{
+				OperatorAddress:  withdrawal.ValidatorAddress,
+				RecipientAddress: withdrawal.RecipientAddress,
+				Amount:           withdrawal.Amount.String(),
+				BlockHeight:      height,
+				BlockTime:        *maybeBlockTime,
+				TransactionHash:  withdrawal.MsgTxHash,
+			}); err != nil {
+				return fmt.Errorf("error inserting commission withdrawal: %v", err)
+			}
+			withdrawnAmount := new(big.Rat).SetInt(withdrawal.Amount.ToBigInt())
+			if err := projection.addToTotal(
+				totalsView, height, withdrawal.ValidatorAddress, newEarnings(), withdrawnAmount,
+			); err != nil {
+				return fmt.Errorf("error updating validator reward total: %v", err)
+			}
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *ValidatorReward) recordEarnings(
+	bucketsView *view.RewardBuckets,
+	blockTime utctime.UTCTime,
+	operatorAddress string,
+	validatorEarnings *earnings,
+) error {
+	for _, interval := range BUCKET_INTERVALS {
+		bucketStart := view.RewardBucketStart(blockTime, interval)
+		mutBucket, err := bucketsView.FindBy(view.RewardBucketIdentity{
+			OperatorAddress: operatorAddress,
+			Interval:        interval,
+			BucketStart:     bucketStart,
+		})
+		if err != nil {
+			if !errors.Is(err, rdb.ErrNoRows) {
+				return fmt.Errorf("error getting existing reward bucket: %v", err)
+			}
+			mutBucket = &view.RewardBucketRow{
+				OperatorAddress: operatorAddress,
+				Interval:        interval,
+				BucketStart:     bucketStart,
+			}
+		}
+
+		if mutBucket.Rewards, err = addAmount(mutBucket.Rewards, validatorEarnings.rewards); err != nil {
+			return fmt.Errorf("error adding bucket rewards: %v", err)
+		}
+		if mutBucket.ProposerRewards, err = addAmount(
+			mutBucket.ProposerRewards, validatorEarnings.proposerRewards,
+		); err != nil {
+			return fmt.Errorf("error adding bucket proposer rewards: %v", err)
+		}
+		if mutBucket.Commissions, err = addAmount(mutBucket.Commissions, validatorEarnings.commissions); err != nil {
+			return fmt.Errorf("error adding bucket commissions: %v", err)
+		}
+
+		if err := bucketsView.Upsert(mutBucket); err != nil {
+			return fmt.Errorf("error upserting reward bucket: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (projection *ValidatorReward) addToTotal(
+	totalsView *view.RewardTotals,
+	blockHeight int64,
+	operatorAddress string,
+	validatorEarnings *earnings,
+	withdrawnCommission *big.Rat,
+) error {
+	mutTotal, err := totalsView.FindBy(operatorAddress)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting existing reward total: %v", err)
+		}
+		mutTotal = &view.RewardTotalRow{
+			OperatorAddress: operatorAddress,
+		}
+	}
+
+	if mutTotal.TotalRewards, err = addAmount(mutTotal.TotalRewards, validatorEarnings.rewards); err != nil {
+		return fmt.Errorf("error adding total rewards: %v", err)
+	}
+	if mutTotal.TotalProposerRewards, err = addAmount(
+		mutTotal.TotalProposerRewards, validatorEarnings.proposerRewards,
+	); err != nil {
+		return fmt.Errorf("error adding total proposer rewards: %v", err)
+	}
+	if mutTotal.TotalCommissions, err = addAmount(
+		mutTotal.TotalCommissions, validatorEarnings.commissions,
+	); err != nil {
+		return fmt.Errorf("error adding total commissions: %v", err)
+	}
+	if mutTotal.TotalWithdrawnCommissions, err = addAmount(
+		mutTotal.TotalWithdrawnCommissions, withdrawnCommission,
+	); err != nil {
+		return fmt.Errorf("error adding total withdrawn commissions: %v", err)
+	}
+
+	outstandingCommission := new(big.Rat).Sub(validatorEarnings.commissions, withdrawnCommission)
+	if mutTotal.OutstandingCommission, err = addAmount(
+		mutTotal.OutstandingCommission, outstandingCommission,
+	); err != nil {
+		return fmt.Errorf("error adding outstanding commission: %v", err)
+	}
+	mutTotal.LastUpdatedBlockHeight = blockHeight
+
+	if err := totalsView.Upsert(mutTotal); err != nil {
+		return fmt.Errorf("error upserting reward total: %v", err)
+	}
+
+	return nil
+}
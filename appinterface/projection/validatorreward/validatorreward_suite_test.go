@@ -0,0 +1,13 @@
+package validatorreward_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestValidatorReward(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ValidatorReward Suite")
+}
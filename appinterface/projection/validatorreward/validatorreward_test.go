@@ -0,0 +1,145 @@
+package validatorreward_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorreward"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatorreward/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("ValidatorReward", func() {
+	const validatorA = "tcrocncl1validatora"
+
+	var conn rdb.Conn
+	var projection *validatorreward.ValidatorReward
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = validatorreward.NewValidatorReward(NewFakeLogger(), conn)
+	})
+
+	hourOf := func(hour int, minute int) utctime.UTCTime {
+		return TimeAt(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	It("should sum the earnings of validators into hour and day buckets", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, hourOf(10, 15)),
+			event_usecase.NewBlockRewarded(1, validatorA, "100.5"),
+			event_usecase.NewProposerRewarded(1, validatorA, "10"),
+			event_usecase.NewBlockCommissioned(1, validatorA, "20.25"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, hourOf(10, 45)),
+			event_usecase.NewBlockRewarded(2, validatorA, "50"),
+			event_usecase.NewBlockCommissioned(2, validatorA, "10"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(3, hourOf(11, 5)),
+			event_usecase.NewBlockRewarded(3, validatorA, "1"),
+		})
+
+		bucketsView := view.NewRewardBuckets(conn.ToHandle())
+		hourBuckets, _, err := bucketsView.List(view.RewardBucketsListFilter{
+			OperatorAddress: validatorA,
+			Interval:        view.REWARD_BUCKET_INTERVAL_HOUR,
+		}, view.RewardBucketsListOrder{}, pagination_interface.NewOffsetPagination(1, 10))
+		Expect(err).To(BeNil())
+		Expect(hourBuckets).To(HaveLen(2))
+		Expect(hourBuckets[0].BucketStart).To(Equal(hourOf(10, 0)))
+		Expect(hourBuckets[0].Rewards).To(Equal("150.500000000000000000"))
+		Expect(hourBuckets[0].ProposerRewards).To(Equal("10.000000000000000000"))
+		Expect(hourBuckets[0].Commissions).To(Equal("30.250000000000000000"))
+		Expect(hourBuckets[1].Rewards).To(Equal("1.000000000000000000"))
+
+		dayBuckets, _, err := bucketsView.List(view.RewardBucketsListFilter{
+			OperatorAddress: validatorA,
+			Interval:        view.REWARD_BUCKET_INTERVAL_DAY,
+		}, view.RewardBucketsListOrder{}, pagination_interface.NewOffsetPagination(1, 10))
+		Expect(err).To(BeNil())
+		Expect(dayBuckets).To(HaveLen(1))
+		Expect(dayBuckets[0].BucketStart).To(Equal(GENESIS_TIME))
+		Expect(dayBuckets[0].Rewards).To(Equal("151.500000000000000000"))
+	})
+
+	It("should record commission withdrawals and the outstanding commission", func() {
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(1, hourOf(10, 0)),
+			event_usecase.NewBlockCommissioned(1, validatorA, "100.5"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{
+			NewBlockCreatedEvent(2, hourOf(10, 1)),
+			event_usecase.NewBlockCommissioned(2, validatorA, "1"),
+			event_usecase.NewMsgWithdrawValidatorCommission(
+				NewMsgCommonParams(2, "withdraw-tx"),
+				usecase_model.MsgWithdrawValidatorCommissionParams{
+					ValidatorAddress: validatorA,
+					RecipientAddress: "tcro1recipient",
+					Amount:           coin.MustNewCoinFromInt(100),
+				},
+			),
+		})
+
+		total, err := view.NewRewardTotals(conn.ToHandle()).FindBy(validatorA)
+		Expect(err).To(BeNil())
+		Expect(total.TotalCommissions).To(Equal("101.500000000000000000"))
+		Expect(total.TotalWithdrawnCommissions).To(Equal("100.000000000000000000"))
+		Expect(total.OutstandingCommission).To(Equal("1.500000000000000000"))
+		Expect(total.LastUpdatedBlockHeight).To(Equal(int64(2)))
+
+		withdrawals, _, err := view.NewCommissionWithdrawals(conn.ToHandle()).List(
+			view.CommissionWithdrawalsListFilter{OperatorAddress: validatorA},
+			view.CommissionWithdrawalsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(withdrawals).To(Equal([]view.CommissionWithdrawalRow{
+			{
+				OperatorAddress:  validatorA,
+				RecipientAddress: "tcro1recipient",
+				Amount:           "100",
+				BlockHeight:      2,
+				BlockTime:        hourOf(10, 1),
+				TransactionHash:  "withdraw-tx",
+			},
+		}))
+	})
+
+	It("should record the commission withdrawal of a real block", func() {
+		const fixtureValidator = "tcrocncl15grftg88l0gdw4mg9t9pwnl0pde2asjzekz0ek"
+
+		MustReplayEvents(projection, MustParseBlockFixtureEvents(parser.NewTxDecoder("basetcro"), NewBlockFixture(
+			usecase_parser_test.TX_MSGS_WITHDRAW_DELEGATOR_REWARD_WITHDRAW_VALIDATOR_COMMISSION_BLOCK_RESP,
+			usecase_parser_test.TX_MSGS_WITHDRAW_DELEGATOR_REWARD_WITHDRAW_VALIDATOR_COMMISSION_BLOCK_RESULTS_RESP,
+		)))
+
+		withdrawals, _, err := view.NewCommissionWithdrawals(conn.ToHandle()).List(
+			view.CommissionWithdrawalsListFilter{OperatorAddress: fixtureValidator},
+			view.CommissionWithdrawalsListOrder{},
+			pagination_interface.NewOffsetPagination(1, 10),
+		)
+		Expect(err).To(BeNil())
+		Expect(withdrawals).To(HaveLen(1))
+		Expect(withdrawals[0].RecipientAddress).To(Equal("tcro15grftg88l0gdw4mg9t9pwnl0pde2asjzvfpkp4"))
+		Expect(withdrawals[0].Amount).To(Equal("4161370358"))
+		Expect(withdrawals[0].BlockHeight).To(Equal(int64(435599)))
+
+		total, err := view.NewRewardTotals(conn.ToHandle()).FindBy(fixtureValidator)
+		Expect(err).To(BeNil())
+		Expect(total.TotalWithdrawnCommissions).To(Equal("4161370358.000000000000000000"))
+	})
+})
@@ -0,0 +1,145 @@
+package view
+
+import (
+	"fmt"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// CommissionWithdrawals records every commission withdrawal of the validators
+type CommissionWithdrawals struct {
+	rdb *rdb.Handle
+}
+
+func NewCommissionWithdrawals(handle *rdb.Handle) *CommissionWithdrawals {
+	return &CommissionWithdrawals{
+		handle,
+	}
+}
+
+func (withdrawalsView *CommissionWithdrawals) Insert(withdrawal *CommissionWithdrawalRow) error {
+	sql, sqlArgs, err := withdrawalsView.rdb.StmtBuilder.Insert(
+		"view_validator_commission_withdrawals",
+	).Columns(
+		"operator_address",
+		"recipient_address",
+		"amount",
+		"block_height",
+		"block_time",
+		"transaction_hash",
+	).Values(
+		withdrawal.OperatorAddress,
+		withdrawal.RecipientAddress,
+		withdrawal.Amount,
+		withdrawal.BlockHeight,
+		withdrawalsView.rdb.Tton(&withdrawal.BlockTime),
+		withdrawal.TransactionHash,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building commission withdrawal insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := withdrawalsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting commission withdrawal into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting commission withdrawal into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type CommissionWithdrawalsListFilter struct {
+	OperatorAddress string
+}
+
+type CommissionWithdrawalsListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (withdrawalsView *CommissionWithdrawals) List(
+	filter CommissionWithdrawalsListFilter,
+	order CommissionWithdrawalsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]CommissionWithdrawalRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := withdrawalsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"recipient_address",
+		"amount",
+		"block_height",
+		"block_time",
+		"transaction_hash",
+	).From(
+		"view_validator_commission_withdrawals",
+	).Where(
+		"operator_address = ?", filter.OperatorAddress,
+	)
+
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC", "id DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height", "id")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		withdrawalsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error building commission withdrawals select SQL: %v, %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	rowsResult, err := withdrawalsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing commission withdrawals select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	withdrawals := make([]CommissionWithdrawalRow, 0)
+	for rowsResult.Next() {
+		var withdrawal CommissionWithdrawalRow
+		blockTimeReader := withdrawalsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&withdrawal.OperatorAddress,
+			&withdrawal.RecipientAddress,
+			&withdrawal.Amount,
+			&withdrawal.BlockHeight,
+			blockTimeReader.ScannableArg(),
+			&withdrawal.TransactionHash,
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning commission withdrawal row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf(
+				"error parsing commission withdrawal block time: %v: %w", parseErr, rdb.ErrQuery,
+			)
+		}
+		withdrawal.BlockTime = *blockTime
+
+		withdrawals = append(withdrawals, withdrawal)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return withdrawals, paginationResult, nil
+}
+
+type CommissionWithdrawalRow struct {
+	OperatorAddress  string          `json:"operatorAddress"`
+	RecipientAddress string          `json:"recipientAddress"`
+	Amount           string          `json:"amount"`
+	BlockHeight      int64           `json:"blockHeight"`
+	BlockTime        utctime.UTCTime `json:"blockTime"`
+	TransactionHash  string          `json:"transactionHash"`
+}
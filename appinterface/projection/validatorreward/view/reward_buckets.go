@@ -0,0 +1,237 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type RewardBucketInterval = string
+
+const (
+	REWARD_BUCKET_INTERVAL_HOUR RewardBucketInterval = "hour"
+	REWARD_BUCKET_INTERVAL_DAY  RewardBucketInterval = "day"
+)
+
+func IsValidRewardBucketInterval(interval string) bool {
+	return interval == REWARD_BUCKET_INTERVAL_HOUR || interval == REWARD_BUCKET_INTERVAL_DAY
+}
+
+// RewardBucketStart returns the start of the UTC hour or day bucket the time falls into
+func RewardBucketStart(t utctime.UTCTime, interval RewardBucketInterval) utctime.UTCTime {
+	goTime := time.Unix(0, t.UnixNano()).UTC()
+	if interval == REWARD_BUCKET_INTERVAL_HOUR {
+		return utctime.FromTime(goTime.Truncate(time.Hour))
+	}
+
+	year, month, day := goTime.Date()
+	return utctime.FromTime(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// RewardBuckets keeps the earnings of each validator summed by the hour and by the day in UTC
+type RewardBuckets struct {
+	rdb *rdb.Handle
+}
+
+func NewRewardBuckets(handle *rdb.Handle) *RewardBuckets {
+	return &RewardBuckets{
+		handle,
+	}
+}
+
+func (bucketsView *RewardBuckets) Upsert(bucket *RewardBucketRow) error {
+	sql, sqlArgs, err := bucketsView.rdb.StmtBuilder.Insert(
+		"view_validator_reward_buckets",
+	).Columns(
+		"operator_address",
+		"bucket_interval",
+		"bucket_start",
+		"rewards",
+		"proposer_rewards",
+		"commissions",
+	).Values(
+		bucket.OperatorAddress,
+		bucket.Interval,
+		bucketsView.rdb.Tton(&bucket.BucketStart),
+		bucket.Rewards,
+		bucket.ProposerRewards,
+		bucket.Commissions,
+	).Suffix(`ON CONFLICT (operator_address, bucket_interval, bucket_start) DO UPDATE SET
+		rewards = EXCLUDED.rewards,
+		proposer_rewards = EXCLUDED.proposer_rewards,
+		commissions = EXCLUDED.commissions
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building validator reward bucket upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := bucketsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting validator reward bucket into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting validator reward bucket into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type RewardBucketIdentity struct {
+	OperatorAddress string
+	Interval        RewardBucketInterval
+	BucketStart     utctime.UTCTime
+}
+
+func (bucketsView *RewardBuckets) FindBy(identity RewardBucketIdentity) (*RewardBucketRow, error) {
+	sql, sqlArgs, err := bucketsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"bucket_interval",
+		"bucket_start",
+		"rewards",
+		"proposer_rewards",
+		"commissions",
+	).From(
+		"view_validator_reward_buckets",
+	).Where(
+		"operator_address = ? AND bucket_interval = ? AND bucket_start = ?",
+		identity.OperatorAddress,
+		identity.Interval,
+		bucketsView.rdb.Tton(&identity.BucketStart),
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building validator reward bucket selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var bucket RewardBucketRow
+	bucketStartReader := bucketsView.rdb.NtotReader()
+	if err = bucketsView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&bucket.OperatorAddress,
+		&bucket.Interval,
+		bucketStartReader.ScannableArg(),
+		&bucket.Rewards,
+		&bucket.ProposerRewards,
+		&bucket.Commissions,
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning validator reward bucket row: %v: %w", err, rdb.ErrQuery)
+	}
+	bucketStart, err := bucketStartReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing validator reward bucket start: %v: %w", err, rdb.ErrQuery)
+	}
+	bucket.BucketStart = *bucketStart
+
+	return &bucket, nil
+}
+
+type RewardBucketsListFilter struct {
+	OperatorAddress string
+	Interval        RewardBucketInterval
+	// Inclusive
+	MaybeFrom *utctime.UTCTime
+	// Exclusive
+	MaybeTo *utctime.UTCTime
+}
+
+type RewardBucketsListOrder struct {
+	MaybeBucketStart *view.ORDER
+}
+
+func (bucketsView *RewardBuckets) List(
+	filter RewardBucketsListFilter,
+	order RewardBucketsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]RewardBucketRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := bucketsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"bucket_interval",
+		"bucket_start",
+		"rewards",
+		"proposer_rewards",
+		"commissions",
+	).From(
+		"view_validator_reward_buckets",
+	).Where(
+		"operator_address = ? AND bucket_interval = ?", filter.OperatorAddress, filter.Interval,
+	)
+
+	if filter.MaybeFrom != nil {
+		stmtBuilder = stmtBuilder.Where("bucket_start >= ?", bucketsView.rdb.Tton(filter.MaybeFrom))
+	}
+	if filter.MaybeTo != nil {
+		stmtBuilder = stmtBuilder.Where("bucket_start < ?", bucketsView.rdb.Tton(filter.MaybeTo))
+	}
+
+	if order.MaybeBucketStart != nil && *order.MaybeBucketStart == view.ORDER_DESC {
+		stmtBuilder = stmtBuilder.OrderBy("bucket_start DESC")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("bucket_start")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		bucketsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error building validator reward buckets select SQL: %v, %w", err, rdb.ErrBuildSQLStmt,
+		)
+	}
+
+	rowsResult, err := bucketsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing validator reward buckets select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	buckets := make([]RewardBucketRow, 0)
+	for rowsResult.Next() {
+		var bucket RewardBucketRow
+		bucketStartReader := bucketsView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&bucket.OperatorAddress,
+			&bucket.Interval,
+			bucketStartReader.ScannableArg(),
+			&bucket.Rewards,
+			&bucket.ProposerRewards,
+			&bucket.Commissions,
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning validator reward bucket row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		bucketStart, parseErr := bucketStartReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf(
+				"error parsing validator reward bucket start: %v: %w", parseErr, rdb.ErrQuery,
+			)
+		}
+		bucket.BucketStart = *bucketStart
+
+		buckets = append(buckets, bucket)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return buckets, paginationResult, nil
+}
+
+// RewardBucketRow amounts are decimal strings in base denom
+type RewardBucketRow struct {
+	OperatorAddress string               `json:"operatorAddress"`
+	Interval        RewardBucketInterval `json:"interval"`
+	BucketStart     utctime.UTCTime      `json:"bucketStart"`
+	// Rewards distributed to the validator, including its commission
+	Rewards         string `json:"rewards"`
+	ProposerRewards string `json:"proposerRewards"`
+	Commissions     string `json:"commissions"`
+}
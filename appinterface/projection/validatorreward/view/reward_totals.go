@@ -0,0 +1,111 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// RewardTotals keeps the lifetime earnings and the outstanding commission of each validator
+type RewardTotals struct {
+	rdb *rdb.Handle
+}
+
+func NewRewardTotals(handle *rdb.Handle) *RewardTotals {
+	return &RewardTotals{
+		handle,
+	}
+}
+
+func (totalsView *RewardTotals) Upsert(total *RewardTotalRow) error {
+	sql, sqlArgs, err := totalsView.rdb.StmtBuilder.Insert(
+		"view_validator_reward_totals",
+	).Columns(
+		"operator_address",
+		"total_rewards",
+		"total_proposer_rewards",
+		"total_commissions",
+		"total_withdrawn_commissions",
+		"outstanding_commission",
+		"last_updated_block_height",
+	).Values(
+		total.OperatorAddress,
+		total.TotalRewards,
+		total.TotalProposerRewards,
+		total.TotalCommissions,
+		total.TotalWithdrawnCommissions,
+		total.OutstandingCommission,
+		total.LastUpdatedBlockHeight,
+	).Suffix(`ON CONFLICT (operator_address) DO UPDATE SET
+		total_rewards = EXCLUDED.total_rewards,
+		total_proposer_rewards = EXCLUDED.total_proposer_rewards,
+		total_commissions = EXCLUDED.total_commissions,
+		total_withdrawn_commissions = EXCLUDED.total_withdrawn_commissions,
+		outstanding_commission = EXCLUDED.outstanding_commission,
+		last_updated_block_height = EXCLUDED.last_updated_block_height
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building validator reward total upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := totalsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting validator reward total into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting validator reward total into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (totalsView *RewardTotals) FindBy(operatorAddress string) (*RewardTotalRow, error) {
+	sql, sqlArgs, err := totalsView.rdb.StmtBuilder.Select(
+		"operator_address",
+		"total_rewards",
+		"total_proposer_rewards",
+		"total_commissions",
+		"total_withdrawn_commissions",
+		"outstanding_commission",
+		"last_updated_block_height",
+	).From(
+		"view_validator_reward_totals",
+	).Where(
+		"operator_address = ?", operatorAddress,
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building validator reward total selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var total RewardTotalRow
+	if err = totalsView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&total.OperatorAddress,
+		&total.TotalRewards,
+		&total.TotalProposerRewards,
+		&total.TotalCommissions,
+		&total.TotalWithdrawnCommissions,
+		&total.OutstandingCommission,
+		&total.LastUpdatedBlockHeight,
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning validator reward total row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &total, nil
+}
+
+// RewardTotalRow amounts are decimal strings in base denom
+type RewardTotalRow struct {
+	OperatorAddress string `json:"operatorAddress"`
+	// Rewards distributed to the validator, including its commission
+	TotalRewards              string `json:"totalRewards"`
+	TotalProposerRewards      string `json:"totalProposerRewards"`
+	TotalCommissions          string `json:"totalCommissions"`
+	TotalWithdrawnCommissions string `json:"totalWithdrawnCommissions"`
+	// Commission earned and not yet withdrawn
+	OutstandingCommission  string `json:"outstandingCommission"`
+	LastUpdatedBlockHeight int64  `json:"lastUpdatedBlockHeight"`
+}
@@ -14,7 +14,7 @@ import (
 	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
 )
 
-var _ entity_projection.Projection = &ValidatorStats{}
+var _ entity_projection.DependentProjection = &ValidatorStats{}
 
 const TOTAL_REWARD = "total_reward"
 const TOTAL_DELEGATE = "total_delegate"
@@ -45,6 +45,10 @@ func (_ *ValidatorStats) GetEventsToListen() []string {
 	}
 }
 
+func (_ *ValidatorStats) GetDependencies() []string {
+	return []string{"Validator"}
+}
+
 func (projection *ValidatorStats) OnInit() error {
 	return nil
 }
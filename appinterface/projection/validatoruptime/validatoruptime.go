@@ -0,0 +1,339 @@
+package validatoruptime
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatoruptime/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+)
+
+var _ projection_entity.Projection = &ValidatorUptime{}
+
+// Slashing module default, used when the genesis does not specify the window
+const DEFAULT_SIGNED_BLOCKS_WINDOW = int64(100)
+
+// Validator updates returned at the end of block H apply to the validator set of block H+2, whose
+// signatures are in the last commit of block H+3
+const VALIDATOR_SET_UPDATE_DELAY = int64(3)
+
+const BLOCK_ID_FLAG_ABSENT = 1
+
+// ValidatorUptime tracks the blocks signed and missed by each validator in the validator set. Same as
+// the slashing module, the uptime is calculated over the last `signed_blocks_window` blocks the
+// validator is expected to sign, and a nil vote counts as signed.
+//
+// Signatures in block H are the commit of block H-1, so the signing of a block is recorded at the
+// next block.
+type ValidatorUptime struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+
+	conNodeAddressPrefix string
+}
+
+func NewValidatorUptime(logger applogger.Logger, rdbConn rdb.Conn, conNodeAddressPrefix string) *ValidatorUptime {
+	return &ValidatorUptime{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "ValidatorUptime"),
+
+		rdbConn,
+		logger,
+		conNodeAddressPrefix,
+	}
+}
+
+func (_ *ValidatorUptime) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_CREATE_VALIDATOR_CREATED,
+		event_usecase.POWER_CHANGED,
+	}
+}
+
+func (projection *ValidatorUptime) OnInit() error {
+	return nil
+}
+
+func (projection *ValidatorUptime) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+	uptimesView := view.NewUptimes(rdbTxHandle)
+	paramsView := view.NewParams(rdbTxHandle)
+
+	var signatures []usecase_model.BlockSignature
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			signatures = blockCreatedEvent.Block.Signatures
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			projection.logger.Debug("handling GenesisCreated event")
+			signedBlocksWindow := genesisCreatedEvent.Genesis.AppState.Slashing.Params.SignedBlocksWindow
+			if signedBlocksWindow != "" {
+				if err := paramsView.Set(view.PARAM_SIGNED_BLOCKS_WINDOW, signedBlocksWindow); err != nil {
+					return fmt.Errorf("error setting signed blocks window: %v", err)
+				}
+			}
+		}
+	}
+
+	for _, event := range events {
+		if msgCreateValidatorEvent, ok := event.(*event_usecase.MsgCreateValidator); ok {
+			projection.logger.Debug("handling MsgCreateValidator event")
+			if err := projection.handleCreateValidator(uptimesView, height, msgCreateValidatorEvent); err != nil {
+				return fmt.Errorf("error handling MsgCreateValidator: %v", err)
+			}
+		}
+	}
+
+	if len(signatures) > 0 {
+		signedBlocksWindow, err := GetSignedBlocksWindow(paramsView)
+		if err != nil {
+			return fmt.Errorf("error getting signed blocks window: %v", err)
+		}
+		if err := projection.recordSignatures(
+			uptimesView, view.NewMissedBlocks(rdbTxHandle), signedBlocksWindow, height, signatures,
+		); err != nil {
+			return fmt.Errorf("error recording block signatures: %v", err)
+		}
+	}
+
+	for _, event := range events {
+		if powerChangedEvent, ok := event.(*event_usecase.PowerChanged); ok {
+			projection.logger.Debug("handling PowerChanged event")
+			if err := projection.handlePowerChanged(uptimesView, height, powerChangedEvent); err != nil {
+				return fmt.Errorf("error handling PowerChanged: %v", err)
+			}
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *ValidatorUptime) handleCreateValidator(
+	uptimesView *view.Uptimes,
+	blockHeight int64,
+	msgCreateValidatorEvent *event_usecase.MsgCreateValidator,
+) error {
+	consensusNodeAddress, tendermintAddress, err := projection.addressesFromTmPubKey(
+		msgCreateValidatorEvent.TendermintPubkey,
+	)
+	if err != nil {
+		return err
+	}
+
+	mutUptimeRow, err := uptimesView.FindBy(view.UptimeIdentity{
+		MaybeConsensusNodeAddress: &consensusNodeAddress,
+	})
+	if err == nil {
+		mutUptimeRow.OperatorAddress = msgCreateValidatorEvent.ValidatorAddress
+		if err := uptimesView.Update(mutUptimeRow); err != nil {
+			return fmt.Errorf("error updating validator uptime operator address: %v", err)
+		}
+		return nil
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		return fmt.Errorf("error getting existing validator uptime: %v", err)
+	}
+
+	// Genesis validators sign from the first block. Others join the validator set on power change.
+	isGenesisValidator := blockHeight == 0
+	if err := uptimesView.Insert(&view.UptimeRow{
+		ConsensusNodeAddress:       consensusNodeAddress,
+		TendermintAddress:          tendermintAddress,
+		OperatorAddress:            msgCreateValidatorEvent.ValidatorAddress,
+		Bonded:                     isGenesisValidator,
+		WasBonded:                  isGenesisValidator,
+		BondedChangedAtBlockHeight: blockHeight,
+	}); err != nil {
+		return fmt.Errorf("error inserting validator uptime: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *ValidatorUptime) handlePowerChanged(
+	uptimesView *view.Uptimes,
+	blockHeight int64,
+	powerChangedEvent *event_usecase.PowerChanged,
+) error {
+	consensusNodeAddress, tendermintAddress, err := projection.addressesFromTmPubKey(
+		powerChangedEvent.TendermintPubkey,
+	)
+	if err != nil {
+		return err
+	}
+	bonded := powerChangedEvent.Power != "0"
+
+	mutUptimeRow, err := uptimesView.FindBy(view.UptimeIdentity{
+		MaybeConsensusNodeAddress: &consensusNodeAddress,
+	})
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			return fmt.Errorf("error getting existing validator uptime: %v", err)
+		}
+
+		// Validator not created by MsgCreateValidator, e.g. from the genesis validator set. The operator
+		// address is filled in when seen.
+		if err := uptimesView.Insert(&view.UptimeRow{
+			ConsensusNodeAddress:       consensusNodeAddress,
+			TendermintAddress:          tendermintAddress,
+			Bonded:                     bonded,
+			WasBonded:                  false,
+			BondedChangedAtBlockHeight: blockHeight,
+		}); err != nil {
+			return fmt.Errorf("error inserting validator uptime: %v", err)
+		}
+		return nil
+	}
+
+	mutUptimeRow.WasBonded = isExpectedToSign(mutUptimeRow, blockHeight)
+	mutUptimeRow.Bonded = bonded
+	mutUptimeRow.BondedChangedAtBlockHeight = blockHeight
+	if err := uptimesView.Update(mutUptimeRow); err != nil {
+		return fmt.Errorf("error updating validator uptime: %v", err)
+	}
+
+	return nil
+}
+
+func (projection *ValidatorUptime) recordSignatures(
+	uptimesView *view.Uptimes,
+	missedBlocksView *view.MissedBlocks,
+	signedBlocksWindow int64,
+	blockHeight int64,
+	signatures []usecase_model.BlockSignature,
+) error {
+	signedBlockHeight := blockHeight - 1
+
+	signers := make(map[string]bool)
+	for _, signature := range signatures {
+		if signature.BlockIdFlag != BLOCK_ID_FLAG_ABSENT {
+			signers[strings.ToUpper(signature.ValidatorAddress)] = true
+		}
+	}
+
+	uptimes, err := uptimesView.ListAll(view.UptimesListFilter{
+		BondedOrWasBonded: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing validator uptimes: %v", err)
+	}
+	for i := range uptimes {
+		mutUptimeRow := &uptimes[i]
+		if !isExpectedToSign(mutUptimeRow, blockHeight) {
+			continue
+		}
+
+		mutUptimeRow.IndexOffset += 1
+		if mutUptimeRow.IndexOffset > signedBlocksWindow {
+			deleted, err := missedBlocksView.Delete(
+				mutUptimeRow.ConsensusNodeAddress, mutUptimeRow.IndexOffset-signedBlocksWindow,
+			)
+			if err != nil {
+				return fmt.Errorf("error deleting missed block out of the window: %v", err)
+			}
+			if deleted {
+				mutUptimeRow.MissedBlocksCounter -= 1
+			}
+		}
+
+		if signers[strings.ToUpper(mutUptimeRow.TendermintAddress)] {
+			mutUptimeRow.TotalSignedBlocks += 1
+			mutUptimeRow.CurrentMissedStreak = 0
+			mutUptimeRow.MaybeLastSignedBlockHeight = &signedBlockHeight
+		} else {
+			if err := missedBlocksView.Insert(&view.MissedBlockRow{
+				ConsensusNodeAddress: mutUptimeRow.ConsensusNodeAddress,
+				IndexOffset:          mutUptimeRow.IndexOffset,
+				BlockHeight:          signedBlockHeight,
+			}); err != nil {
+				return fmt.Errorf("error inserting missed block: %v", err)
+			}
+			mutUptimeRow.MissedBlocksCounter += 1
+			mutUptimeRow.TotalMissedBlocks += 1
+			mutUptimeRow.CurrentMissedStreak += 1
+			if mutUptimeRow.CurrentMissedStreak > mutUptimeRow.MaxMissedStreak {
+				mutUptimeRow.MaxMissedStreak = mutUptimeRow.CurrentMissedStreak
+			}
+			mutUptimeRow.MaybeLastMissedBlockHeight = &signedBlockHeight
+		}
+
+		if err := uptimesView.Update(mutUptimeRow); err != nil {
+			return fmt.Errorf("error updating validator uptime: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (projection *ValidatorUptime) addressesFromTmPubKey(
+	tendermintPubKey string,
+) (consensusNodeAddress string, tendermintAddress string, err error) {
+	pubKey, err := base64.StdEncoding.DecodeString(tendermintPubKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error base64 decoding Tendermint node pubkey: %v", err)
+	}
+	consensusNodeAddress, err = tmcosmosutils.ConsensusNodeAddressFromTmPubKey(projection.conNodeAddressPrefix, pubKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error converting Tendermint node pubkey to address: %v", err)
+	}
+
+	return consensusNodeAddress, tmcosmosutils.TmAddressFromTmPubKey(pubKey), nil
+}
+
+// isExpectedToSign returns whether the validator is in the validator set signing the last commit of
+// the block
+func isExpectedToSign(uptime *view.UptimeRow, blockHeight int64) bool {
+	if blockHeight >= uptime.BondedChangedAtBlockHeight+VALIDATOR_SET_UPDATE_DELAY {
+		return uptime.Bonded
+	}
+	return uptime.WasBonded
+}
+
+// GetSignedBlocksWindow returns the signed blocks window from the genesis, or the default when the
+// genesis does not specify it
+func GetSignedBlocksWindow(paramsView *view.Params) (int64, error) {
+	rawSignedBlocksWindow, err := paramsView.FindBy(view.PARAM_SIGNED_BLOCKS_WINDOW)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return DEFAULT_SIGNED_BLOCKS_WINDOW, nil
+		}
+		return 0, fmt.Errorf("error getting signed blocks window param: %v", err)
+	}
+
+	signedBlocksWindow, err := strconv.ParseInt(rawSignedBlocksWindow, 10, 64)
+	if err != nil || signedBlocksWindow <= 0 {
+		return 0, fmt.Errorf("invalid signed blocks window: %s", rawSignedBlocksWindow)
+	}
+	return signedBlocksWindow, nil
+}
@@ -0,0 +1,13 @@
+package validatoruptime_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestValidatorUptime(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ValidatorUptime Suite")
+}
@@ -0,0 +1,179 @@
+package validatoruptime_test
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatoruptime"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatoruptime/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/model/genesis"
+)
+
+var _ = Describe("ValidatorUptime", func() {
+	const conNodeAddressPrefix = "tcrocnclcons"
+	const validatorA = "tcrocncl1validatora"
+	const validatorB = "tcrocncl1validatorb"
+	const validatorC = "tcrocncl1validatorc"
+	const validatorAPubkey = "Kpox5fS2po0sJUHmzllExuJ4uZ5nm0bbCp6UQKESsnE="
+	const validatorBPubkey = "wWw0e9tZcVmev/NyJlZv5Apd7U5IONoyx3U/9rD5fHI="
+	const validatorCPubkey = "q0Gx6T0Xwc2ZpyXz7Gdn6iS6YDXRt1NXmZmNMP0l1Zw="
+
+	var conn rdb.Conn
+	var projection *validatoruptime.ValidatorUptime
+	BeforeEach(func() {
+		conn = MustNewTestRDbConn()
+		projection = validatoruptime.NewValidatorUptime(NewFakeLogger(), conn, conNodeAddressPrefix)
+	})
+
+	tendermintAddressOf := func(pubkey string) string {
+		rawPubKey, err := base64.StdEncoding.DecodeString(pubkey)
+		Expect(err).To(BeNil())
+		return tmcosmosutils.TmAddressFromTmPubKey(rawPubKey)
+	}
+	consensusNodeAddressOf := func(pubkey string) string {
+		rawPubKey, err := base64.StdEncoding.DecodeString(pubkey)
+		Expect(err).To(BeNil())
+		return tmcosmosutils.MustConsensusAddressFromTmPubKey(conNodeAddressPrefix, rawPubKey)
+	}
+
+	createValidator := func(height int64, operatorAddress string, pubkey string) event_entity.Event {
+		return event_usecase.NewMsgCreateValidator(
+			NewMsgCommonParams(height, "create-validator-"+operatorAddress),
+			usecase_model.MsgCreateValidatorParams{
+				DelegatorAddress: "tcro1self" + operatorAddress,
+				ValidatorAddress: operatorAddress,
+				TendermintPubkey: pubkey,
+				Amount:           coin.MustNewCoinFromInt(1000),
+			},
+		)
+	}
+
+	genesisEvents := func() []event_entity.Event {
+		return []event_entity.Event{
+			NewGenesisCreatedEvent(GENESIS_TIME, func(anyGenesis *genesis.Genesis) {
+				anyGenesis.AppState.Slashing.Params.SignedBlocksWindow = "3"
+			}),
+			createValidator(0, validatorA, validatorAPubkey),
+			createValidator(0, validatorB, validatorBPubkey),
+		}
+	}
+
+	blockCreated := func(height int64, signerPubkeys ...string) event_entity.Event {
+		signatures := make([]usecase_model.BlockSignature, 0)
+		for _, pubkey := range signerPubkeys {
+			signatures = append(signatures, usecase_model.BlockSignature{
+				BlockIdFlag:      2,
+				ValidatorAddress: tendermintAddressOf(pubkey),
+				Timestamp:        BlockTimeAt(height),
+				Signature:        "signature",
+			})
+		}
+		blockCreated := NewBlockCreatedEvent(height, BlockTimeAt(height)).(*event_usecase.BlockCreated)
+		blockCreated.Block.Signatures = signatures
+		return blockCreated
+	}
+
+	powerChanged := func(height int64, pubkey string, power string) event_entity.Event {
+		return event_usecase.NewPowerChanged(height, usecase_model.PowerChangeParams{
+			TendermintPubkey: pubkey,
+			Power:            power,
+		})
+	}
+
+	findSummary := func(operatorAddress string) *view.UptimeSummary {
+		uptime, err := view.NewUptimes(conn.ToHandle()).FindBy(view.UptimeIdentity{
+			MaybeOperatorAddress: primptr.String(operatorAddress),
+		})
+		Expect(err).To(BeNil())
+		signedBlocksWindow, err := validatoruptime.GetSignedBlocksWindow(view.NewParams(conn.ToHandle()))
+		Expect(err).To(BeNil())
+		return uptime.Summary(signedBlocksWindow)
+	}
+
+	It("should track the signed and missed blocks of genesis validators over the window", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(1)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(2, validatorAPubkey, validatorBPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(3, validatorAPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(4, validatorAPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(5, validatorAPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(6, validatorAPubkey, validatorBPubkey)})
+
+		Expect(findSummary(validatorA)).To(Equal(&view.UptimeSummary{
+			ConsensusNodeAddress:       consensusNodeAddressOf(validatorAPubkey),
+			OperatorAddress:            validatorA,
+			SignedBlocksWindow:         3,
+			BlocksInWindow:             3,
+			MissedBlocksInWindow:       0,
+			MaybeUptime:                primptr.String("100.00"),
+			TotalSignedBlocks:          5,
+			TotalMissedBlocks:          0,
+			CurrentMissedStreak:        0,
+			MaxMissedStreak:            0,
+			MaybeLastSignedBlockHeight: primptr.Int64(5),
+			MaybeLastMissedBlockHeight: nil,
+		}))
+		Expect(findSummary(validatorB)).To(Equal(&view.UptimeSummary{
+			ConsensusNodeAddress:       consensusNodeAddressOf(validatorBPubkey),
+			OperatorAddress:            validatorB,
+			SignedBlocksWindow:         3,
+			BlocksInWindow:             3,
+			MissedBlocksInWindow:       2,
+			MaybeUptime:                primptr.String("33.33"),
+			TotalSignedBlocks:          2,
+			TotalMissedBlocks:          3,
+			CurrentMissedStreak:        0,
+			MaxMissedStreak:            3,
+			MaybeLastSignedBlockHeight: primptr.Int64(5),
+			MaybeLastMissedBlockHeight: primptr.Int64(4),
+		}))
+
+		missedHeights, err := view.NewMissedBlocks(conn.ToHandle()).ListHeights(consensusNodeAddressOf(validatorBPubkey))
+		Expect(err).To(BeNil())
+		Expect(missedHeights).To(Equal([]int64{4, 3}))
+	})
+
+	It("should only expect validators to sign once the power change applies to the validator set", func() {
+		MustReplayEvents(projection, genesisEvents())
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(1)})
+		MustReplayEvents(projection, []event_entity.Event{
+			blockCreated(2, validatorAPubkey, validatorBPubkey),
+			createValidator(2, validatorC, validatorCPubkey),
+			powerChanged(2, validatorCPubkey, "10"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(3, validatorAPubkey, validatorBPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(4, validatorAPubkey, validatorBPubkey)})
+
+		summary := findSummary(validatorC)
+		Expect(summary.BlocksInWindow).To(Equal(int64(0)))
+		Expect(summary.MaybeUptime).To(BeNil())
+
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(5, validatorAPubkey, validatorBPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{
+			blockCreated(6, validatorAPubkey, validatorBPubkey, validatorCPubkey),
+			powerChanged(6, validatorCPubkey, "0"),
+		})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(7, validatorAPubkey, validatorBPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(8, validatorAPubkey, validatorBPubkey)})
+		MustReplayEvents(projection, []event_entity.Event{blockCreated(9, validatorAPubkey, validatorBPubkey)})
+
+		summary = findSummary(validatorC)
+		Expect(summary.TotalSignedBlocks).To(Equal(int64(1)))
+		Expect(summary.TotalMissedBlocks).To(Equal(int64(3)))
+		Expect(summary.MaybeLastMissedBlockHeight).To(Equal(primptr.Int64(7)))
+		Expect(summary.CurrentMissedStreak).To(Equal(int64(2)))
+		Expect(summary.MaxMissedStreak).To(Equal(int64(2)))
+	})
+})
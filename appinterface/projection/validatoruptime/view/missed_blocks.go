@@ -0,0 +1,103 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// MissedBlocks keeps the blocks missed by each validator within the signed blocks window, keyed by the
+// index of the block among the blocks the validator is expected to sign
+type MissedBlocks struct {
+	rdb *rdb.Handle
+}
+
+func NewMissedBlocks(handle *rdb.Handle) *MissedBlocks {
+	return &MissedBlocks{
+		handle,
+	}
+}
+
+func (missedBlocksView *MissedBlocks) Insert(missedBlock *MissedBlockRow) error {
+	sql, sqlArgs, err := missedBlocksView.rdb.StmtBuilder.Insert(
+		"view_validator_missed_blocks",
+	).Columns(
+		"consensus_node_address",
+		"index_offset",
+		"block_height",
+	).Values(
+		missedBlock.ConsensusNodeAddress,
+		missedBlock.IndexOffset,
+		missedBlock.BlockHeight,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building missed block insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := missedBlocksView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting missed block into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting missed block into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// Delete deletes the missed block at the index, if any. Returns whether a missed block is deleted.
+func (missedBlocksView *MissedBlocks) Delete(consensusNodeAddress string, indexOffset int64) (bool, error) {
+	sql, sqlArgs, err := missedBlocksView.rdb.StmtBuilder.Delete(
+		"view_validator_missed_blocks",
+	).Where(
+		"consensus_node_address = ? AND index_offset = ?", consensusNodeAddress, indexOffset,
+	).ToSql()
+	if err != nil {
+		return false, fmt.Errorf("error building missed block deletion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := missedBlocksView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return false, fmt.Errorf("error deleting missed block: %v: %w", err, rdb.ErrWrite)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ListHeights returns the heights of the blocks missed by the validator within the window, most recent
+// first
+func (missedBlocksView *MissedBlocks) ListHeights(consensusNodeAddress string) ([]int64, error) {
+	sql, sqlArgs, err := missedBlocksView.rdb.StmtBuilder.Select(
+		"block_height",
+	).From(
+		"view_validator_missed_blocks",
+	).Where(
+		"consensus_node_address = ?", consensusNodeAddress,
+	).OrderBy("index_offset DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building missed blocks select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := missedBlocksView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing missed blocks select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	heights := make([]int64, 0)
+	for rowsResult.Next() {
+		var height int64
+		if scanErr := rowsResult.Scan(&height); scanErr != nil {
+			return nil, fmt.Errorf("error scanning missed block row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		heights = append(heights, height)
+	}
+
+	return heights, nil
+}
+
+type MissedBlockRow struct {
+	ConsensusNodeAddress string
+	IndexOffset          int64
+	BlockHeight          int64
+}
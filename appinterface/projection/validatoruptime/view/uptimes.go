@@ -0,0 +1,299 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// Uptimes keeps the signing participation of each validator
+type Uptimes struct {
+	rdb *rdb.Handle
+}
+
+func NewUptimes(handle *rdb.Handle) *Uptimes {
+	return &Uptimes{
+		handle,
+	}
+}
+
+var uptimeColumns = []string{
+	"consensus_node_address",
+	"tendermint_address",
+	"operator_address",
+	"bonded",
+	"was_bonded",
+	"bonded_changed_at_block_height",
+	"index_offset",
+	"missed_blocks_counter",
+	"total_signed_blocks",
+	"total_missed_blocks",
+	"current_missed_streak",
+	"max_missed_streak",
+	"last_signed_block_height",
+	"last_missed_block_height",
+}
+
+func (uptimesView *Uptimes) Insert(uptime *UptimeRow) error {
+	sql, sqlArgs, err := uptimesView.rdb.StmtBuilder.Insert(
+		"view_validator_uptimes",
+	).Columns(
+		uptimeColumns...,
+	).Values(
+		uptime.ConsensusNodeAddress,
+		uptime.TendermintAddress,
+		uptime.OperatorAddress,
+		uptime.Bonded,
+		uptime.WasBonded,
+		uptime.BondedChangedAtBlockHeight,
+		uptime.IndexOffset,
+		uptime.MissedBlocksCounter,
+		uptime.TotalSignedBlocks,
+		uptime.TotalMissedBlocks,
+		uptime.CurrentMissedStreak,
+		uptime.MaxMissedStreak,
+		uptime.MaybeLastSignedBlockHeight,
+		uptime.MaybeLastMissedBlockHeight,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building validator uptime insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := uptimesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator uptime into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting validator uptime into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+func (uptimesView *Uptimes) Update(uptime *UptimeRow) error {
+	sql, sqlArgs, err := uptimesView.rdb.StmtBuilder.Update(
+		"view_validator_uptimes",
+	).SetMap(map[string]interface{}{
+		"operator_address":               uptime.OperatorAddress,
+		"bonded":                         uptime.Bonded,
+		"was_bonded":                     uptime.WasBonded,
+		"bonded_changed_at_block_height": uptime.BondedChangedAtBlockHeight,
+		"index_offset":                   uptime.IndexOffset,
+		"missed_blocks_counter":          uptime.MissedBlocksCounter,
+		"total_signed_blocks":            uptime.TotalSignedBlocks,
+		"total_missed_blocks":            uptime.TotalMissedBlocks,
+		"current_missed_streak":          uptime.CurrentMissedStreak,
+		"max_missed_streak":              uptime.MaxMissedStreak,
+		"last_signed_block_height":       uptime.MaybeLastSignedBlockHeight,
+		"last_missed_block_height":       uptime.MaybeLastMissedBlockHeight,
+	}).Where(
+		"consensus_node_address = ?", uptime.ConsensusNodeAddress,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building validator uptime update sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := uptimesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error updating validator uptime: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error updating validator uptime: no rows updated: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type UptimeIdentity struct {
+	MaybeConsensusNodeAddress *string
+	MaybeOperatorAddress      *string
+}
+
+func (uptimesView *Uptimes) FindBy(identity UptimeIdentity) (*UptimeRow, error) {
+	stmtBuilder := uptimesView.rdb.StmtBuilder.Select(
+		uptimeColumns...,
+	).From(
+		"view_validator_uptimes",
+	)
+	if identity.MaybeConsensusNodeAddress != nil {
+		stmtBuilder = stmtBuilder.Where("consensus_node_address = ?", *identity.MaybeConsensusNodeAddress)
+	}
+	if identity.MaybeOperatorAddress != nil {
+		stmtBuilder = stmtBuilder.Where("operator_address = ?", *identity.MaybeOperatorAddress)
+	}
+
+	sql, sqlArgs, err := stmtBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building validator uptime selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	var uptime UptimeRow
+	if err = uptimesView.rdb.QueryRow(sql, sqlArgs...).Scan(
+		&uptime.ConsensusNodeAddress,
+		&uptime.TendermintAddress,
+		&uptime.OperatorAddress,
+		&uptime.Bonded,
+		&uptime.WasBonded,
+		&uptime.BondedChangedAtBlockHeight,
+		&uptime.IndexOffset,
+		&uptime.MissedBlocksCounter,
+		&uptime.TotalSignedBlocks,
+		&uptime.TotalMissedBlocks,
+		&uptime.CurrentMissedStreak,
+		&uptime.MaxMissedStreak,
+		&uptime.MaybeLastSignedBlockHeight,
+		&uptime.MaybeLastMissedBlockHeight,
+	); err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			return nil, rdb.ErrNoRows
+		}
+		return nil, fmt.Errorf("error scanning validator uptime row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	return &uptime, nil
+}
+
+type UptimesListFilter struct {
+	// Only list the validators in or leaving the validator set
+	BondedOrWasBonded bool
+	// List the validators with the consensus node addresses only when not nil
+	MaybeConsensusNodeAddresses []string
+}
+
+func (uptimesView *Uptimes) ListAll(filter UptimesListFilter) ([]UptimeRow, error) {
+	stmtBuilder := uptimesView.rdb.StmtBuilder.Select(
+		uptimeColumns...,
+	).From(
+		"view_validator_uptimes",
+	).OrderBy("consensus_node_address")
+
+	if filter.BondedOrWasBonded {
+		stmtBuilder = stmtBuilder.Where("(bonded = true OR was_bonded = true)")
+	}
+	if filter.MaybeConsensusNodeAddresses != nil {
+		if len(filter.MaybeConsensusNodeAddresses) == 0 {
+			return []UptimeRow{}, nil
+		}
+		stmtBuilder = stmtBuilder.Where(sq.Eq{
+			"consensus_node_address": filter.MaybeConsensusNodeAddresses,
+		})
+	}
+
+	sql, sqlArgs, err := stmtBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building validator uptimes select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := uptimesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing validator uptimes select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	uptimes := make([]UptimeRow, 0)
+	for rowsResult.Next() {
+		var uptime UptimeRow
+		if scanErr := rowsResult.Scan(
+			&uptime.ConsensusNodeAddress,
+			&uptime.TendermintAddress,
+			&uptime.OperatorAddress,
+			&uptime.Bonded,
+			&uptime.WasBonded,
+			&uptime.BondedChangedAtBlockHeight,
+			&uptime.IndexOffset,
+			&uptime.MissedBlocksCounter,
+			&uptime.TotalSignedBlocks,
+			&uptime.TotalMissedBlocks,
+			&uptime.CurrentMissedStreak,
+			&uptime.MaxMissedStreak,
+			&uptime.MaybeLastSignedBlockHeight,
+			&uptime.MaybeLastMissedBlockHeight,
+		); scanErr != nil {
+			return nil, fmt.Errorf("error scanning validator uptime row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+
+		uptimes = append(uptimes, uptime)
+	}
+
+	return uptimes, nil
+}
+
+type UptimeRow struct {
+	ConsensusNodeAddress string
+	// Hex address used in the block signatures
+	TendermintAddress string
+	OperatorAddress   string
+
+	// Whether the validator is in the validator set after the last power change
+	Bonded bool
+	// Whether the validator was in the validator set before the last power change
+	WasBonded                  bool
+	BondedChangedAtBlockHeight int64
+
+	// Number of blocks the validator has been expected to sign
+	IndexOffset int64
+	// Number of blocks missed within the signed blocks window
+	MissedBlocksCounter int64
+
+	TotalSignedBlocks          int64
+	TotalMissedBlocks          int64
+	CurrentMissedStreak        int64
+	MaxMissedStreak            int64
+	MaybeLastSignedBlockHeight *int64
+	MaybeLastMissedBlockHeight *int64
+}
+
+// Summary returns the uptime of the validator over the signed blocks window
+func (uptime *UptimeRow) Summary(signedBlocksWindow int64) *UptimeSummary {
+	blocksInWindow := uptime.IndexOffset
+	if blocksInWindow > signedBlocksWindow {
+		blocksInWindow = signedBlocksWindow
+	}
+
+	var maybeUptime *string
+	if blocksInWindow > 0 {
+		percentage := big.NewRat((blocksInWindow-uptime.MissedBlocksCounter)*100, blocksInWindow).FloatString(2)
+		maybeUptime = &percentage
+	}
+
+	return &UptimeSummary{
+		ConsensusNodeAddress: uptime.ConsensusNodeAddress,
+		OperatorAddress:      uptime.OperatorAddress,
+
+		SignedBlocksWindow:   signedBlocksWindow,
+		BlocksInWindow:       blocksInWindow,
+		MissedBlocksInWindow: uptime.MissedBlocksCounter,
+		MaybeUptime:          maybeUptime,
+
+		TotalSignedBlocks:          uptime.TotalSignedBlocks,
+		TotalMissedBlocks:          uptime.TotalMissedBlocks,
+		CurrentMissedStreak:        uptime.CurrentMissedStreak,
+		MaxMissedStreak:            uptime.MaxMissedStreak,
+		MaybeLastSignedBlockHeight: uptime.MaybeLastSignedBlockHeight,
+		MaybeLastMissedBlockHeight: uptime.MaybeLastMissedBlockHeight,
+	}
+}
+
+type UptimeSummary struct {
+	ConsensusNodeAddress string `json:"consensusNodeAddress"`
+	OperatorAddress      string `json:"operatorAddress"`
+
+	SignedBlocksWindow int64 `json:"signedBlocksWindow"`
+	// Number of blocks in the window. Less than the window when the validator has not been expected to
+	// sign that many blocks yet.
+	BlocksInWindow       int64 `json:"blocksInWindow"`
+	MissedBlocksInWindow int64 `json:"missedBlocksInWindow"`
+	// Percentage of the blocks in the window signed, e.g. "99.50". Null when there is no block in the window.
+	MaybeUptime *string `json:"uptime"`
+
+	TotalSignedBlocks          int64  `json:"totalSignedBlocks"`
+	TotalMissedBlocks          int64  `json:"totalMissedBlocks"`
+	CurrentMissedStreak        int64  `json:"currentMissedStreak"`
+	MaxMissedStreak            int64  `json:"maxMissedStreak"`
+	MaybeLastSignedBlockHeight *int64 `json:"lastSignedBlockHeight"`
+	MaybeLastMissedBlockHeight *int64 `json:"lastMissedBlockHeight"`
+}
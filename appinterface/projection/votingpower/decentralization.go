@@ -0,0 +1,82 @@
+package votingpower
+
+import (
+	"math/big"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/votingpower/view"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Decimal places of the top shares
+const SHARE_PRECISION = 6
+
+// NewDecentralization measures how decentralized the voting powers are. The powers must be positive and
+// in descending order.
+//
+// The Nakamoto coefficient is the fewest validators with more than 1/2 of the total power. The validators
+// for one third are the fewest validators with at least 1/3 of the total power, which can halt the chain.
+// The validators for two thirds are the fewest validators with more than 2/3 of the total power, which
+// can commit blocks without the others. All of them are 0 when nothing is bonded.
+func NewDecentralization(
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	sortedPowers []int64,
+) view.DecentralizationRow {
+	total := new(big.Int)
+	for _, power := range sortedPowers {
+		total.Add(total, big.NewInt(power))
+	}
+
+	decentralization := view.DecentralizationRow{
+		BlockHeight:            blockHeight,
+		BlockTime:              blockTime,
+		TotalPower:             total.Int64(),
+		Validators:             int64(len(sortedPowers)),
+		NakamotoCoefficient:    0,
+		ValidatorsForOneThird:  0,
+		ValidatorsForTwoThirds: 0,
+		Top10Share:             new(big.Rat).FloatString(SHARE_PRECISION),
+		Top20Share:             new(big.Rat).FloatString(SHARE_PRECISION),
+	}
+	if total.Sign() == 0 {
+		return decentralization
+	}
+
+	decentralization.NakamotoCoefficient = validatorsFor(sortedPowers, total, func(cumulative *big.Int) bool {
+		// cumulative * 2 > total
+		return new(big.Int).Lsh(cumulative, 1).Cmp(total) > 0
+	})
+	decentralization.ValidatorsForOneThird = validatorsFor(sortedPowers, total, func(cumulative *big.Int) bool {
+		// cumulative * 3 >= total
+		return new(big.Int).Mul(cumulative, big.NewInt(3)).Cmp(total) >= 0
+	})
+	decentralization.ValidatorsForTwoThirds = validatorsFor(sortedPowers, total, func(cumulative *big.Int) bool {
+		// cumulative * 3 > total * 2
+		return new(big.Int).Mul(cumulative, big.NewInt(3)).Cmp(new(big.Int).Lsh(total, 1)) > 0
+	})
+	decentralization.Top10Share = topShare(sortedPowers, 10, total).FloatString(SHARE_PRECISION)
+	decentralization.Top20Share = topShare(sortedPowers, 20, total).FloatString(SHARE_PRECISION)
+
+	return decentralization
+}
+
+// validatorsFor returns the fewest largest validators whose cumulative power reaches the threshold
+func validatorsFor(sortedPowers []int64, total *big.Int, reached func(cumulative *big.Int) bool) int64 {
+	cumulative := new(big.Int)
+	for i, power := range sortedPowers {
+		cumulative.Add(cumulative, big.NewInt(power))
+		if reached(cumulative) {
+			return int64(i + 1)
+		}
+	}
+	return int64(len(sortedPowers))
+}
+
+// topShare returns the share of the total held by the largest top validators
+func topShare(sortedPowers []int64, top int, total *big.Int) *big.Rat {
+	topTotal := new(big.Int)
+	for i := 0; i < len(sortedPowers) && i < top; i++ {
+		topTotal.Add(topTotal, big.NewInt(sortedPowers[i]))
+	}
+	return new(big.Rat).SetFrac(topTotal, total)
+}
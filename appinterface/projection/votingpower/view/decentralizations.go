@@ -0,0 +1,199 @@
+package view
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Decentralizations keeps the total bonded power and the decentralization metrics after every block with
+// power changes
+type Decentralizations struct {
+	rdb *rdb.Handle
+}
+
+func NewDecentralizations(handle *rdb.Handle) *Decentralizations {
+	return &Decentralizations{
+		handle,
+	}
+}
+
+func (decentralizationsView *Decentralizations) Insert(decentralization *DecentralizationRow) error {
+	sql, sqlArgs, err := decentralizationsView.rdb.StmtBuilder.Insert(
+		"view_voting_power_decentralizations",
+	).Columns(
+		"block_height",
+		"block_time",
+		"total_power",
+		"validators",
+		"nakamoto_coefficient",
+		"validators_for_one_third",
+		"validators_for_two_thirds",
+		"top_10_share",
+		"top_20_share",
+	).Values(
+		decentralization.BlockHeight,
+		decentralizationsView.rdb.Tton(&decentralization.BlockTime),
+		decentralization.TotalPower,
+		decentralization.Validators,
+		decentralization.NakamotoCoefficient,
+		decentralization.ValidatorsForOneThird,
+		decentralization.ValidatorsForTwoThirds,
+		decentralization.Top10Share,
+		decentralization.Top20Share,
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building decentralization insertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := decentralizationsView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting decentralization into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error inserting decentralization into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// FindLatest returns rdb.ErrNoRows when there is no power change yet
+func (decentralizationsView *Decentralizations) FindLatest() (*DecentralizationRow, error) {
+	sql, sqlArgs, err := decentralizationsView.selectStmtBuilder().OrderBy(
+		"block_height DESC",
+	).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building decentralization selection sql: %v: %w", err, rdb.ErrPrepare)
+	}
+
+	rowsResult, err := decentralizationsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing decentralization selection sql: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	if !rowsResult.Next() {
+		return nil, rdb.ErrNoRows
+	}
+	return decentralizationsView.scan(rowsResult)
+}
+
+type DecentralizationsListFilter struct {
+	// Inclusive
+	MaybeFromTime *utctime.UTCTime
+	// Exclusive
+	MaybeToTime *utctime.UTCTime
+}
+
+// Defaults to the latest first
+type DecentralizationsListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (decentralizationsView *Decentralizations) List(
+	filter DecentralizationsListFilter,
+	order DecentralizationsListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]DecentralizationRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := whereBlockTime(
+		decentralizationsView.selectStmtBuilder(), decentralizationsView.rdb, filter.MaybeFromTime, filter.MaybeToTime,
+	)
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		decentralizationsView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building decentralizations select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := decentralizationsView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing decentralizations select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	decentralizations := make([]DecentralizationRow, 0)
+	for rowsResult.Next() {
+		decentralization, scanErr := decentralizationsView.scan(rowsResult)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		decentralizations = append(decentralizations, *decentralization)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return decentralizations, paginationResult, nil
+}
+
+func (decentralizationsView *Decentralizations) selectStmtBuilder() sq.SelectBuilder {
+	return decentralizationsView.rdb.StmtBuilder.Select(
+		"block_height",
+		"block_time",
+		"total_power",
+		"validators",
+		"nakamoto_coefficient",
+		"validators_for_one_third",
+		"validators_for_two_thirds",
+		"top_10_share",
+		"top_20_share",
+	).From(
+		"view_voting_power_decentralizations",
+	)
+}
+
+func (decentralizationsView *Decentralizations) scan(rowsResult rdb.RowsResult) (*DecentralizationRow, error) {
+	var decentralization DecentralizationRow
+	blockTimeReader := decentralizationsView.rdb.NtotReader()
+	if err := rowsResult.Scan(
+		&decentralization.BlockHeight,
+		blockTimeReader.ScannableArg(),
+		&decentralization.TotalPower,
+		&decentralization.Validators,
+		&decentralization.NakamotoCoefficient,
+		&decentralization.ValidatorsForOneThird,
+		&decentralization.ValidatorsForTwoThirds,
+		&decentralization.Top10Share,
+		&decentralization.Top20Share,
+	); err != nil {
+		return nil, fmt.Errorf("error scanning decentralization row: %v: %w", err, rdb.ErrQuery)
+	}
+
+	blockTime, err := blockTimeReader.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing decentralization block time: %v: %w", err, rdb.ErrQuery)
+	}
+	decentralization.BlockTime = *blockTime
+
+	return &decentralization, nil
+}
+
+// DecentralizationRow counts the bonded validators only. The Nakamoto coefficient is the fewest
+// validators controlling more than half of the power. The validators for one third can halt the chain
+// and the validators for two thirds can commit blocks on their own.
+type DecentralizationRow struct {
+	BlockHeight            int64           `json:"blockHeight"`
+	BlockTime              utctime.UTCTime `json:"blockTime"`
+	TotalPower             int64           `json:"totalPower"`
+	Validators             int64           `json:"validators"`
+	NakamotoCoefficient    int64           `json:"nakamotoCoefficient"`
+	ValidatorsForOneThird  int64           `json:"validatorsForOneThird"`
+	ValidatorsForTwoThirds int64           `json:"validatorsForTwoThirds"`
+	Top10Share             string          `json:"top10Share"`
+	Top20Share             string          `json:"top20Share"`
+}
@@ -0,0 +1,157 @@
+package view
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// PowerHistories keeps the voting power of each validator after every change
+type PowerHistories struct {
+	rdb *rdb.Handle
+}
+
+func NewPowerHistories(handle *rdb.Handle) *PowerHistories {
+	return &PowerHistories{
+		handle,
+	}
+}
+
+// Upsert keeps the last power when the power of the validator changes more than once in a block
+func (historiesView *PowerHistories) Upsert(history *PowerHistoryRow) error {
+	sql, sqlArgs, err := historiesView.rdb.StmtBuilder.Insert(
+		"view_voting_power_histories",
+	).Columns(
+		"consensus_node_address",
+		"block_height",
+		"block_time",
+		"power",
+	).Values(
+		history.ConsensusNodeAddress,
+		history.BlockHeight,
+		historiesView.rdb.Tton(&history.BlockTime),
+		history.Power,
+	).Suffix(
+		"ON CONFLICT (consensus_node_address, block_height) DO UPDATE SET power = EXCLUDED.power",
+	).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building voting power history upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := historiesView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting voting power history into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting voting power history into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+type PowerHistoriesListFilter struct {
+	// Inclusive
+	MaybeFromTime *utctime.UTCTime
+	// Exclusive
+	MaybeToTime *utctime.UTCTime
+}
+
+// Defaults to the latest change first
+type PowerHistoriesListOrder struct {
+	MaybeBlockHeight *view.ORDER
+}
+
+func (historiesView *PowerHistories) ListByConsensusNodeAddress(
+	consensusNodeAddress string,
+	filter PowerHistoriesListFilter,
+	order PowerHistoriesListOrder,
+	pagination *pagination_interface.Pagination,
+) ([]PowerHistoryRow, *pagination_interface.PaginationResult, error) {
+	stmtBuilder := historiesView.rdb.StmtBuilder.Select(
+		"consensus_node_address",
+		"block_height",
+		"block_time",
+		"power",
+	).From(
+		"view_voting_power_histories",
+	).Where(
+		"consensus_node_address = ?", consensusNodeAddress,
+	)
+	stmtBuilder = whereBlockTime(stmtBuilder, historiesView.rdb, filter.MaybeFromTime, filter.MaybeToTime)
+	if order.MaybeBlockHeight != nil && *order.MaybeBlockHeight == view.ORDER_ASC {
+		stmtBuilder = stmtBuilder.OrderBy("block_height")
+	} else {
+		stmtBuilder = stmtBuilder.OrderBy("block_height DESC")
+	}
+
+	rDbPagination := rdb.NewRDbPaginationBuilder(
+		pagination,
+		historiesView.rdb,
+	).BuildStmt(stmtBuilder)
+	sql, sqlArgs, err := rDbPagination.ToStmtBuilder().ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building voting power histories select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := historiesView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing voting power histories select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	histories := make([]PowerHistoryRow, 0)
+	for rowsResult.Next() {
+		var history PowerHistoryRow
+		blockTimeReader := historiesView.rdb.NtotReader()
+		if scanErr := rowsResult.Scan(
+			&history.ConsensusNodeAddress,
+			&history.BlockHeight,
+			blockTimeReader.ScannableArg(),
+			&history.Power,
+		); scanErr != nil {
+			return nil, nil, fmt.Errorf("error scanning voting power history row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		blockTime, parseErr := blockTimeReader.Parse()
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("error parsing voting power history block time: %v: %w", parseErr, rdb.ErrQuery)
+		}
+		history.BlockTime = *blockTime
+
+		histories = append(histories, history)
+	}
+
+	paginationResult, err := rDbPagination.Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing pagination result: %v", err)
+	}
+
+	return histories, paginationResult, nil
+}
+
+// whereBlockTime filters the block time from the inclusive time to the exclusive time
+func whereBlockTime(
+	stmtBuilder sq.SelectBuilder,
+	handle *rdb.Handle,
+	maybeFromTime *utctime.UTCTime,
+	maybeToTime *utctime.UTCTime,
+) sq.SelectBuilder {
+	if maybeFromTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time >= ?", handle.Tton(maybeFromTime))
+	}
+	if maybeToTime != nil {
+		stmtBuilder = stmtBuilder.Where("block_time < ?", handle.Tton(maybeToTime))
+	}
+	return stmtBuilder
+}
+
+type PowerHistoryRow struct {
+	ConsensusNodeAddress string          `json:"consensusNodeAddress"`
+	BlockHeight          int64           `json:"blockHeight"`
+	BlockTime            utctime.UTCTime `json:"blockTime"`
+	Power                int64           `json:"power"`
+}
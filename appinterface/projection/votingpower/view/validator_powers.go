@@ -0,0 +1,93 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+)
+
+// ValidatorPowers keeps the current voting power of each validator that has ever had a power change
+type ValidatorPowers struct {
+	rdb *rdb.Handle
+}
+
+func NewValidatorPowers(handle *rdb.Handle) *ValidatorPowers {
+	return &ValidatorPowers{
+		handle,
+	}
+}
+
+func (powersView *ValidatorPowers) Upsert(power *ValidatorPowerRow) error {
+	sql, sqlArgs, err := powersView.rdb.StmtBuilder.Insert(
+		"view_voting_power_validators",
+	).Columns(
+		"consensus_node_address",
+		"power",
+		"last_changed_block_height",
+	).Values(
+		power.ConsensusNodeAddress,
+		power.Power,
+		power.LastChangedBlockHeight,
+	).Suffix(`ON CONFLICT (consensus_node_address) DO UPDATE SET
+		power = EXCLUDED.power,
+		last_changed_block_height = EXCLUDED.last_changed_block_height
+	`).ToSql()
+	if err != nil {
+		return fmt.Errorf("error building validator power upsertion sql: %v: %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	result, err := powersView.rdb.Exec(sql, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("error upserting validator power into the table: %v: %w", err, rdb.ErrWrite)
+	}
+	if result.RowsAffected() != 1 {
+		return fmt.Errorf("error upserting validator power into the table: no rows inserted: %w", rdb.ErrWrite)
+	}
+
+	return nil
+}
+
+// ListAllBonded returns the validators with non-zero power
+func (powersView *ValidatorPowers) ListAllBonded() ([]ValidatorPowerRow, error) {
+	sql, sqlArgs, err := powersView.rdb.StmtBuilder.Select(
+		"consensus_node_address",
+		"power",
+		"last_changed_block_height",
+	).From(
+		"view_voting_power_validators",
+	).Where(
+		"power > ?", 0,
+	).OrderBy(
+		"power DESC", "consensus_node_address",
+	).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building validator powers select SQL: %v, %w", err, rdb.ErrBuildSQLStmt)
+	}
+
+	rowsResult, err := powersView.rdb.Query(sql, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing validator powers select SQL: %v: %w", err, rdb.ErrQuery)
+	}
+	defer rowsResult.Close()
+
+	powers := make([]ValidatorPowerRow, 0)
+	for rowsResult.Next() {
+		var power ValidatorPowerRow
+		if scanErr := rowsResult.Scan(
+			&power.ConsensusNodeAddress,
+			&power.Power,
+			&power.LastChangedBlockHeight,
+		); scanErr != nil {
+			return nil, fmt.Errorf("error scanning validator power row: %v: %w", scanErr, rdb.ErrQuery)
+		}
+		powers = append(powers, power)
+	}
+
+	return powers, nil
+}
+
+type ValidatorPowerRow struct {
+	ConsensusNodeAddress   string `json:"consensusNodeAddress"`
+	Power                  int64  `json:"power"`
+	LastChangedBlockHeight int64  `json:"lastChangedBlockHeight"`
+}
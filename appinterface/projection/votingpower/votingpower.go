@@ -0,0 +1,231 @@
+package votingpower
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/votingpower/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+var _ projection_entity.Projection = &VotingPower{}
+
+// VotingPower records the voting power of each validator over time and the decentralization of the
+// bonded power after every block with power changes. Powers are known from the PowerChanged events, except
+// for the genesis validators whose powers are seeded from their gentx self-delegations at genesis time.
+type VotingPower struct {
+	*rdbprojectionbase.Base
+
+	rdbConn rdb.Conn
+	logger  applogger.Logger
+
+	conNodeAddressPrefix string
+}
+
+func NewVotingPower(logger applogger.Logger, rdbConn rdb.Conn, conNodeAddressPrefix string) *VotingPower {
+	return &VotingPower{
+		rdbprojectionbase.NewRDbBase(rdbConn.ToHandle(), "VotingPower"),
+
+		rdbConn,
+		logger,
+
+		conNodeAddressPrefix,
+	}
+}
+
+func (_ *VotingPower) GetEventsToListen() []string {
+	return []string{
+		event_usecase.GENESIS_CREATED,
+		event_usecase.BLOCK_CREATED,
+		event_usecase.MSG_CREATE_VALIDATOR_CREATED,
+		event_usecase.POWER_CHANGED,
+	}
+}
+
+func (projection *VotingPower) OnInit() error {
+	return nil
+}
+
+func (projection *VotingPower) HandleEvents(height int64, events []event_entity.Event) error {
+	rdbTx, err := projection.rdbConn.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %v", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = rdbTx.Rollback()
+		}
+	}()
+
+	rdbTxHandle := rdbTx.ToHandle()
+
+	var maybeBlockTime *utctime.UTCTime
+	var maybeGenesisCreatedEvent *event_usecase.GenesisCreated
+	genesisValidatorEvents := make([]*event_usecase.MsgCreateValidator, 0)
+	powerChangedEvents := make([]*event_usecase.PowerChanged, 0)
+	for _, event := range events {
+		if blockCreatedEvent, ok := event.(*event_usecase.BlockCreated); ok {
+			maybeBlockTime = &blockCreatedEvent.Block.Time
+		} else if genesisCreatedEvent, ok := event.(*event_usecase.GenesisCreated); ok {
+			maybeGenesisCreatedEvent = genesisCreatedEvent
+		} else if msgCreateValidatorEvent, ok := event.(*event_usecase.MsgCreateValidator); ok {
+			// Validators created after genesis only get their power on the PowerChanged event
+			if height == 0 {
+				genesisValidatorEvents = append(genesisValidatorEvents, msgCreateValidatorEvent)
+			}
+		} else if powerChangedEvent, ok := event.(*event_usecase.PowerChanged); ok {
+			powerChangedEvents = append(powerChangedEvents, powerChangedEvent)
+		}
+	}
+
+	if len(genesisValidatorEvents) > 0 {
+		if maybeGenesisCreatedEvent == nil {
+			return fmt.Errorf("error handling genesis validators: missing GenesisCreated event at height %d", height)
+		}
+		projection.logger.Debug("handling genesis validators")
+		if err := projection.handleGenesisValidators(
+			rdbTxHandle, height, maybeGenesisCreatedEvent, genesisValidatorEvents,
+		); err != nil {
+			return fmt.Errorf("error handling genesis validators: %v", err)
+		}
+	}
+
+	if len(powerChangedEvents) > 0 {
+		if maybeBlockTime == nil {
+			return fmt.Errorf("error handling PowerChanged: missing BlockCreated event at height %d", height)
+		}
+		if err := projection.handlePowerChanged(rdbTxHandle, height, *maybeBlockTime, powerChangedEvents); err != nil {
+			return fmt.Errorf("error handling PowerChanged: %v", err)
+		}
+	}
+
+	if err := projection.UpdateLastHandledEventHeight(rdbTxHandle, height); err != nil {
+		return fmt.Errorf("error updating last handled event height: %v", err)
+	}
+
+	if err := rdbTx.Commit(); err != nil {
+		return fmt.Errorf("error committing changes: %v", err)
+	}
+	committed = true
+	return nil
+}
+
+func (projection *VotingPower) handleGenesisValidators(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	genesisCreatedEvent *event_usecase.GenesisCreated,
+	msgCreateValidatorEvents []*event_usecase.MsgCreateValidator,
+) error {
+	genesisTime, err := utctime.Parse(time.RFC3339, genesisCreatedEvent.Genesis.GenesisTime)
+	if err != nil {
+		return fmt.Errorf("error parsing genesis time: %v", err)
+	}
+
+	powers := make([]validatorPower, 0, len(msgCreateValidatorEvents))
+	for _, msgCreateValidatorEvent := range msgCreateValidatorEvents {
+		power, err := tmcosmosutils.PowerFromTokens(msgCreateValidatorEvent.Amount)
+		if err != nil {
+			return fmt.Errorf(
+				"error converting self-delegation of %s to power: %v", msgCreateValidatorEvent.ValidatorAddress, err,
+			)
+		}
+		powers = append(powers, validatorPower{
+			tendermintPubkey: msgCreateValidatorEvent.TendermintPubkey,
+			power:            power,
+		})
+	}
+
+	return projection.recordPowers(rdbTxHandle, blockHeight, genesisTime, powers)
+}
+
+func (projection *VotingPower) handlePowerChanged(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	powerChangedEvents []*event_usecase.PowerChanged,
+) error {
+	powers := make([]validatorPower, 0, len(powerChangedEvents))
+	for _, powerChangedEvent := range powerChangedEvents {
+		power, err := strconv.ParseInt(powerChangedEvent.Power, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing power of %s: %v", powerChangedEvent.TendermintPubkey, err)
+		}
+		powers = append(powers, validatorPower{
+			tendermintPubkey: powerChangedEvent.TendermintPubkey,
+			power:            power,
+		})
+	}
+
+	return projection.recordPowers(rdbTxHandle, blockHeight, blockTime, powers)
+}
+
+type validatorPower struct {
+	tendermintPubkey string
+	power            int64
+}
+
+// recordPowers records the new powers of the validators and the resulting decentralization
+func (projection *VotingPower) recordPowers(
+	rdbTxHandle *rdb.Handle,
+	blockHeight int64,
+	blockTime utctime.UTCTime,
+	powers []validatorPower,
+) error {
+	powersView := view.NewValidatorPowers(rdbTxHandle)
+	historiesView := view.NewPowerHistories(rdbTxHandle)
+
+	for _, validatorPower := range powers {
+		pubKey, err := base64.StdEncoding.DecodeString(validatorPower.tendermintPubkey)
+		if err != nil {
+			return fmt.Errorf("error base64 decoding Tendermint node pubkey: %v", err)
+		}
+		consensusNodeAddress, err := tmcosmosutils.ConsensusNodeAddressFromTmPubKey(
+			projection.conNodeAddressPrefix, pubKey,
+		)
+		if err != nil {
+			return fmt.Errorf("error converting Tendermint node pubkey to address: %v", err)
+		}
+
+		if err := powersView.Upsert(&view.ValidatorPowerRow{
+			ConsensusNodeAddress:   consensusNodeAddress,
+			Power:                  validatorPower.power,
+			LastChangedBlockHeight: blockHeight,
+		}); err != nil {
+			return fmt.Errorf("error upserting validator power: %v", err)
+		}
+		if err := historiesView.Upsert(&view.PowerHistoryRow{
+			ConsensusNodeAddress: consensusNodeAddress,
+			BlockHeight:          blockHeight,
+			BlockTime:            blockTime,
+			Power:                validatorPower.power,
+		}); err != nil {
+			return fmt.Errorf("error upserting voting power history: %v", err)
+		}
+	}
+	bondedPowers, err := powersView.ListAllBonded()
+	if err != nil {
+		return fmt.Errorf("error listing bonded validator powers: %v", err)
+	}
+	sortedPowers := make([]int64, 0, len(bondedPowers))
+	for _, bondedPower := range bondedPowers {
+		sortedPowers = append(sortedPowers, bondedPower.Power)
+	}
+
+	decentralization := NewDecentralization(blockHeight, blockTime, sortedPowers)
+	if err := view.NewDecentralizations(rdbTxHandle).Insert(&decentralization); err != nil {
+		return fmt.Errorf("error inserting decentralization: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package votingpower_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestVotingPower(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "VotingPower Suite")
+}
@@ -0,0 +1,171 @@
+package votingpower_test
+
+import (
+	"encoding/base64"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
+	. "github.com/crypto-com/chain-indexing/appinterface/projection/test"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/votingpower"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/votingpower/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	event_entity "github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	. "github.com/crypto-com/chain-indexing/test"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	usecase_model "github.com/crypto-com/chain-indexing/usecase/model"
+)
+
+var _ = Describe("VotingPower", func() {
+	const conNodeAddressPrefix = "tcrocnclcons"
+
+	// The start of the day, counting from the genesis day as day 1
+	timeOf := func(day int) utctime.UTCTime {
+		return TimeAt(time.Duration(day-1) * 24 * time.Hour)
+	}
+
+	Describe("NewDecentralization", func() {
+		It("should count the fewest largest validators reaching each threshold", func() {
+			decentralization := votingpower.NewDecentralization(1, timeOf(1), []int64{40, 25, 20, 15})
+
+			Expect(decentralization.TotalPower).To(Equal(int64(100)))
+			Expect(decentralization.Validators).To(Equal(int64(4)))
+			Expect(decentralization.NakamotoCoefficient).To(Equal(int64(2)))
+			Expect(decentralization.ValidatorsForOneThird).To(Equal(int64(1)))
+			Expect(decentralization.ValidatorsForTwoThirds).To(Equal(int64(3)))
+			Expect(decentralization.Top10Share).To(Equal("1.000000"))
+		})
+
+		It("should require more than half and more than two thirds", func() {
+			decentralization := votingpower.NewDecentralization(1, timeOf(1), []int64{1, 1, 1, 1, 1, 1})
+
+			Expect(decentralization.NakamotoCoefficient).To(Equal(int64(4)))
+			Expect(decentralization.ValidatorsForOneThird).To(Equal(int64(2)))
+			Expect(decentralization.ValidatorsForTwoThirds).To(Equal(int64(5)))
+		})
+
+		It("should return zero metrics when nothing is bonded", func() {
+			decentralization := votingpower.NewDecentralization(1, timeOf(1), []int64{})
+
+			Expect(decentralization.NakamotoCoefficient).To(Equal(int64(0)))
+			Expect(decentralization.Top20Share).To(Equal("0.000000"))
+		})
+	})
+
+	Describe("projection", func() {
+		var conn rdb.Conn
+		var projection *votingpower.VotingPower
+		BeforeEach(func() {
+			conn = MustNewTestRDbConn()
+			projection = votingpower.NewVotingPower(NewFakeLogger(), conn, conNodeAddressPrefix)
+		})
+
+		pubKeyOf := func(b byte) string {
+			pubKey := make([]byte, 32)
+			pubKey[0] = b
+			return base64.StdEncoding.EncodeToString(pubKey)
+		}
+		addressOf := func(b byte) string {
+			pubKey, _ := base64.StdEncoding.DecodeString(pubKeyOf(b))
+			address, err := tmcosmosutils.ConsensusNodeAddressFromTmPubKey(conNodeAddressPrefix, pubKey)
+			Expect(err).To(BeNil())
+			return address
+		}
+		powerChanged := func(height int64, b byte, power string) event_entity.Event {
+			return event_usecase.NewPowerChanged(height, usecase_model.PowerChangeParams{
+				TendermintPubkey: pubKeyOf(b),
+				Power:            power,
+			})
+		}
+
+		createValidator := func(height int64, b byte, amount int64) event_entity.Event {
+			return event_usecase.NewMsgCreateValidator(
+				NewMsgCommonParams(height, "create-validator-"+pubKeyOf(b)),
+				usecase_model.MsgCreateValidatorParams{
+					TendermintPubkey: pubKeyOf(b),
+					Amount:           coin.MustNewCoinFromInt(amount),
+				},
+			)
+		}
+
+		It("should seed the powers and the first decentralization from the genesis validators", func() {
+			MustReplayEvents(projection, []event_entity.Event{
+				NewGenesisCreatedEvent(timeOf(1), nil),
+				createValidator(0, 1, 30000000),
+				createValidator(0, 2, 10000000),
+				NewBlockCreatedEvent(1, timeOf(2)),
+				NewBlockCreatedEvent(2, timeOf(3)),
+				createValidator(2, 3, 50000000),
+			})
+
+			powers, err := view.NewValidatorPowers(conn.ToHandle()).ListAllBonded()
+			Expect(err).To(BeNil())
+			Expect(powers).To(HaveLen(2))
+
+			histories, _, err := view.NewPowerHistories(conn.ToHandle()).ListByConsensusNodeAddress(
+				addressOf(1),
+				view.PowerHistoriesListFilter{},
+				view.PowerHistoriesListOrder{},
+				pagination_interface.NewOffsetPagination(1, 10),
+			)
+			Expect(err).To(BeNil())
+			Expect(histories).To(HaveLen(1))
+			Expect(histories[0].BlockHeight).To(Equal(int64(0)))
+			Expect(histories[0].BlockTime).To(Equal(timeOf(1)))
+			Expect(histories[0].Power).To(Equal(int64(30)))
+
+			latest, err := view.NewDecentralizations(conn.ToHandle()).FindLatest()
+			Expect(err).To(BeNil())
+			Expect(latest.BlockHeight).To(Equal(int64(0)))
+			Expect(latest.TotalPower).To(Equal(int64(40)))
+			Expect(latest.Validators).To(Equal(int64(2)))
+		})
+
+		It("should keep the power history of each validator and the decentralization of every change", func() {
+			MustReplayEvents(projection, []event_entity.Event{
+				NewBlockCreatedEvent(1, timeOf(1)),
+				powerChanged(1, 1, "60"),
+				powerChanged(1, 2, "40"),
+				NewBlockCreatedEvent(2, timeOf(2)),
+				NewBlockCreatedEvent(3, timeOf(3)),
+				powerChanged(3, 1, "0"),
+			})
+
+			histories, _, err := view.NewPowerHistories(conn.ToHandle()).ListByConsensusNodeAddress(
+				addressOf(1),
+				view.PowerHistoriesListFilter{},
+				view.PowerHistoriesListOrder{MaybeBlockHeight: primptr.String("ASC")},
+				pagination_interface.NewOffsetPagination(1, 10),
+			)
+			Expect(err).To(BeNil())
+			Expect(histories).To(HaveLen(2))
+			Expect(histories[0].Power).To(Equal(int64(60)))
+			Expect(histories[1].Power).To(Equal(int64(0)))
+			Expect(histories[1].BlockTime).To(Equal(timeOf(3)))
+
+			decentralizations, _, err := view.NewDecentralizations(conn.ToHandle()).List(
+				view.DecentralizationsListFilter{MaybeToTime: primptr.UTCTime(timeOf(3))},
+				view.DecentralizationsListOrder{},
+				pagination_interface.NewOffsetPagination(1, 10),
+			)
+			Expect(err).To(BeNil())
+			Expect(decentralizations).To(HaveLen(1))
+			Expect(decentralizations[0].TotalPower).To(Equal(int64(100)))
+			Expect(decentralizations[0].NakamotoCoefficient).To(Equal(int64(1)))
+			Expect(decentralizations[0].ValidatorsForTwoThirds).To(Equal(int64(2)))
+
+			latest, err := view.NewDecentralizations(conn.ToHandle()).FindLatest()
+			Expect(err).To(BeNil())
+			Expect(latest.BlockHeight).To(Equal(int64(3)))
+			Expect(latest.TotalPower).To(Equal(int64(40)))
+			Expect(latest.Validators).To(Equal(int64(1)))
+		})
+	})
+})
@@ -0,0 +1,361 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+var _ rdb.Conn = &InMemoryRDbConn{}
+var _ rdb.Tx = &InMemoryRDbTx{}
+
+// InMemoryRDbConn is a rdb.Conn backed by an in-memory database understanding the subset of
+// Postgres SQL used by the projections: CREATE/ALTER/DROP TABLE, unique indexes, INSERT with
+// ON CONFLICT and RETURNING, UPDATE, DELETE and single-table SELECT with sub-queries, aggregates,
+// GROUP BY, ORDER BY, LIMIT and OFFSET. It allows projections to be tested without a database
+// server.
+type InMemoryRDbConn struct {
+	mutex sync.Mutex
+	db    *memDatabase
+}
+
+func NewInMemoryRDbConn() *InMemoryRDbConn {
+	return &InMemoryRDbConn{
+		db: newMemDatabase(),
+	}
+}
+
+// MustMigrate runs all the `*.up.sql` migrations under the directory in file name order. Panics on
+// error.
+func (conn *InMemoryRDbConn) MustMigrate(migrationsDir string) *InMemoryRDbConn {
+	if err := conn.Migrate(migrationsDir); err != nil {
+		panic(err)
+	}
+	return conn
+}
+
+// Migrate runs all the `*.up.sql` migrations under the directory in file name order
+func (conn *InMemoryRDbConn) Migrate(migrationsDir string) error {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		return fmt.Errorf("error listing migration files: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading migration file %s: %v", file, err)
+		}
+		if _, err := conn.Exec(string(content)); err != nil {
+			return fmt.Errorf("error running migration %s: %v", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// TableRows returns all rows of the table in insertion order as column name to value mappings. It is
+// useful to assert on view content in tests.
+func (conn *InMemoryRDbConn) TableRows(table string) ([]map[string]interface{}, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	memTable, err := conn.db.table(table)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]interface{}, 0, len(memTable.rows))
+	for _, row := range memTable.rows {
+		mapping := make(map[string]interface{}, len(memTable.columns))
+		for i, column := range memTable.columns {
+			mapping[column.name] = memDriverValue(row[i])
+		}
+		rows = append(rows, mapping)
+	}
+	return rows, nil
+}
+
+func (conn *InMemoryRDbConn) Begin() (rdb.Tx, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	return &InMemoryRDbTx{
+		conn: conn,
+		db:   conn.db.clone(),
+	}, nil
+}
+
+func (conn *InMemoryRDbConn) Exec(sql string, args ...interface{}) (rdb.ExecResult, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	// Each statement runs atomically as in an implicit transaction
+	db := conn.db.clone()
+	result, err := memExec(db, sql, args)
+	if err != nil {
+		return nil, err
+	}
+	conn.db = db
+	return result, nil
+}
+
+func (conn *InMemoryRDbConn) Query(sql string, args ...interface{}) (rdb.RowsResult, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	db := conn.db.clone()
+	result, err := memQuery(db, sql, args)
+	if err != nil {
+		return nil, err
+	}
+	conn.db = db
+	return result, nil
+}
+
+func (conn *InMemoryRDbConn) QueryRow(sql string, args ...interface{}) rdb.RowResult {
+	rows, err := conn.Query(sql, args...)
+	return newInMemoryRDbRowResult(rows, err)
+}
+
+func (conn *InMemoryRDbConn) ToHandle() *rdb.Handle {
+	return &rdb.Handle{
+		Runner:   conn,
+		TypeConv: &InMemoryRDbTypeConv{},
+
+		StmtBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// InMemoryRDbTx works on a snapshot of the database which replaces the connection database on
+// commit. Concurrent transactions are not isolated from each other: the last commit wins.
+type InMemoryRDbTx struct {
+	conn   *InMemoryRDbConn
+	db     *memDatabase
+	closed bool
+}
+
+var errInMemoryTxClosed = errors.New("tx is closed")
+
+func (tx *InMemoryRDbTx) Exec(sql string, args ...interface{}) (rdb.ExecResult, error) {
+	if tx.closed {
+		return nil, errInMemoryTxClosed
+	}
+	return memExec(tx.db, sql, args)
+}
+
+func (tx *InMemoryRDbTx) Query(sql string, args ...interface{}) (rdb.RowsResult, error) {
+	if tx.closed {
+		return nil, errInMemoryTxClosed
+	}
+	return memQuery(tx.db, sql, args)
+}
+
+func (tx *InMemoryRDbTx) QueryRow(sql string, args ...interface{}) rdb.RowResult {
+	rows, err := tx.Query(sql, args...)
+	return newInMemoryRDbRowResult(rows, err)
+}
+
+func (tx *InMemoryRDbTx) Commit() error {
+	if tx.closed {
+		return errInMemoryTxClosed
+	}
+	tx.closed = true
+
+	tx.conn.mutex.Lock()
+	defer tx.conn.mutex.Unlock()
+	tx.conn.db = tx.db
+	return nil
+}
+
+func (tx *InMemoryRDbTx) Rollback() error {
+	if tx.closed {
+		return errInMemoryTxClosed
+	}
+	tx.closed = true
+	return nil
+}
+
+func (tx *InMemoryRDbTx) ToHandle() *rdb.Handle {
+	return &rdb.Handle{
+		Runner:   tx,
+		TypeConv: &InMemoryRDbTypeConv{},
+
+		StmtBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func memExec(db *memDatabase, sql string, args []interface{}) (*InMemoryRDbExecResult, error) {
+	statements, err := parseSQL(sql)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SQL `%s`: %v", sql, err)
+	}
+	result := &InMemoryRDbExecResult{}
+	for _, statement := range statements {
+		memResult, err := db.exec(statement, args)
+		if err != nil {
+			return nil, err
+		}
+		result = &InMemoryRDbExecResult{memResult.commandTag, memResult.rowsAffected}
+	}
+	return result, nil
+}
+
+func memQuery(db *memDatabase, sql string, args []interface{}) (*InMemoryRDbRowsResult, error) {
+	statements, err := parseSQL(sql)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SQL `%s`: %v", sql, err)
+	}
+	if len(statements) != 1 {
+		return nil, fmt.Errorf("cannot query multiple statements")
+	}
+	memResult, err := db.exec(statements[0], args)
+	if err != nil {
+		return nil, err
+	}
+	return &InMemoryRDbRowsResult{
+		execResult: &InMemoryRDbExecResult{memResult.commandTag, memResult.rowsAffected},
+		columns:    memResult.columns,
+		rows:       memResult.rows,
+		cursor:     -1,
+	}, nil
+}
+
+type InMemoryRDbExecResult struct {
+	commandTag   string
+	rowsAffected int64
+}
+
+func (result *InMemoryRDbExecResult) RowsAffected() int64 { return result.rowsAffected }
+func (result *InMemoryRDbExecResult) IsInsert() bool      { return result.commandTag == "INSERT" }
+func (result *InMemoryRDbExecResult) IsUpdate() bool      { return result.commandTag == "UPDATE" }
+func (result *InMemoryRDbExecResult) IsDelete() bool      { return result.commandTag == "DELETE" }
+func (result *InMemoryRDbExecResult) IsSelect() bool      { return result.commandTag == "SELECT" }
+func (result *InMemoryRDbExecResult) String() string {
+	if result.commandTag == "INSERT" {
+		return fmt.Sprintf("INSERT 0 %d", result.rowsAffected)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %d", result.commandTag, result.rowsAffected))
+}
+
+type InMemoryRDbRowsResult struct {
+	execResult *InMemoryRDbExecResult
+	columns    []string
+	rows       [][]interface{}
+	cursor     int
+}
+
+func (result *InMemoryRDbRowsResult) Close()     {}
+func (result *InMemoryRDbRowsResult) Err() error { return nil }
+func (result *InMemoryRDbRowsResult) ExecResult() rdb.ExecResult {
+	return result.execResult
+}
+func (result *InMemoryRDbRowsResult) Next() bool {
+	if result.cursor+1 >= len(result.rows) {
+		result.cursor = len(result.rows)
+		return false
+	}
+	result.cursor++
+	return true
+}
+func (result *InMemoryRDbRowsResult) Scan(dest ...interface{}) error {
+	if result.cursor < 0 || result.cursor >= len(result.rows) {
+		return rdb.ErrNoRows
+	}
+	row := result.rows[result.cursor]
+	if len(dest) != len(row) {
+		return fmt.Errorf("number of field descriptions must equal number of destinations, got %d and %d", len(row), len(dest))
+	}
+	for i, value := range row {
+		if err := memScan(dest[i], value); err != nil {
+			return fmt.Errorf("can't scan into dest[%d] (%s): %v", i, result.columns[i], err)
+		}
+	}
+	return nil
+}
+
+type InMemoryRDbRowResult struct {
+	rows rdb.RowsResult
+	err  error
+}
+
+func newInMemoryRDbRowResult(rows rdb.RowsResult, err error) *InMemoryRDbRowResult {
+	return &InMemoryRDbRowResult{rows, err}
+}
+
+func (result *InMemoryRDbRowResult) Scan(dest ...interface{}) error {
+	if result.err != nil {
+		return result.err
+	}
+	if !result.rows.Next() {
+		return rdb.ErrNoRows
+	}
+	return result.rows.Scan(dest...)
+}
+
+// InMemoryRDbTypeConv stores big.Int as NUMERIC and time as unix nanoseconds as the Postgres
+// implementation does
+type InMemoryRDbTypeConv struct{}
+
+func (conv *InMemoryRDbTypeConv) Bton(b *big.Int) interface{} {
+	if b == nil {
+		return nil
+	}
+	return new(big.Rat).SetInt(b)
+}
+func (conv *InMemoryRDbTypeConv) Iton(i int) interface{} {
+	return new(big.Rat).SetInt64(int64(i))
+}
+func (conv *InMemoryRDbTypeConv) NtobReader() rdb.NtobReader {
+	return &InMemoryRDbNtobReader{}
+}
+func (conv *InMemoryRDbTypeConv) Tton(t *utctime.UTCTime) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UnixNano()
+}
+func (conv *InMemoryRDbTypeConv) NtotReader() rdb.NtotReader {
+	return &InMemoryRDbNtotReader{}
+}
+
+type InMemoryRDbNtobReader struct {
+	value *string
+}
+
+func (reader *InMemoryRDbNtobReader) ScannableArg() interface{} {
+	return &reader.value
+}
+func (reader *InMemoryRDbNtobReader) Parse() (*big.Int, error) {
+	if reader.value == nil {
+		return nil, nil
+	}
+	i, ok := new(big.Int).SetString(*reader.value, 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %s to bigInt", *reader.value)
+	}
+	return i, nil
+}
+
+type InMemoryRDbNtotReader struct {
+	unixNano *int64
+}
+
+func (reader *InMemoryRDbNtotReader) ScannableArg() interface{} {
+	return &reader.unixNano
+}
+func (reader *InMemoryRDbNtotReader) Parse() (*utctime.UTCTime, error) {
+	if reader.unixNano == nil {
+		return nil, nil
+	}
+	t := utctime.FromUnixNano(*reader.unixNano)
+	return &t, nil
+}
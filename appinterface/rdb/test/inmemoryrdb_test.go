@@ -0,0 +1,166 @@
+package test_test
+
+import (
+	"math/big"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	. "github.com/crypto-com/chain-indexing/appinterface/rdb/test"
+)
+
+var _ = Describe("InMemoryRDbConn", func() {
+	var conn *InMemoryRDbConn
+	BeforeEach(func() {
+		conn = NewInMemoryRDbConn()
+		_, err := conn.Exec(`
+CREATE TABLE accounts (
+    id BIGSERIAL,
+    address VARCHAR NOT NULL,
+    balance NUMERIC NOT NULL DEFAULT 0,
+    memo VARCHAR NULL,
+    PRIMARY KEY (id),
+    UNIQUE (address)
+);`)
+		Expect(err).To(BeNil())
+	})
+
+	It("should insert rows and return serial ids", func() {
+		var id int64
+		err := conn.QueryRow(
+			"INSERT INTO accounts (address, balance) VALUES ($1, $2) RETURNING id", "alice", conn.ToHandle().Bton(big.NewInt(10)),
+		).Scan(&id)
+		Expect(err).To(BeNil())
+		Expect(id).To(Equal(int64(1)))
+
+		err = conn.QueryRow("INSERT INTO accounts (address) VALUES ($1) RETURNING id", "bob").Scan(&id)
+		Expect(err).To(BeNil())
+		Expect(id).To(Equal(int64(2)))
+	})
+
+	It("should return ErrNoRows when no row is selected", func() {
+		var address string
+		err := conn.QueryRow("SELECT address FROM accounts WHERE id = $1", 1).Scan(&address)
+		Expect(err).To(Equal(rdb.ErrNoRows))
+	})
+
+	It("should reject duplicated unique values", func() {
+		_, err := conn.Exec("INSERT INTO accounts (address) VALUES ($1)", "alice")
+		Expect(err).To(BeNil())
+
+		_, err = conn.Exec("INSERT INTO accounts (address) VALUES ($1)", "alice")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should upsert on conflict", func() {
+		upsert := "INSERT INTO accounts (address, balance) VALUES ($1, $2) " +
+			"ON CONFLICT (address) DO UPDATE SET balance = accounts.balance + EXCLUDED.balance"
+		_, err := conn.Exec(upsert, "alice", 10)
+		Expect(err).To(BeNil())
+		_, err = conn.Exec(upsert, "alice", 5)
+		Expect(err).To(BeNil())
+
+		balanceReader := conn.ToHandle().NtobReader()
+		err = conn.QueryRow("SELECT balance FROM accounts WHERE address = $1", "alice").Scan(balanceReader.ScannableArg())
+		Expect(err).To(BeNil())
+		Expect(balanceReader.Parse()).To(Equal(big.NewInt(15)))
+	})
+
+	It("should scan NULL into nil pointer", func() {
+		_, err := conn.Exec("INSERT INTO accounts (address) VALUES ($1)", "alice")
+		Expect(err).To(BeNil())
+
+		var maybeMemo *string
+		err = conn.QueryRow("SELECT memo FROM accounts WHERE address = $1", "alice").Scan(&maybeMemo)
+		Expect(err).To(BeNil())
+		Expect(maybeMemo).To(BeNil())
+	})
+
+	It("should aggregate, group and order rows", func() {
+		for _, address := range []string{"alice", "bob", "carol", "dave"} {
+			_, err := conn.Exec("INSERT INTO accounts (address, memo) VALUES ($1, $2)", address, address[:1])
+			Expect(err).To(BeNil())
+		}
+		_, err := conn.Exec("UPDATE accounts SET memo = $1 WHERE address IN ($2, $3)", "x", "bob", "carol")
+		Expect(err).To(BeNil())
+
+		rowsResult, err := conn.Query(
+			"SELECT memo, COUNT(*) AS total FROM accounts GROUP BY memo ORDER BY total DESC, memo LIMIT 2",
+		)
+		Expect(err).To(BeNil())
+		defer rowsResult.Close()
+
+		memos := make([]string, 0)
+		totals := make([]int64, 0)
+		for rowsResult.Next() {
+			var memo string
+			var total int64
+			Expect(rowsResult.Scan(&memo, &total)).To(Succeed())
+			memos = append(memos, memo)
+			totals = append(totals, total)
+		}
+		Expect(memos).To(Equal([]string{"x", "a"}))
+		Expect(totals).To(Equal([]int64{2, 1}))
+	})
+
+	It("should filter, aggregate and order rows", func() {
+		for _, address := range []string{"alice", "bob", "carol", "dave"} {
+			_, err := conn.Exec("INSERT INTO accounts (address, memo) VALUES ($1, $2)", address, address[:1])
+			Expect(err).To(BeNil())
+		}
+		_, err := conn.Exec("UPDATE accounts SET memo = $1 WHERE address IN ($2, $3)", "x", "bob", "carol")
+		Expect(err).To(BeNil())
+
+		var count int64
+		var maxAddress string
+		Expect(conn.QueryRow(
+			"SELECT COUNT(*), MAX(address) FROM accounts WHERE memo = $1", "x",
+		).Scan(&count, &maxAddress)).To(Succeed())
+		Expect(count).To(Equal(int64(2)))
+		Expect(maxAddress).To(Equal("carol"))
+
+		rowsResult, err := conn.Query(
+			"SELECT address FROM accounts WHERE UPPER(memo) <> $1 OR address LIKE $2 ORDER BY memo DESC, address LIMIT 3",
+			"X", "c%",
+		)
+		Expect(err).To(BeNil())
+		defer rowsResult.Close()
+
+		addresses := make([]string, 0)
+		for rowsResult.Next() {
+			var address string
+			Expect(rowsResult.Scan(&address)).To(Succeed())
+			addresses = append(addresses, address)
+		}
+		Expect(addresses).To(Equal([]string{"carol", "dave", "alice"}))
+	})
+
+	It("should reject SQL outside of the supported subset", func() {
+		_, err := conn.Query("SELECT memo, COUNT(*) FROM accounts GROUP BY memo HAVING COUNT(*) > 1")
+		Expect(err).NotTo(BeNil())
+		_, err = conn.Query("SELECT address FROM accounts WHERE address IN (SELECT address FROM accounts)")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should discard changes on rollback and keep them on commit", func() {
+		tx, err := conn.Begin()
+		Expect(err).To(BeNil())
+		_, err = tx.Exec("INSERT INTO accounts (address) VALUES ($1)", "alice")
+		Expect(err).To(BeNil())
+		Expect(tx.Rollback()).To(Succeed())
+
+		var count int64
+		Expect(conn.QueryRow("SELECT COUNT(*) FROM accounts").Scan(&count)).To(Succeed())
+		Expect(count).To(Equal(int64(0)))
+
+		tx, err = conn.Begin()
+		Expect(err).To(BeNil())
+		_, err = tx.Exec("INSERT INTO accounts (address) VALUES ($1)", "alice")
+		Expect(err).To(BeNil())
+		Expect(tx.Commit()).To(Succeed())
+
+		Expect(conn.QueryRow("SELECT COUNT(*) FROM accounts").Scan(&count)).To(Succeed())
+		Expect(count).To(Equal(int64(1)))
+	})
+})
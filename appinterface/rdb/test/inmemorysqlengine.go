@@ -0,0 +1,951 @@
+package test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type memColumn struct {
+	name         string
+	class        memTypeClass
+	serial       bool
+	notNull      bool
+	maybeDefault memExpr
+}
+
+type memConstraint struct {
+	// name of the backing unique index, empty for table constraint
+	indexName string
+	columns   []string
+	primary   bool
+}
+
+type memTable struct {
+	name        string
+	columns     []memColumn
+	constraints []memConstraint
+	rows        [][]interface{}
+	sequence    int64
+}
+
+func (table *memTable) columnIndex(name string) int {
+	for i, column := range table.columns {
+		if column.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (table *memTable) columnNames() []string {
+	names := make([]string, 0, len(table.columns))
+	for _, column := range table.columns {
+		names = append(names, column.name)
+	}
+	return names
+}
+
+func (table *memTable) clone() *memTable {
+	cloned := *table
+	cloned.columns = append([]memColumn{}, table.columns...)
+	cloned.constraints = append([]memConstraint{}, table.constraints...)
+	cloned.rows = make([][]interface{}, 0, len(table.rows))
+	for _, row := range table.rows {
+		cloned.rows = append(cloned.rows, append([]interface{}{}, row...))
+	}
+	return &cloned
+}
+
+// memDatabase is the state of the in-memory database
+type memDatabase struct {
+	tables map[string]*memTable
+
+	// Start time of the statement or transaction working on the database, returned by now() as in
+	// Postgres
+	now time.Time
+}
+
+func newMemDatabase() *memDatabase {
+	return &memDatabase{
+		tables: make(map[string]*memTable),
+	}
+}
+
+func (db *memDatabase) clone() *memDatabase {
+	cloned := newMemDatabase()
+	for name, table := range db.tables {
+		cloned.tables[name] = table.clone()
+	}
+	cloned.now = time.Now().UTC()
+	return cloned
+}
+
+func (db *memDatabase) table(name string) (*memTable, error) {
+	table, exist := db.tables[name]
+	if !exist {
+		return nil, fmt.Errorf("relation \"%s\" does not exist", name)
+	}
+	return table, nil
+}
+
+// memResult is the outcome of a statement execution
+type memResult struct {
+	commandTag   string
+	rowsAffected int64
+
+	columns []string
+	rows    [][]interface{}
+}
+
+func (db *memDatabase) exec(statement memStatement, args []interface{}) (*memResult, error) {
+	normalizedArgs := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		normalizedArg, err := memNormalizeArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		normalizedArgs = append(normalizedArgs, normalizedArg)
+	}
+
+	switch stmt := statement.(type) {
+	case *memSelectStmt:
+		columns, rows, err := db.execSelect(stmt, normalizedArgs)
+		if err != nil {
+			return nil, err
+		}
+		return &memResult{commandTag: "SELECT", rowsAffected: int64(len(rows)), columns: columns, rows: rows}, nil
+	case *memInsertStmt:
+		return db.execInsert(stmt, normalizedArgs)
+	case *memUpdateStmt:
+		return db.execUpdate(stmt, normalizedArgs)
+	case *memDeleteStmt:
+		return db.execDelete(stmt, normalizedArgs)
+	case *memCreateTableStmt:
+		return db.execCreateTable(stmt)
+	case *memCreateIndexStmt:
+		return db.execCreateIndex(stmt)
+	case *memAlterTableStmt:
+		return db.execAlterTable(stmt)
+	}
+
+	return nil, fmt.Errorf("unsupported statement %T", statement)
+}
+
+func (db *memDatabase) execCreateTable(stmt *memCreateTableStmt) (*memResult, error) {
+	if _, exist := db.tables[stmt.table]; exist {
+		if stmt.ifNotExists {
+			return &memResult{commandTag: "CREATE TABLE"}, nil
+		}
+		return nil, fmt.Errorf("relation \"%s\" already exists", stmt.table)
+	}
+
+	table := &memTable{name: stmt.table}
+	for _, columnDef := range stmt.columns {
+		table.columns = append(table.columns, memColumn{
+			name:         columnDef.name,
+			class:        memTypeClassOf(columnDef.typeName),
+			serial:       columnDef.serial,
+			notNull:      columnDef.notNull,
+			maybeDefault: columnDef.maybeDefault,
+		})
+	}
+	if stmt.primaryKey != nil {
+		if err := table.addConstraint(memConstraint{columns: stmt.primaryKey, primary: true}); err != nil {
+			return nil, err
+		}
+	}
+	for _, unique := range stmt.uniques {
+		if err := table.addConstraint(memConstraint{columns: unique}); err != nil {
+			return nil, err
+		}
+	}
+
+	db.tables[stmt.table] = table
+	return &memResult{commandTag: "CREATE TABLE"}, nil
+}
+
+func (table *memTable) addConstraint(constraint memConstraint) error {
+	for _, column := range constraint.columns {
+		index := table.columnIndex(column)
+		if index == -1 {
+			return fmt.Errorf("column \"%s\" named in key does not exist", column)
+		}
+		if constraint.primary {
+			table.columns[index].notNull = true
+		}
+	}
+	table.constraints = append(table.constraints, constraint)
+	return nil
+}
+
+func (db *memDatabase) execCreateIndex(stmt *memCreateIndexStmt) (*memResult, error) {
+	table, err := db.table(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	if stmt.unique && stmt.columns != nil {
+		constraint := memConstraint{indexName: stmt.name, columns: stmt.columns}
+		if err := table.addConstraint(constraint); err != nil {
+			return nil, err
+		}
+		if err := table.checkUnique(constraint); err != nil {
+			table.constraints = table.constraints[:len(table.constraints)-1]
+			return nil, err
+		}
+	}
+	return &memResult{commandTag: "CREATE INDEX"}, nil
+}
+
+func (db *memDatabase) execAlterTable(stmt *memAlterTableStmt) (*memResult, error) {
+	table, err := db.table(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+
+	columnDef := stmt.addColumn
+	if table.columnIndex(columnDef.name) != -1 {
+		if stmt.addColumnIfNotExists {
+			return &memResult{commandTag: "ALTER TABLE"}, nil
+		}
+		return nil, fmt.Errorf("column \"%s\" of relation \"%s\" already exists", columnDef.name, table.name)
+	}
+	column := memColumn{
+		name:         columnDef.name,
+		class:        memTypeClassOf(columnDef.typeName),
+		serial:       columnDef.serial,
+		notNull:      columnDef.notNull,
+		maybeDefault: columnDef.maybeDefault,
+	}
+	table.columns = append(table.columns, column)
+	for i := range table.rows {
+		value, err := table.defaultValue(len(table.columns) - 1)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil && column.notNull {
+			return nil, fmt.Errorf("column \"%s\" contains null values", column.name)
+		}
+		table.rows[i] = append(table.rows[i], value)
+	}
+	if stmt.addColumnUnique {
+		if err := table.addConstraint(memConstraint{columns: []string{columnDef.name}}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &memResult{commandTag: "ALTER TABLE"}, nil
+}
+
+func memContains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (table *memTable) defaultValue(columnIndex int) (interface{}, error) {
+	column := table.columns[columnIndex]
+	if column.serial {
+		table.sequence++
+		return table.sequence, nil
+	}
+	if column.maybeDefault == nil {
+		return nil, nil
+	}
+	value, err := (&memEvalContext{}).eval(column.maybeDefault)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating default of column \"%s\": %v", column.name, err)
+	}
+	return memCoerce(value, column.class)
+}
+
+// checkUnique returns error when any two rows violate the constraint
+func (table *memTable) checkUnique(constraint memConstraint) error {
+	seen := make(map[string]bool)
+	for _, row := range table.rows {
+		key, hasNull := table.constraintKey(constraint, row)
+		if hasNull {
+			continue
+		}
+		if seen[key] {
+			return table.uniqueViolation(constraint)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// memDefault marks a column value to be filled by the column default
+type memDefault struct{}
+
+func memIsDefaultExpr(expr memExpr) bool {
+	column, ok := expr.(memColumnExpr)
+	return ok && column.qualifier == "" && column.name == "default"
+}
+
+func (table *memTable) constraintKey(constraint memConstraint, row []interface{}) (string, bool) {
+	keys := make([]string, 0, len(constraint.columns))
+	for _, column := range constraint.columns {
+		value := row[table.columnIndex(column)]
+		if value == nil {
+			return "", true
+		}
+		keys = append(keys, memKey(value))
+	}
+	return strings.Join(keys, "\x00"), false
+}
+
+func (table *memTable) uniqueViolation(constraint memConstraint) error {
+	name := constraint.indexName
+	if name == "" {
+		if constraint.primary {
+			name = table.name + "_pkey"
+		} else {
+			name = table.name + "_" + strings.Join(constraint.columns, "_") + "_key"
+		}
+	}
+	return fmt.Errorf("duplicate key value violates unique constraint \"%s\"", name)
+}
+
+// findConflict returns the index of the existing row conflicting with the row on any constraint.
+// -1 when there is no conflict.
+func (table *memTable) findConflict(row []interface{}, skipRowIndex int) (int, memConstraint) {
+	for _, constraint := range table.constraints {
+		key, hasNull := table.constraintKey(constraint, row)
+		if hasNull {
+			continue
+		}
+		for i, existingRow := range table.rows {
+			if i == skipRowIndex {
+				continue
+			}
+			existingKey, existingHasNull := table.constraintKey(constraint, existingRow)
+			if !existingHasNull && existingKey == key {
+				return i, constraint
+			}
+		}
+	}
+	return -1, memConstraint{}
+}
+
+func (table *memTable) checkNotNull(row []interface{}) error {
+	for i, column := range table.columns {
+		if column.notNull && row[i] == nil {
+			return fmt.Errorf("null value in column \"%s\" violates not-null constraint", column.name)
+		}
+	}
+	return nil
+}
+
+func memSameColumns(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, column := range a {
+		if !memContains(b, column) {
+			return false
+		}
+	}
+	return true
+}
+
+func (db *memDatabase) execInsert(stmt *memInsertStmt, args []interface{}) (*memResult, error) {
+	table, err := db.table(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	qualifier := stmt.table
+	if stmt.alias != "" {
+		qualifier = stmt.alias
+	}
+
+	columns := stmt.columns
+	if columns == nil {
+		columns = table.columnNames()
+	}
+	columnIndexes := make([]int, 0, len(columns))
+	for _, column := range columns {
+		index := table.columnIndex(column)
+		if index == -1 {
+			return nil, fmt.Errorf("column \"%s\" of relation \"%s\" does not exist", column, table.name)
+		}
+		columnIndexes = append(columnIndexes, index)
+	}
+
+	var sourceRows [][]interface{}
+	if stmt.maybeSelect != nil {
+		if _, sourceRows, err = db.execSelect(stmt.maybeSelect, args); err != nil {
+			return nil, err
+		}
+	} else {
+		ctx := &memEvalContext{db: db, args: args}
+		for _, exprs := range stmt.rows {
+			if len(exprs) != len(columns) {
+				return nil, fmt.Errorf("INSERT has %d expressions but %d target columns", len(exprs), len(columns))
+			}
+			values := make([]interface{}, 0, len(exprs))
+			for _, expr := range exprs {
+				if memIsDefaultExpr(expr) {
+					values = append(values, memDefault{})
+					continue
+				}
+				value, err := ctx.eval(expr)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			sourceRows = append(sourceRows, values)
+		}
+	}
+
+	result := &memResult{commandTag: "INSERT"}
+	affectedRows := make([][]interface{}, 0)
+	for _, values := range sourceRows {
+		if len(values) != len(columns) {
+			return nil, fmt.Errorf("INSERT has %d expressions but %d target columns", len(values), len(columns))
+		}
+
+		row := make([]interface{}, len(table.columns))
+		provided := make([]bool, len(table.columns))
+		for i, index := range columnIndexes {
+			if _, isDefault := values[i].(memDefault); isDefault {
+				continue
+			}
+			coerced, err := memCoerce(values[i], table.columns[index].class)
+			if err != nil {
+				return nil, fmt.Errorf("error assigning column \"%s\": %v", table.columns[index].name, err)
+			}
+			row[index] = coerced
+			provided[index] = true
+		}
+		for i := range table.columns {
+			if provided[i] {
+				continue
+			}
+			if row[i], err = table.defaultValue(i); err != nil {
+				return nil, err
+			}
+		}
+
+		conflictIndex, constraint := table.findConflict(row, -1)
+		if conflictIndex != -1 {
+			upsert := stmt.maybeUpsert
+			if upsert == nil || (upsert.targets != nil && !memSameColumns(upsert.targets, constraint.columns)) {
+				return nil, table.uniqueViolation(constraint)
+			}
+			if upsert.doNothing {
+				continue
+			}
+
+			existingRow := table.rows[conflictIndex]
+			ctx := &memEvalContext{
+				db:   db,
+				args: args,
+				row: &memEvalRow{
+					qualifiers: []string{table.name, qualifier},
+					columns:    table.columnNames(),
+					values:     existingRow,
+				},
+				maybeExcluded: &memEvalRow{
+					qualifiers: []string{"excluded"},
+					columns:    table.columnNames(),
+					values:     row,
+				},
+			}
+			if upsert.maybeWhere != nil {
+				matched, err := ctx.evalCondition(upsert.maybeWhere)
+				if err != nil {
+					return nil, err
+				}
+				if !matched {
+					continue
+				}
+			}
+			updatedRow, err := table.applyAssignments(ctx, existingRow, upsert.assignments)
+			if err != nil {
+				return nil, err
+			}
+			if conflict, conflictConstraint := table.findConflict(updatedRow, conflictIndex); conflict != -1 {
+				return nil, table.uniqueViolation(conflictConstraint)
+			}
+			table.rows[conflictIndex] = updatedRow
+			affectedRows = append(affectedRows, updatedRow)
+			continue
+		}
+
+		if err := table.checkNotNull(row); err != nil {
+			return nil, err
+		}
+		table.rows = append(table.rows, row)
+		affectedRows = append(affectedRows, row)
+	}
+
+	result.rowsAffected = int64(len(affectedRows))
+	if stmt.returning != nil {
+		if result.columns, result.rows, err = db.evalReturning(
+			table, qualifier, stmt.returning, affectedRows, args,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (table *memTable) applyAssignments(
+	ctx *memEvalContext,
+	row []interface{},
+	assignments []memAssignment,
+) ([]interface{}, error) {
+	updatedRow := append([]interface{}{}, row...)
+	for _, assignment := range assignments {
+		index := table.columnIndex(assignment.column)
+		if index == -1 {
+			return nil, fmt.Errorf("column \"%s\" of relation \"%s\" does not exist", assignment.column, table.name)
+		}
+
+		var value interface{}
+		var err error
+		if memIsDefaultExpr(assignment.value) {
+			if value, err = table.defaultValue(index); err != nil {
+				return nil, err
+			}
+		} else if value, err = ctx.eval(assignment.value); err != nil {
+			return nil, err
+		}
+		coerced, err := memCoerce(value, table.columns[index].class)
+		if err != nil {
+			return nil, fmt.Errorf("error assigning column \"%s\": %v", assignment.column, err)
+		}
+		updatedRow[index] = coerced
+	}
+	if err := table.checkNotNull(updatedRow); err != nil {
+		return nil, err
+	}
+	return updatedRow, nil
+}
+
+func (db *memDatabase) evalReturning(
+	table *memTable,
+	qualifier string,
+	items []memSelectItem,
+	rows [][]interface{},
+	args []interface{},
+) ([]string, [][]interface{}, error) {
+	source := &memRowSource{
+		qualifiers: []string{table.name, qualifier},
+		columns:    table.columnNames(),
+		rows:       rows,
+	}
+	return db.project(source, &memSelectStmt{items: items}, args)
+}
+
+func (db *memDatabase) execUpdate(stmt *memUpdateStmt, args []interface{}) (*memResult, error) {
+	table, err := db.table(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	qualifier := stmt.table
+	if stmt.alias != "" {
+		qualifier = stmt.alias
+	}
+
+	updatedRows := make(map[int][]interface{})
+	order := make([]int, 0)
+	for i, row := range table.rows {
+		ctx := &memEvalContext{
+			db:   db,
+			args: args,
+			row: &memEvalRow{
+				qualifiers: []string{table.name, qualifier},
+				columns:    table.columnNames(),
+				values:     row,
+			},
+		}
+		if stmt.maybeWhere != nil {
+			matched, err := ctx.evalCondition(stmt.maybeWhere)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		updatedRow, err := table.applyAssignments(ctx, row, stmt.assignments)
+		if err != nil {
+			return nil, err
+		}
+		updatedRows[i] = updatedRow
+		order = append(order, i)
+	}
+
+	previousRows := table.rows
+	table.rows = make([][]interface{}, len(previousRows))
+	copy(table.rows, previousRows)
+	for i, updatedRow := range updatedRows {
+		table.rows[i] = updatedRow
+	}
+	for _, constraint := range table.constraints {
+		if err := table.checkUnique(constraint); err != nil {
+			table.rows = previousRows
+			return nil, err
+		}
+	}
+
+	result := &memResult{commandTag: "UPDATE", rowsAffected: int64(len(order))}
+	if stmt.returning != nil {
+		affectedRows := make([][]interface{}, 0, len(order))
+		for _, i := range order {
+			affectedRows = append(affectedRows, table.rows[i])
+		}
+		if result.columns, result.rows, err = db.evalReturning(
+			table, qualifier, stmt.returning, affectedRows, args,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (db *memDatabase) execDelete(stmt *memDeleteStmt, args []interface{}) (*memResult, error) {
+	table, err := db.table(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	qualifier := stmt.table
+	if stmt.alias != "" {
+		qualifier = stmt.alias
+	}
+
+	remainingRows := make([][]interface{}, 0, len(table.rows))
+	deletedRows := make([][]interface{}, 0)
+	for _, row := range table.rows {
+		matched := true
+		if stmt.maybeWhere != nil {
+			ctx := &memEvalContext{
+				db:   db,
+				args: args,
+				row: &memEvalRow{
+					qualifiers: []string{table.name, qualifier},
+					columns:    table.columnNames(),
+					values:     row,
+				},
+			}
+			if matched, err = ctx.evalCondition(stmt.maybeWhere); err != nil {
+				return nil, err
+			}
+		}
+		if matched {
+			deletedRows = append(deletedRows, row)
+		} else {
+			remainingRows = append(remainingRows, row)
+		}
+	}
+	table.rows = remainingRows
+
+	result := &memResult{commandTag: "DELETE", rowsAffected: int64(len(deletedRows))}
+	if stmt.returning != nil {
+		if result.columns, result.rows, err = db.evalReturning(
+			table, qualifier, stmt.returning, deletedRows, args,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// memRowSource is the rows a SELECT reads from
+type memRowSource struct {
+	qualifiers []string
+	columns    []string
+	rows       [][]interface{}
+}
+
+func (db *memDatabase) execSelect(stmt *memSelectStmt, args []interface{}) ([]string, [][]interface{}, error) {
+	var source *memRowSource
+	switch {
+	case stmt.maybeFromSelect != nil:
+		columns, rows, err := db.execSelect(stmt.maybeFromSelect, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		source = &memRowSource{qualifiers: []string{stmt.fromAlias}, columns: columns, rows: rows}
+	case stmt.fromTable != "":
+		table, err := db.table(stmt.fromTable)
+		if err != nil {
+			return nil, nil, err
+		}
+		qualifiers := []string{table.name}
+		if stmt.fromAlias != "" {
+			qualifiers = []string{stmt.fromAlias}
+		}
+		source = &memRowSource{qualifiers: qualifiers, columns: table.columnNames(), rows: table.rows}
+	default:
+		// SELECT without FROM produces a single row
+		source = &memRowSource{rows: [][]interface{}{{}}}
+	}
+
+	return db.project(source, stmt, args)
+}
+
+type memOutputRow struct {
+	values []interface{}
+	// context to evaluate ORDER BY
+	ctx *memEvalContext
+}
+
+func (db *memDatabase) project(
+	source *memRowSource,
+	stmt *memSelectStmt,
+	args []interface{},
+) ([]string, [][]interface{}, error) {
+	newContext := func(values []interface{}) *memEvalContext {
+		return &memEvalContext{
+			db:   db,
+			args: args,
+			row: &memEvalRow{
+				qualifiers: source.qualifiers,
+				columns:    source.columns,
+				values:     values,
+			},
+		}
+	}
+
+	// WHERE
+	filteredRows := make([][]interface{}, 0, len(source.rows))
+	for _, row := range source.rows {
+		if stmt.maybeWhere != nil {
+			matched, err := newContext(row).evalCondition(stmt.maybeWhere)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		filteredRows = append(filteredRows, row)
+	}
+
+	// Output column names
+	columns := make([]string, 0, len(stmt.items))
+	for _, item := range stmt.items {
+		if item.star {
+			if item.starQualifier != "" && !memContains(source.qualifiers, item.starQualifier) {
+				return nil, nil, fmt.Errorf("missing FROM-clause entry for table \"%s\"", item.starQualifier)
+			}
+			columns = append(columns, source.columns...)
+			continue
+		}
+		columns = append(columns, item.name)
+	}
+
+	evalItems := func(ctx *memEvalContext) ([]interface{}, error) {
+		values := make([]interface{}, 0, len(columns))
+		for _, item := range stmt.items {
+			if item.star {
+				values = append(values, ctx.row.values...)
+				continue
+			}
+			value, err := ctx.eval(item.expr)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	outputRows := make([]memOutputRow, 0)
+	if len(stmt.groupBy) > 0 || memSelectHasAggregate(stmt) {
+		groups := make([][][]interface{}, 0)
+		groupIndexes := make(map[string]int)
+		for _, row := range filteredRows {
+			keys := make([]string, 0, len(stmt.groupBy))
+			for _, groupExpr := range stmt.groupBy {
+				value, err := newContext(row).eval(memResolveOrdinal(groupExpr, stmt.items))
+				if err != nil {
+					return nil, nil, err
+				}
+				keys = append(keys, memKey(value))
+			}
+			key := strings.Join(keys, "\x00")
+			index, exist := groupIndexes[key]
+			if !exist {
+				index = len(groups)
+				groupIndexes[key] = index
+				groups = append(groups, [][]interface{}{})
+			}
+			groups[index] = append(groups[index], row)
+		}
+		// aggregate without GROUP BY always produces one row
+		if len(groups) == 0 && len(stmt.groupBy) == 0 {
+			groups = append(groups, [][]interface{}{})
+		}
+
+		for _, groupRows := range groups {
+			var representative []interface{}
+			if len(groupRows) > 0 {
+				representative = groupRows[0]
+			} else {
+				representative = make([]interface{}, len(source.columns))
+			}
+			ctx := newContext(representative)
+			ctx.grouped = true
+			ctx.maybeGroup = groupRows
+			values, err := evalItems(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			outputRows = append(outputRows, memOutputRow{values, ctx})
+		}
+	} else {
+		for _, row := range filteredRows {
+			ctx := newContext(row)
+			values, err := evalItems(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			outputRows = append(outputRows, memOutputRow{values, ctx})
+		}
+	}
+
+	if len(stmt.orderBy) > 0 {
+		sortKeys := make([][]interface{}, len(outputRows))
+		for i, outputRow := range outputRows {
+			ctx := outputRow.ctx.withOutput(columns, outputRow.values)
+			for _, orderItem := range stmt.orderBy {
+				value, err := ctx.eval(memResolveOrdinal(orderItem.expr, stmt.items))
+				if err != nil {
+					return nil, nil, err
+				}
+				sortKeys[i] = append(sortKeys[i], value)
+			}
+		}
+		indexes := make([]int, len(outputRows))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		var sortErr error
+		sort.SliceStable(indexes, func(a, b int) bool {
+			for k, orderItem := range stmt.orderBy {
+				valueA, valueB := sortKeys[indexes[a]][k], sortKeys[indexes[b]][k]
+				if valueA == nil || valueB == nil {
+					if valueA == nil && valueB == nil {
+						continue
+					}
+					return (valueA == nil) == orderItem.nullsFirst
+				}
+				cmp, err := memCompare(valueA, valueB)
+				if err != nil {
+					sortErr = err
+					return false
+				}
+				if cmp == 0 {
+					continue
+				}
+				if orderItem.desc {
+					return cmp > 0
+				}
+				return cmp < 0
+			}
+			return false
+		})
+		if sortErr != nil {
+			return nil, nil, sortErr
+		}
+		sortedRows := make([]memOutputRow, 0, len(outputRows))
+		for _, i := range indexes {
+			sortedRows = append(sortedRows, outputRows[i])
+		}
+		outputRows = sortedRows
+	}
+
+	ctx := &memEvalContext{db: db, args: args}
+	if stmt.maybeOffset != nil {
+		offset, err := ctx.evalInt(stmt.maybeOffset)
+		if err != nil {
+			return nil, nil, err
+		}
+		if offset >= int64(len(outputRows)) {
+			outputRows = outputRows[:0]
+		} else if offset > 0 {
+			outputRows = outputRows[offset:]
+		}
+	}
+	if stmt.maybeLimit != nil {
+		limit, err := ctx.evalInt(stmt.maybeLimit)
+		if err != nil {
+			return nil, nil, err
+		}
+		if limit >= 0 && limit < int64(len(outputRows)) {
+			outputRows = outputRows[:limit]
+		}
+	}
+
+	rows := make([][]interface{}, 0, len(outputRows))
+	for _, outputRow := range outputRows {
+		rows = append(rows, outputRow.values)
+	}
+	return columns, rows, nil
+}
+
+// memResolveOrdinal resolves `ORDER BY 1` and `GROUP BY 1` to the select item
+func memResolveOrdinal(expr memExpr, items []memSelectItem) memExpr {
+	literal, ok := expr.(memLiteralExpr)
+	if !ok {
+		return expr
+	}
+	ordinal, ok := literal.value.(int64)
+	if !ok || ordinal < 1 || ordinal > int64(len(items)) || items[ordinal-1].star {
+		return expr
+	}
+	return items[ordinal-1].expr
+}
+
+func memSelectHasAggregate(stmt *memSelectStmt) bool {
+	for _, item := range stmt.items {
+		if !item.star && memHasAggregate(item.expr) {
+			return true
+		}
+	}
+	return false
+}
+
+func memHasAggregate(expr memExpr) bool {
+	switch typedExpr := expr.(type) {
+	case memFuncExpr:
+		if memAggregateFuncs[typedExpr.name] {
+			return true
+		}
+		for _, arg := range typedExpr.args {
+			if memHasAggregate(arg) {
+				return true
+			}
+		}
+	case memUnaryExpr:
+		return memHasAggregate(typedExpr.operand)
+	case memBinaryExpr:
+		return memHasAggregate(typedExpr.left) || memHasAggregate(typedExpr.right)
+	case memCastExpr:
+		return memHasAggregate(typedExpr.operand)
+	case memIsExpr:
+		return memHasAggregate(typedExpr.operand)
+	case memCaseExpr:
+		if typedExpr.maybeOperand != nil && memHasAggregate(typedExpr.maybeOperand) {
+			return true
+		}
+		for _, when := range typedExpr.whens {
+			if memHasAggregate(when.when) || memHasAggregate(when.then) {
+				return true
+			}
+		}
+		if typedExpr.maybeElse != nil {
+			return memHasAggregate(typedExpr.maybeElse)
+		}
+	}
+	return false
+}
@@ -0,0 +1,509 @@
+package test
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type memEvalRow struct {
+	qualifiers []string
+	columns    []string
+	values     []interface{}
+}
+
+func (row *memEvalRow) lookup(qualifier string, name string) (interface{}, bool) {
+	if row == nil {
+		return nil, false
+	}
+	if qualifier != "" && !memContains(row.qualifiers, qualifier) {
+		return nil, false
+	}
+	for i, column := range row.columns {
+		if column == name {
+			return row.values[i], true
+		}
+	}
+	return nil, false
+}
+
+// memEvalContext is the environment to evaluate an expression
+type memEvalContext struct {
+	db   *memDatabase
+	args []interface{}
+
+	row           *memEvalRow
+	maybeExcluded *memEvalRow
+
+	// rows of the group when evaluating an aggregate query
+	grouped    bool
+	maybeGroup [][]interface{}
+
+	// select output for ORDER BY
+	maybeOutput *memEvalRow
+}
+
+func (ctx *memEvalContext) withOutput(columns []string, values []interface{}) *memEvalContext {
+	cloned := *ctx
+	cloned.maybeOutput = &memEvalRow{columns: columns, values: values}
+	return &cloned
+}
+
+// evalCondition evaluates a boolean condition. Unknown (NULL) is treated as false.
+func (ctx *memEvalContext) evalCondition(expr memExpr) (bool, error) {
+	value, err := ctx.eval(expr)
+	if err != nil {
+		return false, err
+	}
+	boolValue, err := memToBool(value)
+	if err != nil {
+		return false, err
+	}
+	return boolValue == true, nil
+}
+
+func (ctx *memEvalContext) evalInt(expr memExpr) (int64, error) {
+	value, err := ctx.eval(expr)
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return -1, nil
+	}
+	coerced, err := memCoerce(value, memTypeInt)
+	if err != nil {
+		return 0, err
+	}
+	return coerced.(int64), nil
+}
+
+func (ctx *memEvalContext) lookupColumn(column memColumnExpr) (interface{}, error) {
+	if column.qualifier == "" {
+		if value, found := ctx.maybeOutput.lookup("", column.name); found {
+			return value, nil
+		}
+	}
+	if value, found := ctx.row.lookup(column.qualifier, column.name); found {
+		return value, nil
+	}
+	if column.qualifier == "excluded" {
+		if value, found := ctx.maybeExcluded.lookup("excluded", column.name); found {
+			return value, nil
+		}
+	}
+
+	if column.qualifier != "" {
+		return nil, fmt.Errorf("column %s.%s does not exist", column.qualifier, column.name)
+	}
+	return nil, fmt.Errorf("column \"%s\" does not exist", column.name)
+}
+
+func (ctx *memEvalContext) eval(expr memExpr) (interface{}, error) {
+	switch typedExpr := expr.(type) {
+	case memLiteralExpr:
+		return typedExpr.value, nil
+	case memParamExpr:
+		if typedExpr.index < 0 || typedExpr.index >= len(ctx.args) {
+			return nil, fmt.Errorf("there is no parameter $%d", typedExpr.index+1)
+		}
+		return ctx.args[typedExpr.index], nil
+	case memColumnExpr:
+		return ctx.lookupColumn(typedExpr)
+	case memUnaryExpr:
+		return ctx.evalUnary(typedExpr)
+	case memBinaryExpr:
+		return ctx.evalBinary(typedExpr)
+	case memIsExpr:
+		value, err := ctx.eval(typedExpr.operand)
+		if err != nil {
+			return nil, err
+		}
+		var result bool
+		switch typedExpr.target {
+		case "null":
+			result = value == nil
+		default:
+			boolValue, err := memToBool(value)
+			if err != nil {
+				return nil, err
+			}
+			result = boolValue != nil && boolValue.(bool) == (typedExpr.target == "true")
+		}
+		return result != typedExpr.not, nil
+	case memInExpr:
+		return ctx.evalIn(typedExpr)
+	case memLikeExpr:
+		return ctx.evalLike(typedExpr)
+	case memFuncExpr:
+		return ctx.evalFunc(typedExpr)
+	case memCastExpr:
+		value, err := ctx.eval(typedExpr.operand)
+		if err != nil {
+			return nil, err
+		}
+		return memCoerce(value, memTypeClassOf(typedExpr.typeName))
+	case memCaseExpr:
+		return ctx.evalCase(typedExpr)
+	}
+
+	return nil, fmt.Errorf("unsupported expression %T", expr)
+}
+
+func (ctx *memEvalContext) evalUnary(expr memUnaryExpr) (interface{}, error) {
+	value, err := ctx.eval(expr.operand)
+	if err != nil {
+		return nil, err
+	}
+	switch expr.op {
+	case "not":
+		boolValue, err := memToBool(value)
+		if err != nil || boolValue == nil {
+			return nil, err
+		}
+		return !boolValue.(bool), nil
+	case "-":
+		return memArithmetic("-", int64(0), value)
+	}
+	return nil, fmt.Errorf("unsupported unary operator `%s`", expr.op)
+}
+
+func (ctx *memEvalContext) evalBinary(expr memBinaryExpr) (interface{}, error) {
+	left, err := ctx.eval(expr.left)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.op {
+	case "and", "or":
+		leftBool, err := memToBool(left)
+		if err != nil {
+			return nil, err
+		}
+		// short-circuit
+		if expr.op == "and" && leftBool == false {
+			return false, nil
+		}
+		if expr.op == "or" && leftBool == true {
+			return true, nil
+		}
+		right, err := ctx.eval(expr.right)
+		if err != nil {
+			return nil, err
+		}
+		rightBool, err := memToBool(right)
+		if err != nil {
+			return nil, err
+		}
+		if expr.op == "and" {
+			if rightBool == false {
+				return false, nil
+			}
+			if leftBool == nil || rightBool == nil {
+				return nil, nil
+			}
+			return true, nil
+		}
+		if rightBool == true {
+			return true, nil
+		}
+		if leftBool == nil || rightBool == nil {
+			return nil, nil
+		}
+		return false, nil
+	}
+
+	right, err := ctx.eval(expr.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.op {
+	case "=", "<>", "<", "<=", ">", ">=":
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		cmp, err := memCompare(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch expr.op {
+		case "=":
+			return cmp == 0, nil
+		case "<>":
+			return cmp != 0, nil
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		}
+		return cmp >= 0, nil
+	}
+
+	return memArithmetic(expr.op, left, right)
+}
+
+func (ctx *memEvalContext) evalIn(expr memInExpr) (interface{}, error) {
+	value, err := ctx.eval(expr.operand)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]interface{}, 0, len(expr.list))
+	for _, item := range expr.list {
+		candidate, err := ctx.eval(item)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if value == nil {
+		if len(candidates) == 0 {
+			return expr.not, nil
+		}
+		return nil, nil
+	}
+	hasNull := false
+	for _, candidate := range candidates {
+		if candidate == nil {
+			hasNull = true
+			continue
+		}
+		cmp, err := memCompare(value, candidate)
+		if err != nil {
+			return nil, err
+		}
+		if cmp == 0 {
+			return !expr.not, nil
+		}
+	}
+	if hasNull {
+		return nil, nil
+	}
+	return expr.not, nil
+}
+
+func (ctx *memEvalContext) evalLike(expr memLikeExpr) (interface{}, error) {
+	value, err := ctx.eval(expr.operand)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := ctx.eval(expr.pattern)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil || pattern == nil {
+		return nil, nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("^(?s)")
+	patternRunes := []rune(memFormatValue(pattern))
+	for i := 0; i < len(patternRunes); i++ {
+		switch patternRunes[i] {
+		case '%':
+			builder.WriteString(".*")
+		case '_':
+			builder.WriteString(".")
+		case '\\':
+			if i+1 < len(patternRunes) {
+				i++
+				builder.WriteString(regexp.QuoteMeta(string(patternRunes[i])))
+			}
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(patternRunes[i])))
+		}
+	}
+	builder.WriteString("$")
+	matcher, err := regexp.Compile(builder.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid LIKE pattern: %v", err)
+	}
+	return matcher.MatchString(memFormatValue(value)) != expr.not, nil
+}
+
+func (ctx *memEvalContext) evalCase(expr memCaseExpr) (interface{}, error) {
+	var operand interface{}
+	var err error
+	if expr.maybeOperand != nil {
+		if operand, err = ctx.eval(expr.maybeOperand); err != nil {
+			return nil, err
+		}
+	}
+	for _, when := range expr.whens {
+		matched := false
+		if expr.maybeOperand != nil {
+			whenValue, err := ctx.eval(when.when)
+			if err != nil {
+				return nil, err
+			}
+			if operand != nil && whenValue != nil {
+				cmp, err := memCompare(operand, whenValue)
+				if err != nil {
+					return nil, err
+				}
+				matched = cmp == 0
+			}
+		} else if matched, err = ctx.evalCondition(when.when); err != nil {
+			return nil, err
+		}
+		if matched {
+			return ctx.eval(when.then)
+		}
+	}
+	if expr.maybeElse != nil {
+		return ctx.eval(expr.maybeElse)
+	}
+	return nil, nil
+}
+
+func (ctx *memEvalContext) evalArgs(args []memExpr) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		value, err := ctx.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (ctx *memEvalContext) evalFunc(expr memFuncExpr) (interface{}, error) {
+	if memAggregateFuncs[expr.name] {
+		return ctx.evalAggregate(expr)
+	}
+
+	args, err := ctx.evalArgs(expr.args)
+	if err != nil {
+		return nil, err
+	}
+	expectArgs := func(count int) error {
+		if len(args) != count {
+			return fmt.Errorf("function %s expects %d arguments but got %d", expr.name, count, len(args))
+		}
+		return nil
+	}
+
+	switch expr.name {
+	case "lower", "upper":
+		if err := expectArgs(1); err != nil {
+			return nil, err
+		}
+		if args[0] == nil {
+			return nil, nil
+		}
+		if expr.name == "lower" {
+			return strings.ToLower(memFormatValue(args[0])), nil
+		}
+		return strings.ToUpper(memFormatValue(args[0])), nil
+	case "now", "current_timestamp":
+		if ctx.db.now.IsZero() {
+			return time.Now().UTC(), nil
+		}
+		return ctx.db.now, nil
+	case "date_part":
+		if err := expectArgs(2); err != nil {
+			return nil, err
+		}
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+		field, isString := args[0].(string)
+		if !isString || !strings.EqualFold(field, "epoch") {
+			return nil, fmt.Errorf("unsupported date_part field %v", args[0])
+		}
+		timestamp, isTime := args[1].(time.Time)
+		if !isTime {
+			return nil, fmt.Errorf("cannot extract epoch from %T value", args[1])
+		}
+		return float64(timestamp.UnixNano()) / 1e9, nil
+	}
+
+	return nil, fmt.Errorf("function %s does not exist", expr.name)
+}
+
+func (ctx *memEvalContext) evalAggregate(expr memFuncExpr) (interface{}, error) {
+	if !ctx.grouped {
+		return nil, fmt.Errorf("aggregate function %s is not allowed here", expr.name)
+	}
+
+	values := make([]interface{}, 0, len(ctx.maybeGroup))
+	seen := make(map[string]bool)
+	for _, row := range ctx.maybeGroup {
+		if expr.star {
+			values = append(values, true)
+			continue
+		}
+		if len(expr.args) != 1 {
+			return nil, fmt.Errorf("function %s expects 1 argument", expr.name)
+		}
+		rowCtx := &memEvalContext{
+			db:   ctx.db,
+			args: ctx.args,
+			row: &memEvalRow{
+				qualifiers: ctx.row.qualifiers,
+				columns:    ctx.row.columns,
+				values:     row,
+			},
+		}
+		value, err := rowCtx.eval(expr.args[0])
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		if expr.distinct {
+			key := memKey(value)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		values = append(values, value)
+	}
+
+	switch expr.name {
+	case "count":
+		return int64(len(values)), nil
+	case "sum":
+		if len(values) == 0 {
+			return nil, nil
+		}
+		var sum interface{} = int64(0)
+		for _, value := range values {
+			var err error
+			if _, isInt := value.(int64); isInt {
+				// avoid int64 overflow on large sums
+				value = new(big.Rat).SetInt64(value.(int64))
+			}
+			if sum, err = memArithmetic("+", sum, value); err != nil {
+				return nil, err
+			}
+		}
+		return sum, nil
+	case "max":
+		var result interface{}
+		for _, value := range values {
+			if result == nil {
+				result = value
+				continue
+			}
+			cmp, err := memCompare(value, result)
+			if err != nil {
+				return nil, err
+			}
+			if cmp > 0 {
+				result = value
+			}
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported aggregate function %s", expr.name)
+}
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type memTokenKind int
+
+const (
+	memTokenEOF memTokenKind = iota
+	memTokenIdent
+	memTokenQuotedIdent
+	memTokenNumber
+	memTokenString
+	memTokenParam
+	memTokenSymbol
+)
+
+type memToken struct {
+	kind  memTokenKind
+	value string
+}
+
+// isKeyword returns true when the token is the unquoted keyword (case-insensitive)
+func (token memToken) isKeyword(keyword string) bool {
+	return token.kind == memTokenIdent && strings.EqualFold(token.value, keyword)
+}
+
+func (token memToken) isSymbol(symbol string) bool {
+	return token.kind == memTokenSymbol && token.value == symbol
+}
+
+func (token memToken) String() string {
+	if token.kind == memTokenEOF {
+		return "end of statement"
+	}
+	return fmt.Sprintf("`%s`", token.value)
+}
+
+// tokenizeSQL splits the SQL into tokens. Comments are dropped. Unquoted identifiers are lowercased
+// as in Postgres.
+func tokenizeSQL(sql string) ([]memToken, error) {
+	tokens := make([]memToken, 0)
+	runes := []rune(sql)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += 2
+		case r == '\'':
+			var builder strings.Builder
+			i++
+			terminated := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						builder.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					terminated = true
+					break
+				}
+				builder.WriteRune(runes[i])
+				i++
+			}
+			if !terminated {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			tokens = append(tokens, memToken{memTokenString, builder.String()})
+		case r == '"':
+			start := i + 1
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated quoted identifier")
+			}
+			tokens = append(tokens, memToken{memTokenQuotedIdent, string(runes[start:i])})
+			i++
+		case r == '$' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			start := i + 1
+			i++
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, memToken{memTokenParam, string(runes[start:i])})
+		case r == '?':
+			tokens = append(tokens, memToken{memTokenParam, ""})
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				i++
+				if i < len(runes) && (runes[i] == '+' || runes[i] == '-') {
+					i++
+				}
+				for i < len(runes) && unicode.IsDigit(runes[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, memToken{memTokenNumber, string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, memToken{memTokenIdent, strings.ToLower(string(runes[start:i]))})
+		default:
+			symbol := string(r)
+			if i+1 < len(runes) {
+				twoRunes := string(runes[i : i+2])
+				switch twoRunes {
+				case "<>", "!=", "<=", ">=", "||", "::":
+					symbol = twoRunes
+				}
+			}
+			if !strings.Contains("(),.;*+-/%=<>!|:", string(r)) {
+				return nil, fmt.Errorf("unexpected character `%c`", r)
+			}
+			tokens = append(tokens, memToken{memTokenSymbol, symbol})
+			i += len([]rune(symbol))
+		}
+	}
+
+	return append(tokens, memToken{kind: memTokenEOF}), nil
+}
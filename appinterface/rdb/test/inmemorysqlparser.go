@@ -0,0 +1,1307 @@
+package test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Statements
+
+type memStatement interface{}
+
+type memColumnDef struct {
+	name         string
+	typeName     string
+	serial       bool
+	notNull      bool
+	maybeDefault memExpr
+}
+
+type memCreateTableStmt struct {
+	table       string
+	ifNotExists bool
+	columns     []memColumnDef
+	primaryKey  []string
+	uniques     [][]string
+}
+
+type memCreateIndexStmt struct {
+	name    string
+	table   string
+	unique  bool
+	columns []string
+}
+
+// memAlterTableStmt only supports `ALTER TABLE ... ADD [COLUMN] [IF NOT EXISTS] column_def`
+type memAlterTableStmt struct {
+	table string
+
+	addColumn            memColumnDef
+	addColumnIfNotExists bool
+	addColumnUnique      bool
+}
+
+type memAssignment struct {
+	column string
+	value  memExpr
+}
+
+type memOnConflict struct {
+	targets     []string
+	doNothing   bool
+	assignments []memAssignment
+	maybeWhere  memExpr
+}
+
+type memInsertStmt struct {
+	table       string
+	alias       string
+	columns     []string
+	rows        [][]memExpr
+	maybeSelect *memSelectStmt
+	maybeUpsert *memOnConflict
+	returning   []memSelectItem
+}
+
+type memUpdateStmt struct {
+	table       string
+	alias       string
+	assignments []memAssignment
+	maybeWhere  memExpr
+	returning   []memSelectItem
+}
+
+type memDeleteStmt struct {
+	table      string
+	alias      string
+	maybeWhere memExpr
+	returning  []memSelectItem
+}
+
+type memSelectItem struct {
+	expr memExpr
+	// name of the output column
+	name string
+	// `*` or `table.*`
+	star          bool
+	starQualifier string
+}
+
+type memOrderItem struct {
+	expr       memExpr
+	desc       bool
+	nullsFirst bool
+}
+
+// memSelectStmt is a SELECT from at most one table or subquery
+type memSelectStmt struct {
+	items []memSelectItem
+
+	fromTable       string
+	maybeFromSelect *memSelectStmt
+	fromAlias       string
+
+	maybeWhere  memExpr
+	groupBy     []memExpr
+	orderBy     []memOrderItem
+	maybeLimit  memExpr
+	maybeOffset memExpr
+}
+
+// Expressions
+
+type memExpr interface{}
+
+type memLiteralExpr struct{ value interface{} }
+
+type memParamExpr struct{ index int }
+
+type memColumnExpr struct {
+	qualifier string
+	name      string
+}
+
+type memUnaryExpr struct {
+	op      string
+	operand memExpr
+}
+
+type memBinaryExpr struct {
+	op          string
+	left, right memExpr
+}
+
+type memIsExpr struct {
+	operand memExpr
+	not     bool
+	// one of "null", "true" or "false"
+	target string
+}
+
+type memInExpr struct {
+	operand memExpr
+	not     bool
+	list    []memExpr
+}
+
+type memLikeExpr struct {
+	operand memExpr
+	pattern memExpr
+	not     bool
+}
+
+type memFuncExpr struct {
+	name     string
+	args     []memExpr
+	star     bool
+	distinct bool
+}
+
+type memCastExpr struct {
+	operand  memExpr
+	typeName string
+}
+
+type memCaseWhen struct {
+	when memExpr
+	then memExpr
+}
+
+type memCaseExpr struct {
+	maybeOperand memExpr
+	whens        []memCaseWhen
+	maybeElse    memExpr
+}
+
+var memReservedWords = map[string]bool{
+	"from": true, "where": true, "group": true, "order": true, "limit": true, "offset": true,
+	"having": true, "on": true, "returning": true, "set": true, "values": true, "and": true,
+	"or": true, "not": true, "as": true, "union": true, "join": true, "left": true, "right": true,
+	"inner": true, "outer": true, "cross": true, "do": true, "asc": true, "desc": true,
+	"nulls": true, "is": true, "in": true, "like": true, "ilike": true, "between": true,
+	"then": true, "when": true, "else": true, "end": true, "select": true, "using": true,
+}
+
+var memAggregateFuncs = map[string]bool{
+	"count": true, "sum": true, "max": true,
+}
+
+type memParser struct {
+	tokens []memToken
+	pos    int
+
+	// for `?` placeholders
+	nextParamIndex int
+}
+
+// parseSQL parses the semicolon separated SQL statements
+func parseSQL(sql string) ([]memStatement, error) {
+	tokens, err := tokenizeSQL(sql)
+	if err != nil {
+		return nil, err
+	}
+	parser := &memParser{tokens: tokens}
+
+	statements := make([]memStatement, 0)
+	for {
+		for parser.peek().isSymbol(";") {
+			parser.next()
+		}
+		if parser.peek().kind == memTokenEOF {
+			break
+		}
+		statement, parseErr := parser.parseStatement()
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		statements = append(statements, statement)
+
+		if !parser.peek().isSymbol(";") && parser.peek().kind != memTokenEOF {
+			return nil, fmt.Errorf("syntax error at or near %s", parser.peek())
+		}
+	}
+
+	return statements, nil
+}
+
+func (parser *memParser) peek() memToken {
+	return parser.tokens[parser.pos]
+}
+
+func (parser *memParser) peekAt(offset int) memToken {
+	if parser.pos+offset >= len(parser.tokens) {
+		return parser.tokens[len(parser.tokens)-1]
+	}
+	return parser.tokens[parser.pos+offset]
+}
+
+func (parser *memParser) next() memToken {
+	token := parser.tokens[parser.pos]
+	if token.kind != memTokenEOF {
+		parser.pos++
+	}
+	return token
+}
+
+func (parser *memParser) acceptKeyword(keywords ...string) bool {
+	for i, keyword := range keywords {
+		if !parser.peekAt(i).isKeyword(keyword) {
+			return false
+		}
+	}
+	parser.pos += len(keywords)
+	return true
+}
+
+func (parser *memParser) expectKeyword(keywords ...string) error {
+	for _, keyword := range keywords {
+		if !parser.peek().isKeyword(keyword) {
+			return fmt.Errorf("syntax error: expected %s at or near %s", strings.ToUpper(keyword), parser.peek())
+		}
+		parser.next()
+	}
+	return nil
+}
+
+func (parser *memParser) acceptSymbol(symbol string) bool {
+	if parser.peek().isSymbol(symbol) {
+		parser.next()
+		return true
+	}
+	return false
+}
+
+func (parser *memParser) expectSymbol(symbol string) error {
+	if !parser.acceptSymbol(symbol) {
+		return fmt.Errorf("syntax error: expected `%s` at or near %s", symbol, parser.peek())
+	}
+	return nil
+}
+
+func (parser *memParser) parseIdent() (string, error) {
+	token := parser.peek()
+	if token.kind != memTokenIdent && token.kind != memTokenQuotedIdent {
+		return "", fmt.Errorf("syntax error: expected identifier at or near %s", token)
+	}
+	parser.next()
+	return token.value, nil
+}
+
+// parseTableName parses an optionally schema-qualified table name and drops the schema
+func (parser *memParser) parseTableName() (string, error) {
+	name, err := parser.parseIdent()
+	if err != nil {
+		return "", err
+	}
+	if parser.acceptSymbol(".") {
+		return parser.parseIdent()
+	}
+	return name, nil
+}
+
+func (parser *memParser) parseIdentList() ([]string, error) {
+	if err := parser.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	idents := make([]string, 0)
+	for {
+		ident, err := parser.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		idents = append(idents, ident)
+		if !parser.acceptSymbol(",") {
+			break
+		}
+	}
+	if err := parser.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return idents, nil
+}
+
+// skipBalanced skips tokens until the end of statement, keeping parentheses balanced
+func (parser *memParser) skipToStatementEnd() {
+	depth := 0
+	for {
+		token := parser.peek()
+		if token.kind == memTokenEOF || (depth == 0 && token.isSymbol(";")) {
+			return
+		}
+		if token.isSymbol("(") {
+			depth++
+		} else if token.isSymbol(")") {
+			depth--
+		}
+		parser.next()
+	}
+}
+
+// skipParenthesized skips a parenthesized group of tokens
+func (parser *memParser) skipParenthesized() error {
+	if err := parser.expectSymbol("("); err != nil {
+		return err
+	}
+	depth := 1
+	for depth > 0 {
+		token := parser.next()
+		switch {
+		case token.kind == memTokenEOF:
+			return fmt.Errorf("syntax error: unbalanced parentheses")
+		case token.isSymbol("("):
+			depth++
+		case token.isSymbol(")"):
+			depth--
+		}
+	}
+	return nil
+}
+
+func (parser *memParser) parseStatement() (memStatement, error) {
+	token := parser.peek()
+	switch {
+	case token.isKeyword("select"):
+		return parser.parseSelect()
+	case token.isKeyword("insert"):
+		return parser.parseInsert()
+	case token.isKeyword("update"):
+		return parser.parseUpdate()
+	case token.isKeyword("delete"):
+		return parser.parseDelete()
+	case token.isKeyword("create"):
+		return parser.parseCreate()
+	case token.isKeyword("alter"):
+		return parser.parseAlterTable()
+	}
+
+	return nil, fmt.Errorf("unsupported statement at or near %s", token)
+}
+
+func (parser *memParser) parseCreate() (memStatement, error) {
+	if err := parser.expectKeyword("create"); err != nil {
+		return nil, err
+	}
+	if parser.acceptKeyword("table") {
+		return parser.parseCreateTable()
+	}
+	unique := parser.acceptKeyword("unique")
+	if parser.acceptKeyword("index") {
+		return parser.parseCreateIndex(unique)
+	}
+
+	return nil, fmt.Errorf("unsupported CREATE statement at or near %s", parser.peek())
+}
+
+func (parser *memParser) parseCreateTable() (memStatement, error) {
+	var err error
+
+	stmt := &memCreateTableStmt{}
+	stmt.ifNotExists = parser.acceptKeyword("if", "not", "exists")
+	if stmt.table, err = parser.parseTableName(); err != nil {
+		return nil, err
+	}
+	if err = parser.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	for {
+		if parser.acceptKeyword("constraint") {
+			if _, err = parser.parseIdent(); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case parser.acceptKeyword("primary", "key"):
+			if stmt.primaryKey, err = parser.parseIdentList(); err != nil {
+				return nil, err
+			}
+		case parser.acceptKeyword("unique"):
+			var columns []string
+			if columns, err = parser.parseIdentList(); err != nil {
+				return nil, err
+			}
+			stmt.uniques = append(stmt.uniques, columns)
+		case parser.acceptKeyword("check"):
+			if err = parser.skipParenthesized(); err != nil {
+				return nil, err
+			}
+		default:
+			var column *memColumnDef
+			var columnPrimaryKey, columnUnique bool
+			if column, columnPrimaryKey, columnUnique, err = parser.parseColumnDef(); err != nil {
+				return nil, err
+			}
+			stmt.columns = append(stmt.columns, *column)
+			if columnPrimaryKey {
+				stmt.primaryKey = []string{column.name}
+			}
+			if columnUnique {
+				stmt.uniques = append(stmt.uniques, []string{column.name})
+			}
+		}
+
+		if !parser.acceptSymbol(",") {
+			break
+		}
+	}
+	if err = parser.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+func (parser *memParser) parseTypeName() (string, error) {
+	typeName, err := parser.parseIdent()
+	if err != nil {
+		return "", err
+	}
+	for {
+		token := parser.peek()
+		if token.kind != memTokenIdent {
+			break
+		}
+		switch token.value {
+		case "precision", "varying", "with", "without", "time", "zone":
+			typeName += " " + token.value
+			parser.next()
+			continue
+		}
+		break
+	}
+	if parser.peek().isSymbol("(") {
+		if err := parser.skipParenthesized(); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.ToLower(typeName), nil
+}
+
+func (parser *memParser) parseColumnDef() (column *memColumnDef, primaryKey bool, unique bool, err error) {
+	column = &memColumnDef{}
+	if column.name, err = parser.parseIdent(); err != nil {
+		return nil, false, false, err
+	}
+	if column.typeName, err = parser.parseTypeName(); err != nil {
+		return nil, false, false, err
+	}
+	switch column.typeName {
+	case "serial", "bigserial", "smallserial":
+		column.serial = true
+		column.notNull = true
+	}
+
+	for {
+		switch {
+		case parser.acceptKeyword("not", "null"):
+			column.notNull = true
+		case parser.acceptKeyword("null"):
+			column.notNull = false
+		case parser.acceptKeyword("default"):
+			if column.maybeDefault, err = parser.parseUnary(); err != nil {
+				return nil, false, false, err
+			}
+		case parser.acceptKeyword("primary", "key"):
+			primaryKey = true
+			column.notNull = true
+		case parser.acceptKeyword("unique"):
+			unique = true
+		case parser.acceptKeyword("constraint"):
+			if _, err = parser.parseIdent(); err != nil {
+				return nil, false, false, err
+			}
+		case parser.acceptKeyword("check"):
+			if err = parser.skipParenthesized(); err != nil {
+				return nil, false, false, err
+			}
+		default:
+			return column, primaryKey, unique, nil
+		}
+	}
+}
+
+func (parser *memParser) parseCreateIndex(unique bool) (memStatement, error) {
+	var err error
+
+	stmt := &memCreateIndexStmt{unique: unique}
+	parser.acceptKeyword("concurrently")
+	parser.acceptKeyword("if", "not", "exists")
+	if !parser.peek().isKeyword("on") {
+		if stmt.name, err = parser.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	if err = parser.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+	if stmt.table, err = parser.parseTableName(); err != nil {
+		return nil, err
+	}
+	if parser.acceptKeyword("using") {
+		if _, err = parser.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only plain column index is recorded. Expression index is accepted but not enforced.
+	if err = parser.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	plainColumns := true
+	depth := 1
+	for depth > 0 {
+		token := parser.next()
+		switch {
+		case token.kind == memTokenEOF:
+			return nil, fmt.Errorf("syntax error: unbalanced parentheses")
+		case token.isSymbol("("):
+			depth++
+			plainColumns = false
+		case token.isSymbol(")"):
+			depth--
+		case token.isSymbol(","):
+		case depth == 1 && (token.kind == memTokenIdent || token.kind == memTokenQuotedIdent):
+			if token.isKeyword("asc") || token.isKeyword("desc") {
+				continue
+			}
+			stmt.columns = append(stmt.columns, token.value)
+		default:
+			plainColumns = false
+		}
+	}
+	if !plainColumns {
+		stmt.columns = nil
+	}
+	// partial index condition is ignored
+	parser.skipToStatementEnd()
+
+	return stmt, nil
+}
+
+func (parser *memParser) parseAlterTable() (memStatement, error) {
+	var err error
+
+	if err = parser.expectKeyword("alter", "table"); err != nil {
+		return nil, err
+	}
+	parser.acceptKeyword("if", "exists")
+	stmt := &memAlterTableStmt{}
+	if stmt.table, err = parser.parseTableName(); err != nil {
+		return nil, err
+	}
+
+	if err = parser.expectKeyword("add"); err != nil {
+		return nil, fmt.Errorf("unsupported ALTER TABLE statement: %v", err)
+	}
+	parser.acceptKeyword("column")
+	stmt.addColumnIfNotExists = parser.acceptKeyword("if", "not", "exists")
+	var column *memColumnDef
+	if column, _, stmt.addColumnUnique, err = parser.parseColumnDef(); err != nil {
+		return nil, err
+	}
+	stmt.addColumn = *column
+
+	return stmt, nil
+}
+
+// parseTableAlias parses the optional `[AS] alias` after a table name
+func (parser *memParser) parseTableAlias() (string, error) {
+	if parser.acceptKeyword("as") {
+		return parser.parseIdent()
+	}
+	token := parser.peek()
+	if (token.kind == memTokenIdent && !memReservedWords[token.value]) || token.kind == memTokenQuotedIdent {
+		parser.next()
+		return token.value, nil
+	}
+	return "", nil
+}
+
+func (parser *memParser) parseInsert() (memStatement, error) {
+	var err error
+
+	if err = parser.expectKeyword("insert", "into"); err != nil {
+		return nil, err
+	}
+	stmt := &memInsertStmt{}
+	if stmt.table, err = parser.parseTableName(); err != nil {
+		return nil, err
+	}
+	if parser.acceptKeyword("as") {
+		if stmt.alias, err = parser.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	if parser.peek().isSymbol("(") && !parser.peekAt(1).isKeyword("select") {
+		if stmt.columns, err = parser.parseIdentList(); err != nil {
+			return nil, err
+		}
+	}
+
+	if parser.acceptKeyword("values") {
+		for {
+			if err = parser.expectSymbol("("); err != nil {
+				return nil, err
+			}
+			var row []memExpr
+			if row, err = parser.parseExprList(); err != nil {
+				return nil, err
+			}
+			if err = parser.expectSymbol(")"); err != nil {
+				return nil, err
+			}
+			stmt.rows = append(stmt.rows, row)
+			if !parser.acceptSymbol(",") {
+				break
+			}
+		}
+	} else if parser.peek().isKeyword("select") {
+		if stmt.maybeSelect, err = parser.parseSelect(); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("syntax error: expected VALUES or SELECT at or near %s", parser.peek())
+	}
+
+	if parser.acceptKeyword("on", "conflict") {
+		upsert := &memOnConflict{}
+		if parser.peek().isSymbol("(") {
+			if upsert.targets, err = parser.parseIdentList(); err != nil {
+				return nil, err
+			}
+		}
+		if err = parser.expectKeyword("do"); err != nil {
+			return nil, err
+		}
+		if parser.acceptKeyword("nothing") {
+			upsert.doNothing = true
+		} else {
+			if err = parser.expectKeyword("update", "set"); err != nil {
+				return nil, err
+			}
+			if upsert.assignments, err = parser.parseAssignments(); err != nil {
+				return nil, err
+			}
+			if parser.acceptKeyword("where") {
+				if upsert.maybeWhere, err = parser.parseExpr(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		stmt.maybeUpsert = upsert
+	}
+
+	if stmt.returning, err = parser.parseReturning(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+func (parser *memParser) parseAssignments() ([]memAssignment, error) {
+	assignments := make([]memAssignment, 0)
+	for {
+		column, err := parser.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		// allow qualified target column
+		if parser.acceptSymbol(".") {
+			if column, err = parser.parseIdent(); err != nil {
+				return nil, err
+			}
+		}
+		if err = parser.expectSymbol("="); err != nil {
+			return nil, err
+		}
+		// `DEFAULT` is parsed as column expression and resolved on execution
+		value, err := parser.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, memAssignment{column, value})
+		if !parser.acceptSymbol(",") {
+			break
+		}
+	}
+	return assignments, nil
+}
+
+func (parser *memParser) parseReturning() ([]memSelectItem, error) {
+	if !parser.acceptKeyword("returning") {
+		return nil, nil
+	}
+	return parser.parseSelectItems()
+}
+
+func (parser *memParser) parseUpdate() (memStatement, error) {
+	var err error
+
+	if err = parser.expectKeyword("update"); err != nil {
+		return nil, err
+	}
+	stmt := &memUpdateStmt{}
+	if stmt.table, err = parser.parseTableName(); err != nil {
+		return nil, err
+	}
+	if stmt.alias, err = parser.parseTableAlias(); err != nil {
+		return nil, err
+	}
+	if err = parser.expectKeyword("set"); err != nil {
+		return nil, err
+	}
+	if stmt.assignments, err = parser.parseAssignments(); err != nil {
+		return nil, err
+	}
+	if parser.peek().isKeyword("from") {
+		return nil, fmt.Errorf("unsupported UPDATE ... FROM statement")
+	}
+	if parser.acceptKeyword("where") {
+		if stmt.maybeWhere, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if stmt.returning, err = parser.parseReturning(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+func (parser *memParser) parseDelete() (memStatement, error) {
+	var err error
+
+	if err = parser.expectKeyword("delete", "from"); err != nil {
+		return nil, err
+	}
+	stmt := &memDeleteStmt{}
+	if stmt.table, err = parser.parseTableName(); err != nil {
+		return nil, err
+	}
+	if stmt.alias, err = parser.parseTableAlias(); err != nil {
+		return nil, err
+	}
+	if parser.peek().isKeyword("using") {
+		return nil, fmt.Errorf("unsupported DELETE ... USING statement")
+	}
+	if parser.acceptKeyword("where") {
+		if stmt.maybeWhere, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if stmt.returning, err = parser.parseReturning(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+func (parser *memParser) parseSelect() (*memSelectStmt, error) {
+	var err error
+
+	if err = parser.expectKeyword("select"); err != nil {
+		return nil, err
+	}
+	stmt := &memSelectStmt{}
+	if stmt.items, err = parser.parseSelectItems(); err != nil {
+		return nil, err
+	}
+
+	if parser.acceptKeyword("from") {
+		if parser.acceptSymbol("(") {
+			if stmt.maybeFromSelect, err = parser.parseSelect(); err != nil {
+				return nil, err
+			}
+			if err = parser.expectSymbol(")"); err != nil {
+				return nil, err
+			}
+		} else if stmt.fromTable, err = parser.parseTableName(); err != nil {
+			return nil, err
+		}
+		if stmt.fromAlias, err = parser.parseTableAlias(); err != nil {
+			return nil, err
+		}
+		if parser.peek().isSymbol(",") || parser.peek().isKeyword("join") || parser.peek().isKeyword("left") ||
+			parser.peek().isKeyword("inner") || parser.peek().isKeyword("right") || parser.peek().isKeyword("cross") {
+			return nil, fmt.Errorf("unsupported join at or near %s", parser.peek())
+		}
+	}
+
+	if parser.acceptKeyword("where") {
+		if stmt.maybeWhere, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if parser.acceptKeyword("group", "by") {
+		if stmt.groupBy, err = parser.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if parser.acceptKeyword("order", "by") {
+		for {
+			item := memOrderItem{}
+			if item.expr, err = parser.parseExpr(); err != nil {
+				return nil, err
+			}
+			if parser.acceptKeyword("desc") {
+				item.desc = true
+			} else {
+				parser.acceptKeyword("asc")
+			}
+			// Postgres default: NULLS LAST for ASC and NULLS FIRST for DESC
+			item.nullsFirst = item.desc
+			if parser.acceptKeyword("nulls", "first") {
+				item.nullsFirst = true
+			} else if parser.acceptKeyword("nulls", "last") {
+				item.nullsFirst = false
+			}
+			stmt.orderBy = append(stmt.orderBy, item)
+			if !parser.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+	for {
+		if parser.acceptKeyword("limit") {
+			if parser.acceptKeyword("all") {
+				continue
+			}
+			if stmt.maybeLimit, err = parser.parseExpr(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if parser.acceptKeyword("offset") {
+			if stmt.maybeOffset, err = parser.parseExpr(); err != nil {
+				return nil, err
+			}
+			if !parser.acceptKeyword("rows") {
+				parser.acceptKeyword("row")
+			}
+			continue
+		}
+		break
+	}
+	if parser.peek().isKeyword("for") {
+		// row locking clause has no effect in memory
+		for !parser.peek().isSymbol(";") && !parser.peek().isSymbol(")") && parser.peek().kind != memTokenEOF {
+			parser.next()
+		}
+	}
+
+	return stmt, nil
+}
+
+func (parser *memParser) parseSelectItems() ([]memSelectItem, error) {
+	items := make([]memSelectItem, 0)
+	for {
+		item := memSelectItem{}
+		if parser.acceptSymbol("*") {
+			item.star = true
+		} else if (parser.peek().kind == memTokenIdent || parser.peek().kind == memTokenQuotedIdent) &&
+			parser.peekAt(1).isSymbol(".") && parser.peekAt(2).isSymbol("*") {
+			item.star = true
+			item.starQualifier = parser.next().value
+			parser.next()
+			parser.next()
+		} else {
+			expr, err := parser.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			item.expr = expr
+			item.name = memExprOutputName(expr)
+
+			if parser.acceptKeyword("as") {
+				if item.name, err = parser.parseIdent(); err != nil {
+					return nil, err
+				}
+			} else if token := parser.peek(); (token.kind == memTokenIdent && !memReservedWords[token.value]) ||
+				token.kind == memTokenQuotedIdent {
+				item.name = token.value
+				parser.next()
+			}
+		}
+		items = append(items, item)
+		if !parser.acceptSymbol(",") {
+			break
+		}
+	}
+	return items, nil
+}
+
+// memExprOutputName returns the default output column name of an expression as in Postgres
+func memExprOutputName(expr memExpr) string {
+	switch typedExpr := expr.(type) {
+	case memColumnExpr:
+		return typedExpr.name
+	case memFuncExpr:
+		return typedExpr.name
+	case memCastExpr:
+		if name := memExprOutputName(typedExpr.operand); name != "?column?" {
+			return name
+		}
+		return typedExpr.typeName
+	case memCaseExpr:
+		return "case"
+	}
+	return "?column?"
+}
+
+func (parser *memParser) parseExprList() ([]memExpr, error) {
+	exprs := make([]memExpr, 0)
+	for {
+		expr, err := parser.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if !parser.acceptSymbol(",") {
+			break
+		}
+	}
+	return exprs, nil
+}
+
+func (parser *memParser) parseExpr() (memExpr, error) {
+	return parser.parseOr()
+}
+
+func (parser *memParser) parseOr() (memExpr, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for parser.acceptKeyword("or") {
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = memBinaryExpr{"or", left, right}
+	}
+	return left, nil
+}
+
+func (parser *memParser) parseAnd() (memExpr, error) {
+	left, err := parser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for parser.acceptKeyword("and") {
+		right, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = memBinaryExpr{"and", left, right}
+	}
+	return left, nil
+}
+
+func (parser *memParser) parseNot() (memExpr, error) {
+	if parser.acceptKeyword("not") {
+		operand, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return memUnaryExpr{"not", operand}, nil
+	}
+	return parser.parseComparison()
+}
+
+func (parser *memParser) parseComparison() (memExpr, error) {
+	left, err := parser.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token := parser.peek()
+		switch {
+		case token.kind == memTokenSymbol && (token.value == "=" || token.value == "<>" || token.value == "!=" ||
+			token.value == "<" || token.value == "<=" || token.value == ">" || token.value == ">="):
+			parser.next()
+			op := token.value
+			if op == "!=" {
+				op = "<>"
+			}
+			right, err := parser.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			left = memBinaryExpr{op, left, right}
+		case token.isKeyword("is"):
+			parser.next()
+			not := parser.acceptKeyword("not")
+			var target string
+			switch {
+			case parser.acceptKeyword("null"):
+				target = "null"
+			case parser.acceptKeyword("true"):
+				target = "true"
+			case parser.acceptKeyword("false"):
+				target = "false"
+			default:
+				return nil, fmt.Errorf("unsupported IS expression at or near %s", parser.peek())
+			}
+			left = memIsExpr{left, not, target}
+		default:
+			not := false
+			if token.isKeyword("not") && (parser.peekAt(1).isKeyword("in") || parser.peekAt(1).isKeyword("like")) {
+				parser.next()
+				not = true
+				token = parser.peek()
+			}
+			switch {
+			case token.isKeyword("in"):
+				parser.next()
+				if err = parser.expectSymbol("("); err != nil {
+					return nil, err
+				}
+				inExpr := memInExpr{operand: left, not: not}
+				if inExpr.list, err = parser.parseExprList(); err != nil {
+					return nil, err
+				}
+				if err = parser.expectSymbol(")"); err != nil {
+					return nil, err
+				}
+				left = inExpr
+			case token.isKeyword("like"):
+				parser.next()
+				pattern, err := parser.parseAdditive()
+				if err != nil {
+					return nil, err
+				}
+				left = memLikeExpr{left, pattern, not}
+			default:
+				return left, nil
+			}
+		}
+	}
+}
+
+func (parser *memParser) parseAdditive() (memExpr, error) {
+	left, err := parser.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token := parser.peek()
+		if !(token.isSymbol("+") || token.isSymbol("-") || token.isSymbol("||")) {
+			return left, nil
+		}
+		parser.next()
+		right, err := parser.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = memBinaryExpr{token.value, left, right}
+	}
+}
+
+func (parser *memParser) parseMultiplicative() (memExpr, error) {
+	left, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token := parser.peek()
+		if !(token.isSymbol("*") || token.isSymbol("/") || token.isSymbol("%")) {
+			return left, nil
+		}
+		parser.next()
+		right, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = memBinaryExpr{token.value, left, right}
+	}
+}
+
+func (parser *memParser) parseUnary() (memExpr, error) {
+	if parser.acceptSymbol("-") {
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return memUnaryExpr{"-", operand}, nil
+	}
+	if parser.acceptSymbol("+") {
+		return parser.parseUnary()
+	}
+	return parser.parsePostfix()
+}
+
+func (parser *memParser) parsePostfix() (memExpr, error) {
+	expr, err := parser.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for parser.acceptSymbol("::") {
+		typeName, err := parser.parseTypeName()
+		if err != nil {
+			return nil, err
+		}
+		expr = memCastExpr{expr, typeName}
+	}
+	return expr, nil
+}
+
+func (parser *memParser) parsePrimary() (memExpr, error) {
+	var err error
+
+	token := parser.peek()
+	switch token.kind {
+	case memTokenNumber:
+		parser.next()
+		if !strings.ContainsAny(token.value, ".eE") {
+			if value, parseErr := strconv.ParseInt(token.value, 10, 64); parseErr == nil {
+				return memLiteralExpr{value}, nil
+			}
+		}
+		value, ok := memParseNumeric(token.value)
+		if !ok {
+			return nil, fmt.Errorf("invalid number `%s`", token.value)
+		}
+		return memLiteralExpr{value}, nil
+	case memTokenString:
+		parser.next()
+		return memLiteralExpr{token.value}, nil
+	case memTokenParam:
+		parser.next()
+		if token.value == "" {
+			index := parser.nextParamIndex
+			parser.nextParamIndex++
+			return memParamExpr{index}, nil
+		}
+		index, _ := strconv.Atoi(token.value)
+		return memParamExpr{index - 1}, nil
+	case memTokenSymbol:
+		if token.value != "(" {
+			break
+		}
+		parser.next()
+		var expr memExpr
+		if expr, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+		if err = parser.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	case memTokenIdent, memTokenQuotedIdent:
+		if token.kind == memTokenIdent {
+			switch token.value {
+			case "null":
+				parser.next()
+				return memLiteralExpr{nil}, nil
+			case "true":
+				parser.next()
+				return memLiteralExpr{true}, nil
+			case "false":
+				parser.next()
+				return memLiteralExpr{false}, nil
+			case "case":
+				return parser.parseCase()
+			case "cast":
+				if parser.peekAt(1).isSymbol("(") {
+					parser.next()
+					parser.next()
+					operand, err := parser.parseExpr()
+					if err != nil {
+						return nil, err
+					}
+					if err = parser.expectKeyword("as"); err != nil {
+						return nil, err
+					}
+					typeName, err := parser.parseTypeName()
+					if err != nil {
+						return nil, err
+					}
+					if err = parser.expectSymbol(")"); err != nil {
+						return nil, err
+					}
+					return memCastExpr{operand, typeName}, nil
+				}
+			}
+		}
+		parser.next()
+		if token.kind == memTokenIdent && parser.peek().isSymbol("(") {
+			return parser.parseFuncCall(token.value)
+		}
+		if parser.acceptSymbol(".") {
+			name, err := parser.parseIdent()
+			if err != nil {
+				return nil, err
+			}
+			return memColumnExpr{qualifier: token.value, name: name}, nil
+		}
+		return memColumnExpr{name: token.value}, nil
+	}
+
+	return nil, fmt.Errorf("syntax error at or near %s", token)
+}
+
+func (parser *memParser) parseFuncCall(name string) (memExpr, error) {
+	var err error
+
+	if err = parser.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	funcExpr := memFuncExpr{name: name}
+	if parser.acceptSymbol("*") {
+		funcExpr.star = true
+	} else if !parser.peek().isSymbol(")") {
+		funcExpr.distinct = parser.acceptKeyword("distinct")
+		if funcExpr.args, err = parser.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if err = parser.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	if parser.peek().isKeyword("over") || parser.peek().isKeyword("filter") {
+		return nil, fmt.Errorf("unsupported window or filter clause on function `%s`", name)
+	}
+
+	return funcExpr, nil
+}
+
+func (parser *memParser) parseCase() (memExpr, error) {
+	var err error
+
+	if err = parser.expectKeyword("case"); err != nil {
+		return nil, err
+	}
+	caseExpr := memCaseExpr{}
+	if !parser.peek().isKeyword("when") {
+		if caseExpr.maybeOperand, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	for parser.acceptKeyword("when") {
+		when := memCaseWhen{}
+		if when.when, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+		if err = parser.expectKeyword("then"); err != nil {
+			return nil, err
+		}
+		if when.then, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+		caseExpr.whens = append(caseExpr.whens, when)
+	}
+	if len(caseExpr.whens) == 0 {
+		return nil, fmt.Errorf("syntax error: CASE without WHEN")
+	}
+	if parser.acceptKeyword("else") {
+		if caseExpr.maybeElse, err = parser.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if err = parser.expectKeyword("end"); err != nil {
+		return nil, err
+	}
+	return caseExpr, nil
+}
@@ -0,0 +1,695 @@
+package test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Values stored in the in-memory database are one of nil, int64, float64, *big.Rat (NUMERIC),
+// string, bool and time.Time. *big.Rat values are never mutated in place.
+
+func memParseNumeric(str string) (*big.Rat, bool) {
+	return new(big.Rat).SetString(strings.TrimSpace(str))
+}
+
+func memFormatRat(rat *big.Rat) string {
+	if rat.IsInt() {
+		return rat.Num().String()
+	}
+	str := rat.FloatString(30)
+	str = strings.TrimRight(str, "0")
+	return strings.TrimSuffix(str, ".")
+}
+
+// memNormalizeArg converts an argument provided to Exec/Query into a stored value type
+func memNormalizeArg(arg interface{}) (interface{}, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	if valuer, ok := arg.(driver.Valuer); ok {
+		rv := reflect.ValueOf(arg)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, nil
+		}
+		value, err := valuer.Value()
+		if err != nil {
+			return nil, fmt.Errorf("error getting driver value of %T: %v", arg, err)
+		}
+		return memNormalizeArg(value)
+	}
+
+	switch value := arg.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case bool:
+		return value, nil
+	case *big.Int:
+		if value == nil {
+			return nil, nil
+		}
+		return new(big.Rat).SetInt(value), nil
+	case big.Int:
+		return new(big.Rat).SetInt(&value), nil
+	case *big.Rat:
+		if value == nil {
+			return nil, nil
+		}
+		return new(big.Rat).Set(value), nil
+	case time.Time:
+		return value.UTC(), nil
+	case json.RawMessage:
+		return string(value), nil
+	}
+
+	rv := reflect.ValueOf(arg)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return memNormalizeArg(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint())), nil
+		}
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Map, reflect.Slice, reflect.Struct:
+		// composite values are stored as JSON as pgx does for JSON columns
+		encoded, err := json.Marshal(arg)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %T argument to JSON: %v", arg, err)
+		}
+		return string(encoded), nil
+	}
+
+	return nil, fmt.Errorf("unsupported argument type %T", arg)
+}
+
+type memTypeClass int
+
+const (
+	memTypeAny memTypeClass = iota
+	memTypeInt
+	memTypeNumeric
+	memTypeFloat
+	memTypeBool
+	memTypeText
+	memTypeTime
+)
+
+func memTypeClassOf(typeName string) memTypeClass {
+	typeName = strings.ToLower(typeName)
+	switch typeName {
+	case "int", "integer", "int2", "int4", "int8", "bigint", "smallint", "serial", "bigserial", "smallserial":
+		return memTypeInt
+	case "numeric", "decimal":
+		return memTypeNumeric
+	case "real", "float", "float4", "float8", "double precision":
+		return memTypeFloat
+	case "bool", "boolean":
+		return memTypeBool
+	case "varchar", "character varying", "character", "char", "text", "json", "jsonb", "uuid", "name", "bpchar":
+		return memTypeText
+	}
+	if strings.HasPrefix(typeName, "timestamp") || typeName == "date" {
+		return memTypeTime
+	}
+	return memTypeAny
+}
+
+// memCoerce converts the value to the type class, as an assignment cast in Postgres
+func memCoerce(value interface{}, class memTypeClass) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch class {
+	case memTypeInt:
+		switch typedValue := value.(type) {
+		case int64:
+			return typedValue, nil
+		case float64:
+			return int64(math.Round(typedValue)), nil
+		case *big.Rat:
+			rounded := memRoundRat(typedValue)
+			if !rounded.IsInt64() {
+				return nil, fmt.Errorf("value %s out of range for integer type", memFormatRat(typedValue))
+			}
+			return rounded.Int64(), nil
+		case string:
+			parsed, err := strconv.ParseInt(strings.TrimSpace(typedValue), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input syntax for integer type: \"%s\"", typedValue)
+			}
+			return parsed, nil
+		case bool:
+			if typedValue {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+	case memTypeNumeric:
+		switch typedValue := value.(type) {
+		case int64:
+			return new(big.Rat).SetInt64(typedValue), nil
+		case float64:
+			rat := new(big.Rat)
+			if rat.SetFloat64(typedValue) == nil {
+				return nil, fmt.Errorf("invalid numeric value %v", typedValue)
+			}
+			return rat, nil
+		case *big.Rat:
+			return typedValue, nil
+		case string:
+			rat, ok := memParseNumeric(typedValue)
+			if !ok {
+				return nil, fmt.Errorf("invalid input syntax for type numeric: \"%s\"", typedValue)
+			}
+			return rat, nil
+		}
+	case memTypeFloat:
+		switch typedValue := value.(type) {
+		case int64:
+			return float64(typedValue), nil
+		case float64:
+			return typedValue, nil
+		case *big.Rat:
+			f, _ := typedValue.Float64()
+			return f, nil
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(typedValue), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input syntax for type double precision: \"%s\"", typedValue)
+			}
+			return parsed, nil
+		}
+	case memTypeBool:
+		switch typedValue := value.(type) {
+		case bool:
+			return typedValue, nil
+		case int64:
+			return typedValue != 0, nil
+		case string:
+			parsed, ok := memParseBool(typedValue)
+			if !ok {
+				return nil, fmt.Errorf("invalid input syntax for type boolean: \"%s\"", typedValue)
+			}
+			return parsed, nil
+		}
+	case memTypeText:
+		return memFormatValue(value), nil
+	case memTypeTime:
+		switch typedValue := value.(type) {
+		case time.Time:
+			return typedValue, nil
+		case string:
+			parsed, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(typedValue))
+			if err != nil {
+				return nil, fmt.Errorf("invalid input syntax for type timestamp: \"%s\"", typedValue)
+			}
+			return parsed.UTC(), nil
+		}
+	case memTypeAny:
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert %T value to column type", value)
+}
+
+func memRoundRat(rat *big.Rat) *big.Int {
+	if rat.IsInt() {
+		return new(big.Int).Set(rat.Num())
+	}
+	// round half away from zero as Postgres does
+	doubled := new(big.Int).Mul(rat.Num(), big.NewInt(2))
+	denominator := rat.Denom()
+	if rat.Sign() >= 0 {
+		doubled.Add(doubled, denominator)
+	} else {
+		doubled.Sub(doubled, denominator)
+	}
+	return doubled.Quo(doubled, new(big.Int).Mul(denominator, big.NewInt(2)))
+}
+
+func memParseBool(str string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "t", "true", "y", "yes", "on", "1":
+		return true, true
+	case "f", "false", "n", "no", "off", "0":
+		return false, true
+	}
+	return false, false
+}
+
+func memFormatValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case float64:
+		return strconv.FormatFloat(typedValue, 'g', -1, 64)
+	case *big.Rat:
+		return memFormatRat(typedValue)
+	case string:
+		return typedValue
+	case bool:
+		if typedValue {
+			return "true"
+		}
+		return "false"
+	case time.Time:
+		return typedValue.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func memIsNumber(value interface{}) bool {
+	switch value.(type) {
+	case int64, float64, *big.Rat:
+		return true
+	}
+	return false
+}
+
+func memToRat(value interface{}) (*big.Rat, error) {
+	switch typedValue := value.(type) {
+	case int64:
+		return new(big.Rat).SetInt64(typedValue), nil
+	case float64:
+		rat := new(big.Rat)
+		if rat.SetFloat64(typedValue) == nil {
+			return nil, fmt.Errorf("invalid numeric value %v", typedValue)
+		}
+		return rat, nil
+	case *big.Rat:
+		return typedValue, nil
+	case string:
+		rat, ok := memParseNumeric(typedValue)
+		if !ok {
+			return nil, fmt.Errorf("invalid input syntax for type numeric: \"%s\"", typedValue)
+		}
+		return rat, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T value to numeric", value)
+}
+
+// memCompare compares two non-null values
+func memCompare(a interface{}, b interface{}) (int, error) {
+	if memIsNumber(a) || memIsNumber(b) {
+		if floatA, ok := a.(float64); ok {
+			if floatB, ok := b.(float64); ok {
+				switch {
+				case floatA < floatB:
+					return -1, nil
+				case floatA > floatB:
+					return 1, nil
+				}
+				return 0, nil
+			}
+		}
+		if intA, ok := a.(int64); ok {
+			if intB, ok := b.(int64); ok {
+				switch {
+				case intA < intB:
+					return -1, nil
+				case intA > intB:
+					return 1, nil
+				}
+				return 0, nil
+			}
+		}
+		ratA, err := memToRat(a)
+		if err != nil {
+			return 0, err
+		}
+		ratB, err := memToRat(b)
+		if err != nil {
+			return 0, err
+		}
+		return ratA.Cmp(ratB), nil
+	}
+
+	switch typedA := a.(type) {
+	case string:
+		switch typedB := b.(type) {
+		case string:
+			return strings.Compare(typedA, typedB), nil
+		case bool:
+			parsedA, ok := memParseBool(typedA)
+			if !ok {
+				return 0, fmt.Errorf("invalid input syntax for type boolean: \"%s\"", typedA)
+			}
+			return memCompareBool(parsedA, typedB), nil
+		case time.Time:
+			parsedA, err := memCoerce(typedA, memTypeTime)
+			if err != nil {
+				return 0, err
+			}
+			return memCompareTime(parsedA.(time.Time), typedB), nil
+		}
+	case bool:
+		switch typedB := b.(type) {
+		case bool:
+			return memCompareBool(typedA, typedB), nil
+		case string:
+			parsedB, ok := memParseBool(typedB)
+			if !ok {
+				return 0, fmt.Errorf("invalid input syntax for type boolean: \"%s\"", typedB)
+			}
+			return memCompareBool(typedA, parsedB), nil
+		}
+	case time.Time:
+		switch typedB := b.(type) {
+		case time.Time:
+			return memCompareTime(typedA, typedB), nil
+		case string:
+			parsedB, err := memCoerce(typedB, memTypeTime)
+			if err != nil {
+				return 0, err
+			}
+			return memCompareTime(typedA, parsedB.(time.Time)), nil
+		}
+	}
+
+	return 0, fmt.Errorf("cannot compare %T with %T", a, b)
+}
+
+func memCompareBool(a bool, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+func memCompareTime(a time.Time, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// memKey returns a canonical key of the value for equality in unique constraints, grouping and
+// DISTINCT
+func memKey(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return "N"
+	case int64:
+		return "n:" + strconv.FormatInt(typedValue, 10)
+	case float64:
+		rat := new(big.Rat)
+		if rat.SetFloat64(typedValue) == nil {
+			return "f:" + strconv.FormatFloat(typedValue, 'g', -1, 64)
+		}
+		return "n:" + rat.RatString()
+	case *big.Rat:
+		return "n:" + typedValue.RatString()
+	case string:
+		return "s:" + typedValue
+	case bool:
+		return "b:" + strconv.FormatBool(typedValue)
+	case time.Time:
+		return "t:" + strconv.FormatInt(typedValue.UnixNano(), 10)
+	}
+	return fmt.Sprintf("?:%v", value)
+}
+
+func memArithmetic(op string, a interface{}, b interface{}) (interface{}, error) {
+	if a == nil || b == nil {
+		return nil, nil
+	}
+	if op == "||" {
+		return memFormatValue(a) + memFormatValue(b), nil
+	}
+
+	// unknown-typed literals and text parameters are coerced to number as Postgres does
+	if _, ok := a.(string); ok {
+		var err error
+		if a, err = memStringToNumber(a.(string)); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := b.(string); ok {
+		var err error
+		if b, err = memStringToNumber(b.(string)); err != nil {
+			return nil, err
+		}
+	}
+	if !memIsNumber(a) || !memIsNumber(b) {
+		return nil, fmt.Errorf("operator does not exist: %T %s %T", a, op, b)
+	}
+
+	if intA, ok := a.(int64); ok {
+		if intB, ok := b.(int64); ok {
+			switch op {
+			case "+":
+				return intA + intB, nil
+			case "-":
+				return intA - intB, nil
+			case "*":
+				return intA * intB, nil
+			case "/":
+				if intB == 0 {
+					return nil, fmt.Errorf("division by zero")
+				}
+				return intA / intB, nil
+			case "%":
+				if intB == 0 {
+					return nil, fmt.Errorf("division by zero")
+				}
+				return intA % intB, nil
+			}
+		}
+	}
+	_, isFloatA := a.(float64)
+	_, isFloatB := b.(float64)
+	if isFloatA || isFloatB {
+		floatA, _ := memCoerce(a, memTypeFloat)
+		floatB, _ := memCoerce(b, memTypeFloat)
+		fa, fb := floatA.(float64), floatB.(float64)
+		switch op {
+		case "+":
+			return fa + fb, nil
+		case "-":
+			return fa - fb, nil
+		case "*":
+			return fa * fb, nil
+		case "/":
+			if fb == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return fa / fb, nil
+		case "%":
+			if fb == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return math.Mod(fa, fb), nil
+		}
+	}
+
+	ratA, err := memToRat(a)
+	if err != nil {
+		return nil, err
+	}
+	ratB, err := memToRat(b)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "+":
+		return new(big.Rat).Add(ratA, ratB), nil
+	case "-":
+		return new(big.Rat).Sub(ratA, ratB), nil
+	case "*":
+		return new(big.Rat).Mul(ratA, ratB), nil
+	case "/":
+		if ratB.Sign() == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return new(big.Rat).Quo(ratA, ratB), nil
+	case "%":
+		if ratB.Sign() == 0 || !ratA.IsInt() || !ratB.IsInt() {
+			return nil, fmt.Errorf("invalid modulo operands")
+		}
+		return new(big.Rat).SetInt(new(big.Int).Rem(ratA.Num(), ratB.Num())), nil
+	}
+
+	return nil, fmt.Errorf("unsupported operator `%s`", op)
+}
+
+func memStringToNumber(str string) (interface{}, error) {
+	if parsed, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64); err == nil {
+		return parsed, nil
+	}
+	rat, ok := memParseNumeric(str)
+	if !ok {
+		return nil, fmt.Errorf("invalid input syntax for type numeric: \"%s\"", str)
+	}
+	return rat, nil
+}
+
+// memToBool converts a value to SQL boolean. nil means unknown.
+func memToBool(value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return typedValue, nil
+	case string:
+		parsed, ok := memParseBool(typedValue)
+		if !ok {
+			return nil, fmt.Errorf("invalid input syntax for type boolean: \"%s\"", typedValue)
+		}
+		return parsed, nil
+	}
+	return nil, fmt.Errorf("argument of type %T must be boolean", value)
+}
+
+func memDriverValue(value interface{}) driver.Value {
+	if rat, ok := value.(*big.Rat); ok {
+		return memFormatRat(rat)
+	}
+	return value
+}
+
+var memBigIntType = reflect.TypeOf(big.Int{})
+var memTimeType = reflect.TypeOf(time.Time{})
+
+// memScan assigns the stored value to the scan destination, following the conversion rules of pgx
+// where applicable
+func memScan(dest interface{}, value interface{}) error {
+	if dest == nil {
+		return nil
+	}
+
+	switch typedDest := dest.(type) {
+	case *interface{}:
+		*typedDest = memDriverValue(value)
+		return nil
+	case sql.Scanner:
+		return typedDest.Scan(memDriverValue(value))
+	}
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("scan destination must be a non-nil pointer, got %T", dest)
+	}
+	elem := rv.Elem()
+
+	if elem.Kind() == reflect.Ptr {
+		if value == nil {
+			elem.Set(reflect.Zero(elem.Type()))
+			return nil
+		}
+		target := reflect.New(elem.Type().Elem())
+		if err := memScan(target.Interface(), value); err != nil {
+			return err
+		}
+		elem.Set(target)
+		return nil
+	}
+
+	if value == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dest)
+	}
+
+	switch elem.Type() {
+	case memBigIntType:
+		rat, err := memToRat(value)
+		if err != nil {
+			return err
+		}
+		if !rat.IsInt() {
+			return fmt.Errorf("cannot scan non-integer numeric %s into *big.Int", memFormatRat(rat))
+		}
+		elem.Set(reflect.ValueOf(*new(big.Int).Set(rat.Num())))
+		return nil
+	case memTimeType:
+		coerced, err := memCoerce(value, memTypeTime)
+		if err != nil {
+			return err
+		}
+		elem.Set(reflect.ValueOf(coerced))
+		return nil
+	}
+
+	switch elem.Kind() {
+	case reflect.String:
+		elem.SetString(memFormatValue(value))
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		coerced, err := memCoerce(value, memTypeInt)
+		if err != nil {
+			return err
+		}
+		if elem.OverflowInt(coerced.(int64)) {
+			return fmt.Errorf("value %d overflows %s", coerced, elem.Type())
+		}
+		elem.SetInt(coerced.(int64))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		rat, err := memToRat(value)
+		if err != nil {
+			return err
+		}
+		if !rat.IsInt() || rat.Sign() < 0 || !rat.Num().IsUint64() || elem.OverflowUint(rat.Num().Uint64()) {
+			return fmt.Errorf("value %s overflows %s", memFormatRat(rat), elem.Type())
+		}
+		elem.SetUint(rat.Num().Uint64())
+		return nil
+	case reflect.Float32, reflect.Float64:
+		coerced, err := memCoerce(value, memTypeFloat)
+		if err != nil {
+			return err
+		}
+		elem.SetFloat(coerced.(float64))
+		return nil
+	case reflect.Bool:
+		coerced, err := memCoerce(value, memTypeBool)
+		if err != nil {
+			return err
+		}
+		elem.SetBool(coerced.(bool))
+		return nil
+	case reflect.Slice:
+		if elem.Type().Elem().Kind() == reflect.Uint8 {
+			elem.SetBytes([]byte(memFormatValue(value)))
+			return nil
+		}
+	}
+
+	// decode JSON columns into composite destination
+	if str, ok := value.(string); ok {
+		if err := json.Unmarshal([]byte(str), dest); err != nil {
+			return fmt.Errorf("cannot scan %T into %T: %v", value, dest, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T into %T", value, dest)
+}
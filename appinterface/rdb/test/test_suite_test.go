@@ -0,0 +1,13 @@
+package test_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestInMemoryRDb(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "In-memory RDb Suite")
+}
@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/accountranking"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+func NewAccountRanker(logger applogger.Logger, rdbConn rdb.Conn, config *Config) (*accountranking.Ranker, error) {
+	var err error
+
+	interval := accountranking.DEFAULT_RANK_INTERVAL
+	if config.AccountRanking.Interval != "" {
+		if interval, err = time.ParseDuration(config.AccountRanking.Interval); err != nil {
+			return nil, fmt.Errorf("error parsing Interval string to duration %v", err)
+		}
+	}
+	batchSize := accountranking.DEFAULT_RANK_BATCH_SIZE
+	if config.AccountRanking.BatchSize != 0 {
+		batchSize = config.AccountRanking.BatchSize
+	}
+
+	return accountranking.NewRanker(
+		logger,
+		rdbConn.ToHandle(),
+		accountranking.RankerConfig{
+			AccountAddressPrefix: config.Blockchain.AccountAddressPrefix,
+			Interval:             interval,
+			BatchSize:            batchSize,
+		},
+	), nil
+}
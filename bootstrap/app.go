@@ -1,15 +1,21 @@
-package main
+package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/crypto-com/chain-indexing/appinterface/leaderelection"
+	projection_interface "github.com/crypto-com/chain-indexing/appinterface/projection"
 	"github.com/crypto-com/chain-indexing/internal/primptr"
 
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
 
 	"github.com/crypto-com/chain-indexing/infrastructure"
+	"github.com/crypto-com/chain-indexing/infrastructure/metrics"
+	"github.com/crypto-com/chain-indexing/infrastructure/pg"
 
 	"github.com/crypto-com/chain-indexing/internal/filereader/toml"
 	"github.com/urfave/cli/v2"
@@ -18,7 +24,10 @@ import (
 const SYSTEM_MODE_EVENT_STORE = "EVENT_STORE"
 const SYSTEM_MODE_TENDERMINT_DIRECT = "TENDERMINT_DIRECT"
 
-func CliApp(args []string) error {
+// CliApp runs the chain-indexing command line application. Only the projections in the registry can
+// be enabled in the config. Use it to add your own projections when importing chain-indexing as a
+// library.
+func CliApp(args []string, projectionRegistry *projection_interface.Registry) error {
 	cliApp := &cli.App{
 		Name:                 filepath.Base(args[0]),
 		Usage:                "Crypto.com Chain Indexing Service",
@@ -148,23 +157,107 @@ func CliApp(args []string) error {
 				logger.Panicf("error setting up RDb connection: %v", err)
 			}
 
-			httpAPIServer := NewHTTPAPIServer(logger, rdbConn, &config)
-			go func() {
-				if runErr := httpAPIServer.Run(); runErr != nil {
-					logger.Panicf("%v", runErr)
+			shutdownTimeout := DEFAULT_SHUTDOWN_TIMEOUT
+			if config.System.ShutdownTimeout != "" {
+				if shutdownTimeout, err = time.ParseDuration(config.System.ShutdownTimeout); err != nil {
+					logger.Panicf("error parsing ShutdownTimeout string to duration: %v", err)
+				}
+			}
+			lifecycle := NewLifecycle(logger, shutdownTimeout)
+
+			promMetrics := metrics.NewPrometheusMetrics()
+			if pgxConn, ok := rdbConn.(*pg.PgxConn); ok {
+				promMetrics.MustRegister(metrics.NewPgxPoolCollector(pgxConn.MaybePoolStat))
+			}
+
+			// With leader election the indexing writes are fenced such that a leader which lost the
+			// leadership cannot overwrite the work of its successor
+			var maybeLeaderElector *leaderelection.Elector
+			indexRDbConn := rdbConn
+			if config.LeaderElection.Enabled {
+				if maybeLeaderElector, err = NewLeaderElector(logger, rdbConn, &config); err != nil {
+					logger.Panicf("error setting up leader election: %v", err)
+				}
+				indexRDbConn = maybeLeaderElector.FenceConn(rdbConn)
+			}
+
+			projections, err := initProjections(logger, indexRDbConn, &config, projectionRegistry)
+			if err != nil {
+				logger.Panicf("error initializing projections: %v", err)
+			}
+
+			httpAPIServer := NewHTTPAPIServer(
+				logger, rdbConn, &config,
+			).WithMetricsHandler(
+				promMetrics.Handler(),
+			).WithProjections(projections)
+			lifecycle.Go("HTTPAPIServer", httpAPIServer.Run)
+
+			indexService := NewIndexService(logger, indexRDbConn, &config, projections).WithMetrics(promMetrics)
+			// Services writing to the projections, run only by the leader with leader election
+			leaderServices := []Service{{Name: "IndexService", Run: indexService.Run}}
+
+			if config.BalanceReconciliation.Enabled {
+				balanceEnabled := false
+				for _, projection := range projections {
+					if projection.Id() == "Balance" {
+						balanceEnabled = true
+						break
+					}
+				}
+				if !balanceEnabled {
+					logger.Panicf("balance reconciliation requires the Balance projection to be enabled")
 				}
-			}()
 
-			projections := initProjections(logger, rdbConn, &config)
+				balanceReconciler, reconcilerErr := NewBalanceReconciler(logger, indexRDbConn, &config)
+				if reconcilerErr != nil {
+					logger.Panicf("error setting up balance reconciliation: %v", reconcilerErr)
+				}
+				leaderServices = append(leaderServices, Service{Name: "BalanceReconciler", Run: balanceReconciler.Run})
+			}
 
-			indexService := NewIndexService(logger, rdbConn, &config, projections)
-			go func() {
-				if runErr := indexService.Run(); runErr != nil {
-					logger.Panicf("%v", runErr)
+			if config.AccountRanking.Enabled {
+				enabledProjections := make(map[string]bool)
+				for _, projection := range projections {
+					enabledProjections[projection.Id()] = true
+				}
+				for _, requiredProjection := range []string{"Balance", "Delegation", "AccountMessage"} {
+					if !enabledProjections[requiredProjection] {
+						logger.Panicf("account ranking requires the %s projection to be enabled", requiredProjection)
+					}
 				}
-			}()
 
-			select {}
+				accountRanker, rankerErr := NewAccountRanker(logger, indexRDbConn, &config)
+				if rankerErr != nil {
+					logger.Panicf("error setting up account ranking: %v", rankerErr)
+				}
+				leaderServices = append(leaderServices, Service{Name: "AccountRanker", Run: accountRanker.Run})
+			}
+
+			if maybeLeaderElector != nil {
+				// Standbys serve the HTTP API until they take over
+				leaderElector := maybeLeaderElector
+				lifecycle.Go("Leader", func(ctx context.Context) error {
+					return leaderElector.Run(ctx, func(leaderCtx context.Context) error {
+						return RunServices(leaderCtx, leaderServices)
+					})
+				})
+			} else {
+				for _, service := range leaderServices {
+					lifecycle.Go(service.Name, service.Run)
+				}
+			}
+
+			shutdownErr := lifecycle.Wait()
+			if closableRDbConn, ok := rdbConn.(interface{ Close() }); ok {
+				closableRDbConn.Close()
+			}
+			if shutdownErr != nil {
+				return shutdownErr
+			}
+
+			logger.Info("shutdown completed")
+			return nil
 		},
 	}
 
@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/balance"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	cosmosapp_infrastructure "github.com/crypto-com/chain-indexing/infrastructure/cosmosapp"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+func NewBalanceReconciler(logger applogger.Logger, rdbConn rdb.Conn, config *Config) (*balance.Reconciler, error) {
+	var err error
+
+	interval := balance.DEFAULT_RECONCILE_INTERVAL
+	if config.BalanceReconciliation.Interval != "" {
+		if interval, err = time.ParseDuration(config.BalanceReconciliation.Interval); err != nil {
+			return nil, fmt.Errorf("error parsing Interval string to duration %v", err)
+		}
+	}
+	batchSize := balance.DEFAULT_RECONCILE_BATCH_SIZE
+	if config.BalanceReconciliation.BatchSize != 0 {
+		batchSize = config.BalanceReconciliation.BatchSize
+	}
+
+	return balance.NewReconciler(
+		logger,
+		rdbConn.ToHandle(),
+		cosmosapp_infrastructure.NewHTTPClient(config.CosmosApp.HTTPRPCUL),
+		balance.ReconcilerConfig{
+			BaseDenom: config.Blockchain.BaseDenom,
+			Interval:  interval,
+			BatchSize: batchSize,
+		},
+	), nil
+}
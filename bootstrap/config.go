@@ -1,4 +1,4 @@
-package main
+package bootstrap
 
 type Config struct {
 	FileConfig
@@ -57,15 +57,21 @@ type CLIConfig struct {
 
 // FileConfig is the struct matches config.toml
 type FileConfig struct {
-	Blockchain BlockchainConfig
-	System     SystemConfig
-	Sync       SyncConfig
-	Tendermint TendermintConfig
-	CosmosApp  CosmosAppConfig `toml:"cosmosapp"`
-	HTTP       HTTPConfig
-	Database   DatabaseConfig
-	Postgres   PostgresConfig
-	Logger     LoggerConfig
+	Blockchain            BlockchainConfig
+	System                SystemConfig
+	Sync                  SyncConfig
+	Projection            ProjectionConfig
+	LeaderElection        LeaderElectionConfig        `toml:"leader_election"`
+	BalanceReconciliation BalanceReconciliationConfig `toml:"balance_reconciliation"`
+	AccountRanking        AccountRankingConfig        `toml:"account_ranking"`
+	Supply                SupplyConfig
+	Tendermint            TendermintConfig
+	CosmosApp             CosmosAppConfig `toml:"cosmosapp"`
+	HTTP                  HTTPConfig
+	Health                HealthConfig
+	Database              DatabaseConfig
+	Postgres              PostgresConfig
+	Logger                LoggerConfig
 }
 
 type BlockchainConfig struct {
@@ -82,12 +88,51 @@ type BlockchainConfig struct {
 
 type SystemConfig struct {
 	Mode string `toml:"mode"`
+	// Duration string. Defaults to 30s when empty.
+	ShutdownTimeout string `toml:"shutdown_timeout"`
 }
 
 type SyncConfig struct {
 	WindowSize int `toml:"window_size"`
 }
 
+type LeaderElectionConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Unique identity of the instance. Defaults to hostname and process id when empty.
+	InstanceId string `toml:"instance_id"`
+	// Duration strings
+	LeaseDuration string `toml:"lease_duration"`
+	RenewInterval string `toml:"renew_interval"`
+}
+
+type BalanceReconciliationConfig struct {
+	// Only runs when the Balance projection is enabled
+	Enabled bool `toml:"enabled"`
+	// Duration string. Defaults to 10m when empty.
+	Interval string `toml:"interval"`
+	// Number of accounts reconciled every interval. Defaults to 100 when zero.
+	BatchSize uint64 `toml:"batch_size"`
+}
+
+type AccountRankingConfig struct {
+	// Only runs when the Balance, Delegation and AccountMessage projections are enabled
+	Enabled bool `toml:"enabled"`
+	// Duration string. Defaults to 1m when empty.
+	Interval string `toml:"interval"`
+	// Number of accounts ranked every interval. Defaults to 1000 when zero.
+	BatchSize uint64 `toml:"batch_size"`
+}
+
+type SupplyConfig struct {
+	// Addresses whose base denom balances are excluded from the circulating supply. Requires the
+	// Balance projection.
+	NonCirculatingAddresses []string `toml:"non_circulating_addresses"`
+}
+
+type ProjectionConfig struct {
+	Enables []string `toml:"enables"`
+}
+
 type HTTPConfig struct {
 	ListeningAddress   string   `toml:"listening_address"`
 	RoutePrefix        string   `toml:"route_prefix"`
@@ -96,6 +141,13 @@ type HTTPConfig struct {
 	CorsAllowedHeaders []string `toml:"cors_allowed_headers"`
 }
 
+type HealthConfig struct {
+	// Readiness fails when the last synced block is older than the duration
+	MaxLastBlockAge string `toml:"max_last_block_age"`
+	// Readiness fails when any projection is behind the chain latest height by more blocks
+	MaxProjectionLag int64 `toml:"max_projection_lag"`
+}
+
 type TendermintConfig struct {
 	HTTPRPCURL string `toml:"http_rpc_url"`
 }
@@ -0,0 +1,242 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lab259/cors"
+	"github.com/valyala/fasthttp"
+
+	"github.com/crypto-com/chain-indexing/appinterface/health"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
+	cosmosapp_infrastructure "github.com/crypto-com/chain-indexing/infrastructure/cosmosapp"
+	"github.com/crypto-com/chain-indexing/infrastructure/tendermint"
+
+	"github.com/crypto-com/chain-indexing/appinterface/cosmosapp"
+
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi/routes"
+
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi/handlers"
+
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+const DEFAULT_HEALTH_MAX_LAST_BLOCK_AGE = 10 * time.Minute
+const DEFAULT_HEALTH_MAX_PROJECTION_LAG = int64(100)
+
+type HTTPAPIServer struct {
+	logger              applogger.Logger
+	rdbConn             rdb.Conn
+	cosmosAppClient     cosmosapp.Client
+	cosmosAppHTTPClient *cosmosapp_infrastructure.HTTPClient
+	tendermintClient    *tendermint.HTTPClient
+
+	accountAddressPrefix   string
+	validatorAddressPrefix string
+	conNodeAddressPrefix   string
+
+	supplyNonCirculatingAddresses []string
+
+	listeningAddress string
+	routePrefix      string
+
+	corsAllowedOrigins []string
+	corsAllowedMethods []string
+	corsAllowedHeaders []string
+
+	healthConfig HealthConfig
+	projections  []entity_projection.Projection
+
+	maybeMetricsHandler fasthttp.RequestHandler
+}
+
+// NewIndexService creates a new server instance for polling and indexing
+func NewHTTPAPIServer(logger applogger.Logger, rdbConn rdb.Conn, config *Config) *HTTPAPIServer {
+	cosmosAppClient := cosmosapp_infrastructure.NewHTTPClient(config.CosmosApp.HTTPRPCUL)
+
+	return &HTTPAPIServer{
+		logger:              logger,
+		rdbConn:             rdbConn,
+		cosmosAppClient:     cosmosAppClient,
+		cosmosAppHTTPClient: cosmosAppClient,
+		tendermintClient:    tendermint.NewHTTPClient(config.Tendermint.HTTPRPCURL),
+
+		accountAddressPrefix:   config.Blockchain.AccountAddressPrefix,
+		validatorAddressPrefix: config.Blockchain.ValidatorAddressPrefix,
+		conNodeAddressPrefix:   config.Blockchain.ConNodeAddressPrefix,
+		listeningAddress:       config.HTTP.ListeningAddress,
+		routePrefix:            config.HTTP.RoutePrefix,
+
+		supplyNonCirculatingAddresses: config.Supply.NonCirculatingAddresses,
+
+		corsAllowedOrigins: config.HTTP.CorsAllowedOrigins,
+		corsAllowedMethods: config.HTTP.CorsAllowedMethods,
+		corsAllowedHeaders: config.HTTP.CorsAllowedHeaders,
+
+		healthConfig: config.Health,
+		projections:  make([]entity_projection.Projection, 0),
+	}
+}
+
+// WithProjections checks the lag of the projections in the readiness health check
+func (server *HTTPAPIServer) WithProjections(projections []entity_projection.Projection) *HTTPAPIServer {
+	server.projections = projections
+
+	return server
+}
+
+// WithMetricsHandler serves the metrics at `/metrics`
+func (server *HTTPAPIServer) WithMetricsHandler(handler fasthttp.RequestHandler) *HTTPAPIServer {
+	server.maybeMetricsHandler = handler
+
+	return server
+}
+
+// Run function runs the HTTP API server until the context is done. In-flight requests are completed
+// before it returns.
+func (server *HTTPAPIServer) Run(ctx context.Context) error {
+	httpServer := httpapi.NewServer(
+		server.listeningAddress,
+	).WithLogger(
+		server.logger,
+	)
+
+	if len(server.corsAllowedOrigins) != 0 {
+		httpServer = httpServer.WithCors(cors.Options{
+			AllowedOrigins: server.corsAllowedOrigins,
+			AllowedMethods: server.corsAllowedMethods,
+			AllowedHeaders: server.corsAllowedHeaders,
+			Debug:          true,
+		})
+	}
+
+	searchHandler := handlers.NewSearch(server.logger, server.rdbConn.ToHandle())
+	blocksHandler := handlers.NewBlocks(server.logger, server.rdbConn.ToHandle())
+	statusHandler := handlers.NewStatusHandler(server.logger, server.rdbConn.ToHandle())
+	transactionsHandler := handlers.NewTransactions(server.logger, server.rdbConn.ToHandle())
+	blockEventsHandler := handlers.NewBlockEvents(server.logger, server.rdbConn.ToHandle())
+	validatorsHandler := handlers.NewValidators(
+		server.logger,
+		server.validatorAddressPrefix,
+		server.conNodeAddressPrefix,
+		server.cosmosAppClient,
+		server.rdbConn.ToHandle(),
+	)
+	accountMessagesHandler := handlers.NewAccountMessages(server.logger, server.rdbConn.ToHandle())
+	accountsHandler := handlers.NewAccounts(server.logger, server.rdbConn.ToHandle())
+	livenessChecks, readinessChecks, err := server.healthChecks()
+	if err != nil {
+		return fmt.Errorf("error creating health checks: %v", err)
+	}
+	healthHandler := handlers.NewHealth(server.logger, livenessChecks, readinessChecks)
+	proposalsHandler := handlers.NewProposals(server.logger, server.rdbConn.ToHandle())
+	delegationsHandler := handlers.NewDelegations(server.logger, server.rdbConn.ToHandle())
+	balancesHandler := handlers.NewBalances(server.logger, server.rdbConn.ToHandle())
+	validatorEarningsHandler := handlers.NewValidatorEarnings(server.logger, server.rdbConn.ToHandle())
+	delegatorRewardsHandler := handlers.NewDelegatorRewards(server.logger, server.rdbConn.ToHandle())
+	statsHandler := handlers.NewStats(server.logger, server.rdbConn.ToHandle())
+	supplyHandler := handlers.NewSupply(
+		server.logger,
+		server.rdbConn.ToHandle(),
+		server.supplyNonCirculatingAddresses,
+	)
+	feesHandler := handlers.NewFees(server.logger, server.rdbConn.ToHandle())
+	accountRankingsHandler := handlers.NewAccountRankings(
+		server.logger,
+		server.rdbConn.ToHandle(),
+		server.accountAddressPrefix,
+	)
+	proposerStatsHandler := handlers.NewProposerStats(server.logger, server.rdbConn.ToHandle())
+	slashingHandler := handlers.NewSlashing(server.logger, server.rdbConn.ToHandle())
+
+	routeRegistry := routes.NewRoutesRegistry(
+		searchHandler,
+		blocksHandler,
+		statusHandler,
+		transactionsHandler,
+		blockEventsHandler,
+		validatorsHandler,
+		accountMessagesHandler,
+		accountsHandler,
+		healthHandler,
+		proposalsHandler,
+		delegationsHandler,
+		balancesHandler,
+		validatorEarningsHandler,
+		delegatorRewardsHandler,
+		statsHandler,
+		supplyHandler,
+		feesHandler,
+		accountRankingsHandler,
+		proposerStatsHandler,
+		slashingHandler,
+	)
+	routeRegistry.Register(httpServer, server.routePrefix)
+	if server.maybeMetricsHandler != nil {
+		httpServer.GET("/metrics", server.maybeMetricsHandler)
+	}
+
+	stoppedCh := make(chan struct{})
+	defer close(stoppedCh)
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := httpServer.Shutdown(); err != nil {
+				server.logger.Errorf("error shutting down HTTP API server: %v", err)
+			}
+		case <-stoppedCh:
+		}
+	}()
+
+	server.logger.Infof("server start listening on: %s", server.listeningAddress)
+	if err := httpServer.ListenAndServe(); err != nil {
+		return fmt.Errorf("error listening and serving HTTP API server: %v", err)
+	}
+
+	return nil
+}
+
+// healthChecks returns the liveness checks, which check only the database, and the readiness checks,
+// which additionally check the sync progress and the RPCs reachability
+func (server *HTTPAPIServer) healthChecks() ([]health.Check, []health.Check, error) {
+	maxLastBlockAge := DEFAULT_HEALTH_MAX_LAST_BLOCK_AGE
+	if server.healthConfig.MaxLastBlockAge != "" {
+		var err error
+		if maxLastBlockAge, err = time.ParseDuration(server.healthConfig.MaxLastBlockAge); err != nil {
+			return nil, nil, fmt.Errorf("error parsing MaxLastBlockAge string to duration: %v", err)
+		}
+	}
+	maxProjectionLag := DEFAULT_HEALTH_MAX_PROJECTION_LAG
+	if server.healthConfig.MaxProjectionLag > 0 {
+		maxProjectionLag = server.healthConfig.MaxProjectionLag
+	}
+
+	rdbHandle := server.rdbConn.ToHandle()
+	livenessChecks := []health.Check{
+		health.NewDatabaseCheck(rdbHandle),
+	}
+
+	readinessChecks := []health.Check{
+		health.NewDatabaseCheck(rdbHandle),
+	}
+	for _, projection := range server.projections {
+		// The last synced block time is only available from the blocks view
+		if projection.Id() == "Block" {
+			readinessChecks = append(readinessChecks, health.NewLastSyncedBlockCheck(rdbHandle, maxLastBlockAge))
+			break
+		}
+	}
+	readinessChecks = append(readinessChecks,
+		health.NewProjectionsLagCheck(rdbHandle, server.projections, maxProjectionLag),
+		health.NewRPCCheck("tendermint", func() error {
+			_, err := server.tendermintClient.LatestBlockHeight()
+			return err
+		}),
+		health.NewRPCCheck("cosmosapp", server.cosmosAppHTTPClient.Ping),
+	)
+
+	return livenessChecks, readinessChecks, nil
+}
@@ -1,7 +1,9 @@
-package main
+package bootstrap
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	event_interface "github.com/crypto-com/chain-indexing/appinterface/event"
 	eventhandler_interface "github.com/crypto-com/chain-indexing/appinterface/eventhandler"
@@ -9,6 +11,7 @@ import (
 	"github.com/crypto-com/chain-indexing/entity/event"
 	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/metrics"
 	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
 	"github.com/crypto-com/chain-indexing/usecase/parser"
 )
@@ -17,6 +20,7 @@ type IndexService struct {
 	logger      applogger.Logger
 	rdbConn     rdb.Conn
 	projections []projection_entity.Projection
+	metrics     metrics.Metrics
 
 	systemMode            string
 	baseDenom             string
@@ -36,6 +40,7 @@ func NewIndexService(
 		logger:      logger,
 		rdbConn:     rdbConn,
 		projections: projections,
+		metrics:     metrics.NewNoopMetrics(),
 
 		systemMode:            config.System.Mode,
 		baseDenom:             config.Blockchain.BaseDenom,
@@ -45,21 +50,36 @@ func NewIndexService(
 	}
 }
 
-func (service *IndexService) Run() error {
+// WithMetrics records the sync and projection metrics to the metrics
+func (service *IndexService) WithMetrics(metrics metrics.Metrics) *IndexService {
+	service.metrics = metrics
+
+	return service
+}
+
+// Run indexes the chain until the context is done. It returns after the in-flight block handling is
+// committed or rolled back.
+func (service *IndexService) Run(ctx context.Context) error {
 	// run polling tendermint manager, update view tables directly
 	infoManager := NewInfoManager(
 		service.logger,
 		service.rdbConn,
 		service.tendermintHTTPRPCURL,
 	)
-	infoManager.Run()
+	var infoManagerWaitGroup sync.WaitGroup
+	infoManagerWaitGroup.Add(1)
+	go func() {
+		defer infoManagerWaitGroup.Done()
+		infoManager.Run(ctx)
+	}()
+	defer infoManagerWaitGroup.Wait()
 
 	var err error
 	switch service.systemMode {
 	case SYSTEM_MODE_EVENT_STORE:
-		err = service.RunEventStoreMode()
+		err = service.RunEventStoreMode(ctx)
 	case SYSTEM_MODE_TENDERMINT_DIRECT:
-		err = service.RunTendermintDirectMode()
+		err = service.RunTendermintDirectMode(ctx)
 	}
 
 	if err != nil {
@@ -69,19 +89,24 @@ func (service *IndexService) Run() error {
 	return nil
 }
 
-func (service *IndexService) RunEventStoreMode() error {
+func (service *IndexService) RunEventStoreMode(ctx context.Context) error {
 	eventRegistry := event.NewRegistry()
 	event_usecase.RegisterEvents(eventRegistry)
 	eventStore := event_interface.NewRDbStore(service.rdbConn.ToHandle(), eventRegistry)
 
-	projectionManager := projection_entity.NewStoreBasedManager(service.logger, eventStore)
+	projectionManager := projection_entity.NewStoreBasedManager(
+		service.logger, eventStore,
+	).WithMetrics(service.metrics)
 
 	for _, projection := range service.projections {
 		if err := projectionManager.RegisterProjection(projection); err != nil {
 			return fmt.Errorf("error registering projection `%s` to manager %v", projection.Id(), err)
 		}
 	}
-	projectionManager.RunInBackground()
+	if err := projectionManager.RunInBackground(ctx); err != nil {
+		return fmt.Errorf("error running projection manager %v", err)
+	}
+	defer projectionManager.Wait()
 
 	eventStoreHandler := eventhandler_interface.NewRDbEventStoreHandler(
 
@@ -90,11 +115,15 @@ func (service *IndexService) RunEventStoreMode() error {
 		eventRegistry,
 	)
 	txDecoder := parser.NewTxDecoder(service.baseDenom)
+	// All parsers are run such that the projections enabled later can replay the whole history from the
+	// event store
 	syncManager := NewSyncManager(
 		SyncManagerParams{
-			Logger:    service.logger,
-			RDbConn:   service.rdbConn,
-			TxDecoder: txDecoder,
+			Logger:       service.logger,
+			RDbConn:      service.rdbConn,
+			TxDecoder:    txDecoder,
+			MaybeMetrics: service.metrics,
+			MetricsId:    EVENT_STORE_METRICS_ID,
 			Config: SyncManagerConfig{
 				WindowSize:       service.windowSize,
 				TendermintRPCUrl: service.tendermintHTTPRPCURL,
@@ -102,33 +131,59 @@ func (service *IndexService) RunEventStoreMode() error {
 		},
 		eventStoreHandler,
 	)
-	if err := syncManager.Run(); err != nil {
+	if err := syncManager.Run(ctx); err != nil {
 		return fmt.Errorf("error running sync manager %v", err)
 	}
 
 	return nil
 }
 
-func (service *IndexService) RunTendermintDirectMode() error {
+func (service *IndexService) RunTendermintDirectMode(ctx context.Context) error {
 	txDecoder := parser.NewTxDecoder(service.baseDenom)
 
+	dependencyGraph := projection_entity.NewDependencyGraph()
+	for _, projection := range service.projections {
+		if err := dependencyGraph.Add(projection); err != nil {
+			return fmt.Errorf("error adding projection `%s` to dependency graph: %v", projection.Id(), err)
+		}
+	}
+	if err := dependencyGraph.Validate(); err != nil {
+		return fmt.Errorf("error validating projection dependencies: %v", err)
+	}
+
+	var syncManagersWaitGroup sync.WaitGroup
+	syncManagerErrCh := make(chan error, len(service.projections))
 	for i := range service.projections {
+		syncManagersWaitGroup.Add(1)
 		go func(projection projection_entity.Projection) {
+			defer syncManagersWaitGroup.Done()
+
 			syncManager := NewSyncManager(SyncManagerParams{
 				Logger: service.logger.WithFields(applogger.LogFields{
 					"projection": projection.Id(),
 				}),
-				RDbConn:   service.rdbConn,
-				TxDecoder: txDecoder,
+				RDbConn:      service.rdbConn,
+				TxDecoder:    txDecoder,
+				MaybeMetrics: service.metrics,
+				MetricsId:    projection.Id(),
 				Config: SyncManagerConfig{
-					WindowSize:       service.windowSize,
-					TendermintRPCUrl: service.tendermintHTTPRPCURL,
+					WindowSize:          service.windowSize,
+					TendermintRPCUrl:    service.tendermintHTTPRPCURL,
+					MaybeEventsToListen: projection_entity.EventsToListen([]projection_entity.Projection{projection}),
 				},
-			}, eventhandler_interface.NewProjectionHandler(service.logger, projection))
-			if err := syncManager.Run(); err != nil {
-				panic(fmt.Sprintf("error running sync manager %v", err))
+			}, eventhandler_interface.NewProjectionHandler(
+				service.logger, projection,
+			).WithDependencyGraph(dependencyGraph).WithMetrics(service.metrics))
+			if err := syncManager.Run(ctx); err != nil {
+				syncManagerErrCh <- fmt.Errorf("error running sync manager of `%s` %v", projection.Id(), err)
 			}
 		}(service.projections[i])
 	}
-	select {}
+	syncManagersWaitGroup.Wait()
+	close(syncManagerErrCh)
+
+	if err, ok := <-syncManagerErrCh; ok {
+		return err
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
-package main
+package bootstrap
 
 import (
+	"context"
 	"time"
 
 	"github.com/crypto-com/chain-indexing/appinterface/polling"
@@ -16,7 +17,7 @@ type InfoManager struct {
 	client          *tendermint.HTTPClient
 	pollingInterval time.Duration
 	viewStatus      *polling.Status
-	logger 			applogger.Logger
+	logger          applogger.Logger
 }
 
 func NewInfoManager(
@@ -28,26 +29,35 @@ func NewInfoManager(
 
 	viewStatus := polling.NewStatus(rdbConn.ToHandle())
 	return &InfoManager{
-		logger: logger,
-		rdbConn:    rdbConn,
-		client:     tendermintClient,
-		viewStatus: viewStatus,
+		logger:          logger,
+		rdbConn:         rdbConn,
+		client:          tendermintClient,
+		viewStatus:      viewStatus,
 		pollingInterval: INFO_DEFAULT_POLLING_INTERVAL,
 	}
 
 }
 
-func (manager *InfoManager) Run() {
+// Run polls the chain status into the view until the context is done
+func (manager *InfoManager) Run(ctx context.Context) {
 	manager.logger.Infof("infomanager started")
-	go func() {
-		for {
-			status, _ := manager.client.Status()
+	for {
+		status, err := manager.client.Status()
+		if err != nil {
+			manager.logger.Errorf("error getting chain status: %v", err)
+		} else {
 			result := (*status)["result"]
 			syncInfo := result.(map[string]interface{})["sync_info"]
 			latestHeight := syncInfo.(map[string]interface{})["latest_block_height"].(string)
 			// upsert
-			_= manager.viewStatus.Insert("LatestHeight", latestHeight)
-			time.Sleep(manager.pollingInterval)
+			_ = manager.viewStatus.Insert("LatestHeight", latestHeight)
 		}
-	}()
+
+		select {
+		case <-ctx.Done():
+			manager.logger.Infof("infomanager stopped")
+			return
+		case <-time.After(manager.pollingInterval):
+		}
+	}
 }
@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/appinterface/leaderelection"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+func NewLeaderElector(logger applogger.Logger, rdbConn rdb.Conn, config *Config) (*leaderelection.Elector, error) {
+	var err error
+
+	instanceId := config.LeaderElection.InstanceId
+	if instanceId == "" {
+		hostname, hostnameErr := os.Hostname()
+		if hostnameErr != nil {
+			return nil, fmt.Errorf("error getting hostname for leader election instance id: %v", hostnameErr)
+		}
+		instanceId = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	leaseDuration := leaderelection.DEFAULT_LEASE_DURATION
+	if config.LeaderElection.LeaseDuration != "" {
+		if leaseDuration, err = time.ParseDuration(config.LeaderElection.LeaseDuration); err != nil {
+			return nil, fmt.Errorf("error parsing LeaseDuration string to duration %v", err)
+		}
+	}
+	renewInterval := leaderelection.DEFAULT_RENEW_INTERVAL
+	if config.LeaderElection.RenewInterval != "" {
+		if renewInterval, err = time.ParseDuration(config.LeaderElection.RenewInterval); err != nil {
+			return nil, fmt.Errorf("error parsing RenewInterval string to duration %v", err)
+		}
+	}
+
+	return leaderelection.NewElector(logger, rdbConn.ToHandle(), leaderelection.ElectorConfig{
+		LeaseName:     leaderelection.DEFAULT_LEASE_NAME,
+		HolderId:      instanceId,
+		LeaseDuration: leaseDuration,
+		RenewInterval: renewInterval,
+	})
+}
@@ -0,0 +1,151 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+const DEFAULT_SHUTDOWN_TIMEOUT = 30 * time.Second
+
+// Lifecycle runs the long-running services of the application with a shared context. The context is
+// cancelled on termination signal or when any of the service fails, after which the services are
+// given the shutdown timeout to finish their in-flight work.
+type Lifecycle struct {
+	logger          applogger.Logger
+	shutdownTimeout time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	servicesWaitGroup sync.WaitGroup
+
+	errMutex sync.Mutex
+	maybeErr error
+}
+
+func NewLifecycle(logger applogger.Logger, shutdownTimeout time.Duration) *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Lifecycle{
+		logger: logger.WithFields(applogger.LogFields{
+			"module": "Lifecycle",
+		}),
+		shutdownTimeout: shutdownTimeout,
+
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Context returns the context cancelled on shutdown
+func (lifecycle *Lifecycle) Context() context.Context {
+	return lifecycle.ctx
+}
+
+// Go runs the service in a goroutine. The service should return once the context is done. Returning
+// an error at any time triggers the shutdown of all the services.
+func (lifecycle *Lifecycle) Go(name string, service func(ctx context.Context) error) {
+	lifecycle.servicesWaitGroup.Add(1)
+	go func() {
+		defer lifecycle.servicesWaitGroup.Done()
+
+		if err := service(lifecycle.ctx); err != nil {
+			lifecycle.logger.Errorf("error running %s: %v", name, err)
+			lifecycle.setErr(fmt.Errorf("error running %s: %v", name, err))
+			lifecycle.Shutdown()
+			return
+		}
+		lifecycle.logger.Infof("%s stopped", name)
+	}()
+}
+
+// Shutdown cancels the services context
+func (lifecycle *Lifecycle) Shutdown() {
+	lifecycle.cancel()
+}
+
+// Wait blocks until SIGINT or SIGTERM is received or Shutdown is called, then waits for the services
+// to stop. Returns the error of the first failed service, or an error when the services do not stop
+// within the shutdown timeout.
+func (lifecycle *Lifecycle) Wait() error {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	select {
+	case sig := <-signalCh:
+		lifecycle.logger.Infof("received signal %v, shutting down", sig)
+		lifecycle.Shutdown()
+	case <-lifecycle.ctx.Done():
+		lifecycle.logger.Info("shutting down")
+	}
+
+	stoppedCh := make(chan struct{})
+	go func() {
+		lifecycle.servicesWaitGroup.Wait()
+		close(stoppedCh)
+	}()
+
+	select {
+	case <-stoppedCh:
+		lifecycle.logger.Info("all services stopped")
+	case <-time.After(lifecycle.shutdownTimeout):
+		lifecycle.setErr(fmt.Errorf("error shutting down: services did not stop within %v", lifecycle.shutdownTimeout))
+	case sig := <-signalCh:
+		lifecycle.setErr(fmt.Errorf("error shutting down: received signal %v again", sig))
+	}
+
+	lifecycle.errMutex.Lock()
+	defer lifecycle.errMutex.Unlock()
+	return lifecycle.maybeErr
+}
+
+func (lifecycle *Lifecycle) setErr(err error) {
+	lifecycle.errMutex.Lock()
+	defer lifecycle.errMutex.Unlock()
+
+	if lifecycle.maybeErr == nil {
+		lifecycle.maybeErr = err
+	}
+}
+
+// Service is a long-running service which should return once the context is done
+type Service struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// RunServices runs the services concurrently until all of them stop, e.g. the services run by the
+// leader. The first failing service stops the others and its error is returned.
+func RunServices(ctx context.Context, services []Service) error {
+	servicesCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var servicesWaitGroup sync.WaitGroup
+	errCh := make(chan error, len(services))
+	for i := range services {
+		servicesWaitGroup.Add(1)
+		go func(service Service) {
+			defer servicesWaitGroup.Done()
+
+			if err := service.Run(servicesCtx); err != nil {
+				errCh <- fmt.Errorf("error running %s: %v", service.Name, err)
+				cancel()
+			}
+		}(services[i])
+	}
+	servicesWaitGroup.Wait()
+	close(errCh)
+
+	if err, ok := <-errCh; ok {
+		return err
+	}
+	return nil
+}
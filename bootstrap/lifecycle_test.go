@@ -0,0 +1,89 @@
+package bootstrap_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/bootstrap"
+	. "github.com/crypto-com/chain-indexing/internal/logger/test"
+)
+
+var _ = Describe("Lifecycle", func() {
+	It("should wait for the services to stop on shutdown", func() {
+		lifecycle := bootstrap.NewLifecycle(NewFakeLogger(), time.Second)
+
+		drained := false
+		lifecycle.Go("AnyService", func(ctx context.Context) error {
+			<-ctx.Done()
+			<-time.After(100 * time.Millisecond)
+			drained = true
+			return nil
+		})
+
+		lifecycle.Shutdown()
+		Expect(lifecycle.Wait()).To(Succeed())
+		Expect(drained).To(BeTrue())
+	})
+
+	It("should shut down all the services when any service fails", func() {
+		lifecycle := bootstrap.NewLifecycle(NewFakeLogger(), time.Second)
+
+		lifecycle.Go("AnyService", func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+		lifecycle.Go("FailingService", func(ctx context.Context) error {
+			return errors.New("any error")
+		})
+
+		Expect(lifecycle.Wait()).To(MatchError("error running FailingService: any error"))
+		Expect(lifecycle.Context().Err()).To(Equal(context.Canceled))
+	})
+
+	It("should return error when the services do not stop within the shutdown timeout", func() {
+		lifecycle := bootstrap.NewLifecycle(NewFakeLogger(), 100*time.Millisecond)
+
+		lifecycle.Go("StuckService", func(ctx context.Context) error {
+			<-time.After(time.Second)
+			return nil
+		})
+
+		lifecycle.Shutdown()
+		Expect(lifecycle.Wait()).To(MatchError("error shutting down: services did not stop within 100ms"))
+	})
+
+	Describe("RunServices", func() {
+		It("should stop all the services when any service fails", func() {
+			stopped := false
+			err := bootstrap.RunServices(context.Background(), []bootstrap.Service{
+				{Name: "AnyService", Run: func(ctx context.Context) error {
+					<-ctx.Done()
+					stopped = true
+					return nil
+				}},
+				{Name: "FailingService", Run: func(ctx context.Context) error {
+					return errors.New("any error")
+				}},
+			})
+
+			Expect(err).To(MatchError("error running FailingService: any error"))
+			Expect(stopped).To(BeTrue())
+		})
+
+		It("should stop all the services when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			Expect(bootstrap.RunServices(ctx, []bootstrap.Service{
+				{Name: "AnyService", Run: func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				}},
+			})).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	cosmosapp_infrastructure "github.com/crypto-com/chain-indexing/infrastructure/cosmosapp"
+
+	projection_interface "github.com/crypto-com/chain-indexing/appinterface/projection"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	projection_entity "github.com/crypto-com/chain-indexing/entity/projection"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+func initProjections(
+	logger applogger.Logger,
+	rdbConn rdb.Conn,
+	config *Config,
+	registry *projection_interface.Registry,
+) ([]projection_entity.Projection, error) {
+	enables := config.Projection.Enables
+	if len(enables) == 0 {
+		logger.Info("no projection enables configured, enabling all registered projections")
+		enables = registry.Ids()
+	}
+
+	projections, err := registry.InitProjections(enables, &projection_interface.InitParams{
+		Logger:          logger,
+		RdbConn:         rdbConn,
+		CosmosAppClient: cosmosapp_infrastructure.NewHTTPClient(config.CosmosApp.HTTPRPCUL),
+
+		BaseDenom:            config.Blockchain.BaseDenom,
+		AccountAddressPrefix: config.Blockchain.AccountAddressPrefix,
+		ConNodeAddressPrefix: config.Blockchain.ConNodeAddressPrefix,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing enabled projections: %v", err)
+	}
+
+	return projections, nil
+}
@@ -1,6 +1,7 @@
-package main
+package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,20 +12,28 @@ import (
 	chainfeed "github.com/crypto-com/chain-indexing/infrastructure/feed/chain"
 	"github.com/crypto-com/chain-indexing/infrastructure/tendermint"
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/metrics"
 	"github.com/crypto-com/chain-indexing/usecase/parser"
 	"github.com/crypto-com/chain-indexing/usecase/syncstrategy"
 )
 
 const DEFAULT_POLLING_INTERVAL = 5 * time.Second
 
+// EVENT_STORE_METRICS_ID labels the synchronization metrics of the event store mode, where a single
+// synchronization feeds all the projections
+const EVENT_STORE_METRICS_ID = "EventStore"
+
 type SyncManager struct {
 	rdbConn         rdb.Conn
 	client          *tendermint.HTTPClient
 	logger          applogger.Logger
+	metrics         metrics.Metrics
+	metricsId       string
 	pollingInterval time.Duration
 
-	txDecoder          *parser.TxDecoder
-	windowSyncStrategy *syncstrategy.Window
+	txDecoder           *parser.TxDecoder
+	windowSyncStrategy  *syncstrategy.Window
+	maybeEventsToListen []string
 
 	eventHandler eventhandler_interface.Handler
 
@@ -37,6 +46,11 @@ type SyncManagerParams struct {
 	Logger    applogger.Logger
 	RDbConn   rdb.Conn
 	TxDecoder *parser.TxDecoder
+	// Metrics are discarded when nil
+	MaybeMetrics metrics.Metrics
+	// Value of the `projection` label of the synchronization metrics: the projection id, or
+	// EVENT_STORE_METRICS_ID when synchronizing the event store for all the projections
+	MetricsId string
 
 	Config SyncManagerConfig
 }
@@ -44,6 +58,9 @@ type SyncManagerParams struct {
 type SyncManagerConfig struct {
 	WindowSize       int
 	TendermintRPCUrl string
+	// Parsers yielding none of the events to listen are skipped. All parsers are run when nil, as required
+	// when persisting to the event store.
+	MaybeEventsToListen []string
 }
 
 // NewSyncManager creates a new feed with polling for latest block starts at a specific height
@@ -53,25 +70,35 @@ func NewSyncManager(
 ) *SyncManager {
 	tendermintClient := tendermint.NewHTTPClient(params.Config.TendermintRPCUrl)
 
+	var syncManagerMetrics metrics.Metrics = metrics.NewNoopMetrics()
+	if params.MaybeMetrics != nil {
+		syncManagerMetrics = params.MaybeMetrics
+	}
+
 	return &SyncManager{
 		rdbConn: params.RDbConn,
 		client:  tendermintClient,
 		logger: params.Logger.WithFields(applogger.LogFields{
 			"module": "SyncManager",
 		}),
+		metrics:         syncManagerMetrics,
+		metricsId:       params.MetricsId,
 		pollingInterval: DEFAULT_POLLING_INTERVAL,
 
 		shouldSyncCh: make(chan bool, 1),
 
-		txDecoder:          params.TxDecoder,
-		windowSyncStrategy: syncstrategy.NewWindow(params.Logger, params.Config.WindowSize),
+		txDecoder:           params.TxDecoder,
+		windowSyncStrategy:  syncstrategy.NewWindow(params.Logger, params.Config.WindowSize),
+		maybeEventsToListen: params.Config.MaybeEventsToListen,
 
 		eventHandler: eventHandler,
 	}
 }
 
-// SyncBlocks makes request to tendermint, create and dispatch notifications
-func (manager *SyncManager) SyncBlocks(latestHeight int64) error {
+// SyncBlocks makes request to tendermint, create and dispatch notifications. It stops before
+// handling the next block once the context is done, such that every handled block is either fully
+// committed or rolled back.
+func (manager *SyncManager) SyncBlocks(ctx context.Context, latestHeight int64) error {
 	maybeLastIndexedHeight, err := manager.eventHandler.GetLastHandledEventHeight()
 	if err != nil {
 		return fmt.Errorf("error running GetLastIndexedBlockHeight %v", err)
@@ -85,18 +112,25 @@ func (manager *SyncManager) SyncBlocks(latestHeight int64) error {
 
 	manager.logger.Infof("going to synchronized blocks from %d to %d", currentIndexingHeight, latestHeight)
 	for currentIndexingHeight < latestHeight {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		windowStartTime := time.Now()
 		blocksCommands, syncedHeight, err := manager.windowSyncStrategy.Sync(
 			currentIndexingHeight, latestHeight, manager.syncBlockWorker,
 		)
 		if err != nil {
 			return fmt.Errorf("error when synchronizing block with window strategy: %v", err)
 		}
+		manager.metrics.ObserveSyncWindow(manager.metricsId, len(blocksCommands), time.Since(windowStartTime))
 
-		if err != nil {
-			return fmt.Errorf("error beginning transaction: %v", err)
-		}
 		for i, commands := range blocksCommands {
 			blockHeight := currentIndexingHeight + int64(i)
+			if ctx.Err() != nil {
+				manager.logger.Infof("stopped synchronizing blocks before block height %d", blockHeight)
+				return ctx.Err()
+			}
 
 			events := make([]event.Event, 0, len(commands))
 			for _, command := range commands {
@@ -107,7 +141,7 @@ func (manager *SyncManager) SyncBlocks(latestHeight int64) error {
 				events = append(events, event)
 			}
 
-			err := manager.eventHandler.HandleEvents(blockHeight, events)
+			err := manager.eventHandler.HandleEvents(ctx, blockHeight, events)
 			if err != nil {
 				return fmt.Errorf("error handling events: %v", err)
 			}
@@ -130,8 +164,13 @@ func (manager *SyncManager) syncBlockWorker(blockHeight int64) ([]command_entity
 	logger.Info("synchronizing block")
 
 	if blockHeight == int64(0) {
+		if !parser.ShouldParse(parser.PARSER_GENESIS, manager.maybeEventsToListen) {
+			return []command_entity.Command{}, nil
+		}
+
 		genesis, err := manager.client.Genesis()
 		if err != nil {
+			manager.metrics.IncRPCError("genesis")
 			return nil, fmt.Errorf("error requesting chain genesis: %v", err)
 		}
 
@@ -141,19 +180,22 @@ func (manager *SyncManager) syncBlockWorker(blockHeight int64) ([]command_entity
 	// Request tendermint RPC
 	block, rawBlock, err := manager.client.Block(blockHeight)
 	if err != nil {
+		manager.metrics.IncRPCError("block")
 		return nil, fmt.Errorf("error requesting chain block at height %d: %v", blockHeight, err)
 	}
 
 	blockResults, err := manager.client.BlockResults(blockHeight)
 	if err != nil {
+		manager.metrics.IncRPCError("block_results")
 		return nil, fmt.Errorf("error requesting chain block_results at height %d: %v", blockHeight, err)
 	}
 
-	commands, err := parser.ParseBlockToCommands(
+	commands, err := parser.ParseBlockToCommandsForEvents(
 		manager.txDecoder,
 		block,
 		rawBlock,
 		blockResults,
+		manager.maybeEventsToListen,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing block data to commands %v", err)
@@ -162,17 +204,25 @@ func (manager *SyncManager) syncBlockWorker(blockHeight int64) ([]command_entity
 	return commands, nil
 }
 
-// Run starts the polling service for blocks
-func (manager *SyncManager) Run() error {
-	tracker := chainfeed.NewBlockHeightTracker(manager.logger, manager.client)
+// Run starts the polling service for blocks until the context is done
+func (manager *SyncManager) Run(ctx context.Context) error {
+	tracker := chainfeed.NewBlockHeightTracker(ctx, manager.logger, manager.client)
 	manager.latestBlockHeight = tracker.GetLatestBlockHeight()
+	if manager.latestBlockHeight != nil {
+		manager.metrics.SetChainHeadHeight(manager.metricsId, *manager.latestBlockHeight)
+	}
 	blockHeightCh := make(chan int64, 1)
 	go func() {
 		for {
-			latestBlockHeight := <-blockHeightCh
-			manager.latestBlockHeight = &latestBlockHeight
-			manager.drainShouldSyncCh()
-			manager.shouldSyncCh <- true
+			select {
+			case <-ctx.Done():
+				return
+			case latestBlockHeight := <-blockHeightCh:
+				manager.latestBlockHeight = &latestBlockHeight
+				manager.metrics.SetChainHeadHeight(manager.metricsId, latestBlockHeight)
+				manager.drainShouldSyncCh()
+				manager.shouldSyncCh <- true
+			}
 		}
 	}()
 	tracker.Subscribe(blockHeightCh)
@@ -181,12 +231,15 @@ func (manager *SyncManager) Run() error {
 		if manager.latestBlockHeight == nil {
 			manager.logger.Info("the chain has no block yet")
 		} else {
-			if err := manager.SyncBlocks(*manager.latestBlockHeight); err != nil {
+			if err := manager.SyncBlocks(ctx, *manager.latestBlockHeight); err != nil && ctx.Err() == nil {
 				manager.logger.Errorf("error synchronizing blocks to latest height %d: %v", *manager.latestBlockHeight, err)
 			}
 		}
 
 		select {
+		case <-ctx.Done():
+			manager.logger.Info("sync manager stopped")
+			return nil
 		case <-manager.shouldSyncCh:
 		case <-time.After(manager.pollingInterval):
 		}
@@ -3,10 +3,16 @@ package main
 import (
 	"fmt"
 	"os"
+
+	projection_interface "github.com/crypto-com/chain-indexing/appinterface/projection"
+	"github.com/crypto-com/chain-indexing/bootstrap"
 )
 
 func main() {
-	if err := CliApp(os.Args); err != nil {
+	projectionRegistry := projection_interface.NewRegistry()
+	projection_interface.RegisterProjections(projectionRegistry)
+
+	if err := bootstrap.CliApp(os.Args, projectionRegistry); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
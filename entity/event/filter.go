@@ -0,0 +1,30 @@
+package event
+
+// Filter selects events by name and an inclusive version range
+type Filter struct {
+	Name       string
+	MinVersion int
+	// nil means no upper bound on version
+	MaybeMaxVersion *int
+}
+
+// Matches returns true when the event name and version satisfy the filter
+func (filter *Filter) Matches(event Event) bool {
+	if event.Name() != filter.Name {
+		return false
+	}
+	if filter.MinVersion <= 0 && filter.MaybeMaxVersion == nil {
+		// all versions
+		return true
+	}
+
+	version := event.Version()
+	if version < filter.MinVersion {
+		return false
+	}
+	if filter.MaybeMaxVersion != nil && version > *filter.MaybeMaxVersion {
+		return false
+	}
+
+	return true
+}
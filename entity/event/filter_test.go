@@ -0,0 +1,49 @@
+package event_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/entity/event"
+	. "github.com/crypto-com/chain-indexing/entity/event/test"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+var _ = Describe("Filter", func() {
+	Describe("Matches", func() {
+		newEvent := func(name string, version int) event.Event {
+			mockEvent := NewMockEvent()
+			mockEvent.On("Name").Return(name)
+			mockEvent.On("Version").Return(version)
+
+			return mockEvent
+		}
+
+		It("should return false when the event name is different", func() {
+			filter := event.Filter{Name: "ANY_EVENT"}
+
+			Expect(filter.Matches(newEvent("ANY_OTHER_EVENT", 1))).To(BeFalse())
+		})
+
+		It("should match all versions of the event when no version range is specified", func() {
+			filter := event.Filter{Name: "ANY_EVENT"}
+
+			Expect(filter.Matches(newEvent("ANY_EVENT", 0))).To(BeTrue())
+			Expect(filter.Matches(newEvent("ANY_EVENT", 1))).To(BeTrue())
+			Expect(filter.Matches(newEvent("ANY_EVENT", 100))).To(BeTrue())
+		})
+
+		It("should match only versions within the inclusive range", func() {
+			filter := event.Filter{
+				Name:            "ANY_EVENT",
+				MinVersion:      2,
+				MaybeMaxVersion: primptr.Int(3),
+			}
+
+			Expect(filter.Matches(newEvent("ANY_EVENT", 1))).To(BeFalse())
+			Expect(filter.Matches(newEvent("ANY_EVENT", 2))).To(BeTrue())
+			Expect(filter.Matches(newEvent("ANY_EVENT", 3))).To(BeTrue())
+			Expect(filter.Matches(newEvent("ANY_EVENT", 4))).To(BeFalse())
+		})
+	})
+})
@@ -11,3 +11,12 @@ type Store interface {
 	// InsertAll insert all events into store. It will rollback when the insert fails at any point.
 	InsertAll(evt []Event) error
 }
+
+// FilterableStore is a Store able to filter events on query such that unmatched events are never
+// decoded
+type FilterableStore interface {
+	Store
+
+	// GetAllByHeightWithFilters returns all events at the height matching any of the filters
+	GetAllByHeightWithFilters(height int64, filters []Filter) ([]Event, error)
+}
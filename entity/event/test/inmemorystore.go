@@ -0,0 +1,99 @@
+package test
+
+import (
+	"sort"
+	"sync"
+
+	entity_event "github.com/crypto-com/chain-indexing/entity/event"
+)
+
+var _ entity_event.FilterableStore = &InMemoryEventStore{}
+
+// InMemoryEventStore keeps events in memory grouped by height. It is suitable for replaying events
+// to projections in tests.
+type InMemoryEventStore struct {
+	mutex sync.RWMutex
+
+	eventsByHeight map[int64][]entity_event.Event
+}
+
+func NewInMemoryEventStore() *InMemoryEventStore {
+	return &InMemoryEventStore{
+		eventsByHeight: make(map[int64][]entity_event.Event),
+	}
+}
+
+func (store *InMemoryEventStore) GetLatestHeight() (*int64, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	var maybeLatestHeight *int64
+	for height := range store.eventsByHeight {
+		if maybeLatestHeight == nil || height > *maybeLatestHeight {
+			latestHeight := height
+			maybeLatestHeight = &latestHeight
+		}
+	}
+	return maybeLatestHeight, nil
+}
+
+// Heights returns all the heights with events in ascending order. Heights are sparse when the events
+// come from non-consecutive blocks.
+func (store *InMemoryEventStore) Heights() []int64 {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	heights := make([]int64, 0, len(store.eventsByHeight))
+	for height := range store.eventsByHeight {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	return heights
+}
+
+func (store *InMemoryEventStore) GetAllByHeight(height int64) ([]entity_event.Event, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	events := make([]entity_event.Event, len(store.eventsByHeight[height]))
+	copy(events, store.eventsByHeight[height])
+	return events, nil
+}
+
+func (store *InMemoryEventStore) GetAllByHeightWithFilters(
+	height int64, filters []entity_event.Filter,
+) ([]entity_event.Event, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	events := make([]entity_event.Event, 0)
+	for _, event := range store.eventsByHeight[height] {
+		for i := range filters {
+			if filters[i].Matches(event) {
+				events = append(events, event)
+				break
+			}
+		}
+	}
+	return events, nil
+}
+
+func (store *InMemoryEventStore) Insert(evt entity_event.Event) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.eventsByHeight[evt.Height()] = append(store.eventsByHeight[evt.Height()], evt)
+	return nil
+}
+
+func (store *InMemoryEventStore) InsertAll(evts []entity_event.Event) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, evt := range evts {
+		store.eventsByHeight[evt.Height()] = append(store.eventsByHeight[evt.Height()], evt)
+	}
+	return nil
+}
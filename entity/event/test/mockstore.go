@@ -36,3 +36,20 @@ func (manager *MockEventStore) InsertAll(evts []entity_event.Event) error {
 
 	return mockArgs.Error(0)
 }
+
+type MockFilterableEventStore struct {
+	MockEventStore
+}
+
+func NewMockFilterableEventStore() *MockFilterableEventStore {
+	return &MockFilterableEventStore{}
+}
+
+func (manager *MockFilterableEventStore) GetAllByHeightWithFilters(
+	height int64,
+	filters []entity_event.Filter,
+) ([]entity_event.Event, error) {
+	mockArgs := manager.Called(height, filters)
+
+	return mockArgs.Get(0).([]entity_event.Event), mockArgs.Error(1)
+}
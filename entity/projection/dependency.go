@@ -0,0 +1,158 @@
+package projection
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DependentProjection is a projection which depends on the outcome of other projections. A dependent
+// projection will never handle events at a height before all its dependencies have handled it.
+type DependentProjection interface {
+	Projection
+
+	// Returns the ids of the projections this projection depends on.
+	GetDependencies() []string
+}
+
+// DependenciesOf returns the ids of the projections the provided projection depends on. Empty if
+// the projection does not implement DependentProjection.
+func DependenciesOf(projection Projection) []string {
+	dependentProjection, ok := projection.(DependentProjection)
+	if !ok {
+		return []string{}
+	}
+
+	return dependentProjection.GetDependencies()
+}
+
+// DependencyGraph keeps track of the dependencies between projections and answers whether a
+// projection can proceed to handle a height.
+type DependencyGraph struct {
+	projections map[string]Projection
+	order       []string
+
+	// cache of the last handled event height of projections to reduce the number of lookups
+	handledHeights map[string]int64
+	rwMutex        sync.RWMutex
+}
+
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		projections: make(map[string]Projection),
+		order:       make([]string, 0),
+
+		handledHeights: make(map[string]int64),
+	}
+}
+
+func (graph *DependencyGraph) Add(projection Projection) error {
+	if _, exist := graph.projections[projection.Id()]; exist {
+		return fmt.Errorf("projection `%s` already added to dependency graph", projection.Id())
+	}
+
+	graph.projections[projection.Id()] = projection
+	graph.order = append(graph.order, projection.Id())
+	return nil
+}
+
+// Validate checks all dependencies are added to the graph and there is no circular dependency.
+func (graph *DependencyGraph) Validate() error {
+	if _, err := graph.TopologicalOrder(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TopologicalOrder returns the projections ordered such that every projection comes after all its
+// dependencies. Projections without dependency relationship keep their added order.
+func (graph *DependencyGraph) TopologicalOrder() ([]Projection, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make(map[string]int)
+	result := make([]Projection, 0, len(graph.order))
+
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		switch states[id] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular projection dependency: %v", append(path, id))
+		}
+
+		states[id] = visiting
+		for _, dependency := range DependenciesOf(graph.projections[id]) {
+			if _, exist := graph.projections[dependency]; !exist {
+				return fmt.Errorf("projection `%s` depends on unregistered projection `%s`", id, dependency)
+			}
+			if err := visit(dependency, append(path, id)); err != nil {
+				return err
+			}
+		}
+		states[id] = visited
+		result = append(result, graph.projections[id])
+
+		return nil
+	}
+
+	for _, id := range graph.order {
+		if err := visit(id, []string{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// AreDependenciesHandled returns true when all dependencies of the projection have handled events up
+// to the provided height.
+func (graph *DependencyGraph) AreDependenciesHandled(projectionId string, height int64) (bool, error) {
+	projection, exist := graph.projections[projectionId]
+	if !exist {
+		return false, fmt.Errorf("projection `%s` not found in dependency graph", projectionId)
+	}
+
+	for _, dependency := range DependenciesOf(projection) {
+		handled, err := graph.isHeightHandled(dependency, height)
+		if err != nil {
+			return false, fmt.Errorf("error checking dependency `%s` last handled event height: %v", dependency, err)
+		}
+		if !handled {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (graph *DependencyGraph) isHeightHandled(projectionId string, height int64) (bool, error) {
+	graph.rwMutex.RLock()
+	cachedHeight, cached := graph.handledHeights[projectionId]
+	graph.rwMutex.RUnlock()
+	if cached && cachedHeight >= height {
+		return true, nil
+	}
+
+	projection, exist := graph.projections[projectionId]
+	if !exist {
+		return false, fmt.Errorf("projection `%s` not found in dependency graph", projectionId)
+	}
+	lastHandledEventHeight, err := projection.GetLastHandledEventHeight()
+	if err != nil {
+		return false, err
+	}
+	if lastHandledEventHeight == nil {
+		return false, nil
+	}
+
+	graph.rwMutex.Lock()
+	graph.handledHeights[projectionId] = *lastHandledEventHeight
+	graph.rwMutex.Unlock()
+
+	return *lastHandledEventHeight >= height, nil
+}
@@ -0,0 +1,77 @@
+package projection_test
+
+import (
+	. "github.com/crypto-com/chain-indexing/entity/projection/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/entity/projection"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+var _ = Describe("DependencyGraph", func() {
+	Describe("Validate", func() {
+		It("should return Error when the dependency is not added", func() {
+			graph := projection.NewDependencyGraph()
+
+			dependentProjection := NewMockDependentProjection()
+			dependentProjection.On("Id").Return("Dependent")
+			dependentProjection.On("GetDependencies").Return([]string{"Missing"})
+			Expect(graph.Add(dependentProjection)).To(BeNil())
+
+			Expect(graph.Validate()).To(MatchError("projection `Dependent` depends on unregistered projection `Missing`"))
+		})
+
+		It("should return Error when there is circular dependency", func() {
+			graph := projection.NewDependencyGraph()
+
+			anyProjection := NewMockDependentProjection()
+			anyProjection.On("Id").Return("Any")
+			anyProjection.On("GetDependencies").Return([]string{"AnyOther"})
+			anyOtherProjection := NewMockDependentProjection()
+			anyOtherProjection.On("Id").Return("AnyOther")
+			anyOtherProjection.On("GetDependencies").Return([]string{"Any"})
+			Expect(graph.Add(anyProjection)).To(BeNil())
+			Expect(graph.Add(anyOtherProjection)).To(BeNil())
+
+			Expect(graph.Validate()).To(MatchError("circular projection dependency: [Any AnyOther Any]"))
+		})
+	})
+
+	Describe("TopologicalOrder", func() {
+		It("should order projection after its dependencies", func() {
+			graph := projection.NewDependencyGraph()
+
+			dependentProjection := NewMockDependentProjection()
+			dependentProjection.On("Id").Return("Dependent")
+			dependentProjection.On("GetDependencies").Return([]string{"Dependency"})
+			dependencyProjection := NewMockProjection()
+			dependencyProjection.On("Id").Return("Dependency")
+			Expect(graph.Add(dependentProjection)).To(BeNil())
+			Expect(graph.Add(dependencyProjection)).To(BeNil())
+
+			ordered, err := graph.TopologicalOrder()
+			Expect(err).To(BeNil())
+			Expect(ordered[0].Id()).To(Equal("Dependency"))
+			Expect(ordered[1].Id()).To(Equal("Dependent"))
+		})
+	})
+
+	Describe("AreDependenciesHandled", func() {
+		It("should return false when the dependency has not handled the height", func() {
+			graph := projection.NewDependencyGraph()
+
+			dependentProjection := NewMockDependentProjection()
+			dependentProjection.On("Id").Return("Dependent")
+			dependentProjection.On("GetDependencies").Return([]string{"Dependency"})
+			dependencyProjection := NewMockProjection()
+			dependencyProjection.On("Id").Return("Dependency")
+			dependencyProjection.On("GetLastHandledEventHeight").Return(primptr.Int64(1), nil)
+			Expect(graph.Add(dependentProjection)).To(BeNil())
+			Expect(graph.Add(dependencyProjection)).To(BeNil())
+
+			Expect(graph.AreDependenciesHandled("Dependent", int64(1))).To(BeTrue())
+			Expect(graph.AreDependenciesHandled("Dependent", int64(2))).To(BeFalse())
+		})
+	})
+})
@@ -1,19 +1,26 @@
 package projection
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	entity_event "github.com/crypto-com/chain-indexing/entity/event"
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/metrics"
 )
 
 // StoreBasedManager is a projection manager relies on replaying events from EventStore
 type StoreBasedManager struct {
 	logger     applogger.Logger
 	eventStore entity_event.Store
+	metrics    metrics.Metrics
 
-	projections []Projection
+	projections     []Projection
+	dependencyGraph *DependencyGraph
+
+	runnersWaitGroup sync.WaitGroup
 }
 
 func NewStoreBasedManager(logger applogger.Logger, eventStore entity_event.Store) *StoreBasedManager {
@@ -22,15 +29,27 @@ func NewStoreBasedManager(logger applogger.Logger, eventStore entity_event.Store
 			"module": "projectionManager",
 		}),
 		eventStore: eventStore,
+		metrics:    metrics.NewNoopMetrics(),
 
-		projections: make([]Projection, 0),
+		projections:     make([]Projection, 0),
+		dependencyGraph: NewDependencyGraph(),
 	}
 }
 
+// WithMetrics records the projection progress and event handling latency to the metrics
+func (manager *StoreBasedManager) WithMetrics(metrics metrics.Metrics) *StoreBasedManager {
+	manager.metrics = metrics
+
+	return manager
+}
+
 func (manager *StoreBasedManager) RegisterProjection(projection Projection) error {
 	if manager.IsProjectionRegistered(projection) {
 		return fmt.Errorf("projection `%s` already registered", projection.Id())
 	}
+	if err := manager.dependencyGraph.Add(projection); err != nil {
+		return fmt.Errorf("error adding projection `%s` to dependency graph: %v", projection.Id(), err)
+	}
 	manager.projections = append(manager.projections, projection)
 	return nil
 }
@@ -44,21 +63,44 @@ func (manager *StoreBasedManager) IsProjectionRegistered(projection Projection)
 	return false
 }
 
-// Starts projectionManager by running all registered projection.
-func (manager *StoreBasedManager) RunInBackground() {
+// Starts projectionManager by running all registered projection until the context is done. Returns
+// error when the registered projections have missing or circular dependencies.
+func (manager *StoreBasedManager) RunInBackground(ctx context.Context) error {
+	if err := manager.dependencyGraph.Validate(); err != nil {
+		return fmt.Errorf("error validating projection dependencies: %v", err)
+	}
+
 	for _, projection := range manager.projections {
-		go manager.projectionRunner(projection)
+		manager.runnersWaitGroup.Add(1)
+		go func(projection Projection) {
+			defer manager.runnersWaitGroup.Done()
+			manager.projectionRunner(ctx, projection)
+		}(projection)
 	}
+
+	return nil
+}
+
+// Wait blocks until all projection runners have stopped. A runner stops after the events at the
+// height it is handling are committed or rolled back.
+func (manager *StoreBasedManager) Wait() {
+	manager.runnersWaitGroup.Wait()
 }
 
-func (manager *StoreBasedManager) projectionRunner(projection Projection) {
-	eventsToListen := projection.GetEventsToListen()
+func (manager *StoreBasedManager) projectionRunner(ctx context.Context, projection Projection) {
+	subscriptions := SubscriptionsOf(projection)
+	filters := FiltersOf(subscriptions)
 	logger := manager.logger.WithFields(applogger.LogFields{
 		"projection": projection.Id(),
 	})
 
+	// Push down the filters to the event store when supported such that unmatched events are never
+	// decoded
+	maybeFilterableStore, _ := manager.eventStore.(entity_event.FilterableStore)
+
 	logger.WithFields(applogger.LogFields{
-		"eventsToListen": eventsToListen,
+		"eventsToListen": EventsToListen([]Projection{projection}),
+		"dependencies":   DependenciesOf(projection),
 	}).Infof("projection start running")
 
 	var lastHandledEventHeight *int64
@@ -70,7 +112,10 @@ func (manager *StoreBasedManager) projectionRunner(projection Projection) {
 		}
 
 		logger.Infof("error getting last handled event height from projection")
-		<-waitToRetry(5 * time.Second)
+		if !waitToRetry(ctx, 5*time.Second) {
+			logger.Infof("projection stopped")
+			return
+		}
 	}
 
 	var nextEventHeight int64
@@ -78,69 +123,91 @@ func (manager *StoreBasedManager) projectionRunner(projection Projection) {
 		nextEventHeight = 0
 	} else {
 		nextEventHeight = *lastHandledEventHeight + 1
+		manager.metrics.SetProjectionLastHandledHeight(projection.Id(), *lastHandledEventHeight)
 	}
 
 	for {
 		latestEventHeight, _ := manager.eventStore.GetLatestHeight()
 		if latestEventHeight == nil {
 			logger.Debugf("no event in in the system yet")
-			<-waitToRetry(5 * time.Second)
+			if !waitToRetry(ctx, 5*time.Second) {
+				logger.Infof("projection stopped")
+				return
+			}
 			continue
 		}
 		for nextEventHeight <= *latestEventHeight {
 			var err error
 
+			if ctx.Err() != nil {
+				logger.Infof("projection stopped")
+				return
+			}
+
 			eventLogger := logger.WithFields(applogger.LogFields{
 				"height": nextEventHeight,
 			})
 
+			// Dependent projection should never get ahead of its dependencies
+			var dependenciesHandled bool
+			if dependenciesHandled, err = manager.dependencyGraph.AreDependenciesHandled(
+				projection.Id(), nextEventHeight,
+			); err != nil {
+				eventLogger.Errorf("error checking projection dependencies: %v", err)
+				waitToRetry(ctx, time.Second)
+				continue
+			}
+			if !dependenciesHandled {
+				eventLogger.Debugf("waiting for dependencies to handle the height")
+				waitToRetry(ctx, time.Second)
+				continue
+			}
+
 			var eventsAtHeight []entity_event.Event
-			if eventsAtHeight, err = manager.eventStore.GetAllByHeight(nextEventHeight); err != nil {
+			if maybeFilterableStore != nil {
+				eventsAtHeight, err = maybeFilterableStore.GetAllByHeightWithFilters(nextEventHeight, filters)
+			} else {
+				eventsAtHeight, err = manager.eventStore.GetAllByHeight(nextEventHeight)
+			}
+			if err != nil {
 				eventLogger.Errorf("error getting all events by height: %v", err)
-				<-waitToRetry(time.Second)
+				waitToRetry(ctx, time.Second)
 				continue
 			}
 
-			var events = make([]entity_event.Event, 0)
-			for _, event := range eventsAtHeight {
-				if !isListeningEvent(event, eventsToListen) {
-					//eventLogger.WithFields(applogger.LogFields{
-					//	"eventName": event.Name(),
-					//}).Debugf("skipping because event is not one of the listening events")
-					continue
-				}
-				events = append(events, event)
-			}
+			// Predicates can only be evaluated on decoded events
+			events := FilterSubscribedEvents(eventsAtHeight, subscriptions)
 
 			eventLogger = eventLogger.WithFields(applogger.LogFields{
 				"eventCount": len(events),
 			})
+			startTime := time.Now()
 			if err = projection.HandleEvents(nextEventHeight, events); err != nil {
 				eventLogger.WithFields(applogger.LogFields{
 					"events": events,
 				}).Errorf("error handling events: %v", err)
-				<-waitToRetry(time.Second)
+				waitToRetry(ctx, time.Second)
 				continue
 			}
+			manager.metrics.ObserveProjectionHandleEvents(projection.Id(), len(events), time.Since(startTime))
+			manager.metrics.SetProjectionLastHandledHeight(projection.Id(), nextEventHeight)
 
 			eventLogger.Infof("successfully handled events")
 			nextEventHeight += 1
 		}
-		<-waitToRetry(5 * time.Second)
-	}
-}
-
-func isListeningEvent(event entity_event.Event, eventsToListen []string) bool {
-	targetEventName := event.Name()
-	for _, eventName := range eventsToListen {
-		if targetEventName == eventName {
-			return true
+		if !waitToRetry(ctx, 5*time.Second) {
+			logger.Infof("projection stopped")
+			return
 		}
 	}
-
-	return false
 }
 
-func waitToRetry(wait time.Duration) <-chan time.Time {
-	return time.After(wait)
+// waitToRetry waits for the duration. Returns false when the context is done before that.
+func waitToRetry(ctx context.Context, wait time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(wait):
+		return true
+	}
 }
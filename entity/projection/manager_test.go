@@ -1,6 +1,8 @@
 package projection_test
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	. "github.com/crypto-com/chain-indexing/entity/event/test"
@@ -74,7 +76,7 @@ var _ = Describe("StoreBasedManager", func() {
 			})).Once().Return(nil)
 
 			// RunInBackground the manager
-			manager.RunInBackground()
+			manager.RunInBackground(context.Background())
 			// Since manager create goroutines for Projection, we have to give up the CPU for
 			// events channel to happen
 			<-time.After(time.Second)
@@ -127,7 +129,7 @@ var _ = Describe("StoreBasedManager", func() {
 			})).Once().Return(nil)
 
 			// RunInBackground the manager
-			manager.RunInBackground()
+			manager.RunInBackground(context.Background())
 			// Since manager create goroutines for Projection, we have to give up the CPU for
 			// events channel to happen
 			<-time.After(time.Second)
@@ -188,7 +190,7 @@ var _ = Describe("StoreBasedManager", func() {
 			})).Once().Return(nil)
 
 			// RunInBackground the manager
-			manager.RunInBackground()
+			manager.RunInBackground(context.Background())
 			// Since manager create goroutines for Projection, we have to give up the CPU for
 			// events channel to happen
 			<-time.After(time.Second)
@@ -233,7 +235,7 @@ var _ = Describe("StoreBasedManager", func() {
 			})).Once().Return(nil)
 
 			// RunInBackground the manager
-			manager.RunInBackground()
+			manager.RunInBackground(context.Background())
 			// Since manager create goroutines for Projection, we have to give up the CPU for
 			// events channel to happen
 			<-time.After(time.Second)
@@ -241,6 +243,181 @@ var _ = Describe("StoreBasedManager", func() {
 			// Assert the projection expectations. i.e. events are handled
 			mockProjection.AssertExpectations(GinkgoT())
 		})
+
+		It("should not let dependent projection get ahead of its dependencies", func() {
+			// Setup
+			mockEventStore := NewMockEventStore()
+			manager := projection.NewStoreBasedManager(NewFakeLogger(), mockEventStore)
+			dependencyProjection := NewMockProjection()
+			dependentProjection := NewMockDependentProjection()
+
+			anyEvent := newAnyEvent()
+
+			// Projection setup
+			dependencyProjection.On("Id").Return("DEPENDENCY_PROJECTION_ID")
+			dependencyProjection.On("GetEventsToListen").Return([]string{anyEvent.Name()})
+			// dependency projection is stuck at height 1
+			dependencyProjection.On("GetLastHandledEventHeight").Return(
+				primptr.Int64(1), nil,
+			)
+			dependencyProjection.On("HandleEvents", int64(2), mock.Anything).Return(errors.New("any error"))
+
+			dependentProjection.On("Id").Return("DEPENDENT_PROJECTION_ID")
+			dependentProjection.On("GetEventsToListen").Return([]string{anyEvent.Name()})
+			dependentProjection.On("GetDependencies").Return([]string{"DEPENDENCY_PROJECTION_ID"})
+			dependentProjection.On("GetLastHandledEventHeight").Return(
+				primptr.Int64(0), nil,
+			)
+
+			Expect(manager.RegisterProjection(dependentProjection)).To(BeNil())
+			Expect(manager.RegisterProjection(dependencyProjection)).To(BeNil())
+
+			// Produce event to the event store
+			mockEventStore.On("GetAllByHeight", int64(1)).Return([]entity_event.Event{anyEvent}, nil)
+			mockEventStore.On("GetAllByHeight", int64(2)).Return([]entity_event.Event{anyEvent}, nil)
+			mockEventStore.On("GetLatestHeight").Return(primptr.Int64(int64(2)), nil)
+
+			// Define the assertion expectations
+			dependentProjection.On("HandleEvents", int64(1), mock.Anything).Once().Return(nil)
+
+			Expect(manager.RunInBackground(context.Background())).To(BeNil())
+			<-time.After(time.Second)
+
+			dependentProjection.AssertExpectations(GinkgoT())
+			dependentProjection.AssertNotCalled(GinkgoT(), "HandleEvents", int64(2), mock.Anything)
+		})
+
+		It("should push down subscription filters to filterable event store", func() {
+			// Setup
+			mockEventStore := NewMockFilterableEventStore()
+			manager := projection.NewStoreBasedManager(NewFakeLogger(), mockEventStore)
+			mockProjection := NewMockSubscribingProjection()
+
+			anyEvent := NewMockEvent()
+			anyEvent.On("Name").Return("ANY_EVENT")
+			anyEvent.On("Version").Return(2)
+			anyEvent.On("Height").Return(int64(1))
+
+			// Projection setup
+			subscriptions := []projection.EventSubscription{
+				projection.SubscribeEvent("ANY_EVENT").WithVersions(2, nil),
+			}
+			mockProjection.On("Id").Return("ANY_PROJECTION_ID")
+			mockProjection.On("GetEventSubscriptions").Return(subscriptions)
+			mockProjection.On("GetLastHandledEventHeight").Return(
+				primptr.Int64(0), nil,
+			)
+			Expect(manager.RegisterProjection(mockProjection)).To(BeNil())
+
+			// Produce event to the event store
+			nextHeight := int64(1)
+			mockEventStore.On(
+				"GetAllByHeightWithFilters", nextHeight, []entity_event.Filter{subscriptions[0].Filter},
+			).Return([]entity_event.Event{anyEvent}, nil)
+			mockEventStore.On("GetLatestHeight").Return(primptr.Int64(nextHeight), nil)
+
+			// Define the assertion expectations
+			mockProjection.On("HandleEvents", nextHeight, mock.MatchedBy(func(events interface{}) bool {
+				typedEvents, _ := events.([]entity_event.Event)
+				return len(typedEvents) == 1 && typedEvents[0].Name() == "ANY_EVENT"
+			})).Once().Return(nil)
+
+			Expect(manager.RunInBackground(context.Background())).To(BeNil())
+			<-time.After(time.Second)
+
+			mockProjection.AssertExpectations(GinkgoT())
+			mockEventStore.AssertNotCalled(GinkgoT(), "GetAllByHeight", mock.Anything)
+		})
+
+		It("should pass only events satisfying the subscription predicate to projection", func() {
+			// Setup
+			mockEventStore := NewMockEventStore()
+			manager := projection.NewStoreBasedManager(NewFakeLogger(), mockEventStore)
+			mockProjection := NewMockSubscribingProjection()
+
+			anyEvent := NewMockEvent()
+			anyEvent.On("Name").Return("ANY_EVENT")
+			anyEvent.On("Version").Return(1)
+			anyEvent.On("UUID").Return("ANY_UUID")
+			anyOtherEvent := NewMockEvent()
+			anyOtherEvent.On("Name").Return("ANY_EVENT")
+			anyOtherEvent.On("Version").Return(1)
+			anyOtherEvent.On("UUID").Return("ANY_OTHER_UUID")
+
+			// Projection setup
+			mockProjection.On("Id").Return("ANY_PROJECTION_ID")
+			mockProjection.On("GetEventSubscriptions").Return([]projection.EventSubscription{
+				projection.SubscribeEvent("ANY_EVENT").Where(func(event entity_event.Event) bool {
+					return event.UUID() == "ANY_OTHER_UUID"
+				}),
+			})
+			mockProjection.On("GetLastHandledEventHeight").Return(
+				primptr.Int64(0), nil,
+			)
+			Expect(manager.RegisterProjection(mockProjection)).To(BeNil())
+
+			// Produce event to the event store
+			nextHeight := int64(1)
+			mockEventStore.On("GetAllByHeight", nextHeight).Return(
+				[]entity_event.Event{anyEvent, anyOtherEvent}, nil,
+			)
+			mockEventStore.On("GetLatestHeight").Return(primptr.Int64(nextHeight), nil)
+
+			// Define the assertion expectations
+			mockProjection.On("HandleEvents", nextHeight, mock.MatchedBy(func(events interface{}) bool {
+				typedEvents, _ := events.([]entity_event.Event)
+				return len(typedEvents) == 1 && typedEvents[0].UUID() == "ANY_OTHER_UUID"
+			})).Once().Return(nil)
+
+			Expect(manager.RunInBackground(context.Background())).To(BeNil())
+			<-time.After(time.Second)
+
+			mockProjection.AssertExpectations(GinkgoT())
+		})
+
+		It("should stop running projections when the context is cancelled", func() {
+			mockEventStore := NewMockEventStore()
+			manager := projection.NewStoreBasedManager(NewFakeLogger(), mockEventStore)
+			mockProjection := NewMockProjection()
+
+			mockProjection.On("Id").Return("ANY_PROJECTION_ID")
+			mockProjection.On("GetEventsToListen").Return([]string{"ANY_EVENT"})
+			mockProjection.On("GetLastHandledEventHeight").Return(
+				primptr.Int64(0), nil,
+			)
+			Expect(manager.RegisterProjection(mockProjection)).To(BeNil())
+
+			mockEventStore.On("GetLatestHeight").Return(primptr.Int64Nil(), nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			Expect(manager.RunInBackground(ctx)).To(BeNil())
+			<-time.After(100 * time.Millisecond)
+
+			stopped := make(chan bool)
+			go func() {
+				manager.Wait()
+				close(stopped)
+			}()
+			Consistently(stopped, 100*time.Millisecond).ShouldNot(BeClosed())
+
+			cancel()
+			Eventually(stopped, time.Second).Should(BeClosed())
+			mockProjection.AssertNotCalled(GinkgoT(), "HandleEvents", mock.Anything, mock.Anything)
+		})
+
+		It("should return Error on run when projection dependency is not registered", func() {
+			manager := projection.NewStoreBasedManager(NewFakeLogger(), NewFakeEventStore())
+
+			dependentProjection := NewMockDependentProjection()
+			dependentProjection.On("Id").Return("DEPENDENT_PROJECTION_ID")
+			dependentProjection.On("GetDependencies").Return([]string{"MISSING_PROJECTION_ID"})
+			Expect(manager.RegisterProjection(dependentProjection)).To(BeNil())
+
+			Expect(manager.RunInBackground(context.Background())).To(MatchError(
+				"error validating projection dependencies: " +
+					"projection `DEPENDENT_PROJECTION_ID` depends on unregistered projection `MISSING_PROJECTION_ID`",
+			))
+		})
 	})
 })
 
@@ -9,7 +9,8 @@ type Projection interface {
 	// Unique identity of the projection.
 	Id() string
 
-	// Returns an array of event names to listen. All versions of the events will be listened.
+	// Returns an array of event names to listen. All versions of the events will be listened. Implement
+	// SubscribingProjection for version-aware or predicate-based subscriptions.
 	GetEventsToListen() []string
 
 	// Returns the last handled event height. nil mean no event has been handled so far.
@@ -26,3 +27,20 @@ type Projection interface {
 	// projection. It is also responsible to update the last handled event height.
 	HandleEvents(height int64, events []entity_event.Event) error
 }
+
+// EventsToListen returns the union of the event names subscribed by all the provided projections
+func EventsToListen(projections []Projection) []string {
+	eventsToListen := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, projection := range projections {
+		for _, subscription := range SubscriptionsOf(projection) {
+			if seen[subscription.Name] {
+				continue
+			}
+			seen[subscription.Name] = true
+			eventsToListen = append(eventsToListen, subscription.Name)
+		}
+	}
+
+	return eventsToListen
+}
@@ -0,0 +1,116 @@
+package projection
+
+import (
+	entity_event "github.com/crypto-com/chain-indexing/entity/event"
+)
+
+// SubscribingProjection is a projection with fine-grained event subscriptions. When implemented, the
+// subscriptions take precedence over GetEventsToListen() on deciding which events to pass to the
+// projection.
+type SubscribingProjection interface {
+	Projection
+
+	// Returns the event subscriptions. An event is passed to the projection when it matches any of
+	// them.
+	GetEventSubscriptions() []EventSubscription
+}
+
+// EventSubscription subscribes to events by name, version range and optionally a predicate on the
+// decoded event
+type EventSubscription struct {
+	entity_event.Filter
+
+	MaybePredicate EventPredicate
+}
+
+type EventPredicate = func(event entity_event.Event) bool
+
+// SubscribeEvent creates a subscription to all versions of the event
+func SubscribeEvent(eventName string) EventSubscription {
+	return EventSubscription{
+		Filter: entity_event.Filter{
+			Name:            eventName,
+			MinVersion:      0,
+			MaybeMaxVersion: nil,
+		},
+		MaybePredicate: nil,
+	}
+}
+
+// WithVersions limits the subscription to the inclusive version range. Pass nil maybeMaxVersion for
+// no upper bound.
+func (subscription EventSubscription) WithVersions(minVersion int, maybeMaxVersion *int) EventSubscription {
+	subscription.MinVersion = minVersion
+	subscription.MaybeMaxVersion = maybeMaxVersion
+
+	return subscription
+}
+
+// Where limits the subscription to decoded events satisfying the predicate
+func (subscription EventSubscription) Where(predicate EventPredicate) EventSubscription {
+	subscription.MaybePredicate = predicate
+
+	return subscription
+}
+
+// Matches returns true when the event satisfies the name, version range and predicate
+func (subscription *EventSubscription) Matches(event entity_event.Event) bool {
+	if !subscription.Filter.Matches(event) {
+		return false
+	}
+	if subscription.MaybePredicate != nil && !subscription.MaybePredicate(event) {
+		return false
+	}
+
+	return true
+}
+
+// SubscriptionsOf returns the event subscriptions of the projection. For projection not implementing
+// SubscribingProjection, all versions of the events from GetEventsToListen() are subscribed.
+func SubscriptionsOf(projection Projection) []EventSubscription {
+	if subscribingProjection, ok := projection.(SubscribingProjection); ok {
+		return subscribingProjection.GetEventSubscriptions()
+	}
+
+	eventsToListen := projection.GetEventsToListen()
+	subscriptions := make([]EventSubscription, 0, len(eventsToListen))
+	for _, eventName := range eventsToListen {
+		subscriptions = append(subscriptions, SubscribeEvent(eventName))
+	}
+	return subscriptions
+}
+
+// FiltersOf returns the event store filters of the subscriptions. Predicates are not included
+// because they can only be evaluated on decoded events.
+func FiltersOf(subscriptions []EventSubscription) []entity_event.Filter {
+	filters := make([]entity_event.Filter, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		filters = append(filters, subscription.Filter)
+	}
+
+	return filters
+}
+
+// FilterSubscribedEvents returns the events matching any of the subscriptions, in the original order
+func FilterSubscribedEvents(events []entity_event.Event, subscriptions []EventSubscription) []entity_event.Event {
+	filteredEvents := make([]entity_event.Event, 0)
+	for _, event := range events {
+		if !IsSubscribedEvent(event, subscriptions) {
+			continue
+		}
+		filteredEvents = append(filteredEvents, event)
+	}
+
+	return filteredEvents
+}
+
+// IsSubscribedEvent returns true when the event matches any of the subscriptions
+func IsSubscribedEvent(event entity_event.Event, subscriptions []EventSubscription) bool {
+	for i := range subscriptions {
+		if subscriptions[i].Matches(event) {
+			return true
+		}
+	}
+
+	return false
+}
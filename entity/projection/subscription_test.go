@@ -0,0 +1,87 @@
+package projection_test
+
+import (
+	. "github.com/crypto-com/chain-indexing/entity/event/test"
+	. "github.com/crypto-com/chain-indexing/entity/projection/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	entity_event "github.com/crypto-com/chain-indexing/entity/event"
+	"github.com/crypto-com/chain-indexing/entity/projection"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+var _ = Describe("EventSubscription", func() {
+	newVersionedEvent := func(name string, version int, height int64) entity_event.Event {
+		mockEvent := NewMockEvent()
+		mockEvent.On("Name").Return(name)
+		mockEvent.On("Version").Return(version)
+		mockEvent.On("Height").Return(height)
+
+		return mockEvent
+	}
+
+	Describe("Matches", func() {
+		It("should match event within the version range", func() {
+			subscription := projection.SubscribeEvent("ANY_EVENT").WithVersions(2, nil)
+
+			Expect(subscription.Matches(newVersionedEvent("ANY_EVENT", 1, 1))).To(BeFalse())
+			Expect(subscription.Matches(newVersionedEvent("ANY_EVENT", 2, 1))).To(BeTrue())
+			Expect(subscription.Matches(newVersionedEvent("ANY_OTHER_EVENT", 2, 1))).To(BeFalse())
+		})
+
+		It("should match event only when the predicate is satisfied", func() {
+			subscription := projection.SubscribeEvent("ANY_EVENT").Where(func(event entity_event.Event) bool {
+				return event.Height() > 10
+			})
+
+			Expect(subscription.Matches(newVersionedEvent("ANY_EVENT", 1, 10))).To(BeFalse())
+			Expect(subscription.Matches(newVersionedEvent("ANY_EVENT", 1, 11))).To(BeTrue())
+		})
+	})
+
+	Describe("SubscriptionsOf", func() {
+		It("should subscribe to all versions of the listening events for plain projection", func() {
+			mockProjection := NewMockProjection()
+			mockProjection.On("GetEventsToListen").Return([]string{"ANY_EVENT", "ANY_OTHER_EVENT"})
+
+			subscriptions := projection.SubscriptionsOf(mockProjection)
+
+			Expect(subscriptions).To(HaveLen(2))
+			Expect(subscriptions[0].Name).To(Equal("ANY_EVENT"))
+			Expect(subscriptions[0].MinVersion).To(Equal(0))
+			Expect(subscriptions[0].MaybeMaxVersion).To(BeNil())
+			Expect(subscriptions[1].Name).To(Equal("ANY_OTHER_EVENT"))
+		})
+
+		It("should return the subscriptions of SubscribingProjection", func() {
+			mockProjection := NewMockSubscribingProjection()
+			mockProjection.On("GetEventSubscriptions").Return([]projection.EventSubscription{
+				projection.SubscribeEvent("ANY_EVENT").WithVersions(1, primptr.Int(1)),
+			})
+
+			subscriptions := projection.SubscriptionsOf(mockProjection)
+
+			Expect(subscriptions).To(HaveLen(1))
+			Expect(subscriptions[0].Name).To(Equal("ANY_EVENT"))
+			Expect(*subscriptions[0].MaybeMaxVersion).To(Equal(1))
+			mockProjection.AssertNotCalled(GinkgoT(), "GetEventsToListen")
+		})
+	})
+
+	Describe("EventsToListen", func() {
+		It("should return the union of subscribed event names in order", func() {
+			anyProjection := NewMockProjection()
+			anyProjection.On("GetEventsToListen").Return([]string{"ANY_EVENT", "ANY_OTHER_EVENT"})
+			anyOtherProjection := NewMockSubscribingProjection()
+			anyOtherProjection.On("GetEventSubscriptions").Return([]projection.EventSubscription{
+				projection.SubscribeEvent("ANY_OTHER_EVENT").WithVersions(2, nil),
+				projection.SubscribeEvent("YET_ANOTHER_EVENT"),
+			})
+
+			Expect(projection.EventsToListen([]projection.Projection{
+				anyProjection, anyOtherProjection,
+			})).To(Equal([]string{"ANY_EVENT", "ANY_OTHER_EVENT", "YET_ANOTHER_EVENT"}))
+		})
+	})
+})
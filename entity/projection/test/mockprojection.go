@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	entity_event "github.com/crypto-com/chain-indexing/entity/event"
+	entity_projection "github.com/crypto-com/chain-indexing/entity/projection"
 )
 
 type MockProjection struct {
@@ -43,3 +44,31 @@ func (projection *MockProjection) HandleEvents(height int64, events []entity_eve
 
 	return mockArgs.Error(0)
 }
+
+type MockDependentProjection struct {
+	MockProjection
+}
+
+func NewMockDependentProjection() *MockDependentProjection {
+	return &MockDependentProjection{}
+}
+
+func (projection *MockDependentProjection) GetDependencies() []string {
+	mockArgs := projection.Called()
+
+	return mockArgs.Get(0).([]string)
+}
+
+type MockSubscribingProjection struct {
+	MockProjection
+}
+
+func NewMockSubscribingProjection() *MockSubscribingProjection {
+	return &MockSubscribingProjection{}
+}
+
+func (projection *MockSubscribingProjection) GetEventSubscriptions() []entity_projection.EventSubscription {
+	mockArgs := projection.Called()
+
+	return mockArgs.Get(0).([]entity_projection.EventSubscription)
+}
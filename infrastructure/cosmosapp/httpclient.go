@@ -181,6 +181,17 @@ func (client *HTTPClient) Delegation(
 	return nil, nil
 }
 
+// Ping checks the Cosmos app RPC is reachable by requesting the node info
+func (client *HTTPClient) Ping() error {
+	rawRespBody, err := client.request("cosmos/base/tendermint/v1beta1/node_info")
+	if err != nil {
+		return fmt.Errorf("error requesting node info: %v", err)
+	}
+	defer rawRespBody.Close()
+
+	return nil
+}
+
 func (client *HTTPClient) url(module string, method string) string {
 	return fmt.Sprintf("cosmos/%s/v1beta1/%s", module, method)
 }
@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -24,7 +25,11 @@ type BlockHeightTracker struct {
 	rwMutex           sync.RWMutex
 }
 
-func NewBlockHeightTracker(logger applogger.Logger, client tendermint.Client) *BlockHeightTracker {
+// NewBlockHeightTracker creates a tracker polling the chain latest block height until the context is
+// done
+func NewBlockHeightTracker(
+	ctx context.Context, logger applogger.Logger, client tendermint.Client,
+) *BlockHeightTracker {
 	tracker := &BlockHeightTracker{
 		logger: logger.WithFields(applogger.LogFields{
 			"module": "BlockHeightTracker",
@@ -38,17 +43,21 @@ func NewBlockHeightTracker(logger applogger.Logger, client tendermint.Client) *B
 		latestBlockHeight: primptr.Int64Nil(),
 	}
 
-	go tracker.Run()
+	go tracker.Run(ctx)
 
 	return tracker
 }
 
-func (tracker *BlockHeightTracker) Run() {
+func (tracker *BlockHeightTracker) Run(ctx context.Context) {
 	for {
 		height, err := tracker.client.LatestBlockHeight()
 		if err != nil {
 			tracker.logger.Errorf("error getting chain latest block height: %v", err)
-			<-time.After(1 * time.Second)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(1 * time.Second):
+			}
 			continue
 		}
 
@@ -65,7 +74,12 @@ func (tracker *BlockHeightTracker) Run() {
 		tracker.rwMutex.Unlock()
 
 		tracker.logger.Infof("updated chain latest block height: %d", height)
-		<-time.After(tracker.pollingInterval)
+		select {
+		case <-ctx.Done():
+			tracker.logger.Info("block height tracker stopped")
+			return
+		case <-time.After(tracker.pollingInterval):
+		}
 	}
 }
 
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/valyala/fasthttp"
+
+	accountranking_view "github.com/crypto-com/chain-indexing/appinterface/accountranking/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type AccountRankings struct {
+	logger applogger.Logger
+
+	rankingsView       *accountranking_view.AccountRankings
+	concentrationsView *accountranking_view.AccountRankingConcentrations
+
+	moduleAccounts tmcosmosutils.ModuleAccounts
+}
+
+func NewAccountRankings(
+	logger applogger.Logger,
+	rdbHandle *rdb.Handle,
+	accountAddressPrefix string,
+) *AccountRankings {
+	return &AccountRankings{
+		logger.WithFields(applogger.LogFields{
+			"module": "AccountRankingsHandler",
+		}),
+
+		accountranking_view.NewAccountRankings(rdbHandle),
+		accountranking_view.NewAccountRankingConcentrations(rdbHandle),
+
+		tmcosmosutils.NewModuleAccounts(accountAddressPrefix),
+	}
+}
+
+// ListTop returns the accounts ordered by `by`, one of `balance` (default), `staked` and
+// `transactions`, from the largest. Module accounts are labelled, and excluded when
+// `exclude_module_accounts` is true.
+func (handler *AccountRankings) ListTop(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	by := accountranking_view.RANKING_BY_BALANCE
+	if queryArgs.Has("by") {
+		by = queryArgs.Get("by")
+		if !accountranking_view.IsValidRankingMetric(by) {
+			httpapi.BadRequest(ctx, errors.New("invalid by"))
+			return
+		}
+	}
+
+	filter := accountranking_view.AccountRankingsListFilter{}
+	if queryArgs.Has("exclude_module_accounts") {
+		excludeModuleAccounts, parseErr := strconv.ParseBool(queryArgs.Get("exclude_module_accounts"))
+		if parseErr != nil {
+			httpapi.BadRequest(ctx, errors.New("invalid exclude_module_accounts"))
+			return
+		}
+		if excludeModuleAccounts {
+			filter.ExcludedAddresses = handler.moduleAccounts.Addresses()
+		}
+	}
+
+	rankings, paginationResult, err := handler.rankingsView.List(filter, by, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing account rankings: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	moduleNames := handler.moduleAccounts.NamesByAddress()
+	offset := pagination.OffsetParams().Offset()
+	topAccounts := make([]TopAccount, 0, len(rankings))
+	for i, ranking := range rankings {
+		topAccount := TopAccount{
+			Rank:         offset + int64(i) + 1,
+			Address:      ranking.Address,
+			Balance:      ranking.Balance,
+			Staked:       ranking.Staked,
+			Transactions: ranking.Transactions,
+			RankedAt:     ranking.RankedAt,
+		}
+		if moduleName, ok := moduleNames[ranking.Address]; ok {
+			topAccount.MaybeModuleAccount = &moduleName
+		}
+		topAccounts = append(topAccounts, topAccount)
+	}
+
+	httpapi.SuccessWithPagination(ctx, topAccounts, paginationResult)
+}
+
+// FindConcentration returns how concentrated `by`, one of `balance` (default) and `staked`, is among
+// the accounts other than the module accounts
+func (handler *AccountRankings) FindConcentration(ctx *fasthttp.RequestCtx) {
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	by := accountranking_view.RANKING_BY_BALANCE
+	if queryArgs.Has("by") {
+		by = queryArgs.Get("by")
+		if by != accountranking_view.RANKING_BY_BALANCE && by != accountranking_view.RANKING_BY_STAKED {
+			httpapi.BadRequest(ctx, errors.New("invalid by"))
+			return
+		}
+	}
+
+	concentration, err := handler.concentrationsView.FindBy(by)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding account concentration: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, concentration)
+}
+
+type TopAccount struct {
+	Rank         int64  `json:"rank"`
+	Address      string `json:"address"`
+	Balance      string `json:"balance"`
+	Staked       string `json:"staked"`
+	Transactions int64  `json:"transactions"`
+	// Module name when the account is a module account
+	MaybeModuleAccount *string         `json:"moduleAccount"`
+	RankedAt           utctime.UTCTime `json:"rankedAt"`
+}
@@ -2,20 +2,34 @@ package handlers
 
 import (
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/valyala/fasthttp"
 
+	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
 	account_view "github.com/crypto-com/chain-indexing/appinterface/projection/account/view"
+	accountactivity_view "github.com/crypto-com/chain-indexing/appinterface/projection/accountactivity/view"
+	balance_view "github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
 	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
 	"github.com/crypto-com/chain-indexing/appinterface/rdb"
 	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
 )
 
+// Number of the counterparties with the most transfers in the account summary
+const SUMMARY_TOP_COUNTERPARTIES = 10
+
 type Accounts struct {
 	logger applogger.Logger
 
-	accountsView *account_view.Accounts
+	accountsView       *account_view.Accounts
+	balancesView       *balance_view.Balances
+	activitiesView     *accountactivity_view.AccountActivities
+	messageTypesView   *accountactivity_view.AccountMessageTypes
+	counterpartiesView *accountactivity_view.AccountCounterparties
 }
 
 func NewAccounts(logger applogger.Logger, rdbHandle *rdb.Handle) *Accounts {
@@ -25,6 +39,10 @@ func NewAccounts(logger applogger.Logger, rdbHandle *rdb.Handle) *Accounts {
 		}),
 
 		account_view.NewAccounts(rdbHandle),
+		balance_view.NewBalances(rdbHandle),
+		accountactivity_view.NewAccountActivities(rdbHandle),
+		accountactivity_view.NewAccountMessageTypes(rdbHandle),
+		accountactivity_view.NewAccountCounterparties(rdbHandle),
 	}
 }
 
@@ -75,3 +93,161 @@ func (handler *Accounts) List(ctx *fasthttp.RequestCtx) {
 
 	httpapi.SuccessWithPagination(ctx, accounts, paginationResult)
 }
+
+// FindSummaryBy returns what is known about the account: the account info, the base denom balance, the
+// activity profile, the message counts by type and the counterparties with the most transfers. Parts
+// whose projection is not enabled or which have no record of the account are null.
+func (handler *Accounts) FindSummaryBy(ctx *fasthttp.RequestCtx) {
+	address, _ := ctx.UserValue("account").(string)
+	summary := AccountSummary{
+		Address:           address,
+		MessageTypes:      make([]accountactivity_view.AccountMessageTypeRow, 0),
+		TopCounterparties: make([]accountactivity_view.AccountCounterpartyRow, 0),
+	}
+
+	account, err := handler.accountsView.FindBy(&account_view.AccountIdentity{MaybeAddress: address})
+	if err != nil && !errors.Is(err, rdb.ErrNoRows) {
+		handler.logger.Errorf("error finding account by address: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+	summary.MaybeAccount = account
+
+	balance, err := handler.balancesView.FindBy(address)
+	if err != nil && !errors.Is(err, rdb.ErrNoRows) {
+		handler.logger.Errorf("error finding account balance: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+	if balance != nil {
+		summary.MaybeBalance = &balance.Balance
+	}
+
+	activity, err := handler.activitiesView.FindBy(address)
+	if err != nil && !errors.Is(err, rdb.ErrNoRows) {
+		handler.logger.Errorf("error finding account activity: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+	summary.MaybeActivity = activity
+
+	if summary.MaybeAccount == nil && summary.MaybeBalance == nil && summary.MaybeActivity == nil {
+		httpapi.NotFound(ctx)
+		return
+	}
+
+	if summary.MaybeActivity != nil {
+		if summary.MessageTypes, err = handler.messageTypesView.ListAllByAddress(address); err != nil {
+			handler.logger.Errorf("error listing account message types: %v", err)
+			httpapi.InternalServerError(ctx)
+			return
+		}
+		if summary.TopCounterparties, _, err = handler.counterpartiesView.ListByAddress(
+			address, pagination_interface.NewOffsetPagination(1, SUMMARY_TOP_COUNTERPARTIES),
+		); err != nil {
+			handler.logger.Errorf("error listing account counterparties: %v", err)
+			httpapi.InternalServerError(ctx)
+			return
+		}
+	}
+
+	httpapi.Success(ctx, summary)
+}
+
+// ListCounterparties returns the counterparties of the account with the most transfers first
+func (handler *Accounts) ListCounterparties(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	address, _ := ctx.UserValue("account").(string)
+	counterparties, paginationResult, err := handler.counterpartiesView.ListByAddress(address, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing account counterparties: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, counterparties, paginationResult)
+}
+
+// ListActivities returns the activity profiles of the accounts last active between `active_from`
+// (inclusive) and `active_to` (exclusive), first seen since `first_seen_from`, all in RFC3339, and with
+// at least `min_messages` messages
+func (handler *Accounts) ListActivities(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	filter := accountactivity_view.AccountActivitiesListFilter{}
+	for _, timeArg := range []struct {
+		name   string
+		target **utctime.UTCTime
+	}{
+		{"active_from", &filter.MaybeActiveFromTime},
+		{"active_to", &filter.MaybeActiveToTime},
+		{"first_seen_from", &filter.MaybeFirstSeenFromTime},
+	} {
+		if !queryArgs.Has(timeArg.name) {
+			continue
+		}
+		parsed, parseErr := utctime.Parse(time.RFC3339, queryArgs.Get(timeArg.name))
+		if parseErr != nil {
+			httpapi.BadRequest(ctx, errors.New("invalid "+timeArg.name+", expected RFC3339 format"))
+			return
+		}
+		*timeArg.target = &parsed
+	}
+	if queryArgs.Has("min_messages") {
+		minMessages, parseErr := strconv.ParseInt(queryArgs.Get("min_messages"), 10, 64)
+		if parseErr != nil {
+			httpapi.BadRequest(ctx, errors.New("invalid min_messages"))
+			return
+		}
+		filter.MaybeMinMessages = &minMessages
+	}
+
+	order := accountactivity_view.AccountActivitiesListOrder{}
+	if queryArgs.Has("order") {
+		switch queryArgs.Get("order") {
+		case "last_active":
+			order.MaybeLastActiveBlockHeight = primptr.String(view.ORDER_ASC)
+		case "last_active.desc":
+			order.MaybeLastActiveBlockHeight = primptr.String(view.ORDER_DESC)
+		case "first_seen":
+			order.MaybeFirstSeenBlockHeight = primptr.String(view.ORDER_ASC)
+		case "first_seen.desc":
+			order.MaybeFirstSeenBlockHeight = primptr.String(view.ORDER_DESC)
+		case "messages":
+			order.MaybeMessages = primptr.String(view.ORDER_ASC)
+		case "messages.desc":
+			order.MaybeMessages = primptr.String(view.ORDER_DESC)
+		default:
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	activities, paginationResult, err := handler.activitiesView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing account activities: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, activities, paginationResult)
+}
+
+type AccountSummary struct {
+	Address           string                                        `json:"address"`
+	MaybeAccount      *account_view.Account                         `json:"account"`
+	MaybeBalance      *string                                       `json:"balance"`
+	MaybeActivity     *accountactivity_view.AccountActivityRow      `json:"activity"`
+	MessageTypes      []accountactivity_view.AccountMessageTypeRow  `json:"messageTypes"`
+	TopCounterparties []accountactivity_view.AccountCounterpartyRow `json:"topCounterparties"`
+}
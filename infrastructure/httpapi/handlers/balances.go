@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	balance_view "github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/rdbprojectionbase"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Maximum number of buckets returned by a balance history series request
+const MAX_BALANCE_SERIES_BUCKETS = 1000
+
+type Balances struct {
+	logger applogger.Logger
+
+	balanceProjectionBase *rdbprojectionbase.Base
+	historiesView         *balance_view.BalanceHistories
+}
+
+func NewBalances(logger applogger.Logger, rdbHandle *rdb.Handle) *Balances {
+	return &Balances{
+		logger.WithFields(applogger.LogFields{
+			"module": "BalancesHandler",
+		}),
+
+		rdbprojectionbase.NewRDbBase(rdbHandle, "Balance"),
+		balance_view.NewBalanceHistories(rdbHandle),
+	}
+}
+
+// FindByAccount returns the balance of the account at the end of the `height` block, or at the `time`
+// in RFC3339. Returns the latest indexed balance when neither is provided.
+func (handler *Balances) FindByAccount(ctx *fasthttp.RequestCtx) {
+	accountParam, _ := ctx.UserValue("account").(string)
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+
+	if queryArgs.Has("height") && queryArgs.Has("time") {
+		httpapi.BadRequest(ctx, errors.New("only one of height and time can be provided"))
+		return
+	}
+
+	maybeIndexedHeight, err := handler.balanceProjectionBase.GetLastHandledEventHeight()
+	if err != nil {
+		handler.logger.Errorf("error getting balance projection last handled event height: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+	if maybeIndexedHeight == nil {
+		httpapi.NotFound(ctx)
+		return
+	}
+
+	identity := balance_view.BalanceHistoryIdentity{
+		Address: accountParam,
+	}
+	if queryArgs.Has("height") {
+		height, parseErr := strconv.ParseInt(queryArgs.Get("height"), 10, 64)
+		if parseErr != nil || height < 0 {
+			httpapi.BadRequest(ctx, errors.New("invalid height"))
+			return
+		}
+		if height > *maybeIndexedHeight {
+			httpapi.BadRequest(ctx, fmt.Errorf("height is not indexed yet, last indexed height is %d", *maybeIndexedHeight))
+			return
+		}
+		identity.MaybeBlockHeight = &height
+	} else if queryArgs.Has("time") {
+		blockTime, parseErr := utctime.Parse(time.RFC3339, queryArgs.Get("time"))
+		if parseErr != nil {
+			httpapi.BadRequest(ctx, errors.New("invalid time, expected RFC3339 format"))
+			return
+		}
+		identity.MaybeBlockTime = &blockTime
+	}
+
+	response := BalanceAtResponse{
+		Address:            accountParam,
+		Balance:            "0",
+		IndexedBlockHeight: *maybeIndexedHeight,
+	}
+	history, err := handler.historiesView.FindLatestBy(identity)
+	if err == nil {
+		response.Balance = history.Balance
+		response.MaybeLastChangedBlockHeight = &history.BlockHeight
+		response.MaybeLastChangedBlockTime = &history.BlockTime
+	} else if !errors.Is(err, rdb.ErrNoRows) {
+		handler.logger.Errorf("error finding balance history: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, response)
+}
+
+// ListSeriesByAccount returns the closing balance of the account in every `interval` bucket between
+// `from` (inclusive) and `to` (exclusive, defaults to now), both in RFC3339
+func (handler *Balances) ListSeriesByAccount(ctx *fasthttp.RequestCtx) {
+	accountParam, _ := ctx.UserValue("account").(string)
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+
+	interval := balance_view.BALANCE_SERIES_INTERVAL_DAY
+	if queryArgs.Has("interval") {
+		interval = queryArgs.Get("interval")
+		if !balance_view.IsValidBalanceSeriesInterval(interval) {
+			httpapi.BadRequest(ctx, errors.New("invalid interval, expected one of hour, day, week and month"))
+			return
+		}
+	}
+
+	from, to, err := httpapi.ParseSeriesTimeRange(queryArgs, httpapi.SeriesBuckets{
+		Start: func(t utctime.UTCTime) utctime.UTCTime {
+			return balance_view.BalanceSeriesBucketStart(t, interval)
+		},
+		Next: func(bucketStart utctime.UTCTime) utctime.UTCTime {
+			return balance_view.NextBalanceSeriesBucketStart(bucketStart, interval)
+		},
+		MaxCount: MAX_BALANCE_SERIES_BUCKETS,
+	})
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	series, err := handler.historiesView.ListSeries(balance_view.BalanceSeriesFilter{
+		Address:  accountParam,
+		Interval: interval,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		handler.logger.Errorf("error listing balance series: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, series)
+}
+
+type BalanceAtResponse struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+	// Height and time of the last balance change at or before the queried point. Null when never changed.
+	MaybeLastChangedBlockHeight *int64           `json:"lastChangedBlockHeight"`
+	MaybeLastChangedBlockTime   *utctime.UTCTime `json:"lastChangedBlockTime"`
+	// Last height handled by the balance projection
+	IndexedBlockHeight int64 `json:"indexedBlockHeight"`
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/valyala/fasthttp"
+
+	delegation_view "github.com/crypto-com/chain-indexing/appinterface/projection/delegation/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+type Delegations struct {
+	logger applogger.Logger
+
+	delegationsView          *delegation_view.Delegations
+	unbondingDelegationsView *delegation_view.UnbondingDelegations
+	redelegationsView        *delegation_view.Redelegations
+	historiesView            *delegation_view.Histories
+}
+
+func NewDelegations(logger applogger.Logger, rdbHandle *rdb.Handle) *Delegations {
+	return &Delegations{
+		logger.WithFields(applogger.LogFields{
+			"module": "DelegationsHandler",
+		}),
+
+		delegation_view.NewDelegations(rdbHandle),
+		delegation_view.NewUnbondingDelegations(rdbHandle),
+		delegation_view.NewRedelegations(rdbHandle),
+		delegation_view.NewHistories(rdbHandle),
+	}
+}
+
+func (handler *Delegations) ListByDelegator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	filter := delegation_view.DelegationsListFilter{
+		MaybeDelegatorAddress: &addressParam,
+	}
+	if ctx.QueryArgs().Has("filter.validatorAddress") {
+		filter.MaybeValidatorAddress = primptr.String(string(ctx.QueryArgs().Peek("filter.validatorAddress")))
+	}
+
+	handler.listDelegations(ctx, filter)
+}
+
+func (handler *Delegations) ListByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	filter := delegation_view.DelegationsListFilter{
+		MaybeValidatorAddress: &addressParam,
+	}
+	if ctx.QueryArgs().Has("filter.delegatorAddress") {
+		filter.MaybeDelegatorAddress = primptr.String(string(ctx.QueryArgs().Peek("filter.delegatorAddress")))
+	}
+
+	handler.listDelegations(ctx, filter)
+}
+
+func (handler *Delegations) listDelegations(
+	ctx *fasthttp.RequestCtx,
+	filter delegation_view.DelegationsListFilter,
+) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := delegation_view.DelegationsListOrder{}
+	if ctx.QueryArgs().Has("order") {
+		orderArg := string(ctx.QueryArgs().Peek("order"))
+		if orderArg == "height" {
+			order.MaybeLastUpdatedBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeLastUpdatedBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	delegations, paginationResult, err := handler.delegationsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing delegations: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, delegations, paginationResult)
+}
+
+func (handler *Delegations) ListUnbondingDelegationsByDelegator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	handler.listUnbondingDelegations(ctx, delegation_view.UnbondingDelegationsListFilter{
+		MaybeDelegatorAddress: &addressParam,
+	})
+}
+
+func (handler *Delegations) ListUnbondingDelegationsByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	handler.listUnbondingDelegations(ctx, delegation_view.UnbondingDelegationsListFilter{
+		MaybeValidatorAddress: &addressParam,
+	})
+}
+
+func (handler *Delegations) listUnbondingDelegations(
+	ctx *fasthttp.RequestCtx,
+	filter delegation_view.UnbondingDelegationsListFilter,
+) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := delegation_view.UnbondingDelegationsListOrder{}
+	if ctx.QueryArgs().Has("order") {
+		orderArg := string(ctx.QueryArgs().Peek("order"))
+		if orderArg == "completionTime" {
+			order.MaybeCompletionTime = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "completionTime.desc" {
+			order.MaybeCompletionTime = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	unbondingDelegations, paginationResult, err := handler.unbondingDelegationsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing unbonding delegations: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, unbondingDelegations, paginationResult)
+}
+
+func (handler *Delegations) ListRedelegationsByDelegator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	handler.listRedelegations(ctx, delegation_view.RedelegationsListFilter{
+		MaybeDelegatorAddress: &addressParam,
+	})
+}
+
+// ListRedelegationsByValidator lists the redelegations from or to the validator
+func (handler *Delegations) ListRedelegationsByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	handler.listRedelegations(ctx, delegation_view.RedelegationsListFilter{
+		MaybeValidatorAddress: &addressParam,
+	})
+}
+
+func (handler *Delegations) listRedelegations(
+	ctx *fasthttp.RequestCtx,
+	filter delegation_view.RedelegationsListFilter,
+) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := delegation_view.RedelegationsListOrder{}
+	if ctx.QueryArgs().Has("order") {
+		orderArg := string(ctx.QueryArgs().Peek("order"))
+		if orderArg == "completionTime" {
+			order.MaybeCompletionTime = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "completionTime.desc" {
+			order.MaybeCompletionTime = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	redelegations, paginationResult, err := handler.redelegationsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing redelegations: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, redelegations, paginationResult)
+}
+
+func (handler *Delegations) ListHistoriesByDelegator(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParam, _ := ctx.UserValue("address").(string)
+	filter := delegation_view.HistoriesListFilter{
+		MaybeDelegatorAddress: &addressParam,
+	}
+	order := delegation_view.HistoriesListOrder{}
+	queryArgs := ctx.QueryArgs()
+	if queryArgs.Has("filter.validatorAddress") {
+		filter.MaybeValidatorAddress = primptr.String(string(queryArgs.Peek("filter.validatorAddress")))
+	}
+	if queryArgs.Has("order") {
+		orderArg := string(queryArgs.Peek("order"))
+		if orderArg == "height" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	histories, paginationResult, err := handler.historiesView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing delegation histories: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, histories, paginationResult)
+}
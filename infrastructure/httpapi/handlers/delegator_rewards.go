@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	delegatorreward_view "github.com/crypto-com/chain-indexing/appinterface/projection/delegatorreward/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+// Maximum number of rows in a reward withdrawals export. Requests over it have to narrow the date range.
+const MAX_REWARD_WITHDRAWALS_EXPORT_ROWS = 10000
+
+var REWARD_WITHDRAWALS_EXPORT_HEADER = []string{
+	"block_time",
+	"transaction_hash",
+	"validator_address",
+	"amount",
+	"denom",
+}
+
+type DelegatorRewards struct {
+	logger applogger.Logger
+
+	rewardWithdrawalsView *delegatorreward_view.RewardWithdrawals
+}
+
+func NewDelegatorRewards(logger applogger.Logger, rdbHandle *rdb.Handle) *DelegatorRewards {
+	return &DelegatorRewards{
+		logger.WithFields(applogger.LogFields{
+			"module": "DelegatorRewardsHandler",
+		}),
+
+		delegatorreward_view.NewRewardWithdrawals(rdbHandle),
+	}
+}
+
+func (handler *DelegatorRewards) ListWithdrawalsByDelegator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	filter := delegatorreward_view.RewardWithdrawalsListFilter{
+		MaybeDelegatorAddress: &addressParam,
+	}
+	if ctx.QueryArgs().Has("filter.validatorAddress") {
+		filter.MaybeValidatorAddress = primptr.String(string(ctx.QueryArgs().Peek("filter.validatorAddress")))
+	}
+
+	handler.listWithdrawals(ctx, filter)
+}
+
+func (handler *DelegatorRewards) ListWithdrawalsByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	filter := delegatorreward_view.RewardWithdrawalsListFilter{
+		MaybeValidatorAddress: &addressParam,
+	}
+	if ctx.QueryArgs().Has("filter.delegatorAddress") {
+		filter.MaybeDelegatorAddress = primptr.String(string(ctx.QueryArgs().Peek("filter.delegatorAddress")))
+	}
+
+	handler.listWithdrawals(ctx, filter)
+}
+
+func (handler *DelegatorRewards) listWithdrawals(
+	ctx *fasthttp.RequestCtx,
+	filter delegatorreward_view.RewardWithdrawalsListFilter,
+) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	if filter.MaybeFromTime, filter.MaybeToTime, err = httpapi.ParseTimeRange(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := delegatorreward_view.RewardWithdrawalsListOrder{}
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "height" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	withdrawals, paginationResult, err := handler.rewardWithdrawalsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing reward withdrawals: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, withdrawals, paginationResult)
+}
+
+// ExportWithdrawalsByDelegator responds the reward withdrawals of the delegator between `from`
+// (inclusive) and `to` (exclusive) in RFC3339 as CSV, the format accepted by most tax tools
+func (handler *DelegatorRewards) ExportWithdrawalsByDelegator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	filter := delegatorreward_view.RewardWithdrawalsListFilter{
+		MaybeDelegatorAddress: &addressParam,
+	}
+	var err error
+	if filter.MaybeFromTime, filter.MaybeToTime, err = httpapi.ParseTimeRange(
+		httpapi.NewQueryArgs(ctx.QueryArgs()),
+	); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	if filter.MaybeFromTime == nil || filter.MaybeToTime == nil {
+		httpapi.BadRequest(ctx, errors.New("missing from or to"))
+		return
+	}
+
+	withdrawals, err := handler.rewardWithdrawalsView.ListAll(filter, MAX_REWARD_WITHDRAWALS_EXPORT_ROWS+1)
+	if err != nil {
+		handler.logger.Errorf("error listing reward withdrawals for export: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+	if len(withdrawals) > MAX_REWARD_WITHDRAWALS_EXPORT_ROWS {
+		httpapi.BadRequest(ctx, fmt.Errorf(
+			"too many reward withdrawals, at most %d allowed, narrow the date range", MAX_REWARD_WITHDRAWALS_EXPORT_ROWS,
+		))
+		return
+	}
+
+	records := make([][]string, 0, len(withdrawals)+1)
+	records = append(records, REWARD_WITHDRAWALS_EXPORT_HEADER)
+	for _, withdrawal := range withdrawals {
+		records = append(records, []string{
+			time.Unix(0, withdrawal.BlockTime.UnixNano()).UTC().Format(time.RFC3339),
+			withdrawal.TransactionHash,
+			withdrawal.ValidatorAddress,
+			withdrawal.Amount,
+			withdrawal.Denom,
+		})
+	}
+
+	httpapi.SuccessCSV(ctx, fmt.Sprintf("reward_withdrawals_%s.csv", addressParam), records)
+}
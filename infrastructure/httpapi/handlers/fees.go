@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/feemarket"
+	feemarket_view "github.com/crypto-com/chain-indexing/appinterface/projection/feemarket/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+// Number of the latest priced blocks the gas price estimate is based on
+const FEE_ESTIMATE_BLOCKS = 20
+
+type Fees struct {
+	logger applogger.Logger
+
+	blocksView     *feemarket_view.FeeBlocks
+	hoursView      *feemarket_view.FeeHours
+	messageGasView *feemarket_view.MessageGas
+}
+
+func NewFees(logger applogger.Logger, rdbHandle *rdb.Handle) *Fees {
+	return &Fees{
+		logger.WithFields(applogger.LogFields{
+			"module": "FeesHandler",
+		}),
+
+		feemarket_view.NewFeeBlocks(rdbHandle),
+		feemarket_view.NewFeeHours(rdbHandle),
+		feemarket_view.NewMessageGas(rdbHandle),
+	}
+}
+
+// Estimate suggests the low, medium and high gas prices in base denom per gas from the latest blocks
+func (handler *Fees) Estimate(ctx *fasthttp.RequestCtx) {
+	blocks, err := handler.blocksView.ListLatestPriced(FEE_ESTIMATE_BLOCKS)
+	if err != nil {
+		handler.logger.Errorf("error listing latest priced fee blocks: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	estimate, err := feemarket.EstimateGasPrices(blocks)
+	if err != nil {
+		handler.logger.Errorf("error estimating gas prices: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+	if estimate == nil {
+		httpapi.NotFound(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, estimate)
+}
+
+// ListBlocks returns the fee and gas statistics of the blocks between `from` (inclusive) and `to`
+// (exclusive), both in RFC3339
+func (handler *Fees) ListBlocks(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	maybeFrom, maybeTo, err := httpapi.ParseTimeRange(httpapi.NewQueryArgs(ctx.QueryArgs()))
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	filter := feemarket_view.FeeBlocksListFilter{
+		MaybeFromTime: maybeFrom,
+		MaybeToTime:   maybeTo,
+	}
+
+	order := feemarket_view.FeeBlocksListOrder{}
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "height" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	blocks, paginationResult, err := handler.blocksView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing fee blocks: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, blocks, paginationResult)
+}
+
+// ListHours returns the hourly fee and gas statistics of the hours between `from` (inclusive) and `to`
+// (exclusive), both in RFC3339
+func (handler *Fees) ListHours(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	maybeFrom, maybeTo, err := httpapi.ParseTimeRange(httpapi.NewQueryArgs(ctx.QueryArgs()))
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	filter := feemarket_view.FeeHoursListFilter{
+		MaybeFromTime: maybeFrom,
+		MaybeToTime:   maybeTo,
+	}
+
+	order := feemarket_view.FeeHoursListOrder{}
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "hour" {
+			order.MaybeHourStart = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "hour.desc" {
+			order.MaybeHourStart = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	hours, paginationResult, err := handler.hoursView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing fee hours: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, hours, paginationResult)
+}
+
+// ListMessageGas returns the average gas of the successful single-message transactions by message type
+func (handler *Fees) ListMessageGas(ctx *fasthttp.RequestCtx) {
+	messageGases, err := handler.messageGasView.ListAll()
+	if err != nil {
+		handler.logger.Errorf("error listing message gas: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	responses := make([]MessageGasResponse, 0, len(messageGases))
+	for i := range messageGases {
+		responses = append(responses, MessageGasResponse{
+			MessageGasRow: messageGases[i],
+
+			AvgGasWanted: messageGases[i].AvgGasWanted(),
+			AvgGasUsed:   messageGases[i].AvgGasUsed(),
+		})
+	}
+
+	httpapi.Success(ctx, responses)
+}
+
+type MessageGasResponse struct {
+	feemarket_view.MessageGasRow
+
+	AvgGasWanted string `json:"avgGasWanted"`
+	AvgGasUsed   string `json:"avgGasUsed"`
+}
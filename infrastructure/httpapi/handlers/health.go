@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/crypto-com/chain-indexing/appinterface/health"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+)
+
+// Health reports the components health for load balancers and Kubernetes probes. Responds with
+// 200 when all the checked components are up, otherwise 503, both with the per-component report.
+type Health struct {
+	logger applogger.Logger
+
+	livenessChecks  []health.Check
+	readinessChecks []health.Check
+}
+
+func NewHealth(logger applogger.Logger, livenessChecks []health.Check, readinessChecks []health.Check) *Health {
+	return &Health{
+		logger.WithFields(applogger.LogFields{
+			"module": "HealthHandler",
+		}),
+
+		livenessChecks,
+		readinessChecks,
+	}
+}
+
+// Live reports whether the server is able to serve at all
+func (handler *Health) Live(ctx *fasthttp.RequestCtx) {
+	handler.respond(ctx, health.RunChecks(handler.livenessChecks))
+}
+
+// Ready reports whether the server is serving up-to-date data
+func (handler *Health) Ready(ctx *fasthttp.RequestCtx) {
+	handler.respond(ctx, health.RunChecks(handler.readinessChecks))
+}
+
+func (handler *Health) respond(ctx *fasthttp.RequestCtx, report *health.Report) {
+	if !report.IsUp() {
+		for _, component := range report.Components {
+			if component.Status != health.STATUS_UP {
+				handler.logger.Infof("health check %s is down: %s", component.Name, *component.MaybeMessage)
+			}
+		}
+		ctx.SetStatusCode(fasthttp.StatusServiceUnavailable)
+	}
+
+	httpapi.Success(ctx, report)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposal/constants"
+	proposal_view "github.com/crypto-com/chain-indexing/appinterface/projection/proposal/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+type Proposals struct {
+	logger applogger.Logger
+
+	proposalsView *proposal_view.Proposals
+	votesView     *proposal_view.Votes
+	depositsView  *proposal_view.Deposits
+}
+
+func NewProposals(logger applogger.Logger, rdbHandle *rdb.Handle) *Proposals {
+	return &Proposals{
+		logger.WithFields(applogger.LogFields{
+			"module": "ProposalsHandler",
+		}),
+
+		proposal_view.NewProposals(rdbHandle),
+		proposal_view.NewVotes(rdbHandle),
+		proposal_view.NewDeposits(rdbHandle),
+	}
+}
+
+func (handler *Proposals) FindById(ctx *fasthttp.RequestCtx) {
+	idParam, _ := ctx.UserValue("id").(string)
+
+	proposal, err := handler.proposalsView.FindBy(idParam)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding proposal by id: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, proposal)
+}
+
+func (handler *Proposals) List(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	filter := proposal_view.ProposalsListFilter{}
+	order := proposal_view.ProposalsListOrder{}
+	queryArgs := ctx.QueryArgs()
+	if queryArgs.Has("filter.status") {
+		status := string(queryArgs.Peek("filter.status"))
+		if status != constants.DEPOSIT_PERIOD &&
+			status != constants.VOTING_PERIOD &&
+			status != constants.PASSED &&
+			status != constants.REJECTED &&
+			status != constants.FAILED &&
+			status != constants.INACTIVE {
+			httpapi.BadRequest(ctx, errors.New("invalid status"))
+			return
+		}
+		filter.MaybeStatus = &status
+	}
+	if queryArgs.Has("filter.proposerAddress") {
+		filter.MaybeProposerAddress = primptr.String(string(queryArgs.Peek("filter.proposerAddress")))
+	}
+	if queryArgs.Has("order") {
+		orderArg := string(queryArgs.Peek("order"))
+		if orderArg == "height" {
+			order.MaybeSubmitBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeSubmitBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	proposals, paginationResult, err := handler.proposalsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing proposals: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, proposals, paginationResult)
+}
+
+func (handler *Proposals) ListVotesById(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	idParam, _ := ctx.UserValue("id").(string)
+	filter := proposal_view.VotesListFilter{
+		MaybeProposalId: &idParam,
+	}
+	order := proposal_view.VotesListOrder{}
+	queryArgs := ctx.QueryArgs()
+	if queryArgs.Has("order") {
+		orderArg := string(queryArgs.Peek("order"))
+		if orderArg == "height" {
+			order.MaybeVoteAtBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeVoteAtBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	votes, paginationResult, err := handler.votesView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing proposal votes: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, votes, paginationResult)
+}
+
+func (handler *Proposals) ListDepositsById(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	idParam, _ := ctx.UserValue("id").(string)
+	filter := proposal_view.DepositsListFilter{
+		MaybeProposalId: &idParam,
+	}
+	order := proposal_view.DepositsListOrder{}
+	queryArgs := ctx.QueryArgs()
+	if queryArgs.Has("order") {
+		orderArg := string(queryArgs.Peek("order"))
+		if orderArg == "height" {
+			order.MaybeDepositAtBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeDepositAtBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	deposits, paginationResult, err := handler.depositsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing proposal deposits: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, deposits, paginationResult)
+}
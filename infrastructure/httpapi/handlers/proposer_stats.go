@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/proposerstats"
+	proposerstats_view "github.com/crypto-com/chain-indexing/appinterface/projection/proposerstats/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type ProposerStats struct {
+	logger applogger.Logger
+
+	bucketsView        *proposerstats_view.ProposerBuckets
+	totalsView         *proposerstats_view.ProposerTotals
+	proposedBlocksView *proposerstats_view.ProposedBlocks
+}
+
+func NewProposerStats(logger applogger.Logger, rdbHandle *rdb.Handle) *ProposerStats {
+	return &ProposerStats{
+		logger.WithFields(applogger.LogFields{
+			"module": "ProposerStatsHandler",
+		}),
+
+		proposerstats_view.NewProposerBuckets(rdbHandle),
+		proposerstats_view.NewProposerTotals(rdbHandle),
+		proposerstats_view.NewProposedBlocks(rdbHandle),
+	}
+}
+
+// ListTop returns the lifetime proposer leaderboard, the most proposed blocks first unless ordered by
+// `proposed_blocks`
+func (handler *ProposerStats) ListTop(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	order := proposerstats_view.ProposerTotalsListOrder{}
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "proposed_blocks" {
+			order.MaybeProposedBlocks = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "proposed_blocks.desc" {
+			order.MaybeProposedBlocks = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	totals, paginationResult, err := handler.totalsView.List(order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing proposer totals: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	totalsWithPerformance := make([]ProposerTotalWithPerformance, 0, len(totals))
+	for _, total := range totals {
+		totalWithPerformance, performanceErr := withTotalPerformance(total)
+		if performanceErr != nil {
+			handler.logger.Errorf("error calculating proposer performance: %v", performanceErr)
+			httpapi.InternalServerError(ctx)
+			return
+		}
+		totalsWithPerformance = append(totalsWithPerformance, totalWithPerformance)
+	}
+
+	httpapi.SuccessWithPagination(ctx, totalsWithPerformance, paginationResult)
+}
+
+// ListTopByBucket returns the proposer leaderboard of the `interval` (hour or day, defaults to day) that
+// the RFC3339 `time` falls into, defaults to now. Only validators with proposals or expected proposals
+// in the bucket are returned, the most proposed blocks first.
+func (handler *ProposerStats) ListTopByBucket(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	interval, err := parseProposerBucketInterval(queryArgs)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	bucketTime := utctime.Now()
+	if queryArgs.Has("time") {
+		if bucketTime, err = utctime.Parse(time.RFC3339, queryArgs.Get("time")); err != nil {
+			httpapi.BadRequest(ctx, errors.New("invalid time, expected RFC3339 format"))
+			return
+		}
+	}
+
+	buckets, paginationResult, err := handler.bucketsView.ListByBucket(
+		interval, proposerstats_view.ProposerBucketStart(bucketTime, interval), pagination,
+	)
+	if err != nil {
+		handler.logger.Errorf("error listing proposer buckets: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	bucketsWithPerformance, err := withBucketsPerformance(buckets)
+	if err != nil {
+		handler.logger.Errorf("error calculating proposer performance: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, bucketsWithPerformance, paginationResult)
+}
+
+// ListByValidator returns the proposing statistics of the validator by `interval` (hour or day, defaults
+// to day), optionally between `from` (inclusive) and `to` (exclusive) in RFC3339
+func (handler *ProposerStats) ListByValidator(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParam, _ := ctx.UserValue("address").(string)
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+
+	filter := proposerstats_view.ProposerBucketsListFilter{
+		OperatorAddress: addressParam,
+	}
+	if filter.Interval, err = parseProposerBucketInterval(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	if filter.MaybeFrom, filter.MaybeTo, err = httpapi.ParseTimeRange(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := proposerstats_view.ProposerBucketsListOrder{}
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "time" {
+			order.MaybeBucketStart = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "time.desc" {
+			order.MaybeBucketStart = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	buckets, paginationResult, err := handler.bucketsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing proposer buckets: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	bucketsWithPerformance, err := withBucketsPerformance(buckets)
+	if err != nil {
+		handler.logger.Errorf("error calculating proposer performance: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, bucketsWithPerformance, paginationResult)
+}
+
+// FindTotalByValidator returns the lifetime proposing statistics of the validator
+func (handler *ProposerStats) FindTotalByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+
+	total, err := handler.totalsView.FindBy(addressParam)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding proposer total: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	totalWithPerformance, err := withTotalPerformance(*total)
+	if err != nil {
+		handler.logger.Errorf("error calculating proposer performance: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, totalWithPerformance)
+}
+
+// ListProposedBlocksByValidator returns the blocks proposed by the validator, the latest first unless
+// ordered by `height`
+func (handler *ProposerStats) ListProposedBlocksByValidator(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParam, _ := ctx.UserValue("address").(string)
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	order := proposerstats_view.ProposedBlocksListOrder{}
+	if order.MaybeBlockHeight, err = parseHeightOrder(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	blocks, paginationResult, err := handler.proposedBlocksView.ListByOperatorAddress(addressParam, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing proposed blocks: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, blocks, paginationResult)
+}
+
+func parseProposerBucketInterval(queryArgs *httpapi.QueryArgs) (proposerstats_view.ProposerBucketInterval, error) {
+	if !queryArgs.Has("interval") {
+		return proposerstats_view.PROPOSER_BUCKET_INTERVAL_DAY, nil
+	}
+
+	interval := queryArgs.Get("interval")
+	if !proposerstats_view.IsValidProposerBucketInterval(interval) {
+		return "", errors.New("invalid interval, expected one of hour and day")
+	}
+	return interval, nil
+}
+
+func withTotalPerformance(total proposerstats_view.ProposerTotalRow) (ProposerTotalWithPerformance, error) {
+	performance, err := proposerstats.Performance(total.ProposedBlocks, total.ExpectedProposals)
+	if err != nil {
+		return ProposerTotalWithPerformance{}, fmt.Errorf("error calculating performance: %v", err)
+	}
+
+	return ProposerTotalWithPerformance{
+		ProposerTotalRow: total,
+
+		MaybePerformance: performance,
+	}, nil
+}
+
+func withBucketsPerformance(
+	buckets []proposerstats_view.ProposerBucketRow,
+) ([]ProposerBucketWithPerformance, error) {
+	bucketsWithPerformance := make([]ProposerBucketWithPerformance, 0, len(buckets))
+	for _, bucket := range buckets {
+		performance, err := proposerstats.Performance(bucket.ProposedBlocks, bucket.ExpectedProposals)
+		if err != nil {
+			return nil, fmt.Errorf("error calculating performance: %v", err)
+		}
+		bucketsWithPerformance = append(bucketsWithPerformance, ProposerBucketWithPerformance{
+			ProposerBucketRow: bucket,
+
+			MaybePerformance: performance,
+		})
+	}
+
+	return bucketsWithPerformance, nil
+}
+
+// ProposerTotalWithPerformance performance is the ratio of the blocks proposed to the proposals expected,
+// null when no proposal is expected
+type ProposerTotalWithPerformance struct {
+	proposerstats_view.ProposerTotalRow
+
+	MaybePerformance *string `json:"performance"`
+}
+
+// ProposerBucketWithPerformance performance is the ratio of the blocks proposed to the proposals expected,
+// null when no proposal is expected
+type ProposerBucketWithPerformance struct {
+	proposerstats_view.ProposerBucketRow
+
+	MaybePerformance *string `json:"performance"`
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/slashing"
+	slashing_view "github.com/crypto-com/chain-indexing/appinterface/projection/slashing/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+type Slashing struct {
+	logger applogger.Logger
+
+	validatorsView *slashing_view.SlashingValidators
+	incidentsView  *slashing_view.SlashingIncidents
+	downtimesView  *slashing_view.SlashingDowntimes
+}
+
+func NewSlashing(logger applogger.Logger, rdbHandle *rdb.Handle) *Slashing {
+	return &Slashing{
+		logger.WithFields(applogger.LogFields{
+			"module": "SlashingHandler",
+		}),
+
+		slashing_view.NewSlashingValidators(rdbHandle),
+		slashing_view.NewSlashingIncidents(rdbHandle),
+		slashing_view.NewSlashingDowntimes(rdbHandle),
+	}
+}
+
+// ListIncidents returns the slashing incidents of all validators, optionally of a `reason` and between
+// `from` (inclusive) and `to` (exclusive) in RFC3339. The latest incident first unless ordered by `height`.
+func (handler *Slashing) ListIncidents(ctx *fasthttp.RequestCtx) {
+	handler.listIncidents(ctx, nil)
+}
+
+// ListIncidentsByValidator returns the slashing incidents of the validator, with the same filters as
+// ListIncidents
+func (handler *Slashing) ListIncidentsByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+	handler.listIncidents(ctx, &addressParam)
+}
+
+func (handler *Slashing) listIncidents(ctx *fasthttp.RequestCtx, maybeOperatorAddress *string) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	filter := slashing_view.SlashingIncidentsListFilter{
+		MaybeOperatorAddress: maybeOperatorAddress,
+	}
+	if queryArgs.Has("reason") {
+		reason := queryArgs.Get("reason")
+		if reason != slashing_view.REASON_DOUBLE_SIGN && reason != slashing_view.REASON_MISSING_SIGNATURE {
+			httpapi.BadRequest(ctx, errors.New("invalid reason, expected one of double_sign and missing_signature"))
+			return
+		}
+		filter.MaybeReason = &reason
+	}
+	if filter.MaybeFrom, filter.MaybeTo, err = httpapi.ParseTimeRange(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := slashing_view.SlashingIncidentsListOrder{}
+	if order.MaybeBlockHeight, err = parseHeightOrder(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	incidents, paginationResult, err := handler.incidentsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing slashing incidents: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, incidents, paginationResult)
+}
+
+// ListDowntimesByValidator returns the downtime periods of the validator, the latest first unless ordered
+// by `height`
+func (handler *Slashing) ListDowntimesByValidator(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParam, _ := ctx.UserValue("address").(string)
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	order := slashing_view.SlashingDowntimesListOrder{}
+	if order.MaybeJailedAtBlockHeight, err = parseHeightOrder(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	downtimes, paginationResult, err := handler.downtimesView.ListByOperatorAddress(addressParam, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing slashing downtimes: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, downtimes, paginationResult)
+}
+
+// FindReliabilityByValidator returns the slashing and jailing counters of the validator with its
+// reliability score as of now
+func (handler *Slashing) FindReliabilityByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+
+	validator, err := handler.validatorsView.FindBy(slashing_view.SlashingValidatorIdentity{
+		MaybeOperatorAddress: &addressParam,
+	})
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding slashing validator: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, SlashingValidatorWithReliability{
+		SlashingValidatorRow: *validator,
+
+		Reliability: slashing.Reliability(validator, utctime.Now()),
+	})
+}
+
+// SlashingValidatorWithReliability reliability is the share of time the validator was not jailed since
+// it joined
+type SlashingValidatorWithReliability struct {
+	slashing_view.SlashingValidatorRow
+
+	Reliability string `json:"reliability"`
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+
+	chainstats_view "github.com/crypto-com/chain-indexing/appinterface/projection/chainstats/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// Maximum number of buckets returned by a stats time series request
+const MAX_STATS_SERIES_BUCKETS = 1000
+
+type Stats struct {
+	logger applogger.Logger
+
+	statsBucketsView *chainstats_view.StatsBuckets
+}
+
+func NewStats(logger applogger.Logger, rdbHandle *rdb.Handle) *Stats {
+	return &Stats{
+		logger.WithFields(applogger.LogFields{
+			"module": "StatsHandler",
+		}),
+
+		chainstats_view.NewStatsBuckets(rdbHandle),
+	}
+}
+
+// ListTimeSeries returns the `metric` in every `interval` (hour or day, defaults to day) bucket between
+// `from` (inclusive) and `to` (exclusive, defaults to now), both in RFC3339
+func (handler *Stats) ListTimeSeries(ctx *fasthttp.RequestCtx) {
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+
+	if !queryArgs.Has("metric") {
+		httpapi.BadRequest(ctx, errors.New("missing metric"))
+		return
+	}
+	metric := queryArgs.Get("metric")
+	if !chainstats_view.IsValidStatsMetric(metric) {
+		httpapi.BadRequest(ctx, fmt.Errorf(
+			"invalid metric, expected one of %s", strings.Join(chainstats_view.STATS_METRICS, ", "),
+		))
+		return
+	}
+
+	interval := chainstats_view.STATS_INTERVAL_DAY
+	if queryArgs.Has("interval") {
+		interval = queryArgs.Get("interval")
+		if !chainstats_view.IsValidStatsInterval(interval) {
+			httpapi.BadRequest(ctx, errors.New("invalid interval, expected one of hour and day"))
+			return
+		}
+	}
+
+	from, to, err := httpapi.ParseSeriesTimeRange(queryArgs, httpapi.SeriesBuckets{
+		Start: func(t utctime.UTCTime) utctime.UTCTime {
+			return chainstats_view.StatsBucketStart(t, interval)
+		},
+		Next: func(bucketStart utctime.UTCTime) utctime.UTCTime {
+			return chainstats_view.NextStatsBucketStart(bucketStart, interval)
+		},
+		MaxCount: MAX_STATS_SERIES_BUCKETS,
+	})
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	series, err := handler.statsBucketsView.ListSeries(chainstats_view.StatsSeriesFilter{
+		Metric:   metric,
+		Interval: interval,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		handler.logger.Errorf("error listing stats series: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, series)
+}
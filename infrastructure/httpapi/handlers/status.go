@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
+
+	"github.com/crypto-com/chain-indexing/appinterface/leaderelection"
+	status_polling "github.com/crypto-com/chain-indexing/appinterface/polling"
 	block_view "github.com/crypto-com/chain-indexing/appinterface/projection/block/view"
 	transaction_view "github.com/crypto-com/chain-indexing/appinterface/projection/transaction/view"
 	"github.com/crypto-com/chain-indexing/appinterface/projection/validator/constants"
@@ -12,8 +16,6 @@ import (
 	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
 	"github.com/valyala/fasthttp"
-	status_polling "github.com/crypto-com/chain-indexing/appinterface/polling"
-
 )
 
 type StatusHandler struct {
@@ -23,8 +25,8 @@ type StatusHandler struct {
 	transactionsTotalView *transaction_view.TransactionsTotal
 	validatorsView        *validator_view.Validators
 	validatorStatsView    *validatorstats_view.ValidatorStats
-	statusView         *status_polling.Status
-
+	statusView            *status_polling.Status
+	leasesView            *leaderelection.Leases
 }
 
 func NewStatusHandler(logger applogger.Logger, rdbHandle *rdb.Handle) *StatusHandler {
@@ -38,6 +40,7 @@ func NewStatusHandler(logger applogger.Logger, rdbHandle *rdb.Handle) *StatusHan
 		validator_view.NewValidators(rdbHandle),
 		validatorstats_view.NewValidatorStats(rdbHandle),
 		status_polling.NewStatus(rdbHandle),
+		leaderelection.NewLeases(rdbHandle),
 	}
 }
 
@@ -95,15 +98,26 @@ func (handler *StatusHandler) GetStatus(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-
-	var latestHeightValue int64 =0
-	if n, err := strconv.ParseInt(latestHeight,10,64); err == nil {
-		latestHeightValue= n
+	var latestHeightValue int64 = 0
+	if n, err := strconv.ParseInt(latestHeight, 10, 64); err == nil {
+		latestHeightValue = n
 	} else {
 		handler.logger.Errorf("error convert latest height from string to int64: %v", err)
 		httpapi.InternalServerError(ctx)
 	}
 
+	// Leader is null when leader election is disabled or no instance is leading. The lease expiry is
+	// judged by the database clock the leases are written with.
+	maybeLeader, err := handler.leasesView.FindUnexpiredBy(leaderelection.DEFAULT_LEASE_NAME)
+	if err != nil {
+		if !errors.Is(err, rdb.ErrNoRows) {
+			handler.logger.Errorf("error fetching leader lease: %v", err)
+			httpapi.InternalServerError(ctx)
+			return
+		}
+		maybeLeader = nil
+	}
+
 	status := Status{
 		BlockCount:           blockCount,
 		TransactionCount:     transactionCount,
@@ -111,19 +125,20 @@ func (handler *StatusHandler) GetStatus(ctx *fasthttp.RequestCtx) {
 		TotalReward:          totalReward,
 		ValidatorCount:       validatorCount,
 		ActiveValidatorCount: activeValidatorCount,
-		LatestHeight: latestHeightValue,
-
+		LatestHeight:         latestHeightValue,
+		MaybeLeader:          maybeLeader,
 	}
 
 	httpapi.Success(ctx, status)
 }
 
 type Status struct {
-	BlockCount           int64  `json:"blockCount"`
-	TransactionCount     int64  `json:"transactionCount"`
-	TotalDelegated       string `json:"totalDelegated"`
-	TotalReward          string `json:"totalReward"`
-	ValidatorCount       int64  `json:"validatorCount"`
-	ActiveValidatorCount int64  `json:"activeValidatorCount"`
-	LatestHeight    int64   `json:"latestHeight"`
+	BlockCount           int64                 `json:"blockCount"`
+	TransactionCount     int64                 `json:"transactionCount"`
+	TotalDelegated       string                `json:"totalDelegated"`
+	TotalReward          string                `json:"totalReward"`
+	ValidatorCount       int64                 `json:"validatorCount"`
+	ActiveValidatorCount int64                 `json:"activeValidatorCount"`
+	LatestHeight         int64                 `json:"latestHeight"`
+	MaybeLeader          *leaderelection.Lease `json:"leader"`
 }
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/valyala/fasthttp"
+
+	balance_view "github.com/crypto-com/chain-indexing/appinterface/projection/balance/view"
+	supply_view "github.com/crypto-com/chain-indexing/appinterface/projection/supply/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+)
+
+// Decimal places of the supply in CRO
+const SUPPLY_CRO_PRECISION = 8
+
+type Supply struct {
+	logger applogger.Logger
+
+	historiesView *supply_view.SupplyHistories
+	balancesView  *balance_view.Balances
+
+	nonCirculatingAddresses []string
+}
+
+func NewSupply(logger applogger.Logger, rdbHandle *rdb.Handle, nonCirculatingAddresses []string) *Supply {
+	return &Supply{
+		logger.WithFields(applogger.LogFields{
+			"module": "SupplyHandler",
+		}),
+
+		supply_view.NewSupplyHistories(rdbHandle),
+		balance_view.NewBalances(rdbHandle),
+
+		nonCirculatingAddresses,
+	}
+}
+
+// Latest returns the latest total and circulating supply in base denom, together with the minting
+// parameters
+func (handler *Supply) Latest(ctx *fasthttp.RequestCtx) {
+	history, circulatingSupply, ok := handler.latest(ctx)
+	if !ok {
+		return
+	}
+
+	httpapi.Success(ctx, SupplyResponse{
+		BlockHeight:              history.BlockHeight,
+		BlockTime:                history.BlockTime,
+		TotalSupply:              history.TotalSupply,
+		CirculatingSupply:        circulatingSupply.String(),
+		TotalMinted:              history.TotalMinted,
+		TotalCommunityPoolInflow: history.TotalCommunityPoolInflow,
+		MaybeBondedRatio:         history.MaybeBondedRatio,
+		MaybeInflation:           history.MaybeInflation,
+		MaybeAnnualProvisions:    history.MaybeAnnualProvisions,
+	})
+}
+
+// Total returns the latest total supply in CRO as plain text, as expected by market data aggregators
+func (handler *Supply) Total(ctx *fasthttp.RequestCtx) {
+	history, _, ok := handler.latest(ctx)
+	if !ok {
+		return
+	}
+
+	totalSupply, ok := new(big.Int).SetString(history.TotalSupply, 10)
+	if !ok {
+		handler.logger.Errorf("error parsing total supply: %s", history.TotalSupply)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessText(ctx, croString(totalSupply))
+}
+
+// Circulating returns the latest circulating supply in CRO as plain text, as expected by market data
+// aggregators
+func (handler *Supply) Circulating(ctx *fasthttp.RequestCtx) {
+	_, circulatingSupply, ok := handler.latest(ctx)
+	if !ok {
+		return
+	}
+
+	httpapi.SuccessText(ctx, croString(circulatingSupply))
+}
+
+// ListHistories returns the supply at every height it changes between `from` (inclusive) and `to`
+// (exclusive), both in RFC3339
+func (handler *Supply) ListHistories(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+
+	filter := supply_view.SupplyHistoriesListFilter{}
+	if filter.MaybeFromTime, filter.MaybeToTime, err = httpapi.ParseTimeRange(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := supply_view.SupplyHistoriesListOrder{}
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "height" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	histories, paginationResult, err := handler.historiesView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing supply histories: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, histories, paginationResult)
+}
+
+// latest returns the latest supply history and circulating supply. The circulating supply is the total
+// supply less the balances of the configured non-circulating addresses, such as the distribution module
+// account holding the community pool. Its balance follows the community tax and the community pool spends,
+// which the community pool inflow does not. Like the total supply, it is an approximation which does not
+// account for the coins burned by slashing. It responds the error and returns false on failure.
+func (handler *Supply) latest(ctx *fasthttp.RequestCtx) (*supply_view.SupplyHistoryRow, *big.Int, bool) {
+	history, err := handler.historiesView.FindLatest(nil)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return nil, nil, false
+		}
+		handler.logger.Errorf("error finding latest supply: %v", err)
+		httpapi.InternalServerError(ctx)
+		return nil, nil, false
+	}
+
+	circulatingSupply, err := handler.circulatingSupply(history)
+	if err != nil {
+		handler.logger.Errorf("error calculating circulating supply: %v", err)
+		httpapi.InternalServerError(ctx)
+		return nil, nil, false
+	}
+
+	return history, circulatingSupply, true
+}
+
+func (handler *Supply) circulatingSupply(history *supply_view.SupplyHistoryRow) (*big.Int, error) {
+	circulatingSupply, ok := new(big.Int).SetString(history.TotalSupply, 10)
+	if !ok {
+		return nil, fmt.Errorf("error parsing total supply: %s", history.TotalSupply)
+	}
+
+	for _, address := range handler.nonCirculatingAddresses {
+		balance, err := handler.balancesView.FindBy(address)
+		if err != nil {
+			if errors.Is(err, rdb.ErrNoRows) {
+				continue
+			}
+			return nil, fmt.Errorf("error finding balance of %s: %v", address, err)
+		}
+		balanceValue, ok := new(big.Int).SetString(balance.Balance, 10)
+		if !ok {
+			return nil, fmt.Errorf("error parsing balance of %s: %s", address, balance.Balance)
+		}
+		circulatingSupply.Sub(circulatingSupply, balanceValue)
+	}
+
+	return circulatingSupply, nil
+}
+
+func croString(baseAmount *big.Int) string {
+	return new(big.Rat).SetFrac(baseAmount, coin.MAX_COIN_DECIMALS_BIGINT).FloatString(SUPPLY_CRO_PRECISION)
+}
+
+// SupplyResponse amounts are in base denom
+type SupplyResponse struct {
+	BlockHeight              int64           `json:"blockHeight"`
+	BlockTime                utctime.UTCTime `json:"blockTime"`
+	TotalSupply              string          `json:"totalSupply"`
+	CirculatingSupply        string          `json:"circulatingSupply"`
+	TotalMinted              string          `json:"totalMinted"`
+	TotalCommunityPoolInflow string          `json:"totalCommunityPoolInflow"`
+	MaybeBondedRatio         *string         `json:"bondedRatio"`
+	MaybeInflation           *string         `json:"inflation"`
+	MaybeAnnualProvisions    *string         `json:"annualProvisions"`
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/valyala/fasthttp"
+
+	validatorreward_view "github.com/crypto-com/chain-indexing/appinterface/projection/validatorreward/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
+	applogger "github.com/crypto-com/chain-indexing/internal/logger"
+	"github.com/crypto-com/chain-indexing/internal/primptr"
+)
+
+type ValidatorEarnings struct {
+	logger applogger.Logger
+
+	rewardBucketsView         *validatorreward_view.RewardBuckets
+	rewardTotalsView          *validatorreward_view.RewardTotals
+	commissionWithdrawalsView *validatorreward_view.CommissionWithdrawals
+}
+
+func NewValidatorEarnings(logger applogger.Logger, rdbHandle *rdb.Handle) *ValidatorEarnings {
+	return &ValidatorEarnings{
+		logger.WithFields(applogger.LogFields{
+			"module": "ValidatorEarningsHandler",
+		}),
+
+		validatorreward_view.NewRewardBuckets(rdbHandle),
+		validatorreward_view.NewRewardTotals(rdbHandle),
+		validatorreward_view.NewCommissionWithdrawals(rdbHandle),
+	}
+}
+
+// ListByValidator returns the earnings of the validator by `interval` (hour or day, defaults to day).
+// Only buckets with earnings are returned, optionally between `from` (inclusive) and `to` (exclusive)
+// in RFC3339.
+func (handler *ValidatorEarnings) ListByValidator(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParam, _ := ctx.UserValue("address").(string)
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+
+	filter := validatorreward_view.RewardBucketsListFilter{
+		OperatorAddress: addressParam,
+		Interval:        validatorreward_view.REWARD_BUCKET_INTERVAL_DAY,
+	}
+	if queryArgs.Has("interval") {
+		filter.Interval = queryArgs.Get("interval")
+		if !validatorreward_view.IsValidRewardBucketInterval(filter.Interval) {
+			httpapi.BadRequest(ctx, errors.New("invalid interval, expected one of hour and day"))
+			return
+		}
+	}
+	if filter.MaybeFrom, filter.MaybeTo, err = httpapi.ParseTimeRange(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	order := validatorreward_view.RewardBucketsListOrder{}
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "time" {
+			order.MaybeBucketStart = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "time.desc" {
+			order.MaybeBucketStart = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	buckets, paginationResult, err := handler.rewardBucketsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing validator reward buckets: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, buckets, paginationResult)
+}
+
+// FindTotalByValidator returns the lifetime earnings and the outstanding commission of the validator
+func (handler *ValidatorEarnings) FindTotalByValidator(ctx *fasthttp.RequestCtx) {
+	addressParam, _ := ctx.UserValue("address").(string)
+
+	total, err := handler.rewardTotalsView.FindBy(addressParam)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding validator reward total: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, total)
+}
+
+func (handler *ValidatorEarnings) ListCommissionWithdrawalsByValidator(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParam, _ := ctx.UserValue("address").(string)
+	order := validatorreward_view.CommissionWithdrawalsListOrder{}
+	queryArgs := ctx.QueryArgs()
+	if queryArgs.Has("order") {
+		orderArg := string(queryArgs.Peek("order"))
+		if orderArg == "height" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeBlockHeight = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	withdrawals, paginationResult, err := handler.commissionWithdrawalsView.List(
+		validatorreward_view.CommissionWithdrawalsListFilter{
+			OperatorAddress: addressParam,
+		},
+		order,
+		pagination,
+	)
+	if err != nil {
+		handler.logger.Errorf("error listing commission withdrawals: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, withdrawals, paginationResult)
+}
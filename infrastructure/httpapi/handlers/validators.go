@@ -2,18 +2,27 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/crypto-com/chain-indexing/appinterface/cosmosapp"
 
 	"github.com/crypto-com/chain-indexing/appinterface/projection/view"
 	"github.com/crypto-com/chain-indexing/internal/primptr"
+	"github.com/crypto-com/chain-indexing/internal/utctime"
 
 	"github.com/crypto-com/chain-indexing/appinterface/projection/validator/constants"
 
 	"github.com/valyala/fasthttp"
 
+	"github.com/crypto-com/chain-indexing/appinterface/projection/stakingapr"
+	stakingapr_view "github.com/crypto-com/chain-indexing/appinterface/projection/stakingapr/view"
 	validator_view "github.com/crypto-com/chain-indexing/appinterface/projection/validator/view"
+	validatorhistory_view "github.com/crypto-com/chain-indexing/appinterface/projection/validatorhistory/view"
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validatoruptime"
+	validatoruptime_view "github.com/crypto-com/chain-indexing/appinterface/projection/validatoruptime/view"
+	votingpower_view "github.com/crypto-com/chain-indexing/appinterface/projection/votingpower/view"
 	"github.com/crypto-com/chain-indexing/appinterface/rdb"
 	"github.com/crypto-com/chain-indexing/infrastructure/httpapi"
 	applogger "github.com/crypto-com/chain-indexing/internal/logger"
@@ -28,6 +37,12 @@ type Validators struct {
 	cosmosAppClient         cosmosapp.Client
 	validatorsView          *validator_view.Validators
 	validatorActivitiesView *validator_view.ValidatorActivities
+	uptimesView             *validatoruptime_view.Uptimes
+	uptimeParamsView        *validatoruptime_view.Params
+	aprHistoriesView        *stakingapr_view.APRHistories
+	validatorHistoriesView  *validatorhistory_view.ValidatorHistories
+	powerHistoriesView      *votingpower_view.PowerHistories
+	decentralizationsView   *votingpower_view.Decentralizations
 }
 
 func NewValidators(
@@ -48,6 +63,12 @@ func NewValidators(
 		cosmosAppClient,
 		validator_view.NewValidators(rdbHandle),
 		validator_view.NewValidatorActivities(rdbHandle),
+		validatoruptime_view.NewUptimes(rdbHandle),
+		validatoruptime_view.NewParams(rdbHandle),
+		stakingapr_view.NewAPRHistories(rdbHandle),
+		validatorhistory_view.NewValidatorHistories(rdbHandle),
+		votingpower_view.NewPowerHistories(rdbHandle),
+		votingpower_view.NewDecentralizations(rdbHandle),
 	}
 }
 
@@ -131,7 +152,14 @@ func (handler *Validators) List(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	httpapi.SuccessWithPagination(ctx, validators, paginationResult)
+	validatorsWithUptime, err := handler.withUptimes(validators)
+	if err != nil {
+		handler.logger.Errorf("error getting validator uptimes: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, validatorsWithUptime, paginationResult)
 }
 
 func (handler *Validators) ListActive(ctx *fasthttp.RequestCtx) {
@@ -170,7 +198,14 @@ func (handler *Validators) ListActive(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	httpapi.SuccessWithPagination(ctx, validators, paginationResult)
+	validatorsWithUptime, err := handler.withUptimes(validators)
+	if err != nil {
+		handler.logger.Errorf("error getting validator uptimes: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, validatorsWithUptime, paginationResult)
 }
 
 func (handler *Validators) ListActivities(ctx *fasthttp.RequestCtx) {
@@ -216,6 +251,429 @@ func (handler *Validators) ListActivities(ctx *fasthttp.RequestCtx) {
 	httpapi.SuccessWithPagination(ctx, blocks, paginationResult)
 }
 
+func (handler *Validators) FindUptimeBy(ctx *fasthttp.RequestCtx) {
+	addressParams, _ := ctx.UserValue("address").(string)
+	var identity validatoruptime_view.UptimeIdentity
+	if strings.HasPrefix(addressParams, handler.validatorAddressPrefix) {
+		identity = validatoruptime_view.UptimeIdentity{
+			MaybeOperatorAddress: &addressParams,
+		}
+	} else if strings.HasPrefix(addressParams, handler.consNodeAddressPrefix) {
+		identity = validatoruptime_view.UptimeIdentity{
+			MaybeConsensusNodeAddress: &addressParams,
+		}
+	} else {
+		httpapi.BadRequest(ctx, errors.New("invalid validator address"))
+		return
+	}
+
+	uptime, err := handler.uptimesView.FindBy(identity)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding validator uptime: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	signedBlocksWindow, err := validatoruptime.GetSignedBlocksWindow(handler.uptimeParamsView)
+	if err != nil {
+		handler.logger.Errorf("error getting signed blocks window: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, uptime.Summary(signedBlocksWindow))
+}
+
+// FindNetworkAPR returns the latest network-wide staking APR estimation before validator commission
+func (handler *Validators) FindNetworkAPR(ctx *fasthttp.RequestCtx) {
+	history, err := handler.aprHistoriesView.FindLatest()
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding latest staking APR: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, history)
+}
+
+// ListNetworkAPRHistories returns the daily network-wide staking APR estimations between the days of
+// `from` (inclusive) and `to` (exclusive), both in RFC3339
+func (handler *Validators) ListNetworkAPRHistories(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+
+	filter := stakingapr_view.APRHistoriesListFilter{}
+	maybeFrom, maybeTo, err := httpapi.ParseTimeRange(queryArgs)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	if maybeFrom != nil {
+		fromDay := stakingapr_view.APRDayStart(*maybeFrom)
+		filter.MaybeFromDay = &fromDay
+	}
+	if maybeTo != nil {
+		toDay := stakingapr_view.APRDayStart(*maybeTo)
+		filter.MaybeToDay = &toDay
+	}
+
+	order := stakingapr_view.APRHistoriesListOrder{}
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "day" {
+			order.MaybeDay = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "day.desc" {
+			order.MaybeDay = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	histories, paginationResult, err := handler.aprHistoriesView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing staking APR histories: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, histories, paginationResult)
+}
+
+// FindAPRBy returns the latest staking APR estimation of the delegators of the validator, which is the
+// network-wide APR after the current validator commission
+func (handler *Validators) FindAPRBy(ctx *fasthttp.RequestCtx) {
+	addressParams, _ := ctx.UserValue("address").(string)
+	var identity validator_view.ValidatorIdentity
+	if strings.HasPrefix(addressParams, handler.validatorAddressPrefix) {
+		identity = validator_view.ValidatorIdentity{
+			MaybeOperatorAddress: &addressParams,
+		}
+	} else if strings.HasPrefix(addressParams, handler.consNodeAddressPrefix) {
+		identity = validator_view.ValidatorIdentity{
+			MaybeConsensusNodeAddress: &addressParams,
+		}
+	} else {
+		httpapi.BadRequest(ctx, errors.New("invalid validator address"))
+		return
+	}
+
+	validator, err := handler.validatorsView.FindBy(identity)
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding validator: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	history, err := handler.aprHistoriesView.FindLatest()
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding latest staking APR: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	response := ValidatorAPR{
+		OperatorAddress: validator.OperatorAddress,
+		CommissionRate:  validator.CommissionRate,
+		MaybeNetworkAPR: history.MaybeAPR,
+		BlockHeight:     history.BlockHeight,
+		BlockTime:       history.BlockTime,
+	}
+	if history.MaybeAPR != nil {
+		apr, estimateErr := stakingapr.EstimateValidatorAPR(*history.MaybeAPR, validator.CommissionRate)
+		if estimateErr != nil {
+			handler.logger.Errorf("error estimating validator APR: %v", estimateErr)
+			httpapi.InternalServerError(ctx)
+			return
+		}
+		response.MaybeAPR = &apr
+	}
+
+	httpapi.Success(ctx, response)
+}
+
+// ListHistoryBy returns the description and commission changes of the validator, the latest first unless
+// ordered by `height`
+func (handler *Validators) ListHistoryBy(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParams, _ := ctx.UserValue("address").(string)
+	var operatorAddress string
+	if strings.HasPrefix(addressParams, handler.validatorAddressPrefix) {
+		operatorAddress = addressParams
+	} else if strings.HasPrefix(addressParams, handler.consNodeAddressPrefix) {
+		validator, findErr := handler.validatorsView.FindBy(validator_view.ValidatorIdentity{
+			MaybeConsensusNodeAddress: &addressParams,
+		})
+		if findErr != nil {
+			if errors.Is(findErr, rdb.ErrNoRows) {
+				httpapi.NotFound(ctx)
+				return
+			}
+			handler.logger.Errorf("error finding validator: %v", findErr)
+			httpapi.InternalServerError(ctx)
+			return
+		}
+		operatorAddress = validator.OperatorAddress
+	} else {
+		httpapi.BadRequest(ctx, errors.New("invalid validator address"))
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	var order validatorhistory_view.ValidatorHistoriesListOrder
+	if queryArgs.Has("order") {
+		orderArg := queryArgs.Get("order")
+		if orderArg == "height" {
+			order.MaybeId = primptr.String(view.ORDER_ASC)
+		} else if orderArg == "height.desc" {
+			order.MaybeId = primptr.String(view.ORDER_DESC)
+		} else {
+			httpapi.BadRequest(ctx, errors.New("invalid order"))
+			return
+		}
+	}
+
+	histories, paginationResult, err := handler.validatorHistoriesView.ListByOperatorAddress(
+		operatorAddress, order, pagination,
+	)
+	if err != nil {
+		handler.logger.Errorf("error listing validator histories: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, histories, paginationResult)
+}
+
+// ListCommissionIncreases returns the commission increases of all validators, the latest first, optionally
+// since the RFC3339 time `since`
+func (handler *Validators) ListCommissionIncreases(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	var filter validatorhistory_view.ValidatorCommissionIncreasesListFilter
+	if queryArgs.Has("since") {
+		since, parseErr := utctime.Parse(time.RFC3339, queryArgs.Get("since"))
+		if parseErr != nil {
+			httpapi.BadRequest(ctx, errors.New("invalid since, expected RFC3339 format"))
+			return
+		}
+		filter.MaybeSinceTime = &since
+	}
+
+	increases, paginationResult, err := handler.validatorHistoriesView.ListCommissionIncreases(filter, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing validator commission increases: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, increases, paginationResult)
+}
+
+// ListPowerHistoriesBy returns the voting power changes of the validator between `from` (inclusive) and
+// `to` (exclusive), both in RFC3339, the latest first unless ordered by `height`
+func (handler *Validators) ListPowerHistoriesBy(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	addressParams, _ := ctx.UserValue("address").(string)
+	var consensusNodeAddress string
+	if strings.HasPrefix(addressParams, handler.validatorAddressPrefix) {
+		validator, findErr := handler.validatorsView.FindBy(validator_view.ValidatorIdentity{
+			MaybeOperatorAddress: &addressParams,
+		})
+		if findErr != nil {
+			if errors.Is(findErr, rdb.ErrNoRows) {
+				httpapi.NotFound(ctx)
+				return
+			}
+			handler.logger.Errorf("error finding validator: %v", findErr)
+			httpapi.InternalServerError(ctx)
+			return
+		}
+		consensusNodeAddress = validator.ConsensusNodeAddress
+	} else if strings.HasPrefix(addressParams, handler.consNodeAddressPrefix) {
+		consensusNodeAddress = addressParams
+	} else {
+		httpapi.BadRequest(ctx, errors.New("invalid validator address"))
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	var filter votingpower_view.PowerHistoriesListFilter
+	if filter.MaybeFromTime, filter.MaybeToTime, err = httpapi.ParseTimeRange(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	var order votingpower_view.PowerHistoriesListOrder
+	if order.MaybeBlockHeight, err = parseHeightOrder(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	histories, paginationResult, err := handler.powerHistoriesView.ListByConsensusNodeAddress(
+		consensusNodeAddress, filter, order, pagination,
+	)
+	if err != nil {
+		handler.logger.Errorf("error listing voting power histories: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, histories, paginationResult)
+}
+
+// FindDecentralization returns the latest total bonded power and decentralization metrics
+func (handler *Validators) FindDecentralization(ctx *fasthttp.RequestCtx) {
+	decentralization, err := handler.decentralizationsView.FindLatest()
+	if err != nil {
+		if errors.Is(err, rdb.ErrNoRows) {
+			httpapi.NotFound(ctx)
+			return
+		}
+		handler.logger.Errorf("error finding latest decentralization: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.Success(ctx, decentralization)
+}
+
+// ListDecentralizationHistories returns the total bonded power and decentralization metrics after every
+// block with power changes between `from` (inclusive) and `to` (exclusive), both in RFC3339, the latest
+// first unless ordered by `height`
+func (handler *Validators) ListDecentralizationHistories(ctx *fasthttp.RequestCtx) {
+	pagination, err := httpapi.ParsePagination(ctx)
+	if err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	queryArgs := httpapi.NewQueryArgs(ctx.QueryArgs())
+	var filter votingpower_view.DecentralizationsListFilter
+	if filter.MaybeFromTime, filter.MaybeToTime, err = httpapi.ParseTimeRange(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+	var order votingpower_view.DecentralizationsListOrder
+	if order.MaybeBlockHeight, err = parseHeightOrder(queryArgs); err != nil {
+		httpapi.BadRequest(ctx, err)
+		return
+	}
+
+	decentralizations, paginationResult, err := handler.decentralizationsView.List(filter, order, pagination)
+	if err != nil {
+		handler.logger.Errorf("error listing decentralizations: %v", err)
+		httpapi.InternalServerError(ctx)
+		return
+	}
+
+	httpapi.SuccessWithPagination(ctx, decentralizations, paginationResult)
+}
+
+// parseHeightOrder parses the optional `order` query argument of `height` or `height.desc`
+func parseHeightOrder(queryArgs *httpapi.QueryArgs) (*view.ORDER, error) {
+	if !queryArgs.Has("order") {
+		return nil, nil
+	}
+
+	orderArg := queryArgs.Get("order")
+	if orderArg == "height" {
+		return primptr.String(view.ORDER_ASC), nil
+	} else if orderArg == "height.desc" {
+		return primptr.String(view.ORDER_DESC), nil
+	}
+	return nil, errors.New("invalid order")
+}
+
+func (handler *Validators) withUptimes(
+	validators []validator_view.ListValidatorRow,
+) ([]ListValidatorWithUptime, error) {
+	consensusNodeAddresses := make([]string, 0, len(validators))
+	for _, validator := range validators {
+		consensusNodeAddresses = append(consensusNodeAddresses, validator.ConsensusNodeAddress)
+	}
+	uptimes, err := handler.uptimesView.ListAll(validatoruptime_view.UptimesListFilter{
+		MaybeConsensusNodeAddresses: consensusNodeAddresses,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing validator uptimes: %v", err)
+	}
+	signedBlocksWindow, err := validatoruptime.GetSignedBlocksWindow(handler.uptimeParamsView)
+	if err != nil {
+		return nil, fmt.Errorf("error getting signed blocks window: %v", err)
+	}
+
+	uptimeSummaries := make(map[string]*validatoruptime_view.UptimeSummary, len(uptimes))
+	for i := range uptimes {
+		uptimeSummaries[uptimes[i].ConsensusNodeAddress] = uptimes[i].Summary(signedBlocksWindow)
+	}
+
+	validatorsWithUptime := make([]ListValidatorWithUptime, 0, len(validators))
+	for _, validator := range validators {
+		validatorsWithUptime = append(validatorsWithUptime, ListValidatorWithUptime{
+			ListValidatorRow: validator,
+
+			MaybeUptime: uptimeSummaries[validator.ConsensusNodeAddress],
+		})
+	}
+
+	return validatorsWithUptime, nil
+}
+
+// ListValidatorWithUptime is a validator in the lists. Uptime is null when the ValidatorUptime projection
+// has not seen the validator.
+type ListValidatorWithUptime struct {
+	validator_view.ListValidatorRow
+
+	MaybeUptime *validatoruptime_view.UptimeSummary `json:"uptime"`
+}
+
+// ValidatorAPR is the staking APR of the delegators of the validator. APRs are null when nothing is
+// bonded.
+type ValidatorAPR struct {
+	OperatorAddress string  `json:"operatorAddress"`
+	CommissionRate  string  `json:"commissionRate"`
+	MaybeNetworkAPR *string `json:"networkApr"`
+	MaybeAPR        *string `json:"apr"`
+	// Height and time of the minting parameters the estimation is based on
+	BlockHeight int64           `json:"blockHeight"`
+	BlockTime   utctime.UTCTime `json:"blockTime"`
+}
+
 type ValidatorDetails struct {
 	*validator_view.ValidatorRow
 
@@ -1,6 +1,9 @@
 package httpapi
 
 import (
+	"encoding/csv"
+	"fmt"
+
 	pagination_interface "github.com/crypto-com/chain-indexing/appinterface/pagination"
 	"github.com/valyala/fasthttp"
 
@@ -36,6 +39,23 @@ func SuccessWithPagination(
 	}
 }
 
+// SuccessCSV responds the records as a CSV attachment named filename
+func SuccessCSV(ctx *fasthttp.RequestCtx, filename string, records [][]string) {
+	ctx.Response.Header.Set("Content-Type", "text/csv")
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	writer := csv.NewWriter(ctx.Response.BodyWriter())
+	if err := writer.WriteAll(records); err != nil {
+		ctx.Response.ResetBody()
+		InternalServerError(ctx)
+	}
+}
+
+// SuccessText responds the text as plain text
+func SuccessText(ctx *fasthttp.RequestCtx, text string) {
+	ctx.Response.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	ctx.Response.SetBodyString(text)
+}
+
 func NotFound(ctx *fasthttp.RequestCtx) {
 	ctx.Response.Header.Set("Content-Type", "application/json")
 	message, err := jsoniter.Marshal(Response{
@@ -9,14 +9,26 @@ import (
 )
 
 type RouteRegistry struct {
-	searchHandler          *handlers.Search
-	blocksHandler          *handlers.Blocks
-	statusHandler          *handlers.StatusHandler
-	transactionHandler     *handlers.Transactions
-	blockEventHandler      *handlers.BlockEvents
-	validatorsHandler      *handlers.Validators
-	accountMessagesHandler *handlers.AccountMessages
-	accountsHandler        *handlers.Accounts
+	searchHandler            *handlers.Search
+	blocksHandler            *handlers.Blocks
+	statusHandler            *handlers.StatusHandler
+	transactionHandler       *handlers.Transactions
+	blockEventHandler        *handlers.BlockEvents
+	validatorsHandler        *handlers.Validators
+	accountMessagesHandler   *handlers.AccountMessages
+	accountsHandler          *handlers.Accounts
+	healthHandler            *handlers.Health
+	proposalsHandler         *handlers.Proposals
+	delegationsHandler       *handlers.Delegations
+	balancesHandler          *handlers.Balances
+	validatorEarningsHandler *handlers.ValidatorEarnings
+	delegatorRewardsHandler  *handlers.DelegatorRewards
+	statsHandler             *handlers.Stats
+	supplyHandler            *handlers.Supply
+	feesHandler              *handlers.Fees
+	accountRankingsHandler   *handlers.AccountRankings
+	proposerStatsHandler     *handlers.ProposerStats
+	slashingHandler          *handlers.Slashing
 }
 
 func NewRoutesRegistry(
@@ -28,6 +40,18 @@ func NewRoutesRegistry(
 	validatorsHandler *handlers.Validators,
 	accountMessagesHandler *handlers.AccountMessages,
 	accountsHandler *handlers.Accounts,
+	healthHandler *handlers.Health,
+	proposalsHandler *handlers.Proposals,
+	delegationsHandler *handlers.Delegations,
+	balancesHandler *handlers.Balances,
+	validatorEarningsHandler *handlers.ValidatorEarnings,
+	delegatorRewardsHandler *handlers.DelegatorRewards,
+	statsHandler *handlers.Stats,
+	supplyHandler *handlers.Supply,
+	feesHandler *handlers.Fees,
+	accountRankingsHandler *handlers.AccountRankings,
+	proposerStatsHandler *handlers.ProposerStats,
+	slashingHandler *handlers.Slashing,
 ) *RouteRegistry {
 	return &RouteRegistry{
 		searchHandler,
@@ -38,6 +62,18 @@ func NewRoutesRegistry(
 		validatorsHandler,
 		accountMessagesHandler,
 		accountsHandler,
+		healthHandler,
+		proposalsHandler,
+		delegationsHandler,
+		balancesHandler,
+		validatorEarningsHandler,
+		delegatorRewardsHandler,
+		statsHandler,
+		supplyHandler,
+		feesHandler,
+		accountRankingsHandler,
+		proposerStatsHandler,
+		slashingHandler,
 	}
 }
 
@@ -50,23 +86,76 @@ func (registry *RouteRegistry) Register(server *httpapi.Server, routePrefix stri
 		ctx.SetStatusCode(fasthttp.StatusOK)
 		ctx.SetBody([]byte("Ok"))
 	})
+	server.GET(fmt.Sprintf("%s/api/v1/health/live", routePrefix), registry.healthHandler.Live)
+	server.GET(fmt.Sprintf("%s/api/v1/health/ready", routePrefix), registry.healthHandler.Ready)
 	server.GET(fmt.Sprintf("%s/api/v1/search", routePrefix), registry.searchHandler.Search)
 	server.GET(fmt.Sprintf("%s/api/v1/blocks", routePrefix), registry.blocksHandler.List)
 	server.GET(fmt.Sprintf("%s/api/v1/blocks/{height-or-hash}", routePrefix), registry.blocksHandler.FindBy)
 	server.GET(fmt.Sprintf("%s/api/v1/blocks/{height}/transactions", routePrefix), registry.blocksHandler.ListTransactionsByHeight)
 	server.GET(fmt.Sprintf("%s/api/v1/blocks/{height}/events", routePrefix), registry.blocksHandler.ListEventsByHeight)
 	server.GET(fmt.Sprintf("%s/api/v1/status", routePrefix), registry.statusHandler.GetStatus)
+	server.GET(fmt.Sprintf("%s/api/v1/stats/timeseries", routePrefix), registry.statsHandler.ListTimeSeries)
+	server.GET(fmt.Sprintf("%s/api/v1/supply", routePrefix), registry.supplyHandler.Latest)
+	server.GET(fmt.Sprintf("%s/api/v1/supply/total", routePrefix), registry.supplyHandler.Total)
+	server.GET(fmt.Sprintf("%s/api/v1/supply/circulating", routePrefix), registry.supplyHandler.Circulating)
+	server.GET(fmt.Sprintf("%s/api/v1/supply/histories", routePrefix), registry.supplyHandler.ListHistories)
+	server.GET(fmt.Sprintf("%s/api/v1/fees/estimate", routePrefix), registry.feesHandler.Estimate)
+	server.GET(fmt.Sprintf("%s/api/v1/fees/blocks", routePrefix), registry.feesHandler.ListBlocks)
+	server.GET(fmt.Sprintf("%s/api/v1/fees/hourly", routePrefix), registry.feesHandler.ListHours)
+	server.GET(fmt.Sprintf("%s/api/v1/fees/messages", routePrefix), registry.feesHandler.ListMessageGas)
+	server.GET(fmt.Sprintf("%s/api/v1/slashing", routePrefix), registry.slashingHandler.ListIncidents)
 	server.GET(fmt.Sprintf("%s/api/v1/transactions", routePrefix), registry.transactionHandler.List)
 	server.GET(fmt.Sprintf("%s/api/v1/transactions/{hash}", routePrefix), registry.transactionHandler.FindByHash)
 	server.GET(fmt.Sprintf("%s/api/v1/events", routePrefix), registry.blockEventHandler.List)
 	server.GET(fmt.Sprintf("%s/api/v1/events/{id}", routePrefix), registry.blockEventHandler.FindById)
 	server.GET(fmt.Sprintf("%s/api/v1/accounts/{account}/messages", routePrefix), registry.accountMessagesHandler.ListByAccount)
+	server.GET(fmt.Sprintf("%s/api/v1/accounts/{account}/balance", routePrefix), registry.balancesHandler.FindByAccount)
+	server.GET(fmt.Sprintf("%s/api/v1/accounts/{account}/balance_history", routePrefix), registry.balancesHandler.ListSeriesByAccount)
 	server.GET(fmt.Sprintf("%s/api/v1/validators", routePrefix), registry.validatorsHandler.List)
 	server.GET(fmt.Sprintf("%s/api/v1/validators/active", routePrefix), registry.validatorsHandler.ListActive)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/apr", routePrefix), registry.validatorsHandler.FindNetworkAPR)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/apr/histories", routePrefix), registry.validatorsHandler.ListNetworkAPRHistories)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/commission_increases", routePrefix), registry.validatorsHandler.ListCommissionIncreases)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/decentralization", routePrefix), registry.validatorsHandler.FindDecentralization)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/decentralization/histories", routePrefix), registry.validatorsHandler.ListDecentralizationHistories)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/proposers", routePrefix), registry.proposerStatsHandler.ListTop)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/proposers/buckets", routePrefix), registry.proposerStatsHandler.ListTopByBucket)
 	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}", routePrefix), registry.validatorsHandler.FindBy)
 	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/activities", routePrefix), registry.validatorsHandler.ListActivities)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/uptime", routePrefix), registry.validatorsHandler.FindUptimeBy)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/apr", routePrefix), registry.validatorsHandler.FindAPRBy)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/history", routePrefix), registry.validatorsHandler.ListHistoryBy)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/power_histories", routePrefix), registry.validatorsHandler.ListPowerHistoriesBy)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/earnings", routePrefix), registry.validatorEarningsHandler.ListByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/earnings/total", routePrefix), registry.validatorEarningsHandler.FindTotalByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/commission_withdrawals", routePrefix), registry.validatorEarningsHandler.ListCommissionWithdrawalsByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/proposer_stats", routePrefix), registry.proposerStatsHandler.ListByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/proposer_stats/total", routePrefix), registry.proposerStatsHandler.FindTotalByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/proposed_blocks", routePrefix), registry.proposerStatsHandler.ListProposedBlocksByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/slashing", routePrefix), registry.slashingHandler.ListIncidentsByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/downtimes", routePrefix), registry.slashingHandler.ListDowntimesByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/reliability", routePrefix), registry.slashingHandler.FindReliabilityByValidator)
 	// Account number, sequence number, balance are fetched from the latest state (regardless of current replayed height)
 	server.GET(fmt.Sprintf("%s/api/v1/accounts/info", routePrefix), registry.accountsHandler.List)
+	server.GET(fmt.Sprintf("%s/api/v1/accounts/top", routePrefix), registry.accountRankingsHandler.ListTop)
+	server.GET(fmt.Sprintf("%s/api/v1/accounts/activities", routePrefix), registry.accountsHandler.ListActivities)
+	server.GET(fmt.Sprintf("%s/api/v1/accounts/{account}", routePrefix), registry.accountsHandler.FindSummaryBy)
+	server.GET(fmt.Sprintf("%s/api/v1/accounts/{account}/counterparties", routePrefix), registry.accountsHandler.ListCounterparties)
+	server.GET(fmt.Sprintf("%s/api/v1/accounts/top/concentration", routePrefix), registry.accountRankingsHandler.FindConcentration)
 	server.GET(fmt.Sprintf("%s/api/v1/accounts/info/{address}", routePrefix), registry.accountsHandler.FindBy)
+	server.GET(fmt.Sprintf("%s/api/v1/proposals", routePrefix), registry.proposalsHandler.List)
+	server.GET(fmt.Sprintf("%s/api/v1/proposals/{id}", routePrefix), registry.proposalsHandler.FindById)
+	server.GET(fmt.Sprintf("%s/api/v1/proposals/{id}/votes", routePrefix), registry.proposalsHandler.ListVotesById)
+	server.GET(fmt.Sprintf("%s/api/v1/proposals/{id}/deposits", routePrefix), registry.proposalsHandler.ListDepositsById)
+	server.GET(fmt.Sprintf("%s/api/v1/delegators/{address}/delegations", routePrefix), registry.delegationsHandler.ListByDelegator)
+	server.GET(fmt.Sprintf("%s/api/v1/delegators/{address}/unbonding_delegations", routePrefix), registry.delegationsHandler.ListUnbondingDelegationsByDelegator)
+	server.GET(fmt.Sprintf("%s/api/v1/delegators/{address}/redelegations", routePrefix), registry.delegationsHandler.ListRedelegationsByDelegator)
+	server.GET(fmt.Sprintf("%s/api/v1/delegators/{address}/histories", routePrefix), registry.delegationsHandler.ListHistoriesByDelegator)
+	server.GET(fmt.Sprintf("%s/api/v1/delegators/{address}/reward_withdrawals", routePrefix), registry.delegatorRewardsHandler.ListWithdrawalsByDelegator)
+	server.GET(fmt.Sprintf("%s/api/v1/delegators/{address}/reward_withdrawals/export", routePrefix), registry.delegatorRewardsHandler.ExportWithdrawalsByDelegator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/delegations", routePrefix), registry.delegationsHandler.ListByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/unbonding_delegations", routePrefix), registry.delegationsHandler.ListUnbondingDelegationsByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/redelegations", routePrefix), registry.delegationsHandler.ListRedelegationsByValidator)
+	server.GET(fmt.Sprintf("%s/api/v1/validators/{address}/reward_withdrawals", routePrefix), registry.delegatorRewardsHandler.ListWithdrawalsByValidator)
 
 }
@@ -10,6 +10,7 @@ import (
 type Server struct {
 	router           *router.Router
 	listeningAddress string
+	httpServer       *fasthttp.Server
 
 	middlewares      []Middleware
 	corsMiddleware   Middleware
@@ -22,6 +23,7 @@ func NewServer(listeningAddress string) *Server {
 	return &Server{
 		r,
 		listeningAddress,
+		&fasthttp.Server{},
 
 		middlewares,
 		nil,
@@ -77,7 +79,14 @@ func (server *Server) ListenAndServe() error {
 	for _, middleware := range server.middlewares {
 		handler = middleware(handler)
 	}
-	return fasthttp.ListenAndServe(server.listeningAddress, handler)
+	server.httpServer.Handler = handler
+	return server.httpServer.ListenAndServe(server.listeningAddress)
+}
+
+// Shutdown stops accepting new connections and waits for the in-flight requests to complete.
+// ListenAndServe returns once Shutdown is called.
+func (server *Server) Shutdown() error {
+	return server.httpServer.Shutdown()
 }
 
 type Middleware = func(fasthttp.RequestHandler) fasthttp.RequestHandler
@@ -0,0 +1,77 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/crypto-com/chain-indexing/internal/utctime"
+)
+
+// ParseTimeRange parses the optional RFC3339 `from` (inclusive) and `to` (exclusive) query arguments
+func ParseTimeRange(queryArgs *QueryArgs) (*utctime.UTCTime, *utctime.UTCTime, error) {
+	var maybeFrom, maybeTo *utctime.UTCTime
+	if queryArgs.Has("from") {
+		from, err := utctime.Parse(time.RFC3339, queryArgs.Get("from"))
+		if err != nil {
+			return nil, nil, errors.New("invalid from, expected RFC3339 format")
+		}
+		maybeFrom = &from
+	}
+	if queryArgs.Has("to") {
+		to, err := utctime.Parse(time.RFC3339, queryArgs.Get("to"))
+		if err != nil {
+			return nil, nil, errors.New("invalid to, expected RFC3339 format")
+		}
+		maybeTo = &to
+	}
+	if maybeFrom != nil && maybeTo != nil && maybeTo.UnixNano() <= maybeFrom.UnixNano() {
+		return nil, nil, errors.New("to must be after from")
+	}
+
+	return maybeFrom, maybeTo, nil
+}
+
+// SeriesBuckets splits a time series into buckets
+type SeriesBuckets struct {
+	// Start returns the start of the bucket the time falls into
+	Start func(t utctime.UTCTime) utctime.UTCTime
+	// Next returns the start of the bucket following the one starting at the time
+	Next func(bucketStart utctime.UTCTime) utctime.UTCTime
+	// Maximum number of buckets in a series
+	MaxCount int
+}
+
+// ParseSeriesTimeRange parses the RFC3339 `from` (inclusive, required) and `to` (exclusive, defaults to
+// now) query arguments of a time series, and ensures the series does not span more than the maximum
+// number of buckets
+func ParseSeriesTimeRange(queryArgs *QueryArgs, buckets SeriesBuckets) (utctime.UTCTime, utctime.UTCTime, error) {
+	if !queryArgs.Has("from") {
+		return utctime.UTCTime{}, utctime.UTCTime{}, errors.New("missing from")
+	}
+	maybeFrom, maybeTo, err := ParseTimeRange(queryArgs)
+	if err != nil {
+		return utctime.UTCTime{}, utctime.UTCTime{}, err
+	}
+	from := *maybeFrom
+	to := utctime.Now()
+	if maybeTo != nil {
+		to = *maybeTo
+	} else if to.UnixNano() <= from.UnixNano() {
+		return utctime.UTCTime{}, utctime.UTCTime{}, errors.New("to must be after from")
+	}
+
+	bucketCount := 0
+	bucketStart := buckets.Start(from)
+	for bucketStart.UnixNano() < to.UnixNano() {
+		bucketCount += 1
+		if bucketCount > buckets.MaxCount {
+			return utctime.UTCTime{}, utctime.UTCTime{}, fmt.Errorf(
+				"too many buckets, at most %d allowed", buckets.MaxCount,
+			)
+		}
+		bucketStart = buckets.Next(bucketStart)
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ prometheus.Collector = &PgxPoolCollector{}
+
+// PgxPoolCollector collects the database connection pool statistics on scrape
+type PgxPoolCollector struct {
+	statFn func() *pgxpool.Stat
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+}
+
+// NewPgxPoolCollector creates a collector reading the statistics from the function. Nothing is
+// collected when the function returns nil.
+func NewPgxPoolCollector(statFn func() *pgxpool.Stat) *PgxPoolCollector {
+	newDesc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(NAMESPACE, "db_pool", name), help, nil, nil)
+	}
+
+	return &PgxPoolCollector{
+		statFn: statFn,
+
+		acquiredConns:        newDesc("acquired_conns", "Number of connections currently in use."),
+		idleConns:            newDesc("idle_conns", "Number of idle connections in the pool."),
+		totalConns:           newDesc("total_conns", "Number of connections in the pool."),
+		maxConns:             newDesc("max_conns", "Maximum number of connections of the pool."),
+		acquireCount:         newDesc("acquires_total", "Number of successful connection acquisitions."),
+		acquireDuration:      newDesc("acquire_duration_seconds_total", "Time spent acquiring connections."),
+		emptyAcquireCount:    newDesc("empty_acquires_total", "Number of acquisitions waiting for a connection."),
+		canceledAcquireCount: newDesc("canceled_acquires_total", "Number of acquisitions cancelled."),
+	}
+}
+
+func (collector *PgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.acquiredConns
+	ch <- collector.idleConns
+	ch <- collector.totalConns
+	ch <- collector.maxConns
+	ch <- collector.acquireCount
+	ch <- collector.acquireDuration
+	ch <- collector.emptyAcquireCount
+	ch <- collector.canceledAcquireCount
+}
+
+func (collector *PgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := collector.statFn()
+	if stat == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(collector.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(collector.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(collector.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(collector.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(collector.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(
+		collector.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds(),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		collector.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		collector.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()),
+	)
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+
+	"github.com/crypto-com/chain-indexing/internal/metrics"
+)
+
+const NAMESPACE = "chainindex"
+
+var _ metrics.Metrics = &PrometheusMetrics{}
+
+// PrometheusMetrics collects the metrics in its own Prometheus registry, together with the Go runtime
+// and process metrics
+type PrometheusMetrics struct {
+	registry *prometheus.Registry
+
+	chainHeadHeight               *prometheus.GaugeVec
+	projectionLastHandledHeight   *prometheus.GaugeVec
+	projectionEventsHandled       *prometheus.CounterVec
+	projectionHandleEventsLatency *prometheus.HistogramVec
+	syncWindowDuration            *prometheus.HistogramVec
+	syncedBlocks                  *prometheus.CounterVec
+	rpcErrors                     *prometheus.CounterVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGoCollector())
+	registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+
+	promMetrics := &PrometheusMetrics{
+		registry: registry,
+
+		chainHeadHeight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Name:      "chain_head_height",
+			Help:      "Latest block height of the chain seen by the synchronization of the projection.",
+		}, []string{"projection"}),
+		projectionLastHandledHeight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Name:      "projection_last_handled_height",
+			Help:      "Last block height handled by the projection.",
+		}, []string{"projection"}),
+		projectionEventsHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "projection_events_handled_total",
+			Help:      "Number of events handled by the projection.",
+		}, []string{"projection"}),
+		projectionHandleEventsLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Name:      "projection_handle_events_duration_seconds",
+			Help:      "Time spent by the projection to handle the events of a block.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+		}, []string{"projection"}),
+		syncWindowDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Name:      "sync_window_duration_seconds",
+			Help:      "Time spent to synchronize a window of blocks from the chain for the projection.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 15),
+		}, []string{"projection"}),
+		syncedBlocks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "synced_blocks_total",
+			Help:      "Number of blocks synchronized from the chain for the projection.",
+		}, []string{"projection"}),
+		rpcErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "rpc_errors_total",
+			Help:      "Number of failed requests to the chain RPC.",
+		}, []string{"method"}),
+	}
+	registry.MustRegister(
+		promMetrics.chainHeadHeight,
+		promMetrics.projectionLastHandledHeight,
+		promMetrics.projectionEventsHandled,
+		promMetrics.projectionHandleEventsLatency,
+		promMetrics.syncWindowDuration,
+		promMetrics.syncedBlocks,
+		promMetrics.rpcErrors,
+	)
+
+	return promMetrics
+}
+
+// MustRegister registers additional collectors, e.g. the database pool statistics
+func (promMetrics *PrometheusMetrics) MustRegister(collectors ...prometheus.Collector) {
+	promMetrics.registry.MustRegister(collectors...)
+}
+
+func (promMetrics *PrometheusMetrics) Gatherer() prometheus.Gatherer {
+	return promMetrics.registry
+}
+
+// Handler serves the metrics in Prometheus text format
+func (promMetrics *PrometheusMetrics) Handler() fasthttp.RequestHandler {
+	return fasthttpadaptor.NewFastHTTPHandler(
+		promhttp.HandlerFor(promMetrics.registry, promhttp.HandlerOpts{}),
+	)
+}
+
+func (promMetrics *PrometheusMetrics) SetChainHeadHeight(projectionId string, height int64) {
+	promMetrics.chainHeadHeight.WithLabelValues(projectionId).Set(float64(height))
+}
+
+func (promMetrics *PrometheusMetrics) SetProjectionLastHandledHeight(projectionId string, height int64) {
+	promMetrics.projectionLastHandledHeight.WithLabelValues(projectionId).Set(float64(height))
+}
+
+func (promMetrics *PrometheusMetrics) ObserveProjectionHandleEvents(
+	projectionId string, eventCount int, duration time.Duration,
+) {
+	promMetrics.projectionEventsHandled.WithLabelValues(projectionId).Add(float64(eventCount))
+	promMetrics.projectionHandleEventsLatency.WithLabelValues(projectionId).Observe(duration.Seconds())
+}
+
+func (promMetrics *PrometheusMetrics) ObserveSyncWindow(projectionId string, blockCount int, duration time.Duration) {
+	promMetrics.syncedBlocks.WithLabelValues(projectionId).Add(float64(blockCount))
+	promMetrics.syncWindowDuration.WithLabelValues(projectionId).Observe(duration.Seconds())
+}
+
+func (promMetrics *PrometheusMetrics) IncRPCError(method string) {
+	promMetrics.rpcErrors.WithLabelValues(method).Inc()
+}
@@ -0,0 +1,53 @@
+package metrics_test
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+
+	"github.com/crypto-com/chain-indexing/infrastructure/metrics"
+)
+
+var _ = Describe("PrometheusMetrics", func() {
+	scrape := func(promMetrics *metrics.PrometheusMetrics) string {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.SetRequestURI("/metrics")
+		promMetrics.Handler()(&ctx)
+
+		Expect(ctx.Response.StatusCode()).To(Equal(fasthttp.StatusOK))
+		return string(ctx.Response.Body())
+	}
+
+	It("should serve the recorded metrics in Prometheus text format", func() {
+		promMetrics := metrics.NewPrometheusMetrics()
+
+		promMetrics.SetChainHeadHeight("Block", 100)
+		promMetrics.SetProjectionLastHandledHeight("Block", 90)
+		promMetrics.ObserveProjectionHandleEvents("Block", 3, 20*time.Millisecond)
+		promMetrics.ObserveProjectionHandleEvents("Block", 2, 10*time.Millisecond)
+		promMetrics.ObserveSyncWindow("Block", 50, time.Second)
+		promMetrics.IncRPCError("block")
+
+		body := scrape(promMetrics)
+		Expect(body).To(ContainSubstring(`chainindex_chain_head_height{projection="Block"} 100` + "\n"))
+		Expect(body).To(ContainSubstring(`chainindex_projection_last_handled_height{projection="Block"} 90` + "\n"))
+		Expect(body).To(ContainSubstring(`chainindex_projection_events_handled_total{projection="Block"} 5` + "\n"))
+		Expect(body).To(ContainSubstring(`chainindex_projection_handle_events_duration_seconds_count{projection="Block"} 2` + "\n"))
+		Expect(body).To(ContainSubstring(`chainindex_sync_window_duration_seconds_count{projection="Block"} 1` + "\n"))
+		Expect(body).To(ContainSubstring(`chainindex_synced_blocks_total{projection="Block"} 50` + "\n"))
+		Expect(body).To(ContainSubstring(`chainindex_rpc_errors_total{method="block"} 1` + "\n"))
+		Expect(body).To(ContainSubstring("go_goroutines"))
+	})
+
+	It("should not collect database pool statistics when unavailable", func() {
+		promMetrics := metrics.NewPrometheusMetrics()
+		promMetrics.MustRegister(metrics.NewPgxPoolCollector(func() *pgxpool.Stat {
+			return nil
+		}))
+
+		Expect(scrape(promMetrics)).NotTo(ContainSubstring("chainindex_db_pool"))
+	})
+})
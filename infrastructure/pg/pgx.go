@@ -155,6 +155,25 @@ func (conn *PgxConn) ToHandle() *rdb.Handle {
 	}
 }
 
+// MaybePoolStat returns the connection pool statistics, nil when the connection is not a pool
+func (conn *PgxConn) MaybePoolStat() *pgxpool.Stat {
+	if pool, ok := conn.pgxConn.(*pgxpool.Pool); ok {
+		return pool.Stat()
+	}
+	return nil
+}
+
+// Close closes the connection or all the connections in the pool. Connections in use are closed
+// once released.
+func (conn *PgxConn) Close() {
+	switch pgxConn := conn.pgxConn.(type) {
+	case *pgxpool.Pool:
+		pgxConn.Close()
+	case *pgx.Conn:
+		_ = pgxConn.Close(context.Background())
+	}
+}
+
 var _ rdb.Tx = &PgxRDbTx{}
 
 type PgxRDbTx struct {
@@ -0,0 +1,38 @@
+package metrics
+
+import "time"
+
+// Metrics records the indexing metrics. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// SetChainHeadHeight records the latest block height of the chain seen by the synchronization of
+	// the projection
+	SetChainHeadHeight(projectionId string, height int64)
+
+	// SetProjectionLastHandledHeight records the last height handled by the projection
+	SetProjectionLastHandledHeight(projectionId string, height int64)
+	// ObserveProjectionHandleEvents records a HandleEvents call of the projection with the number of
+	// events handled and the time spent
+	ObserveProjectionHandleEvents(projectionId string, eventCount int, duration time.Duration)
+
+	// ObserveSyncWindow records a window of blocks synchronized from the chain for the projection and
+	// the time spent
+	ObserveSyncWindow(projectionId string, blockCount int, duration time.Duration)
+
+	// IncRPCError counts a failed request to the chain RPC method
+	IncRPCError(method string)
+}
+
+var _ Metrics = &NoopMetrics{}
+
+// NoopMetrics discards all the metrics. It is used when no metrics is provided.
+type NoopMetrics struct{}
+
+func NewNoopMetrics() *NoopMetrics {
+	return &NoopMetrics{}
+}
+
+func (*NoopMetrics) SetChainHeadHeight(_ string, _ int64)                           {}
+func (*NoopMetrics) SetProjectionLastHandledHeight(_ string, _ int64)               {}
+func (*NoopMetrics) ObserveProjectionHandleEvents(_ string, _ int, _ time.Duration) {}
+func (*NoopMetrics) ObserveSyncWindow(_ string, _ int, _ time.Duration)             {}
+func (*NoopMetrics) IncRPCError(_ string)                                           {}
@@ -1,5 +1,21 @@
 package tmcosmosutils
 
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+const (
+	MODULE_FEE_COLLECTOR          = "fee_collector"
+	MODULE_MINT                   = "mint"
+	MODULE_DISTRIBUTION           = "distribution"
+	MODULE_GOV                    = "gov"
+	MODULE_BONDED_TOKENS_POOL     = "bonded_tokens_pool"
+	MODULE_NOT_BONDED_TOKENS_POOL = "not_bonded_tokens_pool"
+)
+
 type ModuleAccounts struct {
 	FeeCollector        string
 	Mint                string
@@ -10,16 +26,62 @@ type ModuleAccounts struct {
 }
 
 func NewModuleAccounts(accountAddressPrefix string) ModuleAccounts {
-	if accountAddressPrefix == "trco" {
-		return ModuleAccounts{
-			FeeCollector:        "tcro17xpfvakm2amg962yls6f84z3kell8c5lxhzaha",
-			Mint:                "tcro1m3h30wlvsf8llruxtpukdvsy0km2kum87lx9mq",
-			Distribution:        "tcro1jv65s3grqf6v6jl3dp4t6c9t9rk99cd8339p4l",
-			Gov:                 "tcro10d07y265gmmuvt4z0w9aw880jnsr700jvvjc2n",
-			BondedTokensPool:    "tcro1fl48vsnmsdzcv85q5d2q4z5ajdha8yu3r4gj9h",
-			NotBondedTokensPool: "tcro1tygms3xhhs3yv487phx3dw4a95jn7t7lh45rnr",
-		}
+	return ModuleAccounts{
+		FeeCollector:        MustModuleAccountAddress(accountAddressPrefix, MODULE_FEE_COLLECTOR),
+		Mint:                MustModuleAccountAddress(accountAddressPrefix, MODULE_MINT),
+		Distribution:        MustModuleAccountAddress(accountAddressPrefix, MODULE_DISTRIBUTION),
+		Gov:                 MustModuleAccountAddress(accountAddressPrefix, MODULE_GOV),
+		BondedTokensPool:    MustModuleAccountAddress(accountAddressPrefix, MODULE_BONDED_TOKENS_POOL),
+		NotBondedTokensPool: MustModuleAccountAddress(accountAddressPrefix, MODULE_NOT_BONDED_TOKENS_POOL),
+	}
+}
+
+// NamesByAddress returns the module name of each module account address
+func (accounts ModuleAccounts) NamesByAddress() map[string]string {
+	return map[string]string{
+		accounts.FeeCollector:        MODULE_FEE_COLLECTOR,
+		accounts.Mint:                MODULE_MINT,
+		accounts.Distribution:        MODULE_DISTRIBUTION,
+		accounts.Gov:                 MODULE_GOV,
+		accounts.BondedTokensPool:    MODULE_BONDED_TOKENS_POOL,
+		accounts.NotBondedTokensPool: MODULE_NOT_BONDED_TOKENS_POOL,
 	}
-	// TODO: Dynamically hash and encode the module accounts addresses
-	panic("unimplemented ModuleAccounts for non-tcro prefix")
+}
+
+// Addresses returns the addresses of all module accounts
+func (accounts ModuleAccounts) Addresses() []string {
+	return []string{
+		accounts.FeeCollector,
+		accounts.Mint,
+		accounts.Distribution,
+		accounts.Gov,
+		accounts.BondedTokensPool,
+		accounts.NotBondedTokensPool,
+	}
+}
+
+func MustModuleAccountAddress(accountAddressPrefix string, moduleName string) string {
+	address, err := ModuleAccountAddress(accountAddressPrefix, moduleName)
+	if err != nil {
+		panic(err)
+	}
+
+	return address
+}
+
+// ModuleAccountAddress returns the address of the module account, which is the first 20 bytes of the
+// SHA-256 hash of the module name
+func ModuleAccountAddress(accountAddressPrefix string, moduleName string) (string, error) {
+	hash := sha256.Sum256([]byte(moduleName))
+
+	conv, err := bech32.ConvertBits(hash[:20], 8, 5, true)
+	if err != nil {
+		return "", fmt.Errorf("error converting module account address to bech32 bits: %v", err)
+	}
+	address, err := bech32.Encode(accountAddressPrefix, conv)
+	if err != nil {
+		return "", fmt.Errorf("error encoding module account address bits: %v", err)
+	}
+
+	return address, nil
 }
@@ -0,0 +1,32 @@
+package tmcosmosutils_test
+
+import (
+	"github.com/crypto-com/chain-indexing/internal/tmcosmosutils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ModuleAccounts", func() {
+	Describe("NewModuleAccounts", func() {
+		It("should derive the module account addresses from the prefix", func() {
+			Expect(tmcosmosutils.NewModuleAccounts("tcro")).To(Equal(tmcosmosutils.ModuleAccounts{
+				FeeCollector:        "tcro17xpfvakm2amg962yls6f84z3kell8c5lxhzaha",
+				Mint:                "tcro1m3h30wlvsf8llruxtpukdvsy0km2kum87lx9mq",
+				Distribution:        "tcro1jv65s3grqf6v6jl3dp4t6c9t9rk99cd8339p4l",
+				Gov:                 "tcro10d07y265gmmuvt4z0w9aw880jnsr700jvvjc2n",
+				BondedTokensPool:    "tcro1fl48vsnmsdzcv85q5d2q4z5ajdha8yu3r4gj9h",
+				NotBondedTokensPool: "tcro1tygms3xhhs3yv487phx3dw4a95jn7t7lh45rnr",
+			}))
+		})
+	})
+
+	Describe("NamesByAddress", func() {
+		It("should return the module name of each address", func() {
+			names := tmcosmosutils.NewModuleAccounts("tcro").NamesByAddress()
+
+			Expect(names).To(HaveLen(6))
+			Expect(names["tcro1m3h30wlvsf8llruxtpukdvsy0km2kum87lx9mq"]).To(Equal(tmcosmosutils.MODULE_MINT))
+		})
+	})
+})
@@ -0,0 +1,21 @@
+package tmcosmosutils
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/crypto-com/chain-indexing/usecase/coin"
+)
+
+// Amount of base units bonded per unit of Tendermint voting power
+var POWER_REDUCTION = big.NewInt(1000000)
+
+// PowerFromTokens returns the Tendermint voting power of the bonded tokens
+func PowerFromTokens(tokens coin.Coin) (int64, error) {
+	power := new(big.Int).Quo(tokens.ToBigInt(), POWER_REDUCTION)
+	if !power.IsInt64() {
+		return 0, errors.New("power overflows int64")
+	}
+
+	return power.Int64(), nil
+}
@@ -0,0 +1,8 @@
+CREATE TABLE leader_leases (
+    name VARCHAR NOT NULL,
+    holder_id VARCHAR NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    renewed_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (name)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS view_proposals_proposer_address_btree_index;
+DROP INDEX IF EXISTS view_proposals_status_btree_index;
+DROP TABLE IF EXISTS view_proposals;
@@ -0,0 +1,30 @@
+CREATE TABLE view_proposals (
+    id BIGSERIAL,
+    proposal_id VARCHAR NOT NULL,
+    title VARCHAR NOT NULL,
+    description VARCHAR NOT NULL,
+    type VARCHAR NOT NULL,
+    status VARCHAR NOT NULL,
+    proposer_address VARCHAR NOT NULL,
+    data JSONB NOT NULL,
+    initial_deposit VARCHAR NOT NULL,
+    total_deposit VARCHAR NOT NULL,
+    total_vote BIGINT NOT NULL,
+    yes_count BIGINT NOT NULL,
+    no_count BIGINT NOT NULL,
+    no_with_veto_count BIGINT NOT NULL,
+    abstain_count BIGINT NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    submit_block_height BIGINT NOT NULL,
+    submit_time BIGINT NOT NULL,
+    deposit_end_time BIGINT NULL,
+    voting_start_block_height BIGINT NULL,
+    voting_start_time BIGINT NULL,
+    voting_end_time BIGINT NULL,
+    end_block_height BIGINT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (proposal_id)
+);
+
+CREATE INDEX view_proposals_status_btree_index ON view_proposals USING btree (status);
+CREATE INDEX view_proposals_proposer_address_btree_index ON view_proposals USING btree (proposer_address);
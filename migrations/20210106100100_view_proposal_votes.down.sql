@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS view_proposal_votes_voter_address_btree_index;
+DROP TABLE IF EXISTS view_proposal_votes;
@@ -0,0 +1,14 @@
+CREATE TABLE view_proposal_votes (
+    id BIGSERIAL,
+    proposal_id VARCHAR NOT NULL,
+    voter_address VARCHAR NOT NULL,
+    answer VARCHAR NOT NULL,
+    vote_count INT NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    vote_at_block_height BIGINT NOT NULL,
+    vote_at_block_time BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (proposal_id, voter_address)
+);
+
+CREATE INDEX view_proposal_votes_voter_address_btree_index ON view_proposal_votes USING btree (voter_address);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS view_proposal_deposits_depositor_address_btree_index;
+DROP INDEX IF EXISTS view_proposal_deposits_proposal_id_btree_index;
+DROP TABLE IF EXISTS view_proposal_deposits;
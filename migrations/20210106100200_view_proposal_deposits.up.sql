@@ -0,0 +1,13 @@
+CREATE TABLE view_proposal_deposits (
+    id BIGSERIAL,
+    proposal_id VARCHAR NOT NULL,
+    depositor_address VARCHAR NOT NULL,
+    amount VARCHAR NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    deposit_at_block_height BIGINT NOT NULL,
+    deposit_at_block_time BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_proposal_deposits_proposal_id_btree_index ON view_proposal_deposits USING btree (proposal_id);
+CREATE INDEX view_proposal_deposits_depositor_address_btree_index ON view_proposal_deposits USING btree (depositor_address);
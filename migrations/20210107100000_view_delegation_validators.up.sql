@@ -0,0 +1,8 @@
+CREATE TABLE view_delegation_validators (
+    operator_address VARCHAR,
+    consensus_node_address VARCHAR NOT NULL,
+    tokens VARCHAR NOT NULL,
+    shares VARCHAR NOT NULL,
+    PRIMARY KEY (operator_address),
+    UNIQUE (consensus_node_address)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS view_delegations_validator_address_btree_index;
+DROP TABLE IF EXISTS view_delegations;
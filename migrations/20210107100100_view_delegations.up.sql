@@ -0,0 +1,12 @@
+CREATE TABLE view_delegations (
+    id BIGSERIAL,
+    delegator_address VARCHAR NOT NULL,
+    validator_address VARCHAR NOT NULL,
+    shares VARCHAR NOT NULL,
+    amount VARCHAR NOT NULL,
+    last_updated_block_height BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (delegator_address, validator_address)
+);
+
+CREATE INDEX view_delegations_validator_address_btree_index ON view_delegations USING btree (validator_address);
@@ -0,0 +1,13 @@
+CREATE TABLE view_unbonding_delegations (
+    id BIGSERIAL,
+    delegator_address VARCHAR NOT NULL,
+    validator_address VARCHAR NOT NULL,
+    amount VARCHAR NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    creation_block_height BIGINT NOT NULL,
+    completion_time BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_unbonding_delegations_delegator_address_btree_index ON view_unbonding_delegations USING btree (delegator_address);
+CREATE INDEX view_unbonding_delegations_validator_address_btree_index ON view_unbonding_delegations USING btree (validator_address);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS view_redelegations_completion_time_btree_index;
+DROP INDEX IF EXISTS view_redelegations_validator_dst_address_btree_index;
+DROP INDEX IF EXISTS view_redelegations_validator_src_address_btree_index;
+DROP INDEX IF EXISTS view_redelegations_delegator_address_btree_index;
+DROP TABLE IF EXISTS view_redelegations;
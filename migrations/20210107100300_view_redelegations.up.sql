@@ -0,0 +1,17 @@
+CREATE TABLE view_redelegations (
+    id BIGSERIAL,
+    delegator_address VARCHAR NOT NULL,
+    validator_src_address VARCHAR NOT NULL,
+    validator_dst_address VARCHAR NOT NULL,
+    amount VARCHAR NOT NULL,
+    shares_dst VARCHAR NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    creation_block_height BIGINT NOT NULL,
+    completion_time BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_redelegations_delegator_address_btree_index ON view_redelegations USING btree (delegator_address);
+CREATE INDEX view_redelegations_validator_src_address_btree_index ON view_redelegations USING btree (validator_src_address);
+CREATE INDEX view_redelegations_validator_dst_address_btree_index ON view_redelegations USING btree (validator_dst_address);
+CREATE INDEX view_redelegations_completion_time_btree_index ON view_redelegations USING btree (completion_time);
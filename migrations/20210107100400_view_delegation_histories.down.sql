@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS view_delegation_histories_validator_address_btree_index;
+DROP INDEX IF EXISTS view_delegation_histories_delegator_address_btree_index;
+DROP TABLE IF EXISTS view_delegation_histories;
@@ -0,0 +1,15 @@
+CREATE TABLE view_delegation_histories (
+    id BIGSERIAL,
+    delegator_address VARCHAR NOT NULL,
+    validator_address VARCHAR NOT NULL,
+    type VARCHAR NOT NULL,
+    amount VARCHAR NOT NULL,
+    shares VARCHAR NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_delegation_histories_delegator_address_btree_index ON view_delegation_histories USING btree (delegator_address);
+CREATE INDEX view_delegation_histories_validator_address_btree_index ON view_delegation_histories USING btree (validator_address);
@@ -0,0 +1,6 @@
+CREATE TABLE view_balances (
+    address VARCHAR,
+    balance NUMERIC NOT NULL,
+    last_updated_block_height BIGINT NOT NULL,
+    PRIMARY KEY (address)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE view_balance_histories (
+    id BIGSERIAL,
+    address VARCHAR NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    change NUMERIC NOT NULL,
+    balance NUMERIC NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (address, block_height)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE view_balance_reconciliations (
+    address VARCHAR,
+    indexed_balance NUMERIC NOT NULL,
+    indexed_block_height BIGINT NOT NULL,
+    node_balance NUMERIC NOT NULL,
+    drift NUMERIC NOT NULL,
+    checked_at BIGINT NOT NULL,
+    PRIMARY KEY (address)
+);
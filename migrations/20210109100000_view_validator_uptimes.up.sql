@@ -0,0 +1,20 @@
+CREATE TABLE view_validator_uptimes (
+    consensus_node_address VARCHAR,
+    tendermint_address VARCHAR NOT NULL,
+    operator_address VARCHAR NOT NULL,
+    bonded BOOLEAN NOT NULL,
+    was_bonded BOOLEAN NOT NULL,
+    bonded_changed_at_block_height BIGINT NOT NULL,
+    index_offset BIGINT NOT NULL,
+    missed_blocks_counter BIGINT NOT NULL,
+    total_signed_blocks BIGINT NOT NULL,
+    total_missed_blocks BIGINT NOT NULL,
+    current_missed_streak BIGINT NOT NULL,
+    max_missed_streak BIGINT NOT NULL,
+    last_signed_block_height BIGINT NULL,
+    last_missed_block_height BIGINT NULL,
+    PRIMARY KEY (consensus_node_address),
+    UNIQUE (tendermint_address)
+);
+
+CREATE INDEX view_validator_uptimes_operator_address_btree_index ON view_validator_uptimes USING btree (operator_address);
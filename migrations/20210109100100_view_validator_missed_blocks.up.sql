@@ -0,0 +1,6 @@
+CREATE TABLE view_validator_missed_blocks (
+    consensus_node_address VARCHAR,
+    index_offset BIGINT,
+    block_height BIGINT NOT NULL,
+    PRIMARY KEY (consensus_node_address, index_offset)
+);
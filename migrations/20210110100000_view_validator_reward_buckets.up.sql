@@ -0,0 +1,9 @@
+CREATE TABLE view_validator_reward_buckets (
+    operator_address VARCHAR,
+    bucket_interval VARCHAR,
+    bucket_start BIGINT,
+    rewards VARCHAR NOT NULL,
+    proposer_rewards VARCHAR NOT NULL,
+    commissions VARCHAR NOT NULL,
+    PRIMARY KEY (operator_address, bucket_interval, bucket_start)
+);
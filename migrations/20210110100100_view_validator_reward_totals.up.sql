@@ -0,0 +1,10 @@
+CREATE TABLE view_validator_reward_totals (
+    operator_address VARCHAR,
+    total_rewards VARCHAR NOT NULL,
+    total_proposer_rewards VARCHAR NOT NULL,
+    total_commissions VARCHAR NOT NULL,
+    total_withdrawn_commissions VARCHAR NOT NULL,
+    outstanding_commission VARCHAR NOT NULL,
+    last_updated_block_height BIGINT NOT NULL,
+    PRIMARY KEY (operator_address)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_validator_commission_withdrawals;
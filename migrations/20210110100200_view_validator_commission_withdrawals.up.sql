@@ -0,0 +1,12 @@
+CREATE TABLE view_validator_commission_withdrawals (
+    id BIGSERIAL,
+    operator_address VARCHAR NOT NULL,
+    recipient_address VARCHAR NOT NULL,
+    amount VARCHAR NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_validator_commission_withdrawals_operator_address_btree_index ON view_validator_commission_withdrawals USING btree (operator_address, block_height);
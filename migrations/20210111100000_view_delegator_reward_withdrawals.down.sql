@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_delegator_reward_withdrawals;
@@ -0,0 +1,16 @@
+CREATE TABLE view_delegator_reward_withdrawals (
+    id BIGSERIAL,
+    delegator_address VARCHAR NOT NULL,
+    validator_address VARCHAR NOT NULL,
+    recipient_address VARCHAR NOT NULL,
+    amount NUMERIC NOT NULL,
+    denom VARCHAR NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    msg_index INTEGER NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_delegator_reward_withdrawals_delegator_address_btree_index ON view_delegator_reward_withdrawals USING btree (delegator_address, block_time);
+CREATE INDEX view_delegator_reward_withdrawals_validator_address_btree_index ON view_delegator_reward_withdrawals USING btree (validator_address, block_time);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_chain_stats_buckets;
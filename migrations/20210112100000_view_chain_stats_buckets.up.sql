@@ -0,0 +1,16 @@
+CREATE TABLE view_chain_stats_buckets (
+    bucket_interval VARCHAR,
+    bucket_start BIGINT,
+    blocks BIGINT NOT NULL,
+    block_intervals BIGINT NOT NULL,
+    total_block_interval BIGINT NOT NULL,
+    transactions BIGINT NOT NULL,
+    failed_transactions BIGINT NOT NULL,
+    fees NUMERIC NOT NULL,
+    gas_used BIGINT NOT NULL,
+    active_addresses BIGINT NOT NULL,
+    new_addresses BIGINT NOT NULL,
+    transfer_volume NUMERIC NOT NULL,
+    minted NUMERIC NOT NULL,
+    PRIMARY KEY (bucket_interval, bucket_start)
+);
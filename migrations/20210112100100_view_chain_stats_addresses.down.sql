@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS view_chain_stats_bucket_addresses;
+DROP TABLE IF EXISTS view_chain_stats_addresses;
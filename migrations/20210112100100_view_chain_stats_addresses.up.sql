@@ -0,0 +1,12 @@
+CREATE TABLE view_chain_stats_addresses (
+    address VARCHAR,
+    first_seen_block_height BIGINT NOT NULL,
+    PRIMARY KEY (address)
+);
+
+CREATE TABLE view_chain_stats_bucket_addresses (
+    bucket_interval VARCHAR,
+    bucket_start BIGINT,
+    address VARCHAR,
+    PRIMARY KEY (bucket_interval, bucket_start, address)
+);
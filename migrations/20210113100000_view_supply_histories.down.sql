@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_supply_histories;
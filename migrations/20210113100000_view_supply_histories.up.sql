@@ -0,0 +1,15 @@
+CREATE TABLE view_supply_histories (
+    block_height BIGINT,
+    block_time BIGINT NOT NULL,
+    total_supply NUMERIC NOT NULL,
+    minted NUMERIC NOT NULL,
+    total_minted NUMERIC NOT NULL,
+    bonded_ratio VARCHAR NULL,
+    inflation VARCHAR NULL,
+    annual_provisions VARCHAR NULL,
+    community_pool_inflow NUMERIC NOT NULL,
+    total_community_pool_inflow NUMERIC NOT NULL,
+    PRIMARY KEY (block_height)
+);
+
+CREATE INDEX view_supply_histories_block_time_btree_index ON view_supply_histories USING btree (block_time);
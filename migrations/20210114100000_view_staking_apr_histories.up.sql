@@ -0,0 +1,11 @@
+CREATE TABLE view_staking_apr_histories (
+    day BIGINT,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    annual_provisions VARCHAR NOT NULL,
+    bonded_ratio VARCHAR NOT NULL,
+    inflation VARCHAR NOT NULL,
+    community_tax VARCHAR NOT NULL,
+    apr VARCHAR NULL,
+    PRIMARY KEY (day)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE view_staking_apr_params (
+    key VARCHAR,
+    value VARCHAR NOT NULL,
+    PRIMARY KEY (key)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE view_fee_market_blocks (
+    block_height BIGINT,
+    block_time BIGINT NOT NULL,
+    transactions BIGINT NOT NULL,
+    gas_wanted BIGINT NOT NULL,
+    gas_used BIGINT NOT NULL,
+    max_gas BIGINT NULL,
+    fullness VARCHAR NULL,
+    fees NUMERIC NOT NULL,
+    gas_price_p25 VARCHAR NULL,
+    gas_price_p50 VARCHAR NULL,
+    gas_price_p75 VARCHAR NULL,
+    PRIMARY KEY (block_height)
+);
+
+CREATE INDEX view_fee_market_blocks_block_time_btree_index ON view_fee_market_blocks USING btree (block_time);
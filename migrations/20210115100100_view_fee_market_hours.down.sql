@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_fee_market_hours;
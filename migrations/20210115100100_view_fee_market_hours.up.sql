@@ -0,0 +1,15 @@
+CREATE TABLE view_fee_market_hours (
+    hour_start BIGINT,
+    blocks BIGINT NOT NULL,
+    transactions BIGINT NOT NULL,
+    gas_wanted BIGINT NOT NULL,
+    gas_used BIGINT NOT NULL,
+    max_gas BIGINT NULL,
+    fullness VARCHAR NULL,
+    fees NUMERIC NOT NULL,
+    gas_price_p25 VARCHAR NULL,
+    gas_price_p50 VARCHAR NULL,
+    gas_price_p75 VARCHAR NULL,
+    finalized BOOLEAN NOT NULL,
+    PRIMARY KEY (hour_start)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE view_fee_market_pending_gas_prices (
+    block_height BIGINT,
+    transaction_hash VARCHAR,
+    gas_price VARCHAR NOT NULL,
+    PRIMARY KEY (block_height, transaction_hash)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE view_fee_market_message_gas (
+    msg_type VARCHAR,
+    transactions BIGINT NOT NULL,
+    total_gas_wanted BIGINT NOT NULL,
+    total_gas_used BIGINT NOT NULL,
+    PRIMARY KEY (msg_type)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE view_fee_market_params (
+    key VARCHAR,
+    value VARCHAR NOT NULL,
+    PRIMARY KEY (key)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_account_transactions_total;
\ No newline at end of file
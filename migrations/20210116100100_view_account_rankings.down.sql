@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_account_rankings;
\ No newline at end of file
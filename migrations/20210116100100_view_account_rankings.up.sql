@@ -0,0 +1,12 @@
+CREATE TABLE view_account_rankings (
+    address VARCHAR,
+    balance NUMERIC NOT NULL,
+    staked NUMERIC NOT NULL,
+    transactions BIGINT NOT NULL,
+    ranked_at BIGINT NOT NULL,
+    PRIMARY KEY (address)
+);
+
+CREATE INDEX view_account_rankings_balance_btree_index ON view_account_rankings USING btree (balance);
+CREATE INDEX view_account_rankings_staked_btree_index ON view_account_rankings USING btree (staked);
+CREATE INDEX view_account_rankings_transactions_btree_index ON view_account_rankings USING btree (transactions);
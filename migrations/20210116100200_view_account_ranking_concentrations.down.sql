@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_account_ranking_concentrations;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE view_account_ranking_concentrations (
+    metric VARCHAR,
+    accounts BIGINT NOT NULL,
+    total NUMERIC NOT NULL,
+    gini VARCHAR NOT NULL,
+    top_10_share VARCHAR NOT NULL,
+    top_100_share VARCHAR NOT NULL,
+    computed_at BIGINT NOT NULL,
+    PRIMARY KEY (metric)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_account_activities;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE view_account_activities (
+    address VARCHAR,
+    first_seen_block_height BIGINT NOT NULL,
+    first_seen_block_time BIGINT NOT NULL,
+    last_active_block_height BIGINT NOT NULL,
+    last_active_block_time BIGINT NOT NULL,
+    messages BIGINT NOT NULL,
+    fees_paid NUMERIC NOT NULL,
+    total_sent NUMERIC NOT NULL,
+    total_received NUMERIC NOT NULL,
+    PRIMARY KEY (address)
+);
+
+CREATE INDEX view_account_activities_first_seen_block_height_btree_index ON view_account_activities USING btree (first_seen_block_height);
+CREATE INDEX view_account_activities_last_active_block_height_btree_index ON view_account_activities USING btree (last_active_block_height);
+CREATE INDEX view_account_activities_messages_btree_index ON view_account_activities USING btree (messages);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_account_activity_message_types;
\ No newline at end of file
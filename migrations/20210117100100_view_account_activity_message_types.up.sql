@@ -0,0 +1,6 @@
+CREATE TABLE view_account_activity_message_types (
+    address VARCHAR NOT NULL,
+    msg_type VARCHAR NOT NULL,
+    total BIGINT NOT NULL,
+    PRIMARY KEY (address, msg_type)
+);
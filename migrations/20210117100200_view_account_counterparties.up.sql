@@ -0,0 +1,9 @@
+CREATE TABLE view_account_counterparties (
+    address VARCHAR NOT NULL,
+    counterparty VARCHAR NOT NULL,
+    transfers BIGINT NOT NULL,
+    last_transferred_block_height BIGINT NOT NULL,
+    PRIMARY KEY (address, counterparty)
+);
+
+CREATE INDEX view_account_counterparties_address_transfers_btree_index ON view_account_counterparties USING btree (address, transfers);
@@ -0,0 +1,23 @@
+CREATE TABLE view_validator_histories (
+    id BIGSERIAL,
+    operator_address VARCHAR NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    action VARCHAR NOT NULL,
+    moniker VARCHAR NOT NULL,
+    identity VARCHAR NOT NULL,
+    website VARCHAR NOT NULL,
+    security_contact VARCHAR NOT NULL,
+    details VARCHAR NOT NULL,
+    commission_rate VARCHAR NOT NULL,
+    previous_commission_rate VARCHAR NULL,
+    min_self_delegation VARCHAR NOT NULL,
+    description_changed BOOLEAN NOT NULL,
+    commission_changed BOOLEAN NOT NULL,
+    commission_increased BOOLEAN NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_validator_histories_operator_address_btree_index ON view_validator_histories USING btree (operator_address, id);
+CREATE INDEX view_validator_histories_commission_increased_btree_index ON view_validator_histories USING btree (commission_increased, block_height);
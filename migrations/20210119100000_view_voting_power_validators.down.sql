@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_voting_power_validators;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE view_voting_power_validators (
+    consensus_node_address VARCHAR NOT NULL,
+    power BIGINT NOT NULL,
+    last_changed_block_height BIGINT NOT NULL,
+    PRIMARY KEY (consensus_node_address)
+);
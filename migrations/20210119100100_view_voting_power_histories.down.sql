@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_voting_power_histories;
\ No newline at end of file
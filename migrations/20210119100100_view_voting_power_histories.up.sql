@@ -0,0 +1,7 @@
+CREATE TABLE view_voting_power_histories (
+    consensus_node_address VARCHAR NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    power BIGINT NOT NULL,
+    PRIMARY KEY (consensus_node_address, block_height)
+);
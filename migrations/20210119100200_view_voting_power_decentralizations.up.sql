@@ -0,0 +1,14 @@
+CREATE TABLE view_voting_power_decentralizations (
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    total_power BIGINT NOT NULL,
+    validators BIGINT NOT NULL,
+    nakamoto_coefficient BIGINT NOT NULL,
+    validators_for_one_third BIGINT NOT NULL,
+    validators_for_two_thirds BIGINT NOT NULL,
+    top_10_share VARCHAR NOT NULL,
+    top_20_share VARCHAR NOT NULL,
+    PRIMARY KEY (block_height)
+);
+
+CREATE INDEX view_voting_power_decentralizations_block_time_btree_index ON view_voting_power_decentralizations USING btree (block_time);
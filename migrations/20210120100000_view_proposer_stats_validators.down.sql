@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_proposer_stats_validators;
\ No newline at end of file
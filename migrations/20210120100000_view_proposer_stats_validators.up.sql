@@ -0,0 +1,8 @@
+CREATE TABLE view_proposer_stats_validators (
+    tendermint_address VARCHAR,
+    operator_address VARCHAR NOT NULL,
+    power BIGINT NOT NULL,
+    previous_power BIGINT NOT NULL,
+    power_changed_at_block_height BIGINT NOT NULL,
+    PRIMARY KEY (tendermint_address)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE view_proposer_stats_buckets (
+    operator_address VARCHAR,
+    bucket_interval VARCHAR,
+    bucket_start BIGINT,
+    proposed_blocks BIGINT NOT NULL,
+    expected_proposals VARCHAR NOT NULL,
+    proposer_rewards VARCHAR NOT NULL,
+    PRIMARY KEY (operator_address, bucket_interval, bucket_start)
+);
+
+CREATE INDEX view_proposer_stats_buckets_bucket_btree_index ON view_proposer_stats_buckets USING btree (bucket_interval, bucket_start, proposed_blocks);
@@ -0,0 +1,10 @@
+CREATE TABLE view_proposer_stats_totals (
+    operator_address VARCHAR,
+    proposed_blocks BIGINT NOT NULL,
+    expected_proposals VARCHAR NOT NULL,
+    proposer_rewards VARCHAR NOT NULL,
+    last_proposed_block_height BIGINT NULL,
+    PRIMARY KEY (operator_address)
+);
+
+CREATE INDEX view_proposer_stats_totals_proposed_blocks_btree_index ON view_proposer_stats_totals USING btree (proposed_blocks);
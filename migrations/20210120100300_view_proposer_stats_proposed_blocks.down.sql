@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_proposer_stats_proposed_blocks;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE view_proposer_stats_proposed_blocks (
+    operator_address VARCHAR,
+    block_height BIGINT,
+    block_time BIGINT NOT NULL,
+    PRIMARY KEY (operator_address, block_height)
+);
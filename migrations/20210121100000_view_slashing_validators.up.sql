@@ -0,0 +1,15 @@
+CREATE TABLE view_slashing_validators (
+    consensus_node_address VARCHAR,
+    operator_address VARCHAR NOT NULL,
+    tendermint_address VARCHAR NOT NULL,
+    joined_at_block_height BIGINT NOT NULL,
+    joined_at_block_time BIGINT NOT NULL,
+    slash_count BIGINT NOT NULL,
+    double_sign_count BIGINT NOT NULL,
+    jail_count BIGINT NOT NULL,
+    total_downtime_seconds BIGINT NOT NULL,
+    jailed_since_block_time BIGINT NULL,
+    PRIMARY KEY (consensus_node_address)
+);
+
+CREATE UNIQUE INDEX view_slashing_validators_operator_address_uindex ON view_slashing_validators (operator_address);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_slashing_incidents;
\ No newline at end of file
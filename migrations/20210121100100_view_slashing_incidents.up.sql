@@ -0,0 +1,19 @@
+CREATE TABLE view_slashing_incidents (
+    id BIGSERIAL,
+    operator_address VARCHAR NOT NULL,
+    consensus_node_address VARCHAR NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_time BIGINT NOT NULL,
+    reason VARCHAR NOT NULL,
+    slashed_power VARCHAR NOT NULL,
+    jailed BOOLEAN NOT NULL,
+    evidence_type VARCHAR NULL,
+    evidence_height BIGINT NULL,
+    evidence_vote_a_block_hash VARCHAR NULL,
+    evidence_vote_b_block_hash VARCHAR NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_slashing_incidents_block_height_btree_index ON view_slashing_incidents USING btree (block_height, id);
+CREATE INDEX view_slashing_incidents_operator_address_btree_index ON view_slashing_incidents USING btree (operator_address, block_height);
+CREATE INDEX view_slashing_incidents_reason_btree_index ON view_slashing_incidents USING btree (reason, block_height);
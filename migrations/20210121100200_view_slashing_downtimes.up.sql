@@ -0,0 +1,15 @@
+CREATE TABLE view_slashing_downtimes (
+    id BIGSERIAL,
+    operator_address VARCHAR NOT NULL,
+    consensus_node_address VARCHAR NOT NULL,
+    reason VARCHAR NOT NULL,
+    jailed_at_block_height BIGINT NOT NULL,
+    jailed_at_block_time BIGINT NOT NULL,
+    unjailed_at_block_height BIGINT NULL,
+    unjailed_at_block_time BIGINT NULL,
+    unjail_transaction_hash VARCHAR NULL,
+    duration_seconds BIGINT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX view_slashing_downtimes_operator_address_btree_index ON view_slashing_downtimes USING btree (operator_address, id);
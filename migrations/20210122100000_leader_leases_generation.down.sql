@@ -0,0 +1 @@
+ALTER TABLE leader_leases DROP COLUMN generation;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE leader_leases ADD COLUMN generation BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+-- The backfilled totals are kept and maintained by the AccountMessage projection
\ No newline at end of file
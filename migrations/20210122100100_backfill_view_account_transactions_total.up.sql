@@ -0,0 +1,3 @@
+INSERT INTO view_account_transactions_total (identity, total)
+SELECT account, COUNT(DISTINCT transaction_hash) FROM view_account_messages GROUP BY account
+ON CONFLICT (identity) DO UPDATE SET total = EXCLUDED.total;
@@ -203,6 +203,7 @@ elif [[ "${FN}" == FN_TEST ]]; then
     setup
 
     export TEST_DATABASE_URL="${POSTGRES_DRIVER_URL}"
+    export TEST_POSTGRES="${TEST_DB}"
     echo
     if [[ "${WATCH_MODE}" == 1 ]]; then
         run_test_watch
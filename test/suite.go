@@ -4,7 +4,10 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sync"
 
+	"github.com/crypto-com/chain-indexing/appinterface/rdb"
+	rdbtest "github.com/crypto-com/chain-indexing/appinterface/rdb/test"
 	"github.com/crypto-com/chain-indexing/infrastructure/pg"
 	. "github.com/crypto-com/chain-indexing/internal/logger/test"
 	"github.com/crypto-com/chain-indexing/internal/primptr"
@@ -56,3 +59,44 @@ func WithTestPgxConn(body func(*pg.PgxConn, *pg.Migrate)) bool {
 
 	return true
 }
+
+// MustNewInMemoryRDbConn creates an in-memory rdb.Conn migrated with the repository migrations.
+// Unlike WithTestPgxConn, it does not require a Postgres server.
+func MustNewInMemoryRDbConn() *rdbtest.InMemoryRDbConn {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		panic("error retrieving file directory")
+	}
+
+	return rdbtest.NewInMemoryRDbConn().MustMigrate(path.Join(filename, "../../migrations"))
+}
+
+var testPgxConn struct {
+	once    sync.Once
+	conn    *pg.PgxConn
+	migrate *pg.Migrate
+}
+
+// MustNewTestRDbConn creates the rdb.Conn of the projection tests. When TEST_POSTGRES is 1, it returns the
+// connection to the test Postgres with all the migrations re-applied, so the projection suites run against
+// the real database. Otherwise it falls back to MustNewInMemoryRDbConn, unless TEST_POSTGRES_REQUIRED is 1
+// as in CI.
+func MustNewTestRDbConn() rdb.Conn {
+	if os.Getenv("TEST_POSTGRES") != "1" {
+		if os.Getenv("TEST_POSTGRES_REQUIRED") == "1" {
+			panic("TEST_POSTGRES_REQUIRED is 1 but TEST_POSTGRES is not 1")
+		}
+		return MustNewInMemoryRDbConn()
+	}
+
+	testPgxConn.once.Do(func() {
+		WithTestPgxConn(func(conn *pg.PgxConn, migrate *pg.Migrate) {
+			testPgxConn.conn = conn
+			testPgxConn.migrate = migrate
+		})
+	})
+	_ = testPgxConn.migrate.Reset()
+	testPgxConn.migrate.MustUp()
+
+	return testPgxConn.conn
+}
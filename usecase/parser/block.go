@@ -14,6 +14,18 @@ func ParseBlockToCommands(
 	block *usecase_model.Block,
 	rawBlock *usecase_model.RawBlock,
 	blockResults *usecase_model.BlockResults,
+) ([]entity_command.Command, error) {
+	return ParseBlockToCommandsForEvents(txDecoder, block, rawBlock, blockResults, nil)
+}
+
+// ParseBlockToCommandsForEvents parses the block into commands but skips the parsers which yield none
+// of the events to listen. All parsers are run when maybeEventsToListen is nil.
+func ParseBlockToCommandsForEvents(
+	txDecoder *TxDecoder,
+	block *usecase_model.Block,
+	rawBlock *usecase_model.RawBlock,
+	blockResults *usecase_model.BlockResults,
+	maybeEventsToListen []string,
 ) ([]entity_command.Command, error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -24,51 +36,69 @@ func ParseBlockToCommands(
 	var err error
 	var commands []entity_command.Command
 
-	createRawBlockCommand := ParseCreateRawBlockCommand(rawBlock)
-	commands = append(commands, createRawBlockCommand)
+	shouldParse := newParserSelector(maybeEventsToListen)
 
-	createBlockCommand := ParseCreateBlockCommand(block)
-	commands = append(commands, createBlockCommand)
+	if shouldParse(PARSER_RAW_BLOCK) {
+		createRawBlockCommand := ParseCreateRawBlockCommand(rawBlock)
+		commands = append(commands, createRawBlockCommand)
+	}
+
+	if shouldParse(PARSER_BLOCK) {
+		createBlockCommand := ParseCreateBlockCommand(block)
+		commands = append(commands, createBlockCommand)
+	}
 
 	if len(blockResults.TxsResults) > 0 {
-		transactionCommands, parseErr := ParseTransactionCommands(txDecoder, block, blockResults)
-		if parseErr != nil {
-			return nil, fmt.Errorf("error parsing transaction commands: %v", parseErr)
+		if shouldParse(PARSER_TRANSACTION) {
+			transactionCommands, parseErr := ParseTransactionCommands(txDecoder, block, blockResults)
+			if parseErr != nil {
+				return nil, fmt.Errorf("error parsing transaction commands: %v", parseErr)
+			}
+			commands = append(commands, transactionCommands...)
 		}
-		commands = append(commands, transactionCommands...)
 
-		msgCommands, parseErr := ParseBlockResultsTxsMsgToCommands(txDecoder, block, blockResults)
-		if parseErr != nil {
-			return nil, fmt.Errorf("error parsing message commands: %v", parseErr)
+		if shouldParse(PARSER_MSG) {
+			msgCommands, parseErr := ParseBlockResultsTxsMsgToCommands(txDecoder, block, blockResults)
+			if parseErr != nil {
+				return nil, fmt.Errorf("error parsing message commands: %v", parseErr)
+			}
+			commands = append(commands, msgCommands...)
 		}
-		commands = append(commands, msgCommands...)
 
-		txsAccountTransferCommands, parseErr := ParseTxAccountTransferCommands(
-			block.Height,
-			blockResults.TxsResults,
-		)
-		if parseErr != nil {
-			return nil, fmt.Errorf("error parsing block_results account transfer commands: %v", parseErr)
+		if shouldParse(PARSER_TX_ACCOUNT_TRANSFER) {
+			txsAccountTransferCommands, parseErr := ParseTxAccountTransferCommands(
+				block.Height,
+				blockResults.TxsResults,
+			)
+			if parseErr != nil {
+				return nil, fmt.Errorf("error parsing block_results account transfer commands: %v", parseErr)
+			}
+			commands = append(commands, txsAccountTransferCommands...)
 		}
-		commands = append(commands, txsAccountTransferCommands...)
 	}
 
-	beginBlockEventsCommands, parseErr := ParseBeginBlockEventsCommands(block.Height, blockResults.BeginBlockEvents)
-	if parseErr != nil {
-		return nil, fmt.Errorf("error parsing block_results_events commands: %v", parseErr)
+	if shouldParse(PARSER_BEGIN_BLOCK_EVENTS) {
+		beginBlockEventsCommands, parseErr := ParseBeginBlockEventsCommands(block.Height, blockResults.BeginBlockEvents)
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing block_results_events commands: %v", parseErr)
+		}
+		commands = append(commands, beginBlockEventsCommands...)
 	}
-	commands = append(commands, beginBlockEventsCommands...)
 
-	endBlockEventsCommands, parseErr := ParseEndBlockEventsCommands(block.Height, blockResults.BeginBlockEvents)
-	if parseErr != nil {
-		return nil, fmt.Errorf("error parsing block_results_events commands: %v", parseErr)
+	if shouldParse(PARSER_END_BLOCK_EVENTS) {
+		endBlockEventsCommands, parseErr := ParseEndBlockEventsCommands(block.Height, blockResults.EndBlockEvents)
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing block_results_events commands: %v", parseErr)
+		}
+		commands = append(commands, endBlockEventsCommands...)
 	}
-	commands = append(commands, endBlockEventsCommands...)
 
-	validatorUpdatesCommands, parseErr := ParseValidatorUpdatesCommands(block.Height, blockResults.ValidatorUpdates)
-	commands = append(commands, validatorUpdatesCommands...)
-	if parseErr != nil {
-		return nil, fmt.Errorf("error parsing validator_updates commands: %v", parseErr)
+	if shouldParse(PARSER_VALIDATOR_UPDATES) {
+		validatorUpdatesCommands, parseErr := ParseValidatorUpdatesCommands(block.Height, blockResults.ValidatorUpdates)
+		commands = append(commands, validatorUpdatesCommands...)
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing validator_updates commands: %v", parseErr)
+		}
 	}
 
 	return commands, err
@@ -0,0 +1,67 @@
+package parser_test
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	entity_command "github.com/crypto-com/chain-indexing/entity/command"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	"github.com/crypto-com/chain-indexing/usecase/model"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("ParseBlockToCommands", func() {
+	parseBlockResults := func(rawBlockResultsResp string) []entity_command.Command {
+		blockResults := mustParseBlockResultsResp(rawBlockResultsResp)
+		var rawBlock model.RawBlock
+		rawBlock.Block.Header.Height = strconv.FormatInt(blockResults.Height, 10)
+
+		cmds, err := parser.ParseBlockToCommands(
+			parser.NewTxDecoder("basetcro"),
+			&model.Block{Height: blockResults.Height},
+			&rawBlock,
+			blockResults,
+		)
+		Expect(err).To(BeNil())
+		return cmds
+	}
+	countOf := func(cmds []entity_command.Command, eventName string) int {
+		count := 0
+		for _, cmd := range cmds {
+			event, err := cmd.Exec()
+			Expect(err).To(BeNil())
+			if event.Name() == eventName {
+				count += 1
+			}
+		}
+		return count
+	}
+
+	It("should parse the begin_block_events transfers once", func() {
+		cmds := parseBlockResults(usecase_parser_test.BEGIN_BLOCK_COMMON_EVENTS_BLOCK_RESULTS_RESP)
+
+		Expect(countOf(cmds, event_usecase.ACCOUNT_TRANSFERRED)).To(Equal(2))
+	})
+
+	It("should parse the complete_unbonding event of end_block_events", func() {
+		cmds := parseBlockResults(usecase_parser_test.END_BLOCK_COMPLETE_UNBONDING_BLOCK_RESULTS_RESP)
+
+		Expect(countOf(cmds, event_usecase.ACCOUNT_TRANSFERRED)).To(Equal(2))
+		Expect(countOf(cmds, event_usecase.BONDING_COMPLETED)).To(Equal(1))
+	})
+
+	It("should parse the active_proposal event of end_block_events", func() {
+		cmds := parseBlockResults(usecase_parser_test.END_BLOCK_PROPOSAL_REJECTED_BLOCK_RESULTS_RESP)
+
+		Expect(countOf(cmds, event_usecase.PROPOSAL_ENDED)).To(Equal(1))
+	})
+
+	It("should parse the inactive_proposal event of end_block_events", func() {
+		cmds := parseBlockResults(usecase_parser_test.END_BLOCK_PROPOSAL_INACTIVED_BLOCK_RESULTS_RESP)
+
+		Expect(countOf(cmds, event_usecase.PROPOSAL_INACTIVED)).To(Equal(1))
+	})
+})
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+)
+
+const (
+	PARSER_GENESIS             = "Genesis"
+	PARSER_RAW_BLOCK           = "RawBlock"
+	PARSER_BLOCK               = "Block"
+	PARSER_TRANSACTION         = "Transaction"
+	PARSER_MSG                 = "Msg"
+	PARSER_TX_ACCOUNT_TRANSFER = "TxAccountTransfer"
+	PARSER_BEGIN_BLOCK_EVENTS  = "BeginBlockEvents"
+	PARSER_END_BLOCK_EVENTS    = "EndBlockEvents"
+	PARSER_VALIDATOR_UPDATES   = "ValidatorUpdates"
+)
+
+// PARSER_EVENTS maps each parser to the events it may yield
+var PARSER_EVENTS = map[string][]string{
+	PARSER_GENESIS: {
+		event_usecase.GENESIS_CREATED,
+		event_usecase.MSG_CREATE_VALIDATOR_CREATED,
+	},
+	PARSER_RAW_BLOCK: {event_usecase.RAW_BLOCK_CREATED},
+	PARSER_BLOCK:     {event_usecase.BLOCK_CREATED},
+	PARSER_TRANSACTION: {
+		event_usecase.TRANSACTION_CREATED,
+		event_usecase.TRANSACTION_FAILED,
+	},
+	PARSER_MSG:                 event_usecase.MSG_EVENTS,
+	PARSER_TX_ACCOUNT_TRANSFER: {event_usecase.ACCOUNT_TRANSFERRED},
+	PARSER_BEGIN_BLOCK_EVENTS: {
+		event_usecase.ACCOUNT_TRANSFERRED,
+		event_usecase.MINTED,
+		event_usecase.BLOCK_PROPOSER_REWARDED,
+		event_usecase.BLOCK_REWARDED,
+		event_usecase.BLOCK_COMMISSIONED,
+		event_usecase.VALIDATOR_SLASHED,
+		event_usecase.VALIDATOR_JAILED,
+	},
+	PARSER_END_BLOCK_EVENTS: {
+		event_usecase.ACCOUNT_TRANSFERRED,
+		event_usecase.BONDING_COMPLETED,
+		event_usecase.PROPOSAL_ENDED,
+		event_usecase.PROPOSAL_INACTIVED,
+	},
+	PARSER_VALIDATOR_UPDATES: {event_usecase.POWER_CHANGED},
+}
+
+// ShouldParse returns true when the parser yields any of the events to listen. Always true when
+// maybeEventsToListen is nil.
+func ShouldParse(parser string, maybeEventsToListen []string) bool {
+	return newParserSelector(maybeEventsToListen)(parser)
+}
+
+func newParserSelector(maybeEventsToListen []string) func(parser string) bool {
+	if maybeEventsToListen == nil {
+		return func(_ string) bool {
+			return true
+		}
+	}
+
+	eventsToListen := make(map[string]bool, len(maybeEventsToListen))
+	for _, eventName := range maybeEventsToListen {
+		eventsToListen[eventName] = true
+	}
+	return func(parser string) bool {
+		for _, eventName := range PARSER_EVENTS[parser] {
+			if eventsToListen[eventName] {
+				return true
+			}
+		}
+		return false
+	}
+}
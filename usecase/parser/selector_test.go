@@ -0,0 +1,39 @@
+package parser_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/crypto-com/chain-indexing/appinterface/projection/validator"
+	command_usecase "github.com/crypto-com/chain-indexing/usecase/command"
+	event_usecase "github.com/crypto-com/chain-indexing/usecase/event"
+	"github.com/crypto-com/chain-indexing/usecase/parser"
+	usecase_parser_test "github.com/crypto-com/chain-indexing/usecase/parser/test"
+)
+
+var _ = Describe("ShouldParse", func() {
+	It("should always parse when no events to listen are given", func() {
+		Expect(parser.ShouldParse(parser.PARSER_GENESIS, nil)).To(BeTrue())
+	})
+
+	It("should skip parsers yielding none of the events to listen", func() {
+		Expect(parser.ShouldParse(parser.PARSER_GENESIS, []string{event_usecase.BLOCK_CREATED})).To(BeFalse())
+	})
+
+	It("should parse the genesis gentx validators for a Validator projection", func() {
+		eventsToListen := (&validator.Validator{}).GetEventsToListen()
+		Expect(eventsToListen).NotTo(ContainElement(event_usecase.GENESIS_CREATED))
+
+		Expect(parser.ShouldParse(parser.PARSER_GENESIS, eventsToListen)).To(BeTrue())
+
+		cmds, err := parser.ParseGenesisCommands(mustParseGenesisResp(usecase_parser_test.GENESIS_RESP))
+		Expect(err).To(BeNil())
+		msgCreateValidatorCmds := 0
+		for _, cmd := range cmds {
+			if _, ok := cmd.(*command_usecase.CreateMsgCreateValidator); ok {
+				msgCreateValidatorCmds += 1
+			}
+		}
+		Expect(msgCreateValidatorCmds).To(Equal(3))
+	})
+})